@@ -2487,6 +2487,8 @@ func (this *ArkhamCore) SetLeverage(leverage interface{}, optionalArgs ...interf
 		_ = symbol
 		params := GetArg(optionalArgs, 1, map[string]interface{}{})
 		_ = params
+		retRes24907 := (<-this.CheckLeverage(leverage, symbol, params))
+		PanicOnError(retRes24907)
 		if IsTrue(IsEqual(symbol, nil)) {
 			panic(ArgumentsRequired(Add(this.Id, " setLeverage() requires a symbol argument")))
 		}
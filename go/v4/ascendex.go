@@ -3637,6 +3637,8 @@ func (this *AscendexCore) SetLeverage(leverage interface{}, optionalArgs ...inte
 		_ = symbol
 		params := GetArg(optionalArgs, 1, map[string]interface{}{})
 		_ = params
+		retRes36407 := (<-this.CheckLeverage(leverage, symbol, params))
+		PanicOnError(retRes36407)
 		if IsTrue(IsEqual(symbol, nil)) {
 			panic(ArgumentsRequired(Add(this.Id, " setLeverage() requires a symbol argument")))
 		}
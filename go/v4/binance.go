@@ -33,6 +33,7 @@ func (this *BinanceCore) Describe() interface{} {
 			"borrowIsolatedMargin":                 true,
 			"cancelAllOrders":                      true,
 			"cancelOrder":                          true,
+			"cancelOrderWithClientOrderId":         true,
 			"cancelOrders":                         true,
 			"closeAllPositions":                    false,
 			"closePosition":                        false,
@@ -61,6 +62,7 @@ func (this *BinanceCore) Describe() interface{} {
 			"editOrders":                           true,
 			"fetchAccounts":                        nil,
 			"fetchADLRank":                         true,
+			"fetchAllBalances":                     true,
 			"fetchAllGreeks":                       true,
 			"fetchBalance":                         true,
 			"fetchBidsAsks":                        true,
@@ -81,7 +83,7 @@ func (this *BinanceCore) Describe() interface{} {
 			"fetchDeposit":                         false,
 			"fetchDepositAddress":                  true,
 			"fetchDepositAddresses":                false,
-			"fetchDepositAddressesByNetwork":       false,
+			"fetchDepositAddressesByNetwork":       true,
 			"fetchDeposits":                        true,
 			"fetchDepositsWithdrawals":             false,
 			"fetchDepositWithdrawFee":              "emulated",
@@ -94,13 +96,14 @@ func (this *BinanceCore) Describe() interface{} {
 			"fetchFundingRates":                    true,
 			"fetchGreeks":                          true,
 			"fetchIndexOHLCV":                      true,
+			"fetchIndexPrices":                     true,
 			"fetchIsolatedBorrowRate":              "emulated",
 			"fetchIsolatedBorrowRates":             true,
 			"fetchL3OrderBook":                     false,
 			"fetchLastPrices":                      true,
 			"fetchLedger":                          true,
 			"fetchLedgerEntry":                     true,
-			"fetchLeverage":                        "emulated",
+			"fetchLeverage":                        true,
 			"fetchLeverages":                       true,
 			"fetchLeverageTiers":                   true,
 			"fetchLiquidations":                    false,
@@ -109,7 +112,7 @@ func (this *BinanceCore) Describe() interface{} {
 			"fetchMarginAdjustmentHistory":         true,
 			"fetchMarginMode":                      true,
 			"fetchMarginModes":                     true,
-			"fetchMarketLeverageTiers":             "emulated",
+			"fetchMarketLeverageTiers":             true,
 			"fetchMarkets":                         true,
 			"fetchMarkOHLCV":                       true,
 			"fetchMarkPrice":                       true,
@@ -129,9 +132,10 @@ func (this *BinanceCore) Describe() interface{} {
 			"fetchOrderBooks":                      false,
 			"fetchOrders":                          true,
 			"fetchOrderTrades":                     true,
+			"fetchOrderWithClientOrderId":          true,
 			"fetchPosition":                        true,
 			"fetchPositionADLRank":                 true,
-			"fetchPositionHistory":                 false,
+			"fetchPositionHistory":                 true,
 			"fetchPositionMode":                    true,
 			"fetchPositions":                       true,
 			"fetchPositionsADLRank":                true,
@@ -140,6 +144,8 @@ func (this *BinanceCore) Describe() interface{} {
 			"fetchPremiumIndexOHLCV":               true,
 			"fetchSettlementHistory":               true,
 			"fetchStatus":                          true,
+			"fetchSubAccountBalance":               true,
+			"fetchSubAccounts":                     true,
 			"fetchTicker":                          true,
 			"fetchTickers":                         true,
 			"fetchTime":                            true,
@@ -1358,6 +1364,10 @@ func (this *BinanceCore) Describe() interface{} {
 			"fetchMarkets": map[string]interface{}{
 				"types": []interface{}{"spot", "linear", "inverse"},
 			},
+			"raceAlternateHosts": false,
+			"alternateHosts": map[string]interface{}{
+				"api.binance.com": []interface{}{"api-gcp.binance.com"},
+			},
 			"loadAllOptions":                      false,
 			"fetchCurrencies":                     true,
 			"defaultTimeInForce":                  "GTC",
@@ -1368,6 +1378,7 @@ func (this *BinanceCore) Describe() interface{} {
 			"currencyToPrecisionRoundingMode":     TRUNCATE,
 			"throwMarginModeAlreadySet":           false,
 			"fetchPositions":                      "positionRisk",
+			"borrowRatesExpires":                  60000,
 			"recvWindow":                          Multiply(10, 1000),
 			"timeDifference":                      0,
 			"adjustForTimeDifference":             false,
@@ -1398,6 +1409,9 @@ func (this *BinanceCore) Describe() interface{} {
 				"inverse":  "CMFUTURE",
 				"option":   "OPTION",
 			},
+			"fetchTransfers": map[string]interface{}{
+				"types": []interface{}{"MAIN_UMFUTURE", "UMFUTURE_MAIN", "MAIN_CMFUTURE", "CMFUTURE_MAIN", "MAIN_MARGIN", "MARGIN_MAIN", "MAIN_FUNDING", "FUNDING_MAIN"},
+			},
 			"accountsById": map[string]interface{}{
 				"MAIN":     "spot",
 				"FUNDING":  "funding",
@@ -1694,9 +1708,9 @@ func (this *BinanceCore) Describe() interface{} {
 					"-3003":     BadRequest,
 					"-3004":     OperationRejected,
 					"-3005":     BadRequest,
-					"-3006":     BadRequest,
+					"-3006":     InsufficientFunds,
 					"-3007":     OperationFailed,
-					"-3008":     BadRequest,
+					"-3008":     InsufficientFunds,
 					"-3009":     OperationRejected,
 					"-3010":     BadRequest,
 					"-3011":     BadRequest,
@@ -1794,8 +1808,8 @@ func (this *BinanceCore) Describe() interface{} {
 					"-4064":     ExchangeError,
 					"-4065":     ExchangeError,
 					"-4066":     ExchangeError,
-					"-4067":     ExchangeError,
-					"-4068":     ExchangeError,
+					"-4067":     OperationRejected,
+					"-4068":     OperationRejected,
 					"-4069":     ExchangeError,
 					"-4070":     ExchangeError,
 					"-4071":     ExchangeError,
@@ -2195,8 +2209,8 @@ func (this *BinanceCore) Describe() interface{} {
 					"-4064":  ExchangeError,
 					"-4065":  ExchangeError,
 					"-4066":  ExchangeError,
-					"-4067":  ExchangeError,
-					"-4068":  ExchangeError,
+					"-4067":  OperationRejected,
+					"-4068":  OperationRejected,
 					"-4069":  ExchangeError,
 					"-4070":  ExchangeError,
 					"-4071":  ExchangeError,
@@ -2461,7 +2475,7 @@ func (this *BinanceCore) Describe() interface{} {
 			},
 			"exact": map[string]interface{}{
 				"-1000":                        OperationFailed,
-				"-1001":                        OperationFailed,
+				"-1001":                        ExchangeNotAvailable,
 				"-1002":                        AuthenticationError,
 				"-1003":                        RateLimitExceeded,
 				"-1004":                        OperationRejected,
@@ -2999,9 +3013,10 @@ func (this *BinanceCore) FetchCurrencies(optionalArgs ...interface{}) <-chan int
 			var code interface{} = this.SafeCurrencyCode(id)
 			var isFiat interface{} = this.SafeBool(entry, "isLegalMoney")
 			var minPrecision interface{} = nil
-			var isWithdrawEnabled interface{} = true
-			var isDepositEnabled interface{} = true
 			var networkList interface{} = this.SafeList(entry, "networkList", []interface{}{})
+			// a coin can be deposited or withdrawn when any of its networks allows it
+			var isWithdrawEnabled interface{} = Ternary(IsTrue(IsGreaterThan(GetArrayLength(networkList), 0)), false, this.SafeBool(entry, "withdrawAllEnable", true))
+			var isDepositEnabled interface{} = Ternary(IsTrue(IsGreaterThan(GetArrayLength(networkList), 0)), false, this.SafeBool(entry, "depositAllEnable", true))
 			var fees interface{} = map[string]interface{}{}
 			var fee interface{} = nil
 			var networks interface{} = map[string]interface{}{}
@@ -3041,14 +3056,15 @@ func (this *BinanceCore) FetchCurrencies(optionalArgs ...interface{}) <-chan int
 					}
 				}
 				AddElementToObject(networks, networkCode, map[string]interface{}{
-					"info":      networkItem,
-					"id":        network,
-					"network":   networkCode,
-					"active":    IsTrue(depositEnable) && IsTrue(withdrawEnable),
-					"deposit":   depositEnable,
-					"withdraw":  withdrawEnable,
-					"fee":       withdrawFee,
-					"precision": this.ParseNumber(withdrawPrecision),
+					"info":          networkItem,
+					"id":            network,
+					"network":       networkCode,
+					"active":        IsTrue(depositEnable) && IsTrue(withdrawEnable),
+					"deposit":       depositEnable,
+					"withdraw":      withdrawEnable,
+					"fee":           withdrawFee,
+					"precision":     this.ParseNumber(withdrawPrecision),
+					"confirmations": this.SafeInteger(networkItem, "minConfirm"),
 					"limits": map[string]interface{}{
 						"withdraw": map[string]interface{}{
 							"min": this.SafeNumber(networkItem, "withdrawMin"),
@@ -4034,6 +4050,121 @@ func (this *BinanceCore) FetchBalance(optionalArgs ...interface{}) <-chan interf
 	return ch
 }
 
+/**
+ * @method
+ * @name binance#fetchSubAccounts
+ * @description fetches the sub-accounts of the master account
+ * @see https://developers.binance.com/docs/sub_account/account-management/Query-Sub-account-List
+ * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @param {string} [params.email] sub-account email
+ * @param {boolean} [params.isFreeze] true or false
+ * @returns {object[]} a list of sub-account structures, the id is the sub-account email
+ */
+func (this *BinanceCore) FetchSubAccounts(optionalArgs ...interface{}) <-chan interface{} {
+	ch := make(chan interface{})
+	go func() interface{} {
+		defer close(ch)
+		defer ReturnPanicError(ch)
+		params := GetArg(optionalArgs, 0, map[string]interface{}{})
+		_ = params
+
+		response := (<-this.SapiGetSubAccountList(params))
+		this.PanicOnSubAccountError("fetchSubAccounts", response)
+		//
+		//     {
+		//         "subAccounts": [
+		//             {
+		//                 "email": "testsub@gmail.com",
+		//                 "isFreeze": false,
+		//                 "createTime": 1544433328000,
+		//                 "isManagedSubAccount": false,
+		//                 "isAssetManagementSubAccount": false
+		//             }
+		//         ]
+		//     }
+		//
+		var subAccounts interface{} = this.SafeList(response, "subAccounts", []interface{}{})
+		var result interface{} = []interface{}{}
+		for i := 0; IsLessThan(i, GetArrayLength(subAccounts)); i++ {
+			AppendToArray(&result, this.ParseSubAccount(GetValue(subAccounts, i)))
+		}
+
+		ch <- result
+		return nil
+
+	}()
+	return ch
+}
+func (this *BinanceCore) ParseSubAccount(subAccount interface{}) interface{} {
+	var email interface{} = this.SafeString(subAccount, "email")
+	var isFreeze interface{} = this.SafeBool(subAccount, "isFreeze")
+	var timestamp interface{} = this.SafeInteger(subAccount, "createTime")
+	return map[string]interface{}{
+		"id":        email,
+		"name":      email,
+		"status":    Ternary(IsTrue(isFreeze), "frozen", "active"),
+		"timestamp": timestamp,
+		"datetime":  this.Iso8601(timestamp),
+		"info":      subAccount,
+	}
+}
+
+/**
+ * @method
+ * @name binance#fetchSubAccountBalance
+ * @description fetches the spot balance of a sub-account
+ * @see https://developers.binance.com/docs/sub_account/asset-management/Query-Sub-account-Assets-V3
+ * @param {string} subAccountId the sub-account email
+ * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @returns {object} a [balance structure]{@link https://docs.ccxt.com/?id=balance-structure}
+ */
+func (this *BinanceCore) FetchSubAccountBalance(subAccountId interface{}, optionalArgs ...interface{}) <-chan interface{} {
+	ch := make(chan interface{})
+	go func() interface{} {
+		defer close(ch)
+		defer ReturnPanicError(ch)
+		params := GetArg(optionalArgs, 0, map[string]interface{}{})
+		_ = params
+		var request interface{} = map[string]interface{}{
+			"email": subAccountId,
+		}
+
+		response := (<-this.SapiV3GetSubAccountAssets(this.Extend(request, params)))
+		this.PanicOnSubAccountError("fetchSubAccountBalance", response)
+		//
+		//     {
+		//         "balances": [
+		//             {
+		//                 "freeze": 0,
+		//                 "withdrawing": 0,
+		//                 "asset": "ADA",
+		//                 "free": 10000,
+		//                 "locked": 0
+		//             }
+		//         ]
+		//     }
+		//
+		var balances interface{} = this.SafeList(response, "balances", []interface{}{})
+		var result interface{} = map[string]interface{}{
+			"info": response,
+		}
+		for i := 0; IsLessThan(i, GetArrayLength(balances)); i++ {
+			var balance interface{} = GetValue(balances, i)
+			var code interface{} = this.SafeCurrencyCode(this.SafeString(balance, "asset"))
+			var account interface{} = this.Account()
+			AddElementToObject(account, "free", this.SafeString(balance, "free"))
+			var used interface{} = Precise.StringAdd(this.SafeString(balance, "locked", "0"), this.SafeString(balance, "freeze", "0"))
+			AddElementToObject(account, "used", Precise.StringAdd(used, this.SafeString(balance, "withdrawing", "0")))
+			AddElementToObject(result, code, account)
+		}
+
+		ch <- this.SafeBalance(result)
+		return nil
+
+	}()
+	return ch
+}
+
 /**
  * @method
  * @name binance#fetchOrderBook
@@ -4363,7 +4494,7 @@ func (this *BinanceCore) FetchStatus(optionalArgs ...interface{}) <-chan interfa
 				"0": "ok",
 				"1": "maintenance",
 			}, statusRaw, statusRaw),
-			"updated": nil,
+			"updated": this.Milliseconds(),
 			"eta":     nil,
 			"url":     nil,
 			"info":    response,
@@ -4809,6 +4940,60 @@ func (this *BinanceCore) FetchMarkPrices(optionalArgs ...interface{}) <-chan int
 	}()
 	return ch
 }
+
+/**
+ * @method
+ * @name binance#fetchIndexPrices
+ * @description fetches index prices for multiple markets
+ * @see https://developers.binance.com/docs/derivatives/coin-margined-futures/market-data/rest-api/Index-Price-and-Mark-Price
+ * @see https://developers.binance.com/docs/derivatives/usds-margined-futures/market-data/rest-api/Mark-Price
+ * @param {string[]} [symbols] unified symbols of the markets to fetch the index price for, all markets are returned if not assigned
+ * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @param {string} [params.subType] "linear" or "inverse"
+ * @returns {object} a dictionary of [ticker structures]{@link https://docs.ccxt.com/?id=ticker-structure}
+ */
+func (this *BinanceCore) FetchIndexPrices(optionalArgs ...interface{}) <-chan interface{} {
+	ch := make(chan interface{})
+	go func() interface{} {
+		defer close(ch)
+		defer ReturnPanicError(ch)
+		symbols := GetArg(optionalArgs, 0, nil)
+		_ = symbols
+		params := GetArg(optionalArgs, 1, map[string]interface{}{})
+		_ = params
+
+		retRes46319 := (<-this.LoadMarkets())
+		PanicOnError(retRes46319)
+		symbols = this.MarketSymbols(symbols, nil, true, true, true)
+		var market interface{} = this.GetMarketFromSymbols(symbols)
+		var typeVar interface{} = nil
+		typeVarparamsVariable := this.HandleMarketTypeAndParams("fetchIndexPrices", market, params, "swap")
+		typeVar = GetValue(typeVarparamsVariable, 0)
+		params = GetValue(typeVarparamsVariable, 1)
+		var subType interface{} = nil
+		subTypeparamsVariable := this.HandleSubTypeAndParams("fetchIndexPrices", market, params, "linear")
+		subType = GetValue(subTypeparamsVariable, 0)
+		params = GetValue(subTypeparamsVariable, 1)
+		var response interface{} = nil
+		// the premium index carries the index price next to the mark price
+		if IsTrue(this.IsLinear(typeVar, subType)) {
+
+			response = (<-this.FapiPublicGetPremiumIndex(params))
+			PanicOnError(response)
+		} else if IsTrue(this.IsInverse(typeVar, subType)) {
+
+			response = (<-this.DapiPublicGetPremiumIndex(params))
+			PanicOnError(response)
+		} else {
+			panic(NotSupported(Add(Add(Add(this.Id, " fetchIndexPrices() does not support "), typeVar), " markets yet")))
+		}
+
+		ch <- this.ParseTickers(response, symbols)
+		return nil
+
+	}()
+	return ch
+}
 func (this *BinanceCore) ParseOHLCV(ohlcv interface{}, optionalArgs ...interface{}) interface{} {
 	// when api method = publicGetKlines || fapiPublicGetKlines || dapiPublicGetKlines
 	//     [
@@ -6887,6 +7072,7 @@ func (this *BinanceCore) CreateOrderRequest(symbol interface{}, typeVar interfac
 	params := GetArg(optionalArgs, 1, map[string]interface{}{})
 	_ = params
 	var market interface{} = this.Market(symbol)
+	params = this.NormalizeTimeInForce(market, typeVar, params)
 	var marketType interface{} = this.SafeString(params, "type", GetValue(market, "type"))
 	var clientOrderId interface{} = this.SafeStringN(params, []interface{}{"clientAlgoId", "newClientOrderId", "clientOrderId"})
 	var initialUppercaseType interface{} = ToUpper(typeVar)
@@ -9182,11 +9368,12 @@ func (this *BinanceCore) ParseDustTrade(trade interface{}, optionalArgs ...inter
  * @see https://developers.binance.com/docs/wallet/capital/deposite-history
  * @see https://developers.binance.com/docs/fiat/rest-api/Get-Fiat-Deposit-Withdraw-History
  * @param {string} code unified currency code
- * @param {int} [since] the earliest time in ms to fetch deposits for
+ * @param {int} [since] the earliest time in ms to fetch deposits for, at most 90 days are fetched from it unless params.paginate is set
  * @param {int} [limit] the maximum number of deposits structures to retrieve
  * @param {object} [params] extra parameters specific to the exchange API endpoint
  * @param {bool} [params.fiat] if true, only fiat deposits will be returned
  * @param {int} [params.until] the latest time in ms to fetch entries for
+ * @param {string} [params.status] 'pending', 'ok', 'failed' or 'canceled', or a binance status code
  * @param {boolean} [params.paginate] default false, when true will automatically paginate by calling this endpoint multiple times. See in the docs all the [available parameters](https://github.com/ccxt/ccxt/wiki/Manual#pagination-params)
  * @returns {object[]} a list of [transaction structures]{@link https://docs.ccxt.com/?id=transaction-structure}
  */
@@ -9225,6 +9412,13 @@ func (this *BinanceCore) FetchDeposits(optionalArgs ...interface{}) <-chan inter
 		params = this.Omit(params, "fiatOnly")
 		var until interface{} = this.SafeInteger(params, "until")
 		params = this.Omit(params, "until")
+		// a unified status covers several binance statuses, it is filtered after parsing
+		var status interface{} = this.SafeString(params, "status")
+		if IsTrue(this.InArray(status, []interface{}{"pending", "ok", "failed", "canceled"})) {
+			params = this.Omit(params, "status")
+		} else {
+			status = nil
+		}
 		if IsTrue(IsTrue(fiatOnly) || IsTrue((InOp(legalMoney, code)))) {
 			if IsTrue(!IsEqual(code, nil)) {
 				currency = this.Currency(code)
@@ -9245,17 +9439,19 @@ func (this *BinanceCore) FetchDeposits(optionalArgs ...interface{}) <-chan inter
 				currency = this.Currency(code)
 				AddElementToObject(request, "coin", GetValue(currency, "id"))
 			}
+			if IsTrue(!IsEqual(limit, nil)) {
+				AddElementToObject(request, "limit", limit)
+			}
 			if IsTrue(!IsEqual(since, nil)) {
 				AddElementToObject(request, "startTime", since)
-				// max 3 months range https://github.com/ccxt/ccxt/issues/6495
+				// max 3 months range https://github.com/ccxt/ccxt/issues/6495, longer ranges are fetched with params.paginate
 				var endTime interface{} = this.Sum(since, 7776000000)
 				if IsTrue(!IsEqual(until, nil)) {
 					endTime = mathMin(endTime, until)
 				}
 				AddElementToObject(request, "endTime", endTime)
-			}
-			if IsTrue(!IsEqual(limit, nil)) {
-				AddElementToObject(request, "limit", limit)
+			} else if IsTrue(!IsEqual(until, nil)) {
+				AddElementToObject(request, "endTime", until)
 			}
 
 			response = (<-this.SapiGetCapitalDepositHisrec(this.Extend(request, params)))
@@ -9264,6 +9460,12 @@ func (this *BinanceCore) FetchDeposits(optionalArgs ...interface{}) <-chan inter
 		for i := 0; IsLessThan(i, GetArrayLength(response)); i++ {
 			AddElementToObject(GetValue(response, i), "type", "deposit")
 		}
+		if IsTrue(!IsEqual(status, nil)) {
+			var deposits interface{} = this.FilterBy(this.ParseTransactions(response, currency, since), "status", status)
+
+			ch <- this.FilterBySinceLimit(deposits, since, limit)
+			return nil
+		}
 
 		ch <- this.ParseTransactions(response, currency, since, limit)
 		return nil
@@ -9279,11 +9481,12 @@ func (this *BinanceCore) FetchDeposits(optionalArgs ...interface{}) <-chan inter
  * @see https://developers.binance.com/docs/wallet/capital/withdraw-history
  * @see https://developers.binance.com/docs/fiat/rest-api/Get-Fiat-Deposit-Withdraw-History
  * @param {string} code unified currency code
- * @param {int} [since] the earliest time in ms to fetch withdrawals for
+ * @param {int} [since] the earliest time in ms to fetch withdrawals for, at most 90 days are fetched from it unless params.paginate is set
  * @param {int} [limit] the maximum number of withdrawals structures to retrieve
  * @param {object} [params] extra parameters specific to the exchange API endpoint
  * @param {bool} [params.fiat] if true, only fiat withdrawals will be returned
  * @param {int} [params.until] the latest time in ms to fetch withdrawals for
+ * @param {string} [params.status] 'pending', 'ok', 'failed' or 'canceled', or a binance status code
  * @param {boolean} [params.paginate] default false, when true will automatically paginate by calling this endpoint multiple times. See in the docs all the [available parameters](https://github.com/ccxt/ccxt/wiki/Manual#pagination-params)
  * @returns {object[]} a list of [transaction structures]{@link https://docs.ccxt.com/?id=transaction-structure}
  */
@@ -9319,9 +9522,13 @@ func (this *BinanceCore) FetchWithdrawals(optionalArgs ...interface{}) <-chan in
 		params = this.Omit(params, "fiatOnly")
 		var request interface{} = map[string]interface{}{}
 		var until interface{} = this.SafeInteger(params, "until")
-		if IsTrue(!IsEqual(until, nil)) {
-			params = this.Omit(params, "until")
-			AddElementToObject(request, "endTime", until)
+		params = this.Omit(params, "until")
+		// a unified status covers several binance statuses, it is filtered after parsing
+		var status interface{} = this.SafeString(params, "status")
+		if IsTrue(this.InArray(status, []interface{}{"pending", "ok", "failed", "canceled"})) {
+			params = this.Omit(params, "status")
+		} else {
+			status = nil
 		}
 		var response interface{} = nil
 		var currency interface{} = nil
@@ -9333,6 +9540,9 @@ func (this *BinanceCore) FetchWithdrawals(optionalArgs ...interface{}) <-chan in
 			if IsTrue(!IsEqual(since, nil)) {
 				AddElementToObject(request, "beginTime", since)
 			}
+			if IsTrue(!IsEqual(until, nil)) {
+				AddElementToObject(request, "endTime", until)
+			}
 
 			raw := (<-this.SapiGetFiatOrders(this.Extend(request, params)))
 			PanicOnError(raw)
@@ -9342,14 +9552,20 @@ func (this *BinanceCore) FetchWithdrawals(optionalArgs ...interface{}) <-chan in
 				currency = this.Currency(code)
 				AddElementToObject(request, "coin", GetValue(currency, "id"))
 			}
-			if IsTrue(!IsEqual(since, nil)) {
-				AddElementToObject(request, "startTime", since)
-				// max 3 months range https://github.com/ccxt/ccxt/issues/6495
-				AddElementToObject(request, "endTime", this.Sum(since, 7776000000))
-			}
 			if IsTrue(!IsEqual(limit, nil)) {
 				AddElementToObject(request, "limit", limit)
 			}
+			if IsTrue(!IsEqual(since, nil)) {
+				AddElementToObject(request, "startTime", since)
+				// max 3 months range https://github.com/ccxt/ccxt/issues/6495, longer ranges are fetched with params.paginate
+				var endTime interface{} = this.Sum(since, 7776000000)
+				if IsTrue(!IsEqual(until, nil)) {
+					endTime = mathMin(endTime, until)
+				}
+				AddElementToObject(request, "endTime", endTime)
+			} else if IsTrue(!IsEqual(until, nil)) {
+				AddElementToObject(request, "endTime", until)
+			}
 
 			response = (<-this.SapiGetCapitalWithdrawHistory(this.Extend(request, params)))
 			PanicOnError(response)
@@ -9357,6 +9573,12 @@ func (this *BinanceCore) FetchWithdrawals(optionalArgs ...interface{}) <-chan in
 		for i := 0; IsLessThan(i, GetArrayLength(response)); i++ {
 			AddElementToObject(GetValue(response, i), "type", "withdrawal")
 		}
+		if IsTrue(!IsEqual(status, nil)) {
+			var withdrawals interface{} = this.FilterBy(this.ParseTransactions(response, currency, since), "status", status)
+
+			ch <- this.FilterBySinceLimit(withdrawals, since, limit)
+			return nil
+		}
 
 		ch <- this.ParseTransactions(response, currency, since, limit)
 		return nil
@@ -9374,7 +9596,10 @@ func (this *BinanceCore) ParseTransactionStatusByType(status interface{}, option
 		"deposit": map[string]interface{}{
 			"0":             "pending",
 			"1":             "ok",
+			"2":             "failed",
 			"6":             "ok",
+			"7":             "failed",
+			"8":             "pending",
 			"Processing":    "pending",
 			"Failed":        "failed",
 			"Successful":    "ok",
@@ -9539,7 +9764,9 @@ func (this *BinanceCore) ParseTransaction(transaction interface{}, optionalArgs
 }
 func (this *BinanceCore) ParseTransferStatus(status interface{}) interface{} {
 	var statuses interface{} = map[string]interface{}{
+		"PENDING":   "pending",
 		"CONFIRMED": "ok",
+		"FAILED":    "failed",
 	}
 	return this.SafeString(statuses, status, status)
 }
@@ -9775,7 +10002,21 @@ func (this *BinanceCore) Transfer(code interface{}, amount interface{}, fromAcco
 				}
 				AddElementToObject(request, "type", Add(Add(fromId, "_"), toId))
 			} else {
-				AddElementToObject(request, "type", Add(Add(fromId, "_"), toId))
+				if IsTrue(IsEqual(fromId, toId)) {
+					panic(BadRequest(Add(Add(Add(Add(this.Id, " transfer () requires different accounts, got "), fromAccount), " and "), toAccount)))
+				}
+				// coin-margined futures only exchange with the spot, margin and funding wallets
+				var unsupportedTypes interface{} = map[string]interface{}{
+					"UMFUTURE_CMFUTURE": true,
+					"CMFUTURE_UMFUTURE": true,
+					"CMFUTURE_OPTION":   true,
+					"OPTION_CMFUTURE":   true,
+				}
+				var transferType interface{} = Add(Add(fromId, "_"), toId)
+				if IsTrue(InOp(unsupportedTypes, transferType)) {
+					panic(BadRequest(Add(Add(Add(Add(this.Id, " transfer () does not allow transfers between "), fromAccount), " and "), toAccount)))
+				}
+				AddElementToObject(request, "type", transferType)
 			}
 		}
 
@@ -9806,6 +10047,9 @@ func (this *BinanceCore) Transfer(code interface{}, amount interface{}, fromAcco
  * @param {int} [params.until] the latest time in ms to fetch transfers for
  * @param {boolean} [params.paginate] default false, when true will automatically paginate by calling this endpoint multiple times. See in the docs all the [available parameters](https://github.com/ccxt/ccxt/wiki/Manual#pagination-params)
  * @param {boolean} [params.internal] default false, when true will fetch pay trade history
+ * @param {string} [params.fromAccount] the account transferred from, by default the types in options.fetchTransfers.types are all queried
+ * @param {string} [params.toAccount] the account transferred to
+ * @param {string} [params.type] exchange specific transfer type, like MAIN_UMFUTURE
  * @returns {object[]} a list of [transfer structures]{@link https://docs.ccxt.com/?id=transfer-structure}
  */
 func (this *BinanceCore) FetchTransfers(optionalArgs ...interface{}) <-chan interface{} {
@@ -9843,16 +10087,26 @@ func (this *BinanceCore) FetchTransfers(optionalArgs ...interface{}) <-chan inte
 		}
 		var request interface{} = map[string]interface{}{}
 		var limitKey interface{} = "limit"
+		// the universal transfer history is queried one transfer type at a time
+		var types interface{} = []interface{}{nil}
 		if !IsTrue(internal) {
-			var defaultType interface{} = this.SafeString2(this.Options, "fetchTransfers", "defaultType", "spot")
-			var fromAccount interface{} = this.SafeString(params, "fromAccount", defaultType)
-			var defaultTo interface{} = Ternary(IsTrue((IsEqual(fromAccount, "future"))), "spot", "future")
-			var toAccount interface{} = this.SafeString(params, "toAccount", defaultTo)
+			var fromAccount interface{} = this.SafeString(params, "fromAccount")
+			var toAccount interface{} = this.SafeString(params, "toAccount")
 			var typeVar interface{} = this.SafeString(params, "type")
-			var accountsByType interface{} = this.SafeDict(this.Options, "accountsByType", map[string]interface{}{})
-			var fromId interface{} = this.SafeString(accountsByType, fromAccount)
-			var toId interface{} = this.SafeString(accountsByType, toAccount)
-			if IsTrue(IsEqual(typeVar, nil)) {
+			params = this.Omit(params, []interface{}{"fromAccount", "toAccount", "type"})
+			if IsTrue(!IsEqual(typeVar, nil)) {
+				types = []interface{}{typeVar}
+			} else if IsTrue(IsTrue(IsEqual(fromAccount, nil)) && IsTrue(IsEqual(toAccount, nil))) {
+				var options interface{} = this.SafeDict(this.Options, "fetchTransfers", map[string]interface{}{})
+				types = this.SafeList(options, "types", []interface{}{})
+			} else {
+				var defaultType interface{} = this.SafeString(this.Options, "defaultType", "spot")
+				fromAccount = Ternary(IsTrue((IsEqual(fromAccount, nil))), defaultType, fromAccount)
+				var defaultTo interface{} = Ternary(IsTrue((IsEqual(fromAccount, "future"))), "spot", "future")
+				toAccount = Ternary(IsTrue((IsEqual(toAccount, nil))), defaultTo, toAccount)
+				var accountsByType interface{} = this.SafeDict(this.Options, "accountsByType", map[string]interface{}{})
+				var fromId interface{} = this.SafeString(accountsByType, fromAccount)
+				var toId interface{} = this.SafeString(accountsByType, toAccount)
 				if IsTrue(IsEqual(fromId, nil)) {
 					var keys interface{} = ObjectKeys(accountsByType)
 					panic(ExchangeError(Add(Add(this.Id, " fromAccount parameter must be one of "), Join(keys, ", "))))
@@ -9861,9 +10115,8 @@ func (this *BinanceCore) FetchTransfers(optionalArgs ...interface{}) <-chan inte
 					var keys interface{} = ObjectKeys(accountsByType)
 					panic(ExchangeError(Add(Add(this.Id, " toAccount parameter must be one of "), Join(keys, ", "))))
 				}
-				typeVar = Add(Add(fromId, "_"), toId)
+				types = []interface{}{Add(Add(fromId, "_"), toId)}
 			}
-			AddElementToObject(request, "type", typeVar)
 			limitKey = "size"
 		}
 		if IsTrue(!IsEqual(limit, nil)) {
@@ -9877,17 +10130,21 @@ func (this *BinanceCore) FetchTransfers(optionalArgs ...interface{}) <-chan inte
 			params = this.Omit(params, "until")
 			AddElementToObject(request, "endTime", until)
 		}
-		var response interface{} = nil
-		if IsTrue(internal) {
+		var rows interface{} = []interface{}{}
+		for i := 0; IsLessThan(i, GetArrayLength(types)); i++ {
+			var response interface{} = nil
+			if IsTrue(internal) {
 
-			response = (<-this.SapiGetPayTransactions(this.Extend(request, params)))
-			PanicOnError(response)
-		} else {
+				response = (<-this.SapiGetPayTransactions(this.Extend(request, params)))
+				PanicOnError(response)
+			} else {
+				AddElementToObject(request, "type", GetValue(types, i))
 
-			response = (<-this.SapiGetAssetTransfer(this.Extend(request, params)))
-			PanicOnError(response)
+				response = (<-this.SapiGetAssetTransfer(this.Extend(request, params)))
+				PanicOnError(response)
+			}
+			rows = this.ArrayConcat(rows, this.SafeList2(response, "rows", "data", []interface{}{}))
 		}
-		var rows interface{} = this.SafeList2(response, "rows", "data", []interface{}{})
 
 		ch <- this.ParseTransfers(rows, currency, since, limit)
 		return nil
@@ -9951,6 +10208,79 @@ func (this *BinanceCore) FetchDepositAddress(code interface{}, optionalArgs ...i
 	}()
 	return ch
 }
+
+/**
+ * @method
+ * @name binance#fetchDepositAddressesByNetwork
+ * @description fetch the deposit addresses of every network a currency can be deposited on
+ * @see https://developers.binance.com/docs/wallet/capital/deposite-address
+ * @param {string} code unified currency code
+ * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @returns {object} a dictionary of [address structures]{@link https://docs.ccxt.com/?id=address-structure} indexed by the network code
+ */
+func (this *BinanceCore) FetchDepositAddressesByNetwork(code interface{}, optionalArgs ...interface{}) <-chan interface{} {
+	ch := make(chan interface{})
+	go func() interface{} {
+		defer close(ch)
+		defer ReturnPanicError(ch)
+		params := GetArg(optionalArgs, 0, map[string]interface{}{})
+		_ = params
+
+		retRes92328 := (<-this.LoadMarkets())
+		PanicOnError(retRes92328)
+		var currency interface{} = this.Currency(code)
+		if IsTrue(IsEqual(this.SafeBool(currency, "deposit"), false)) {
+			panic(BadRequest(Add(Add(Add(this.Id, " fetchDepositAddressesByNetwork() "), GetValue(currency, "code")), " deposits are disabled")))
+		}
+		var networks interface{} = this.SafeDict(currency, "networks", map[string]interface{}{})
+		var networkCodes interface{} = this.Sort(ObjectKeys(networks))
+		var result interface{} = map[string]interface{}{}
+		if IsTrue(IsEqual(GetArrayLength(networkCodes), 0)) {
+			// the networks are unknown without the currencies, the default network is returned
+
+			response := (<-this.SapiGetCapitalDepositAddress(this.Extend(map[string]interface{}{
+				"coin": GetValue(currency, "id"),
+			}, params)))
+			PanicOnError(response)
+			var address interface{} = this.ParseDepositAddress(response, currency)
+			AddElementToObject(result, this.SafeString(address, "network", GetValue(currency, "code")), address)
+
+			ch <- result
+			return nil
+		}
+		for i := 0; IsLessThan(i, GetArrayLength(networkCodes)); i++ {
+			var networkCode interface{} = GetValue(networkCodes, i)
+			var network interface{} = GetValue(networks, networkCode)
+			if IsTrue(!IsTrue(this.SafeBool(network, "deposit", true))) {
+				continue
+			}
+			var request interface{} = map[string]interface{}{
+				"coin":    GetValue(currency, "id"),
+				"network": GetValue(network, "id"),
+			}
+
+			response := (<-this.SapiGetCapitalDepositAddress(this.Extend(request, params)))
+			PanicOnError(response)
+			//
+			//     {
+			//         "coin": "USDT",
+			//         "address": "0xd9e1cd77afa0e50b452a62fbb68a3340602286c3",
+			//         "tag": "",
+			//         "url": "https://etherscan.io/address/0xd9e1cd77afa0e50b452a62fbb68a3340602286c3"
+			//     }
+			//
+			// the response has no network, only an explorer url
+			var address interface{} = this.ParseDepositAddress(response, currency)
+			AddElementToObject(address, "network", networkCode)
+			AddElementToObject(result, networkCode, address)
+		}
+
+		ch <- result
+		return nil
+
+	}()
+	return ch
+}
 func (this *BinanceCore) ParseDepositAddress(response interface{}, optionalArgs ...interface{}) interface{} {
 	//
 	//     {
@@ -11696,59 +12026,151 @@ func (this *BinanceCore) FetchLeverageTiers(optionalArgs ...interface{}) <-chan
 	}()
 	return ch
 }
-func (this *BinanceCore) ParseMarketLeverageTiers(info interface{}, optionalArgs ...interface{}) interface{} {
-	/**
-	 * @ignore
-	 * @method
-	 * @param {object} info Exchange response for 1 market
-	 * @param {object} market CCXT market
-	 */
-	//
-	//    {
-	//        "symbol": "SUSHIUSDT",
-	//        "brackets": [
-	//            {
-	//                "bracket": 1,
-	//                "initialLeverage": 50,
-	//                "notionalCap": 50000,
-	//                "notionalFloor": 0,
-	//                "maintMarginRatio": 0.01,
-	//                "cum": 0.0
-	//            },
-	//            ...
-	//        ]
-	//    }
-	//
-	market := GetArg(optionalArgs, 0, nil)
-	_ = market
-	var marketId interface{} = this.SafeString(info, "symbol")
-	market = this.SafeMarket(marketId, market, nil, "contract")
-	var brackets interface{} = this.SafeList(info, "brackets", []interface{}{})
-	var tiers interface{} = []interface{}{}
-	for j := 0; IsLessThan(j, GetArrayLength(brackets)); j++ {
-		var bracket interface{} = GetValue(brackets, j)
-		AppendToArray(&tiers, map[string]interface{}{
-			"tier":                  this.SafeNumber(bracket, "bracket"),
-			"symbol":                this.SafeSymbol(marketId, market),
-			"currency":              GetValue(market, "quote"),
-			"minNotional":           this.SafeNumber2(bracket, "notionalFloor", "qtyFloor"),
-			"maxNotional":           this.SafeNumber2(bracket, "notionalCap", "qtyCap"),
-			"maintenanceMarginRate": this.SafeNumber(bracket, "maintMarginRatio"),
-			"maxLeverage":           this.SafeNumber(bracket, "initialLeverage"),
-			"info":                  bracket,
-		})
-	}
-	return tiers
-}
 
 /**
  * @method
- * @name binance#fetchPosition
- * @description fetch data on an open position
- * @see https://developers.binance.com/docs/derivatives/option/trade/Option-Position-Information
- * @param {string} symbol unified market symbol of the market the position is held in
- * @param {object} [params] extra parameters specific to the exchange API endpoint
- * @returns {object} a [position structure]{@link https://docs.ccxt.com/?id=position-structure}
+ * @name binance#fetchMarketLeverageTiers
+ * @description retrieve information on the maximum leverage, and maintenance margin for trades of varying trade sizes for a single market
+ * @see https://developers.binance.com/docs/derivatives/usds-margined-futures/account/rest-api/Notional-and-Leverage-Brackets
+ * @see https://developers.binance.com/docs/derivatives/coin-margined-futures/account/rest-api/Notional-Bracket-for-Pair
+ * @see https://developers.binance.com/docs/derivatives/portfolio-margin/account/UM-Notional-and-Leverage-Brackets
+ * @see https://developers.binance.com/docs/derivatives/portfolio-margin/account/CM-Notional-and-Leverage-Brackets
+ * @param {string} symbol unified market symbol
+ * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @param {boolean} [params.portfolioMargin] set to true if you would like to fetch the leverage tiers for a portfolio margin account
+ * @returns {object[]} a list of [leverage tiers structures]{@link https://docs.ccxt.com/?id=leverage-tiers-structure}
+ */
+func (this *BinanceCore) FetchMarketLeverageTiers(symbol interface{}, optionalArgs ...interface{}) <-chan interface{} {
+	ch := make(chan interface{})
+	go func() interface{} {
+		defer close(ch)
+		defer ReturnPanicError(ch)
+		params := GetArg(optionalArgs, 0, map[string]interface{}{})
+		_ = params
+
+		retRes107018 := (<-this.LoadMarkets())
+		PanicOnError(retRes107018)
+		var market interface{} = this.Market(symbol)
+		if !IsTrue(GetValue(market, "contract")) {
+			panic(BadSymbol(Add(this.Id, " fetchMarketLeverageTiers() supports contract markets only")))
+		}
+		var isPortfolioMargin interface{} = nil
+		isPortfolioMarginparamsVariable := this.HandleOptionAndParams2(params, "fetchMarketLeverageTiers", "papi", "portfolioMargin", false)
+		isPortfolioMargin = GetValue(isPortfolioMarginparamsVariable, 0)
+		params = GetValue(isPortfolioMarginparamsVariable, 1)
+		// the brackets of the other markets are left out by the exchange
+		var request interface{} = map[string]interface{}{
+			"symbol": GetValue(market, "id"),
+		}
+		var response interface{} = nil
+		if IsTrue(GetValue(market, "linear")) {
+			if IsTrue(isPortfolioMargin) {
+
+				response = (<-this.PapiGetUmLeverageBracket(this.Extend(request, params)))
+				PanicOnError(response)
+			} else {
+
+				response = (<-this.FapiPrivateGetLeverageBracket(this.Extend(request, params)))
+				PanicOnError(response)
+			}
+		} else if IsTrue(GetValue(market, "inverse")) {
+			if IsTrue(isPortfolioMargin) {
+
+				response = (<-this.PapiGetCmLeverageBracket(this.Extend(request, params)))
+				PanicOnError(response)
+			} else {
+
+				response = (<-this.DapiPrivateV2GetLeverageBracket(this.Extend(request, params)))
+				PanicOnError(response)
+			}
+		} else {
+			panic(NotSupported(Add(this.Id, " fetchMarketLeverageTiers() supports linear and inverse contracts only")))
+		}
+		//
+		// a list of one entry, or the entry itself for the usdm endpoint
+		//
+		//    [
+		//        {
+		//            "symbol": "BTCUSDT",
+		//            "brackets": [
+		//                {
+		//                    "bracket": 1,
+		//                    "initialLeverage": 125,
+		//                    "notionalCap": 50000,
+		//                    "notionalFloor": 0,
+		//                    "maintMarginRatio": 0.004,
+		//                    "cum": 0.0
+		//                },
+		//                ...
+		//            ]
+		//        }
+		//    ]
+		//
+		var entry interface{} = response
+		if IsTrue(IsArray(response)) {
+			var entries interface{} = this.FilterBy(response, "symbol", GetValue(market, "id"))
+			entry = this.SafeDict(entries, 0, map[string]interface{}{})
+		}
+
+		ch <- this.ParseMarketLeverageTiers(entry, market)
+		return nil
+
+	}()
+	return ch
+}
+func (this *BinanceCore) ParseMarketLeverageTiers(info interface{}, optionalArgs ...interface{}) interface{} {
+	/**
+	 * @ignore
+	 * @method
+	 * @param {object} info Exchange response for 1 market
+	 * @param {object} market CCXT market
+	 */
+	//
+	//    {
+	//        "symbol": "SUSHIUSDT",
+	//        "brackets": [
+	//            {
+	//                "bracket": 1,
+	//                "initialLeverage": 50,
+	//                "notionalCap": 50000,
+	//                "notionalFloor": 0,
+	//                "maintMarginRatio": 0.01,
+	//                "cum": 0.0
+	//            },
+	//            ...
+	//        ]
+	//    }
+	//
+	market := GetArg(optionalArgs, 0, nil)
+	_ = market
+	var marketId interface{} = this.SafeString(info, "symbol")
+	market = this.SafeMarket(marketId, market, nil, "contract")
+	var brackets interface{} = this.SafeList(info, "brackets", []interface{}{})
+	var tiers interface{} = []interface{}{}
+	for j := 0; IsLessThan(j, GetArrayLength(brackets)); j++ {
+		var bracket interface{} = GetValue(brackets, j)
+		AppendToArray(&tiers, map[string]interface{}{
+			"tier":                  this.SafeNumber(bracket, "bracket"),
+			"symbol":                this.SafeSymbol(marketId, market),
+			"currency":              GetValue(market, "quote"),
+			"minNotional":           this.SafeNumber2(bracket, "notionalFloor", "qtyFloor"),
+			"maxNotional":           this.SafeNumber2(bracket, "notionalCap", "qtyCap"),
+			"maintenanceMarginRate": this.SafeNumber(bracket, "maintMarginRatio"),
+			"maxLeverage":           this.SafeNumber(bracket, "initialLeverage"),
+			"info":                  bracket,
+		})
+	}
+	return tiers
+}
+
+/**
+ * @method
+ * @name binance#fetchPosition
+ * @description fetch data on an open position
+ * @see https://developers.binance.com/docs/derivatives/option/trade/Option-Position-Information
+ * @param {string} symbol unified market symbol of the market the position is held in
+ * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @returns {object} a [position structure]{@link https://docs.ccxt.com/?id=position-structure}
  */
 func (this *BinanceCore) FetchPosition(symbol interface{}, optionalArgs ...interface{}) <-chan interface{} {
 	ch := make(chan interface{})
@@ -12279,6 +12701,280 @@ func (this *BinanceCore) FetchPositionsRisk(optionalArgs ...interface{}) <-chan
 	return ch
 }
 
+/**
+ * @method
+ * @name binance#fetchPositionHistory
+ * @description fetches the closed positions of a contract market, they are rebuilt from the account trades
+ * @see https://developers.binance.com/docs/derivatives/usds-margined-futures/trade/rest-api/Account-Trade-List
+ * @see https://developers.binance.com/docs/derivatives/coin-margined-futures/trade/rest-api/Account-Trade-List
+ * @see https://developers.binance.com/docs/derivatives/usds-margined-futures/account/rest-api/Get-Income-History
+ * @see https://developers.binance.com/docs/derivatives/coin-margined-futures/account/rest-api/Get-Income-History
+ * @param {string} symbol unified contract symbol
+ * @param {int} [since] the earliest time in ms to fetch positions for, default is 7 days before until
+ * @param {int} [limit] the maximum number of position structures to retrieve
+ * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @param {int} [params.until] the latest time in ms to fetch positions for, default is now
+ * @returns {object[]} a list of [position structures]{@link https://docs.ccxt.com/?id=position-structure}, the fees and the funding of each position are in its info
+ */
+func (this *BinanceCore) FetchPositionHistory(symbol interface{}, optionalArgs ...interface{}) <-chan interface{} {
+	ch := make(chan interface{})
+	go func() interface{} {
+		defer close(ch)
+		defer ReturnPanicError(ch)
+		since := GetArg(optionalArgs, 0, nil)
+		_ = since
+		limit := GetArg(optionalArgs, 1, nil)
+		_ = limit
+		params := GetArg(optionalArgs, 2, map[string]interface{}{})
+		_ = params
+
+		retRes113098 := (<-this.LoadMarkets())
+		PanicOnError(retRes113098)
+		var market interface{} = this.Market(symbol)
+		if !IsTrue(GetValue(market, "contract")) {
+			panic(NotSupported(Add(this.Id, " fetchPositionHistory() supports linear and inverse contracts only")))
+		}
+		var until interface{} = this.SafeInteger2(params, "until", "endTime", this.Milliseconds())
+		params = this.Omit(params, []interface{}{"until", "endTime"})
+		// the time between startTime and endTime cannot be longer than 7 days, longer ranges are fetched week by week
+		var oneWeek interface{} = Multiply(Multiply(Multiply(Multiply(7, 24), 60), 60), 1000)
+		var start interface{} = Ternary(IsTrue((!IsEqual(since, nil))), since, Subtract(until, oneWeek))
+		var maxEntriesPerRequest interface{} = 1000
+		var trades interface{} = []interface{}{}
+		var tradeIds interface{} = map[string]interface{}{}
+		var startTime interface{} = start
+		for IsTrue(IsLessThanOrEqual(startTime, until)) {
+			var endTime interface{} = mathMin(Subtract(Add(startTime, oneWeek), 1), until)
+			var request interface{} = map[string]interface{}{
+				"symbol":    GetValue(market, "id"),
+				"startTime": startTime,
+				"endTime":   endTime,
+				"limit":     maxEntriesPerRequest,
+			}
+			var response interface{} = nil
+			if IsTrue(GetValue(market, "linear")) {
+
+				response = (<-this.FapiPrivateGetUserTrades(this.Extend(request, params)))
+				PanicOnError(response)
+			} else {
+
+				response = (<-this.DapiPrivateGetUserTrades(this.Extend(request, params)))
+				PanicOnError(response)
+			}
+			var responseLength interface{} = GetArrayLength(response)
+			for i := 0; IsLessThan(i, responseLength); i++ {
+				var trade interface{} = GetValue(response, i)
+				var tradeId interface{} = this.SafeString(trade, "id")
+				if !IsTrue(InOp(tradeIds, tradeId)) {
+					AddElementToObject(tradeIds, tradeId, true)
+					AppendToArray(&trades, trade)
+				}
+			}
+			// a full page goes on from the time of its last trade, the trades already seen are skipped
+			var lastTime interface{} = nil
+			if IsTrue(IsGreaterThan(responseLength, 0)) {
+				lastTime = this.SafeInteger(GetValue(response, Subtract(responseLength, 1)), "time")
+			}
+			if IsTrue(IsTrue(IsEqual(responseLength, maxEntriesPerRequest)) && IsTrue(IsGreaterThan(lastTime, startTime))) {
+				startTime = lastTime
+			} else {
+				startTime = Add(endTime, 1)
+			}
+		}
+		var fundings interface{} = []interface{}{}
+		startTime = start
+		for IsTrue(IsLessThanOrEqual(startTime, until)) {
+			var endTime interface{} = mathMin(Subtract(Add(startTime, oneWeek), 1), until)
+			var request interface{} = map[string]interface{}{
+				"symbol":     GetValue(market, "id"),
+				"incomeType": "FUNDING_FEE",
+				"startTime":  startTime,
+				"endTime":    endTime,
+				"limit":      maxEntriesPerRequest,
+			}
+			var response interface{} = nil
+			if IsTrue(GetValue(market, "linear")) {
+
+				response = (<-this.FapiPrivateGetIncome(this.Extend(request, params)))
+				PanicOnError(response)
+			} else {
+
+				response = (<-this.DapiPrivateGetIncome(this.Extend(request, params)))
+				PanicOnError(response)
+			}
+			fundings = this.ArrayConcat(fundings, response)
+			startTime = Add(endTime, 1)
+		}
+		trades = this.SortBy2(trades, "time", "id")
+		var records interface{} = this.BuildPositionHistory(trades, fundings)
+		var positions interface{} = []interface{}{}
+		for i := 0; IsLessThan(i, GetArrayLength(records)); i++ {
+			AppendToArray(&positions, this.ParsePositionHistory(GetValue(records, i), market))
+		}
+
+		ch <- this.FilterBySymbolSinceLimit(positions, symbol, since, limit)
+		return nil
+
+	}()
+	return ch
+}
+
+/**
+ * @ignore
+ * @method
+ * @description replays the account trades of a market, a position is closed when its size gets back to zero,
+ * the trades closing a position that was opened before the first trade are skipped and the open positions are left out
+ * @param {object[]} trades the account trades sorted by time
+ * @param {object[]} fundings the funding fee incomes of the market
+ * @returns {object[]} the closed positions
+ */
+func (this *BinanceCore) BuildPositionHistory(trades interface{}, fundings interface{}) interface{} {
+	var open interface{} = map[string]interface{}{}
+	var result interface{} = []interface{}{}
+	for i := 0; IsLessThan(i, GetArrayLength(trades)); i++ {
+		var trade interface{} = GetValue(trades, i)
+		var positionSide interface{} = this.SafeString(trade, "positionSide", "BOTH")
+		var direction interface{} = Ternary(IsTrue((IsEqual(this.SafeString(trade, "side"), "BUY"))), "1", "-1")
+		var amount interface{} = this.SafeString(trade, "qty")
+		var price interface{} = this.SafeString(trade, "price")
+		var commission interface{} = this.SafeString(trade, "commission", "0")
+		var timestamp interface{} = this.SafeInteger(trade, "time")
+		var position interface{} = this.SafeDict(open, positionSide)
+		if IsTrue(IsEqual(position, nil)) {
+			if !IsTrue(Precise.StringEq(this.SafeString(trade, "realizedPnl", "0"), "0")) {
+				continue
+			}
+			position = this.OpenPositionHistoryRecord(trade, positionSide, direction, amount, commission)
+			AddElementToObject(open, positionSide, position)
+			continue
+		}
+		var size interface{} = this.SafeString(position, "size")
+		if IsTrue(Precise.StringGt(Precise.StringMul(size, direction), "0")) {
+			// the trade adds to the position
+			size = Precise.StringAdd(size, Precise.StringMul(direction, amount))
+			AddElementToObject(position, "size", size)
+			AddElementToObject(position, "entryCost", Precise.StringAdd(this.SafeString(position, "entryCost"), Precise.StringMul(amount, price)))
+			AddElementToObject(position, "openedAmount", Precise.StringAdd(this.SafeString(position, "openedAmount"), amount))
+			AddElementToObject(position, "contracts", Precise.StringMax(this.SafeString(position, "contracts"), Precise.StringAbs(size)))
+			AddElementToObject(position, "commission", Precise.StringAdd(this.SafeString(position, "commission"), commission))
+			var positionTrades interface{} = GetValue(position, "trades")
+			AppendToArray(&positionTrades, trade)
+			AddElementToObject(position, "trades", positionTrades)
+			continue
+		}
+		// the trade reduces the position, the part of it exceeding the size opens a position on the other side
+		var closedAmount interface{} = Precise.StringMin(amount, Precise.StringAbs(size))
+		var remainingAmount interface{} = Precise.StringSub(amount, closedAmount)
+		var closedCommission interface{} = Precise.StringDiv(Precise.StringMul(commission, closedAmount), amount)
+		size = Precise.StringAdd(size, Precise.StringMul(direction, closedAmount))
+		AddElementToObject(position, "size", size)
+		AddElementToObject(position, "exitCost", Precise.StringAdd(this.SafeString(position, "exitCost"), Precise.StringMul(closedAmount, price)))
+		AddElementToObject(position, "closedAmount", Precise.StringAdd(this.SafeString(position, "closedAmount"), closedAmount))
+		AddElementToObject(position, "realizedPnl", Precise.StringAdd(this.SafeString(position, "realizedPnl"), this.SafeString(trade, "realizedPnl", "0")))
+		AddElementToObject(position, "commission", Precise.StringAdd(this.SafeString(position, "commission"), closedCommission))
+		AddElementToObject(position, "updateTime", timestamp)
+		var positionTrades interface{} = GetValue(position, "trades")
+		AppendToArray(&positionTrades, trade)
+		AddElementToObject(position, "trades", positionTrades)
+		if IsTrue(Precise.StringEq(size, "0")) {
+			AppendToArray(&result, position)
+			Remove(open, positionSide)
+			if IsTrue(Precise.StringGt(remainingAmount, "0")) {
+				var remainingCommission interface{} = Precise.StringSub(commission, closedCommission)
+				AddElementToObject(open, positionSide, this.OpenPositionHistoryRecord(trade, positionSide, direction, remainingAmount, remainingCommission))
+			}
+		}
+	}
+	for i := 0; IsLessThan(i, GetArrayLength(result)); i++ {
+		var position interface{} = GetValue(result, i)
+		var totalFunding interface{} = "0"
+		for j := 0; IsLessThan(j, GetArrayLength(fundings)); j++ {
+			var funding interface{} = GetValue(fundings, j)
+			var fundingTime interface{} = this.SafeInteger(funding, "time")
+			if IsTrue(IsTrue(IsGreaterThanOrEqual(fundingTime, GetValue(position, "openTime"))) && IsTrue(IsLessThanOrEqual(fundingTime, GetValue(position, "updateTime")))) {
+				totalFunding = Precise.StringAdd(totalFunding, this.SafeString(funding, "income", "0"))
+			}
+		}
+		AddElementToObject(position, "totalFunding", totalFunding)
+		AddElementToObject(position, "entryPrice", Precise.StringDiv(GetValue(position, "entryCost"), GetValue(position, "openedAmount")))
+		AddElementToObject(position, "closePrice", Precise.StringDiv(GetValue(position, "exitCost"), GetValue(position, "closedAmount")))
+	}
+	return result
+}
+
+func (this *BinanceCore) OpenPositionHistoryRecord(trade interface{}, positionSide interface{}, direction interface{}, amount interface{}, commission interface{}) interface{} {
+	return map[string]interface{}{
+		"symbol":       this.SafeString(trade, "symbol"),
+		"positionSide": positionSide,
+		"side":         Ternary(IsTrue((IsEqual(direction, "1"))), "long", "short"),
+		"size":         Precise.StringMul(direction, amount),
+		"contracts":    amount,
+		"entryCost":    Precise.StringMul(amount, this.SafeString(trade, "price")),
+		"openedAmount": amount,
+		"exitCost":     "0",
+		"closedAmount": "0",
+		"realizedPnl":  "0",
+		"commission":   commission,
+		"openTime":     this.SafeInteger(trade, "time"),
+		"updateTime":   this.SafeInteger(trade, "time"),
+		"trades":       []interface{}{trade},
+	}
+}
+
+func (this *BinanceCore) ParsePositionHistory(position interface{}, optionalArgs ...interface{}) interface{} {
+	//
+	//     {
+	//         "symbol": "BTCUSDT",
+	//         "positionSide": "BOTH",
+	//         "side": "long",
+	//         "contracts": "0.02",
+	//         "entryPrice": "60000",
+	//         "closePrice": "61000",
+	//         "realizedPnl": "20",
+	//         "commission": "0.96",
+	//         "totalFunding": "-0.5",
+	//         "openTime": 1714560000000,
+	//         "updateTime": 1715169600000,
+	//         "trades": [ ... ]
+	//     }
+	//
+	market := GetArg(optionalArgs, 0, nil)
+	_ = market
+	var marketId interface{} = this.SafeString(position, "symbol")
+	var timestamp interface{} = this.SafeInteger(position, "openTime")
+	var positionSide interface{} = this.SafeString(position, "positionSide")
+	return this.SafePosition(map[string]interface{}{
+		"info":                        position,
+		"id":                          nil,
+		"symbol":                      this.SafeSymbol(marketId, market, nil, "contract"),
+		"notional":                    nil,
+		"marginMode":                  nil,
+		"liquidationPrice":            nil,
+		"entryPrice":                  this.SafeNumber(position, "entryPrice"),
+		"unrealizedPnl":               nil,
+		"realizedPnl":                 this.SafeNumber(position, "realizedPnl"),
+		"percentage":                  nil,
+		"contracts":                   this.SafeNumber(position, "contracts"),
+		"contractSize":                this.SafeNumber(market, "contractSize"),
+		"markPrice":                   nil,
+		"lastPrice":                   this.SafeNumber(position, "closePrice"),
+		"side":                        this.SafeString(position, "side"),
+		"hedged":                      !IsEqual(positionSide, "BOTH"),
+		"timestamp":                   timestamp,
+		"datetime":                    this.Iso8601(timestamp),
+		"lastUpdateTimestamp":         this.SafeInteger(position, "updateTime"),
+		"maintenanceMargin":           nil,
+		"maintenanceMarginPercentage": nil,
+		"collateral":                  nil,
+		"initialMargin":               nil,
+		"initialMarginPercentage":     nil,
+		"leverage":                    nil,
+		"marginRatio":                 nil,
+		"stopLossPrice":               nil,
+		"takeProfitPrice":             nil,
+	})
+}
+
 /**
  * @method
  * @name binance#fetchFundingHistory
@@ -12398,6 +13094,8 @@ func (this *BinanceCore) SetLeverage(leverage interface{}, optionalArgs ...inter
 		_ = symbol
 		params := GetArg(optionalArgs, 1, map[string]interface{}{})
 		_ = params
+		retRes131087 := (<-this.CheckLeverage(leverage, symbol, params))
+		PanicOnError(retRes131087)
 		if IsTrue(IsEqual(symbol, nil)) {
 			panic(ArgumentsRequired(Add(this.Id, " setLeverage() requires a symbol argument")))
 		}
@@ -12576,6 +13274,9 @@ func (this *BinanceCore) SetPositionMode(hedged interface{}, optionalArgs ...int
 		_ = symbol
 		params := GetArg(optionalArgs, 1, map[string]interface{}{})
 		_ = params
+
+		retRes11298 := (<-this.LoadMarkets())
+		PanicOnError(retRes11298)
 		var market interface{} = nil
 		if IsTrue(!IsEqual(symbol, nil)) {
 			market = this.Market(symbol)
@@ -12639,6 +13340,128 @@ func (this *BinanceCore) SetPositionMode(hedged interface{}, optionalArgs ...int
 	return ch
 }
 
+/**
+ * @method
+ * @name binance#fetchLeverage
+ * @description fetch the set leverage for a market
+ * @see https://developers.binance.com/docs/derivatives/usds-margined-futures/account/rest-api/Symbol-Config
+ * @see https://developers.binance.com/docs/derivatives/coin-margined-futures/trade/rest-api/Position-Information
+ * @see https://developers.binance.com/docs/derivatives/portfolio-margin/account/Query-UM-Position-Information
+ * @see https://developers.binance.com/docs/derivatives/portfolio-margin/account/Query-CM-Position-Information
+ * @param {string} symbol unified market symbol
+ * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @param {boolean} [params.portfolioMargin] set to true if you would like to fetch the leverage for a portfolio margin account
+ * @returns {object} a [leverage structure]{@link https://docs.ccxt.com/?id=leverage-structure}
+ */
+func (this *BinanceCore) FetchLeverage(symbol interface{}, optionalArgs ...interface{}) <-chan interface{} {
+	ch := make(chan interface{})
+	go func() interface{} {
+		defer close(ch)
+		defer ReturnPanicError(ch)
+		params := GetArg(optionalArgs, 0, map[string]interface{}{})
+		_ = params
+
+		retRes115418 := (<-this.LoadMarkets())
+		PanicOnError(retRes115418)
+		var market interface{} = this.Market(symbol)
+		if !IsTrue(GetValue(market, "contract")) || IsTrue(GetValue(market, "option")) {
+			panic(BadSymbol(Add(this.Id, " fetchLeverage() supports linear and inverse contracts only")))
+		}
+		var isPortfolioMargin interface{} = nil
+		isPortfolioMarginparamsVariable := this.HandleOptionAndParams2(params, "fetchLeverage", "papi", "portfolioMargin", false)
+		isPortfolioMargin = GetValue(isPortfolioMarginparamsVariable, 0)
+		params = GetValue(isPortfolioMarginparamsVariable, 1)
+		var response interface{} = nil
+		if IsTrue(GetValue(market, "linear")) {
+			var request interface{} = map[string]interface{}{
+				"symbol": GetValue(market, "id"),
+			}
+			if IsTrue(isPortfolioMargin) {
+
+				response = (<-this.PapiGetUmPositionRisk(this.Extend(request, params)))
+				PanicOnError(response)
+			} else {
+
+				response = (<-this.FapiPrivateGetSymbolConfig(this.Extend(request, params)))
+				PanicOnError(response)
+			}
+		} else {
+			// the coin-m positions are listed by pair
+			var request interface{} = map[string]interface{}{
+				"pair": this.SafeString(GetValue(market, "info"), "pair"),
+			}
+			if IsTrue(isPortfolioMargin) {
+
+				response = (<-this.PapiGetCmPositionRisk(this.Extend(request, params)))
+				PanicOnError(response)
+			} else {
+
+				response = (<-this.DapiPrivateGetPositionRisk(this.Extend(request, params)))
+				PanicOnError(response)
+			}
+		}
+		//
+		// symbolConfig, one entry per symbol whatever the position mode
+		//
+		//     [
+		//         {
+		//             "symbol": "BTCUSDT",
+		//             "marginType": "CROSSED",
+		//             "isAutoAddMargin": "false",
+		//             "leverage": 21,
+		//             "maxNotionalValue": "1000000"
+		//         }
+		//     ]
+		//
+		// positionRisk, a BOTH entry in one-way mode, a LONG and a SHORT entry in hedge mode
+		//
+		//     [
+		//         {
+		//             "symbol": "BTCUSD_PERP",
+		//             "positionAmt": "0",
+		//             "leverage": "20",
+		//             "marginType": "isolated",
+		//             "positionSide": "LONG",
+		//             ...
+		//         },
+		//         {
+		//             "symbol": "BTCUSD_PERP",
+		//             "positionAmt": "0",
+		//             "leverage": "20",
+		//             "marginType": "isolated",
+		//             "positionSide": "SHORT",
+		//             ...
+		//         }
+		//     ]
+		//
+		var result interface{} = nil
+		for i := 0; IsLessThan(i, GetArrayLength(response)); i++ {
+			var entry interface{} = GetValue(response, i)
+			if IsTrue(!IsEqual(this.SafeString(entry, "symbol"), GetValue(market, "id"))) {
+				continue
+			}
+			var leverage interface{} = this.ParseLeverage(entry, market)
+			if IsTrue(IsEqual(result, nil)) {
+				result = leverage
+			} else {
+				// merge the sides of a hedge mode position
+				AddElementToObject(result, "longLeverage", this.SafeInteger(result, "longLeverage", GetValue(leverage, "longLeverage")))
+				AddElementToObject(result, "shortLeverage", this.SafeInteger(result, "shortLeverage", GetValue(leverage, "shortLeverage")))
+			}
+		}
+		if IsTrue(IsEqual(result, nil)) {
+			result = this.ParseLeverage(map[string]interface{}{
+				"symbol": GetValue(market, "id"),
+			}, market)
+		}
+
+		ch <- result
+		return nil
+
+	}()
+	return ch
+}
+
 /**
  * @method
  * @name binance#fetchLeverages
@@ -13452,7 +14275,7 @@ func (this *BinanceCore) Sign(path interface{}, optionalArgs ...interface{}) int
 }
 func (this *BinanceCore) GetExceptionsByUrl(url interface{}, exactOrBroad interface{}) interface{} {
 	var marketType interface{} = nil
-	var hostname interface{} = Ternary(IsTrue((!IsEqual(this.Hostname, nil))), this.Hostname, "binance.com")
+	var hostname interface{} = Ternary(IsTrue(IsTrue((!IsEqual(this.Hostname, nil))) && IsTrue((!IsEqual(this.Hostname, "")))), this.Hostname, "binance.com")
 	if IsTrue(IsTrue(IsTrue(StartsWith(url, Add(Add("https://api.", hostname), "/"))) || IsTrue(StartsWith(url, "https://demo-api"))) || IsTrue(StartsWith(url, "https://testnet.binance.vision"))) {
 		marketType = "spot"
 	} else if IsTrue(IsTrue(IsTrue(StartsWith(url, Add(Add("https://dapi.", hostname), "/"))) || IsTrue(StartsWith(url, "https://demo-dapi"))) || IsTrue(StartsWith(url, "https://testnet.binancefuture.com/dapi"))) {
@@ -13786,8 +14609,10 @@ func (this *BinanceCore) AddMargin(symbol interface{}, amount interface{}, optio
  * @name binance#fetchCrossBorrowRate
  * @description fetch the rate of interest to borrow a currency for margin trading
  * @see https://developers.binance.com/docs/margin_trading/borrow-and-repay/Query-Margin-Interest-Rate-History
+ * @see https://developers.binance.com/docs/margin_trading/borrow-and-repay/Get-future-hourly-interest-rate
  * @param {string} code unified currency code
  * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @param {string} [params.period] '1d' (default) for the daily rate or '1h' for the rate of the next hour
  * @returns {object} a [borrow rate structure]{@link https://docs.ccxt.com/?id=borrow-rate-structure}
  */
 func (this *BinanceCore) FetchCrossBorrowRate(code interface{}, optionalArgs ...interface{}) <-chan interface{} {
@@ -13801,11 +14626,45 @@ func (this *BinanceCore) FetchCrossBorrowRate(code interface{}, optionalArgs ...
 		retRes124618 := (<-this.LoadMarkets())
 		PanicOnError(retRes124618)
 		var currency interface{} = this.Currency(code)
+		var period interface{} = this.SafeString(params, "period", "1d")
+		params = this.Omit(params, "period")
+		var cacheKey interface{} = this.BorrowRateCacheKey("fetchCrossBorrowRate", GetValue(currency, "code"), period, params)
+		var cached interface{} = this.GetCachedBorrowRate(cacheKey)
+		if IsTrue(!IsEqual(cached, nil)) {
+
+			ch <- cached
+			return nil
+		}
+		var response interface{} = nil
+		if IsTrue(IsEqual(period, "1h")) {
+			var hourlyRequest interface{} = map[string]interface{}{
+				"assets":     GetValue(currency, "id"),
+				"isIsolated": "FALSE",
+			}
+
+			response = (<-this.SapiGetMarginNextHourlyInterestRate(this.Extend(hourlyRequest, params)))
+			PanicOnError(response)
+			//
+			//     [
+			//         {
+			//             "asset": "BTC",
+			//             "nextHourlyInterestRate": "0.00000571"
+			//         }
+			//     ]
+			//
+			var hourlyRate interface{} = this.ParseBorrowRate(this.SafeDict(response, 0), currency)
+			this.SetCachedBorrowRate(cacheKey, hourlyRate)
+
+			ch <- hourlyRate
+			return nil
+		} else if IsTrue(!IsEqual(period, "1d")) {
+			panic(BadRequest(Add(this.Id, " fetchCrossBorrowRate() period must be 1d or 1h")))
+		}
 		var request interface{} = map[string]interface{}{
 			"asset": GetValue(currency, "id"),
 		}
 
-		response := (<-this.SapiGetMarginInterestRateHistory(this.Extend(request, params)))
+		response = (<-this.SapiGetMarginInterestRateHistory(this.Extend(request, params)))
 		PanicOnError(response)
 		//
 		//     [
@@ -13817,9 +14676,10 @@ func (this *BinanceCore) FetchCrossBorrowRate(code interface{}, optionalArgs ...
 		//         },
 		//     ]
 		//
-		var rate interface{} = this.SafeDict(response, 0)
+		var rate interface{} = this.ParseBorrowRate(this.SafeDict(response, 0), currency)
+		this.SetCachedBorrowRate(cacheKey, rate)
 
-		ch <- this.ParseBorrowRate(rate)
+		ch <- rate
 		return nil
 
 	}()
@@ -13831,8 +14691,10 @@ func (this *BinanceCore) FetchCrossBorrowRate(code interface{}, optionalArgs ...
  * @name binance#fetchIsolatedBorrowRate
  * @description fetch the rate of interest to borrow a currency for margin trading
  * @see https://developers.binance.com/docs/margin_trading/account/Query-Isolated-Margin-Fee-Data
+ * @see https://developers.binance.com/docs/margin_trading/borrow-and-repay/Get-future-hourly-interest-rate
  * @param {string} symbol unified market symbol
  * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @param {string} [params.period] '1d' (default) for the daily rates or '1h' for the rates of the next hour
  *
  * EXCHANGE SPECIFIC PARAMETERS
  * @param {object} [params.vipLevel] user's current specific margin data will be returned if viplevel is omitted
@@ -13845,14 +14707,56 @@ func (this *BinanceCore) FetchIsolatedBorrowRate(symbol interface{}, optionalArg
 		defer ReturnPanicError(ch)
 		params := GetArg(optionalArgs, 0, map[string]interface{}{})
 		_ = params
-		var request interface{} = map[string]interface{}{
-			"symbol": symbol,
+
+		retRes124978 := (<-this.LoadMarkets())
+		PanicOnError(retRes124978)
+		var market interface{} = this.Market(symbol)
+		var period interface{} = this.SafeString(params, "period", "1d")
+		params = this.Omit(params, "period")
+		var cacheKey interface{} = this.BorrowRateCacheKey("fetchIsolatedBorrowRate", GetValue(market, "symbol"), period, params)
+		var cached interface{} = this.GetCachedBorrowRate(cacheKey)
+		if IsTrue(!IsEqual(cached, nil)) {
+
+			ch <- cached
+			return nil
 		}
+		var borrowRate interface{} = nil
+		if IsTrue(IsEqual(period, "1h")) {
+			var request interface{} = map[string]interface{}{
+				"assets":     Add(Add(GetValue(market, "baseId"), ","), GetValue(market, "quoteId")),
+				"isIsolated": "TRUE",
+			}
 
-		borrowRates := (<-this.FetchIsolatedBorrowRates(this.Extend(request, params)))
-		PanicOnError(borrowRates)
+			response := (<-this.SapiGetMarginNextHourlyInterestRate(this.Extend(request, params)))
+			PanicOnError(response)
+			var rates interface{} = this.IndexBy(response, "asset")
+			var baseRate interface{} = this.SafeDict(rates, GetValue(market, "baseId"), map[string]interface{}{})
+			var quoteRate interface{} = this.SafeDict(rates, GetValue(market, "quoteId"), map[string]interface{}{})
+			borrowRate = map[string]interface{}{
+				"info":      rates,
+				"symbol":    GetValue(market, "symbol"),
+				"base":      GetValue(market, "base"),
+				"baseRate":  this.SafeNumber(baseRate, "nextHourlyInterestRate"),
+				"quote":     GetValue(market, "quote"),
+				"quoteRate": this.SafeNumber(quoteRate, "nextHourlyInterestRate"),
+				"period":    3600000,
+				"timestamp": nil,
+				"datetime":  nil,
+			}
+		} else if IsTrue(IsEqual(period, "1d")) {
+			var request interface{} = map[string]interface{}{
+				"symbol": symbol,
+			}
 
-		ch <- this.SafeDict(borrowRates, symbol)
+			borrowRates := (<-this.FetchIsolatedBorrowRates(this.Extend(request, params)))
+			PanicOnError(borrowRates)
+			borrowRate = this.SafeDict(borrowRates, symbol)
+		} else {
+			panic(BadRequest(Add(this.Id, " fetchIsolatedBorrowRate() period must be 1d or 1h")))
+		}
+		this.SetCachedBorrowRate(cacheKey, borrowRate)
+
+		ch <- borrowRate
 		return nil
 
 	}()
@@ -13990,14 +14894,26 @@ func (this *BinanceCore) ParseBorrowRate(info interface{}, optionalArgs ...inter
 	//        "vipLevel": 0
 	//    }
 	//
+	// fetchCrossBorrowRate hourly
+	//
+	//    {
+	//        "asset": "BTC",
+	//        "nextHourlyInterestRate": "0.00000571"
+	//    }
+	//
 	currency := GetArg(optionalArgs, 0, nil)
 	_ = currency
 	var timestamp interface{} = this.SafeInteger(info, "timestamp")
 	var currencyId interface{} = this.SafeString(info, "asset")
+	var hourlyRate interface{} = this.SafeNumber(info, "nextHourlyInterestRate")
+	var period interface{} = 86400000
+	if IsTrue(!IsEqual(hourlyRate, nil)) {
+		period = 3600000
+	}
 	return map[string]interface{}{
 		"currency":  this.SafeCurrencyCode(currencyId, currency),
-		"rate":      this.SafeNumber(info, "dailyInterestRate"),
-		"period":    86400000,
+		"rate":      this.SafeNumber(info, "dailyInterestRate", hourlyRate),
+		"period":    period,
 		"timestamp": timestamp,
 		"datetime":  this.Iso8601(timestamp),
 		"info":      info,
@@ -14043,6 +14959,36 @@ func (this *BinanceCore) ParseIsolatedBorrowRate(info interface{}, optionalArgs
 	}
 }
 
+func (this *BinanceCore) GetCachedBorrowRate(key interface{}) interface{} {
+	// the borrow rate of the request fetched less than options.borrowRatesExpires ms ago, as a copy
+	var expires interface{} = this.SafeInteger(this.Options, "borrowRatesExpires", 0)
+	if IsTrue(IsLessThanOrEqual(expires, 0)) {
+		return nil
+	}
+	var cache interface{} = this.SafeDict(this.Options, "borrowRatesCache", map[string]interface{}{})
+	var cached interface{} = this.SafeDict(cache, key)
+	if IsTrue(IsTrue((IsEqual(cached, nil))) || IsTrue((IsGreaterThanOrEqual((Subtract(this.Milliseconds(), GetValue(cached, "timestamp"))), expires)))) {
+		return nil
+	}
+	return this.Clone(GetValue(cached, "rate"))
+}
+func (this *BinanceCore) SetCachedBorrowRate(key interface{}, rate interface{}) {
+	// the cache is replaced instead of updated in place, it is shared by the concurrent calls
+	var cache interface{} = this.SafeDict(this.Options, "borrowRatesCache", map[string]interface{}{})
+	var entry interface{} = map[string]interface{}{}
+	AddElementToObject(entry, key, map[string]interface{}{
+		"rate":      this.Clone(rate),
+		"timestamp": this.Milliseconds(),
+	})
+	AddElementToObject(this.Options, "borrowRatesCache", this.Extend(cache, entry))
+}
+func (this *BinanceCore) BorrowRateCacheKey(method interface{}, id interface{}, period interface{}, optionalArgs ...interface{}) interface{} {
+	// a borrow rate request is identified by the method, the currency or symbol, the period and the params
+	params := GetArg(optionalArgs, 0, map[string]interface{}{})
+	_ = params
+	return Add(Add(Add(Add(Add(Add(method, ":"), id), ":"), period), ":"), this.Json(params))
+}
+
 /**
  * @method
  * @name binance#createGiftCode
@@ -14188,6 +15134,7 @@ func (this *BinanceCore) VerifyGiftCode(id interface{}, optionalArgs ...interfac
  * @param {int} [limit] the maximum number of structures to retrieve
  * @param {object} [params] extra parameters specific to the exchange API endpoint
  * @param {boolean} [params.portfolioMargin] set to true if you would like to fetch the borrow interest in a portfolio margin account
+ * @param {string} [params.marginMode] 'cross' or 'isolated', isolated by default when a symbol is provided
  * @returns {object[]} a list of [borrow interest structures]{@link https://docs.ccxt.com/?id=borrow-interest-structure}
  */
 func (this *BinanceCore) FetchBorrowInterest(optionalArgs ...interface{}) <-chan interface{} {
@@ -14233,7 +15180,15 @@ func (this *BinanceCore) FetchBorrowInterest(optionalArgs ...interface{}) <-chan
 			response = (<-this.PapiGetMarginMarginInterestHistory(this.Extend(request, params)))
 			PanicOnError(response)
 		} else {
-			if IsTrue(!IsEqual(symbol, nil)) {
+			var marginMode interface{} = nil
+			marginModeparamsVariable := this.HandleMarginModeAndParams("fetchBorrowInterest", params)
+			marginMode = GetValue(marginModeparamsVariable, 0)
+			params = GetValue(marginModeparamsVariable, 1)
+			if IsTrue(IsTrue(IsEqual(marginMode, "isolated")) && IsTrue(IsEqual(symbol, nil))) {
+				panic(ArgumentsRequired(Add(this.Id, " fetchBorrowInterest() requires a symbol argument for isolated margin")))
+			}
+			// without an isolatedSymbol the endpoint returns the cross margin interest
+			if IsTrue(IsTrue(!IsEqual(symbol, nil)) && IsTrue(!IsEqual(marginMode, "cross"))) {
 				market = this.Market(symbol)
 				AddElementToObject(request, "isolatedSymbol", GetValue(market, "id"))
 			}
@@ -14289,9 +15244,13 @@ func (this *BinanceCore) FetchBorrowInterest(optionalArgs ...interface{}) <-chan
 func (this *BinanceCore) ParseBorrowInterest(info interface{}, optionalArgs ...interface{}) interface{} {
 	market := GetArg(optionalArgs, 0, nil)
 	_ = market
-	var symbol interface{} = this.SafeString(info, "isolatedSymbol")
+	var marketId interface{} = this.SafeString(info, "isolatedSymbol")
 	var timestamp interface{} = this.SafeInteger(info, "interestAccuredTime")
-	var marginMode interface{} = Ternary(IsTrue((IsEqual(symbol, nil))), "cross", "isolated")
+	var marginMode interface{} = Ternary(IsTrue((IsEqual(marketId, nil))), "cross", "isolated")
+	var symbol interface{} = nil
+	if IsTrue(!IsEqual(marketId, nil)) {
+		symbol = this.SafeSymbol(marketId, market, nil, "spot")
+	}
 	return map[string]interface{}{
 		"info":           info,
 		"symbol":         symbol,
@@ -14335,6 +15294,9 @@ func (this *BinanceCore) RepayCrossMargin(code interface{}, amount interface{},
 			"asset":  GetValue(currency, "id"),
 			"amount": this.CurrencyToPrecision(code, amount),
 		}
+		if IsTrue(Precise.StringLe(GetValue(request, "amount"), "0")) {
+			panic(BadRequest(Add(this.Id, " repayCrossMargin() requires an amount greater than zero")))
+		}
 		var response interface{} = nil
 		var isPortfolioMargin interface{} = nil
 		isPortfolioMarginparamsVariable := this.HandleOptionAndParams2(params, "repayCrossMargin", "papi", "portfolioMargin", false)
@@ -14362,7 +15324,12 @@ func (this *BinanceCore) RepayCrossMargin(code interface{}, amount interface{},
 			PanicOnError(response)
 		}
 
-		ch <- this.ParseMarginLoan(response, currency)
+		var transaction interface{} = this.ParseMarginLoan(response, currency)
+
+		ch <- this.Extend(transaction, map[string]interface{}{
+			"symbol": nil,
+			"amount": this.ParseNumber(GetValue(request, "amount")),
+		})
 		return nil
 
 	}()
@@ -14399,6 +15366,9 @@ func (this *BinanceCore) RepayIsolatedMargin(symbol interface{}, code interface{
 			"isIsolated": "TRUE",
 			"type":       "REPAY",
 		}
+		if IsTrue(Precise.StringLe(GetValue(request, "amount"), "0")) {
+			panic(BadRequest(Add(this.Id, " repayIsolatedMargin() requires an amount greater than zero")))
+		}
 
 		response := (<-this.SapiPostMarginBorrowRepay(this.Extend(request, params)))
 		PanicOnError(response)
@@ -14409,7 +15379,12 @@ func (this *BinanceCore) RepayIsolatedMargin(symbol interface{}, code interface{
 		//         "clientTag":""
 		//     }
 		//
-		ch <- this.ParseMarginLoan(response, currency)
+		var transaction interface{} = this.ParseMarginLoan(response, currency)
+
+		ch <- this.Extend(transaction, map[string]interface{}{
+			"symbol": GetValue(market, "symbol"),
+			"amount": this.ParseNumber(GetValue(request, "amount")),
+		})
 		return nil
 
 	}()
@@ -14443,6 +15418,9 @@ func (this *BinanceCore) BorrowCrossMargin(code interface{}, amount interface{},
 			"asset":  GetValue(currency, "id"),
 			"amount": this.CurrencyToPrecision(code, amount),
 		}
+		if IsTrue(Precise.StringLe(GetValue(request, "amount"), "0")) {
+			panic(BadRequest(Add(this.Id, " borrowCrossMargin() requires an amount greater than zero")))
+		}
 		var response interface{} = nil
 		var isPortfolioMargin interface{} = nil
 		isPortfolioMarginparamsVariable := this.HandleOptionAndParams2(params, "borrowCrossMargin", "papi", "portfolioMargin", false)
@@ -14466,7 +15444,12 @@ func (this *BinanceCore) BorrowCrossMargin(code interface{}, amount interface{},
 		//         "clientTag":""
 		//     }
 		//
-		ch <- this.ParseMarginLoan(response, currency)
+		var transaction interface{} = this.ParseMarginLoan(response, currency)
+
+		ch <- this.Extend(transaction, map[string]interface{}{
+			"symbol": nil,
+			"amount": this.ParseNumber(GetValue(request, "amount")),
+		})
 		return nil
 
 	}()
@@ -14503,6 +15486,9 @@ func (this *BinanceCore) BorrowIsolatedMargin(symbol interface{}, code interface
 			"isIsolated": "TRUE",
 			"type":       "BORROW",
 		}
+		if IsTrue(Precise.StringLe(GetValue(request, "amount"), "0")) {
+			panic(BadRequest(Add(this.Id, " borrowIsolatedMargin() requires an amount greater than zero")))
+		}
 
 		response := (<-this.SapiPostMarginBorrowRepay(this.Extend(request, params)))
 		PanicOnError(response)
@@ -14513,7 +15499,12 @@ func (this *BinanceCore) BorrowIsolatedMargin(symbol interface{}, code interface
 		//         "clientTag":""
 		//     }
 		//
-		ch <- this.ParseMarginLoan(response, currency)
+		var transaction interface{} = this.ParseMarginLoan(response, currency)
+
+		ch <- this.Extend(transaction, map[string]interface{}{
+			"symbol": GetValue(market, "symbol"),
+			"amount": this.ParseNumber(GetValue(request, "amount")),
+		})
 		return nil
 
 	}()
@@ -15249,9 +16240,12 @@ func (this *BinanceCore) FetchTradingLimits(optionalArgs ...interface{}) <-chan
  * @description fetchs the position mode, hedged or one way, hedged for binance is set identically for all linear markets or all inverse markets
  * @see https://developers.binance.com/docs/derivatives/usds-margined-futures/account/rest-api/Get-Current-Position-Mode
  * @see https://developers.binance.com/docs/derivatives/coin-margined-futures/account/rest-api/Get-Current-Position-Mode
- * @param {string} symbol unified symbol of the market to fetch the order book for
+ * @see https://developers.binance.com/docs/derivatives/portfolio-margin/account/Get-UM-Current-Position-Mode
+ * @see https://developers.binance.com/docs/derivatives/portfolio-margin/account/Get-CM-Current-Position-Mode
+ * @param {string} symbol unified symbol of the market to fetch the position mode for
  * @param {object} [params] extra parameters specific to the exchange API endpoint
  * @param {string} [params.subType] "linear" or "inverse"
+ * @param {boolean} [params.portfolioMargin] set to true if you would like to fetch the position mode for a portfolio margin account
  * @returns {object} an object detailing whether the market is in hedged or one-way mode
  */
 func (this *BinanceCore) FetchPositionMode(optionalArgs ...interface{}) <-chan interface{} {
@@ -15263,6 +16257,9 @@ func (this *BinanceCore) FetchPositionMode(optionalArgs ...interface{}) <-chan i
 		_ = symbol
 		params := GetArg(optionalArgs, 1, map[string]interface{}{})
 		_ = params
+
+		retRes14388 := (<-this.LoadMarkets())
+		PanicOnError(retRes14388)
 		var market interface{} = nil
 		if IsTrue(!IsEqual(symbol, nil)) {
 			market = this.Market(symbol)
@@ -15271,15 +16268,31 @@ func (this *BinanceCore) FetchPositionMode(optionalArgs ...interface{}) <-chan i
 		subTypeparamsVariable := this.HandleSubTypeAndParams("fetchPositionMode", market, params)
 		subType = GetValue(subTypeparamsVariable, 0)
 		params = GetValue(subTypeparamsVariable, 1)
+		var isPortfolioMargin interface{} = nil
+		isPortfolioMarginparamsVariable := this.HandleOptionAndParams2(params, "fetchPositionMode", "papi", "portfolioMargin", false)
+		isPortfolioMargin = GetValue(isPortfolioMarginparamsVariable, 0)
+		params = GetValue(isPortfolioMarginparamsVariable, 1)
 		var response interface{} = nil
 		if IsTrue(IsEqual(subType, "linear")) {
+			if IsTrue(isPortfolioMargin) {
 
-			response = (<-this.FapiPrivateGetPositionSideDual(params))
-			PanicOnError(response)
+				response = (<-this.PapiGetUmPositionSideDual(params))
+				PanicOnError(response)
+			} else {
+
+				response = (<-this.FapiPrivateGetPositionSideDual(params))
+				PanicOnError(response)
+			}
 		} else if IsTrue(IsEqual(subType, "inverse")) {
+			if IsTrue(isPortfolioMargin) {
 
-			response = (<-this.DapiPrivateGetPositionSideDual(params))
-			PanicOnError(response)
+				response = (<-this.PapiGetCmPositionSideDual(params))
+				PanicOnError(response)
+			} else {
+
+				response = (<-this.DapiPrivateGetPositionSideDual(params))
+				PanicOnError(response)
+			}
 		} else {
 			panic(BadRequest(Add(this.Id, " fetchPositionMode requires either a symbol argument or params[\"subType\"]")))
 		}
@@ -16027,22 +17040,26 @@ func (this *BinanceCore) ParseConversion(conversion interface{}, optionalArgs ..
 	_ = fromCurrency
 	toCurrency := GetArg(optionalArgs, 1, nil)
 	_ = toCurrency
-	var timestamp interface{} = this.SafeIntegerN(conversion, []interface{}{"time", "validTimestamp", "createTime"})
+	var timestamp interface{} = this.SafeInteger2(conversion, "time", "createTime")
+	// a quote can only be accepted until validTimestamp
+	var expiry interface{} = this.SafeInteger(conversion, "validTimestamp")
 	var fromCur interface{} = this.SafeString2(conversion, "deductedAsset", "fromAsset")
 	var fromCode interface{} = this.SafeCurrencyCode(fromCur, fromCurrency)
 	var to interface{} = this.SafeString2(conversion, "targetAsset", "toAsset")
 	var toCode interface{} = this.SafeCurrencyCode(to, toCurrency)
 	return map[string]interface{}{
-		"info":         conversion,
-		"timestamp":    timestamp,
-		"datetime":     this.Iso8601(timestamp),
-		"id":           this.SafeStringN(conversion, []interface{}{"tranId", "orderId", "quoteId"}),
-		"fromCurrency": fromCode,
-		"fromAmount":   this.SafeNumber2(conversion, "deductedAmount", "fromAmount"),
-		"toCurrency":   toCode,
-		"toAmount":     this.SafeNumber2(conversion, "targetAmount", "toAmount"),
-		"price":        nil,
-		"fee":          nil,
+		"info":           conversion,
+		"timestamp":      timestamp,
+		"datetime":       this.Iso8601(timestamp),
+		"id":             this.SafeStringN(conversion, []interface{}{"tranId", "orderId", "quoteId"}),
+		"fromCurrency":   fromCode,
+		"fromAmount":     this.SafeNumber2(conversion, "deductedAmount", "fromAmount"),
+		"toCurrency":     toCode,
+		"toAmount":       this.SafeNumber2(conversion, "targetAmount", "toAmount"),
+		"price":          this.SafeNumber(conversion, "ratio"),
+		"fee":            nil,
+		"expiry":         expiry,
+		"expiryDatetime": this.Iso8601(expiry),
 	}
 }
 
@@ -0,0 +1,1447 @@
+package ccxt
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// mockTransport answers every request of the exchange http client with a canned body,
+// bodies holds the responses of specific endpoints keyed by the suffix of their path
+// and queue the responses served one after the other before falling back to them
+type mockTransport struct {
+	mu       sync.Mutex
+	body     string
+	bodies   map[string]string
+	queue    []string
+	status   int
+	requests []*http.Request
+}
+
+func (m *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests = append(m.requests, req)
+	body := m.body
+	for suffix, b := range m.bodies {
+		if strings.HasSuffix(req.URL.Path, suffix) {
+			body = b
+		}
+	}
+	if len(m.queue) > 0 {
+		body, m.queue = m.queue[0], m.queue[1:]
+	}
+	status := m.status
+	if status == 0 {
+		status = 200
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func newMockedBinance(body string) (*BinanceCore, *mockTransport) {
+	exchange := NewBinanceCore()
+	exchange.Init(map[string]interface{}{})
+	transport := &mockTransport{body: body}
+	exchange.httpClient.Transport = transport
+	exchange.SetMarkets([]interface{}{
+		exchange.SafeMarketStructure(map[string]interface{}{
+			"id":           "BTCUSDT",
+			"symbol":       "BTC/USDT:USDT",
+			"base":         "BTC",
+			"quote":        "USDT",
+			"settle":       "USDT",
+			"baseId":       "BTC",
+			"quoteId":      "USDT",
+			"settleId":     "USDT",
+			"type":         "swap",
+			"subType":      "linear",
+			"swap":         true,
+			"contract":     true,
+			"linear":       true,
+			"inverse":      false,
+			"contractSize": 1.0,
+			"active":       true,
+		}),
+	})
+	return exchange, transport
+}
+
+func TestBinanceFetchPremiumIndexOHLCV(t *testing.T) {
+	exchange, transport := newMockedBinance(`[
+		[1700000000000,"-0.00010000","0.00020000","-0.00030000","0.00005000","0",1700003599999,"0",60,"0","0","0"],
+		[1700003600000,"0.00005000","0.00012000","-0.00001000","0.00011000","0",1700007199999,"0",60,"0","0","0"]
+	]`)
+	result := <-exchange.FetchPremiumIndexOHLCV("BTC/USDT:USDT", "1h", nil, 2)
+	if err, ok := result.(error); ok {
+		t.Fatal(err)
+	}
+	if len(transport.requests) != 1 {
+		t.Fatalf("expected one request, got %d", len(transport.requests))
+	}
+	request := transport.requests[0]
+	if !strings.HasSuffix(request.URL.Path, "/fapi/v1/premiumIndexKlines") {
+		t.Fatalf("expected the premium index klines endpoint, got %s", request.URL.Path)
+	}
+	query := request.URL.Query()
+	if query.Get("symbol") != "BTCUSDT" || query.Get("interval") != "1h" || query.Get("limit") != "2" {
+		t.Fatalf("unexpected query %s", request.URL.RawQuery)
+	}
+	candles := result.([]interface{})
+	if len(candles) != 2 {
+		t.Fatalf("expected 2 candles, got %d", len(candles))
+	}
+	first := candles[0].([]interface{})
+	expected := []interface{}{int64(1700000000000), -0.0001, 0.0002, -0.0003, 0.00005}
+	for i, value := range expected {
+		if !IsEqual(first[i], value) {
+			t.Fatalf("candle field %d: expected %v, got %v", i, value, first[i])
+		}
+	}
+	if !IsEqual(GetValue(candles[1], 4), 0.00011) {
+		t.Fatalf("expected the second close to be 0.00011, got %v", GetValue(candles[1], 4))
+	}
+}
+
+// ---------------------------------------------------------------------------
+// sub-accounts
+// ---------------------------------------------------------------------------
+
+func newMockedBinanceSubAccounts(bodies map[string]string) (*BinanceCore, *mockTransport) {
+	exchange, transport := newMockedBinance(`{}`)
+	exchange.ApiKey = "key"
+	exchange.Secret = "secret"
+	transport.bodies = bodies
+	return exchange, transport
+}
+
+func TestBinanceFetchSubAccounts(t *testing.T) {
+	exchange, transport := newMockedBinanceSubAccounts(map[string]string{
+		"/sapi/v1/sub-account/list": `{"subAccounts":[
+			{"email":"alice@example.com","isFreeze":false,"createTime":1700000000000},
+			{"email":"bob@example.com","isFreeze":true,"createTime":1700000001000}
+		]}`,
+	})
+	result := <-exchange.FetchSubAccounts()
+	if IsError(result) {
+		t.Fatal(CreateReturnError(result))
+	}
+	if len(transport.requests) != 1 {
+		t.Fatalf("expected one request, got %d", len(transport.requests))
+	}
+	subAccounts := result.([]interface{})
+	if len(subAccounts) != 2 {
+		t.Fatalf("expected 2 sub-accounts, got %d", len(subAccounts))
+	}
+	first := subAccounts[0]
+	if GetValue(first, "id") != "alice@example.com" || GetValue(first, "status") != "active" {
+		t.Fatalf("unexpected sub-account %v", first)
+	}
+	if GetValue(first, "timestamp") != int64(1700000000000) || GetValue(first, "datetime") != "2023-11-14T22:13:20.000Z" {
+		t.Fatalf("unexpected sub-account timestamp %v", first)
+	}
+	if GetValue(subAccounts[1], "status") != "frozen" {
+		t.Fatalf("expected the second sub-account to be frozen, got %v", GetValue(subAccounts[1], "status"))
+	}
+}
+
+func TestBinanceFetchAllBalancesAggregatesSubAccounts(t *testing.T) {
+	exchange, _ := newMockedBinanceSubAccounts(map[string]string{
+		"/api/v3/account": `{"updateTime":1700000000000,"balances":[
+			{"asset":"BTC","free":"1.5","locked":"0.5"},
+			{"asset":"USDT","free":"100","locked":"0"}
+		]}`,
+		"/sapi/v1/sub-account/list": `{"subAccounts":[
+			{"email":"alice@example.com","isFreeze":false,"createTime":1700000000000}
+		]}`,
+		"/sapi/v3/sub-account/assets": `{"balances":[
+			{"asset":"BTC","free":0.25,"locked":0.1,"freeze":0.05,"withdrawing":0.1},
+			{"asset":"ETH","free":2,"locked":0,"freeze":0,"withdrawing":0}
+		]}`,
+	})
+	exchange.Has["fetchSubAccounts"] = true
+	result := <-exchange.FetchAllBalances(map[string]interface{}{"type": "spot"})
+	if IsError(result) {
+		t.Fatal(CreateReturnError(result))
+	}
+	expected := map[string][]float64{
+		"BTC":  {1.75, 0.75, 2.5},
+		"USDT": {100, 0, 100},
+		"ETH":  {2, 0, 2},
+	}
+	for code, amounts := range expected {
+		balance := GetValue(result, code)
+		for i, key := range []string{"free", "used", "total"} {
+			if !IsEqual(GetValue(balance, key), amounts[i]) {
+				t.Fatalf("%s %s: expected %v, got %v", code, key, amounts[i], GetValue(balance, key))
+			}
+		}
+	}
+	info := GetValue(result, "info")
+	subAccount := GetValue(GetValue(info, "subAccounts"), "alice@example.com")
+	if !IsEqual(GetValue(GetValue(subAccount, "BTC"), "used"), 0.25) {
+		t.Fatalf("expected the sub-account balance in info, got %v", subAccount)
+	}
+	if GetValue(GetValue(GetValue(info, "master"), "BTC"), "total") == nil {
+		t.Fatal("expected the master balance in info")
+	}
+}
+
+func TestBinanceFetchSubAccountsRequiresMasterKey(t *testing.T) {
+	exchange, transport := newMockedBinanceSubAccounts(map[string]string{
+		"/sapi/v1/sub-account/list": `{"code":-2015,"msg":"Invalid API-key, IP, or permissions for action."}`,
+	})
+	transport.status = 401
+	result := <-exchange.FetchSubAccounts()
+	if !IsError(result) {
+		t.Fatalf("expected an error, got %v", result)
+	}
+	err := CreateReturnError(result).(*Error)
+	if err.Type != "PermissionDenied" || !strings.Contains(err.Message, "master account") {
+		t.Fatalf("expected a PermissionDenied about the master account, got %s: %s", err.Type, err.Message)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// client order ids: orders are fetched and cancelled by origClientOrderId
+// ---------------------------------------------------------------------------
+
+const binanceClientIdOrder = `{"avgPrice":"0.00000","clientOrderId":"my-id-1","cumQuote":"0","executedQty":"0","orderId":1917641,` +
+	`"origQty":"0.400","origType":"LIMIT","price":"30000","reduceOnly":false,"side":"BUY","positionSide":"BOTH","status":"NEW",` +
+	`"stopPrice":"0","closePosition":false,"symbol":"BTCUSDT","time":1579276756075,"timeInForce":"GTC","type":"LIMIT","updateTime":1579276756075}`
+
+func newMockedBinanceClientId() (*BinanceCore, *mockTransport) {
+	exchange, transport := newMockedBinance(binanceClientIdOrder)
+	exchange.ApiKey = "key"
+	exchange.Secret = "secret"
+	return exchange, transport
+}
+
+func assertClientIdRequest(t *testing.T, transport *mockTransport, method string) {
+	t.Helper()
+	if len(transport.requests) != 1 {
+		t.Fatalf("expected one request, got %d", len(transport.requests))
+	}
+	request := transport.requests[0]
+	if request.Method != method || !strings.HasSuffix(request.URL.Path, "/fapi/v1/order") {
+		t.Fatalf("unexpected request %s %s", request.Method, request.URL.Path)
+	}
+	query := request.URL.Query()
+	if query.Get("origClientOrderId") != "my-id-1" || query.Get("symbol") != "BTCUSDT" || query.Has("orderId") || query.Has("clientOrderId") {
+		t.Fatalf("unexpected query %s", request.URL.RawQuery)
+	}
+}
+
+func TestBinanceFetchOrderWithClientOrderId(t *testing.T) {
+	exchange, transport := newMockedBinanceClientId()
+	order, err := NewExchangeTyped(&exchange.Exchange).FetchOrderWithClientOrderId("my-id-1", WithFetchOrderWithClientOrderIdSymbol("BTC/USDT:USDT"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertClientIdRequest(t, transport, "GET")
+	if *order.ClientOrderId != "my-id-1" || *order.Id != "1917641" || *order.Symbol != "BTC/USDT:USDT" {
+		t.Fatalf("unexpected order %+v", order)
+	}
+}
+
+func TestBinanceCancelOrderWithClientOrderId(t *testing.T) {
+	exchange, transport := newMockedBinanceClientId()
+	result := <-exchange.CancelOrderWithClientOrderId("my-id-1", "BTC/USDT:USDT")
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	assertClientIdRequest(t, transport, "DELETE")
+	if clientOrderId := GetValue(result, "clientOrderId"); clientOrderId != "my-id-1" {
+		t.Fatalf("expected the cancelled order my-id-1, got %v", clientOrderId)
+	}
+}
+
+func TestOrderWithClientOrderIdNotSupported(t *testing.T) {
+	exchange, transport := newMockedBinanceClientId()
+	exchange.Has["fetchOrderWithClientOrderId"] = false
+	exchange.Has["cancelOrderWithClientOrderId"] = false
+	for method, result := range map[string]interface{}{
+		"fetchOrderWithClientOrderId":  <-exchange.FetchOrderWithClientOrderId("my-id-1", "BTC/USDT:USDT"),
+		"cancelOrderWithClientOrderId": <-exchange.CancelOrderWithClientOrderId("my-id-1", "BTC/USDT:USDT"),
+	} {
+		err, ok := CreateReturnError(result).(*Error)
+		if !ok || err.Type != "NotSupported" || err.Message != "binance "+method+"() is not supported yet" {
+			t.Fatalf("expected %s to be NotSupported, got %v", method, result)
+		}
+	}
+	if len(transport.requests) != 0 {
+		t.Fatalf("expected no request, got %d", len(transport.requests))
+	}
+}
+
+// ---------------------------------------------------------------------------
+// fetchPositionHistory: closed positions rebuilt from the trades of several 7 day windows
+// ---------------------------------------------------------------------------
+
+func TestBinanceFetchPositionHistoryStitchesWindows(t *testing.T) {
+	exchange, transport := newMockedBinance(`[]`)
+	exchange.ApiKey = "key"
+	exchange.Secret = "secret"
+	// the trade and income endpoints are heavy, the test does not wait for the rate limiter
+	exchange.EnableRateLimit = false
+	day := int64(24 * 60 * 60 * 1000)
+	since := int64(1714521600000)
+	until := since + 10*day
+	trade := func(id int, side string, price string, qty string, realizedPnl string, commission string, time int64) string {
+		return fmt.Sprintf(`{"symbol":"BTCUSDT","id":%d,"orderId":%d,"side":"%s","positionSide":"BOTH","price":"%s","qty":"%s",`+
+			`"realizedPnl":"%s","commission":"%s","commissionAsset":"USDT","time":%d,"buyer":%t,"maker":false}`,
+			id, id+100, side, price, qty, realizedPnl, commission, time, side == "BUY")
+	}
+	funding := func(income string, time int64) string {
+		return fmt.Sprintf(`{"symbol":"BTCUSDT","incomeType":"FUNDING_FEE","income":"%s","asset":"USDT","time":%d,"info":"","tranId":%d}`, income, time, time)
+	}
+	transport.queue = []string{
+		// the long position is opened in the first week and closed in the second one, then a short one is opened
+		"[" + trade(1, "BUY", "60000", "0.01", "0", "0.24", since+day) + "," + trade(2, "BUY", "62000", "0.01", "0", "0.248", since+5*day) + "]",
+		"[" + trade(3, "SELL", "63000", "0.02", "40", "0.504", since+8*day) + "," + trade(4, "SELL", "63500", "0.01", "0", "0.254", since+9*day) + "]",
+		"[" + funding("-0.5", since+2*day) + "]",
+		"[" + funding("-0.2", since+7*day+day/2) + "," + funding("-0.3", since+8*day+day/2) + "]",
+	}
+
+	result := <-exchange.FetchPositionHistory("BTC/USDT:USDT", since, nil, map[string]interface{}{"until": until})
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	expectedRequests := []struct {
+		path      string
+		startTime int64
+		endTime   int64
+	}{
+		{"/fapi/v1/userTrades", since, since + 7*day - 1},
+		{"/fapi/v1/userTrades", since + 7*day, until},
+		{"/fapi/v1/income", since, since + 7*day - 1},
+		{"/fapi/v1/income", since + 7*day, until},
+	}
+	if len(transport.requests) != len(expectedRequests) {
+		t.Fatalf("expected %d requests, got %d", len(expectedRequests), len(transport.requests))
+	}
+	for i, expected := range expectedRequests {
+		request := transport.requests[i]
+		query := request.URL.Query()
+		if !strings.HasSuffix(request.URL.Path, expected.path) || query.Get("startTime") != fmt.Sprint(expected.startTime) || query.Get("endTime") != fmt.Sprint(expected.endTime) {
+			t.Fatalf("request %d: expected %s from %d to %d, got %s", i, expected.path, expected.startTime, expected.endTime, request.URL)
+		}
+	}
+
+	positions := NewPositionArray(result)
+	if len(positions) != 1 {
+		t.Fatalf("expected the closed position only, got %d positions", len(positions))
+	}
+	position := positions[0]
+	if *position.Symbol != "BTC/USDT:USDT" || *position.Side != "long" || *position.Contracts != 0.02 {
+		t.Fatalf("unexpected position %+v", position)
+	}
+	if *position.EntryPrice != 61000 || *position.LastPrice != 63000 || *position.RealizedPnl != 40 {
+		t.Fatalf("expected an entry at 61000, an exit at 63000 and a pnl of 40, got %v, %v and %v", *position.EntryPrice, *position.LastPrice, *position.RealizedPnl)
+	}
+	if int64(*position.Timestamp) != since+day || int64(*position.LastUpdateTimestamp) != since+8*day {
+		t.Fatalf("expected the position to span the two windows, got %v to %v", *position.Timestamp, *position.LastUpdateTimestamp)
+	}
+	if position.Info["commission"] != "0.992" || position.Info["totalFunding"] != "-0.7" {
+		t.Fatalf("expected fees of 0.992 and a funding of -0.7, got %v and %v", position.Info["commission"], position.Info["totalFunding"])
+	}
+}
+
+// ---------------------------------------------------------------------------
+// fetchLeverage: one-way and hedge mode positions read back as one leverage structure
+// ---------------------------------------------------------------------------
+
+func newMockedBinanceLeverage(bodies map[string]string) (*BinanceCore, *mockTransport) {
+	exchange, transport := newMockedBinanceSubAccounts(bodies)
+	exchange.SetMarkets([]interface{}{
+		exchange.SafeMarketStructure(map[string]interface{}{
+			"id":       "BTCUSDT",
+			"symbol":   "BTC/USDT:USDT",
+			"base":     "BTC",
+			"quote":    "USDT",
+			"settle":   "USDT",
+			"type":     "swap",
+			"subType":  "linear",
+			"swap":     true,
+			"contract": true,
+			"linear":   true,
+			"inverse":  false,
+			"active":   true,
+		}),
+		exchange.SafeMarketStructure(map[string]interface{}{
+			"id":       "BTCUSD_PERP",
+			"symbol":   "BTC/USD:BTC",
+			"base":     "BTC",
+			"quote":    "USD",
+			"settle":   "BTC",
+			"type":     "swap",
+			"subType":  "inverse",
+			"swap":     true,
+			"contract": true,
+			"linear":   false,
+			"inverse":  true,
+			"active":   true,
+			"info":     map[string]interface{}{"symbol": "BTCUSD_PERP", "pair": "BTCUSD"},
+		}),
+		exchange.SafeMarketStructure(map[string]interface{}{
+			"id":      "BTCUSDT",
+			"symbol":  "BTC/USDT",
+			"base":    "BTC",
+			"quote":   "USDT",
+			"baseId":  "BTC",
+			"quoteId": "USDT",
+			"type":    "spot",
+			"spot":    true,
+			"active":  true,
+		}),
+	})
+	return exchange, transport
+}
+
+func TestBinanceFetchLeverageOneWay(t *testing.T) {
+	exchange, transport := newMockedBinanceLeverage(map[string]string{
+		"/fapi/v1/symbolConfig": `[{"symbol":"BTCUSDT","marginType":"CROSSED","isAutoAddMargin":"false","leverage":21,"maxNotionalValue":"1000000"}]`,
+	})
+	result := <-exchange.FetchLeverage("BTC/USDT:USDT")
+	if IsError(result) {
+		t.Fatal(CreateReturnError(result))
+	}
+	if query := transport.requests[0].URL.Query(); query.Get("symbol") != "BTCUSDT" {
+		t.Fatalf("expected the config of BTCUSDT, got %s", transport.requests[0].URL)
+	}
+	leverage := NewLeverage(result)
+	if *leverage.Symbol != "BTC/USDT:USDT" || *leverage.MarginMode != "cross" || *leverage.LongLeverage != 21 || *leverage.ShortLeverage != 21 {
+		t.Fatalf("unexpected leverage %v", result)
+	}
+}
+
+func TestBinanceFetchLeverageHedgeMode(t *testing.T) {
+	position := func(side string, leverage string) string {
+		return `{"symbol":"BTCUSD_PERP","positionAmt":"0","entryPrice":"0.0","markPrice":"64000.1","unRealizedProfit":"0.00000000",` +
+			`"liquidationPrice":"0","leverage":"` + leverage + `","maxQty":"50","marginType":"isolated","isolatedMargin":"0.00000000",` +
+			`"isAutoAddMargin":"false","positionSide":"` + side + `","notionalValue":"0","isolatedWallet":"0","updateTime":0}`
+	}
+	exchange, transport := newMockedBinanceLeverage(map[string]string{
+		"/dapi/v1/positionRisk": `[` + position("LONG", "20") + `,` + position("SHORT", "10") + `,` +
+			strings.Replace(position("BOTH", "5"), "BTCUSD_PERP", "BTCUSD_250926", 1) + `]`,
+	})
+	result := <-exchange.FetchLeverage("BTC/USD:BTC")
+	if IsError(result) {
+		t.Fatal(CreateReturnError(result))
+	}
+	if query := transport.requests[0].URL.Query(); query.Get("pair") != "BTCUSD" {
+		t.Fatalf("expected the positions of the BTCUSD pair, got %s", transport.requests[0].URL)
+	}
+	leverage := NewLeverage(result)
+	if *leverage.Symbol != "BTC/USD:BTC" || *leverage.MarginMode != "isolated" || *leverage.LongLeverage != 20 || *leverage.ShortLeverage != 10 {
+		t.Fatalf("expected the long and short leverage of the hedge mode position, got %v", result)
+	}
+
+	result = <-exchange.FetchLeverage("BTC/USDT")
+	if err, ok := CreateReturnError(result).(*Error); !ok || err.Type != "BadSymbol" {
+		t.Fatalf("expected a BadSymbol error, got %v", result)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// fetchConvertQuote / createConvertTrade: a quote is accepted by id before it expires
+// ---------------------------------------------------------------------------
+
+// binanceRequestParams returns the parameters of a signed request, sent in the query or in the body
+func binanceRequestParams(t *testing.T, request *http.Request) url.Values {
+	params := request.URL.Query()
+	if request.GetBody != nil {
+		body, _ := request.GetBody()
+		raw, _ := io.ReadAll(body)
+		form, err := url.ParseQuery(string(raw))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for key, values := range form {
+			params[key] = values
+		}
+	}
+	return params
+}
+
+func TestBinanceConvertQuoteAndAccept(t *testing.T) {
+	exchange, transport := newMockedBinanceSubAccounts(map[string]string{
+		"/sapi/v1/convert/getQuote": `{"quoteId":"12415572564","ratio":"38163.7","inverseRatio":"0.0000262",` +
+			`"validTimestamp":1623319461670,"toAmount":"3816.37","fromAmount":"0.1"}`,
+		"/sapi/v1/convert/acceptQuote": `{"orderId":"933256278426274426","createTime":1623319455000,"orderStatus":"PROCESS"}`,
+	})
+	result := <-exchange.FetchConvertQuote("BTC", "USDT", 0.1)
+	if IsError(result) {
+		t.Fatal(CreateReturnError(result))
+	}
+	quote := NewConversion(result)
+	if *quote.Id != "12415572564" || *quote.Price != 38163.7 || *quote.FromAmount != 0.1 || *quote.ToAmount != 3816.37 {
+		t.Fatalf("unexpected quote %v", result)
+	}
+	if *quote.Expiry != 1623319461670 || *quote.ExpiryDatetime != "2021-06-10T10:04:21.670Z" || quote.Timestamp != nil {
+		t.Fatalf("expected the quote to expire at its valid timestamp, got %v", result)
+	}
+	params := binanceRequestParams(t, transport.requests[0])
+	if params.Get("fromAsset") != "BTC" || params.Get("toAsset") != "USDT" || params.Get("fromAmount") != "0.1" {
+		t.Fatalf("unexpected quote request %v", params)
+	}
+
+	result = <-exchange.CreateConvertTrade(*quote.Id, "BTC", "USDT")
+	if IsError(result) {
+		t.Fatal(CreateReturnError(result))
+	}
+	if request := transport.requests[1]; request.Method != "POST" || binanceRequestParams(t, request).Get("quoteId") != "12415572564" {
+		t.Fatalf("expected the quote to be accepted by id, got %s %s", request.Method, request.URL)
+	}
+	trade := NewConversion(result)
+	if *trade.Id != "933256278426274426" || *trade.Timestamp != 1623319455000 || *trade.FromCurrency != "BTC" || *trade.ToCurrency != "USDT" {
+		t.Fatalf("unexpected conversion %v", result)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// fetchOrderBook: the throttle cost of the spot depth comes from the byLimit tiers of the depth endpoint
+// ---------------------------------------------------------------------------
+
+func TestBinanceDepthCostTiers(t *testing.T) {
+	exchange := NewBinanceCore()
+	exchange.Init(map[string]interface{}{})
+	endpoint := exchange.TransformedApi["publicGetDepth"].(map[string]interface{})
+	config := exchange.Extend(endpoint["config"], map[string]interface{}{"cost": endpoint["cost"]})
+	for limit, weight := range map[int]float64{
+		1:    1,
+		100:  1,
+		101:  5,
+		500:  5,
+		501:  10,
+		1000: 10,
+		1001: 50,
+		5000: 50,
+	} {
+		cost := exchange.CalculateRateLimiterCost(endpoint["api"], endpoint["method"], endpoint["path"], map[string]interface{}{"limit": limit}, config)
+		if ToFloat64(cost) != weight {
+			t.Fatalf("expected a weight of %v for limit=%d, got %v", weight, limit, cost)
+		}
+	}
+	// without a limit binance returns its default depth of 100
+	if cost := exchange.CalculateRateLimiterCost(endpoint["api"], endpoint["method"], endpoint["path"], map[string]interface{}{}, config); ToFloat64(cost) != 1 {
+		t.Fatalf("expected a weight of 1 without a limit, got %v", cost)
+	}
+}
+
+func TestBinanceFetchOrderBookThrottlesByLimit(t *testing.T) {
+	exchange, transport := newMockedBinance(`{"lastUpdateId":1027024,"bids":[["4.00000000","431.00000000"]],"asks":[["4.00000200","12.00000000"]]}`)
+	exchange.SetMarkets([]interface{}{
+		exchange.SafeMarketStructure(map[string]interface{}{
+			"id":      "BTCUSDT",
+			"symbol":  "BTC/USDT",
+			"base":    "BTC",
+			"quote":   "USDT",
+			"baseId":  "BTC",
+			"quoteId": "USDT",
+			"type":    "spot",
+			"spot":    true,
+			"active":  true,
+		}),
+	})
+	limiter := &countingRateLimiter{}
+	exchange.RateLimiter = limiter
+	for _, limit := range []interface{}{nil, 100, 5000} {
+		if result := <-exchange.FetchOrderBook("BTC/USDT", limit); IsError(result) {
+			t.Fatal(result)
+		}
+	}
+	if len(limiter.costs) != 3 || limiter.costs[0] != 1 || limiter.costs[1] != 1 || limiter.costs[2] != 50 {
+		t.Fatalf("expected the costs 1, 1 and 50, got %v", limiter.costs)
+	}
+	request := transport.requests[2].URL
+	if !strings.HasSuffix(request.Path, "/api/v3/depth") || request.Query().Get("limit") != "5000" || request.Query().Get("symbol") != "BTCUSDT" {
+		t.Fatalf("expected the spot depth of 5000 levels, got %s", request)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// adjustForTimeDifference: a request rejected for its timestamp is signed again after syncing the clock
+// ---------------------------------------------------------------------------
+
+func TestBinanceRetriesTimestampErrorsAfterSyncingTheClock(t *testing.T) {
+	exchange, transport := newMockedBinanceSubAccounts(nil)
+	exchange.Options.Store("adjustForTimeDifference", true)
+	serverTime := ParseInt(exchange.Milliseconds()) - 5000
+	transport.queue = []string{
+		`{"code":-1021,"msg":"Timestamp for this request was 1000ms ahead of the server's time."}`,
+		fmt.Sprintf(`{"serverTime":%d}`, serverTime),
+		`{"tranId":100000001}`,
+		`{"tranId":100000002}`,
+	}
+	for i := 0; i < 2; i++ {
+		if result := <-exchange.SapiPostAssetTransfer(map[string]interface{}{"type": "MAIN_UMFUTURE", "asset": "USDT", "amount": "1"}); IsError(result) {
+			t.Fatal(result)
+		}
+	}
+	if len(transport.requests) != 4 || !strings.HasSuffix(transport.requests[1].URL.Path, "/api/v3/time") {
+		t.Fatalf("expected a single clock sync between the rejected request and its retry, got %d requests", len(transport.requests))
+	}
+	difference := ToFloat64(exchange.SafeValue(exchange.Options, "timeDifference"))
+	if difference < 5000 || difference > 6000 {
+		t.Fatalf("expected a time difference of about 5000ms, got %v", difference)
+	}
+	for _, i := range []int{2, 3} {
+		params := binanceRequestParams(t, transport.requests[i])
+		if timestamp, _ := strconv.ParseInt(params.Get("timestamp"), 10, 64); timestamp > serverTime+1000 {
+			t.Fatalf("request %d: expected a timestamp corrected by the time difference, got %d for a server time of %d", i, timestamp, serverTime)
+		}
+	}
+}
+
+func TestBinanceTimestampErrorsWithoutTimeAdjustment(t *testing.T) {
+	exchange, transport := newMockedBinanceSubAccounts(nil)
+	transport.queue = []string{`{"code":-1021,"msg":"Timestamp for this request is outside of the recvWindow."}`}
+	result := <-exchange.SapiPostAssetTransfer(map[string]interface{}{"type": "MAIN_UMFUTURE", "asset": "USDT", "amount": "1"})
+	if err, ok := CreateReturnError(result).(*Error); !ok || err.Type != "InvalidNonce" {
+		t.Fatalf("expected an InvalidNonce error, got %v", result)
+	}
+	if len(transport.requests) != 1 {
+		t.Fatalf("expected no retry, got %d requests", len(transport.requests))
+	}
+}
+
+// ---------------------------------------------------------------------------
+// handleErrors: the error codes map to typed errors that errors.Is matches against the hierarchy
+// ---------------------------------------------------------------------------
+
+func TestBinanceErrorCodesMapToTypedErrors(t *testing.T) {
+	cases := []struct {
+		body     string
+		expected ErrorType
+		parent   error
+	}{
+		{`{"code":-2010,"msg":"Account has insufficient balance for requested action."}`, InsufficientFundsErrType, ExchangeError()},
+		{`{"code":-2010,"msg":"Order would immediately match and take."}`, OrderImmediatelyFillableErrType, InvalidOrder()},
+		{`{"code":-2013,"msg":"Order does not exist."}`, OrderNotFoundErrType, InvalidOrder()},
+		{`{"code":-2015,"msg":"Invalid API-key, IP, or permissions for action."}`, AuthenticationErrorErrType, ExchangeError()},
+		{`{"code":-1003,"msg":"Too much request weight used; current limit is 6000 request weight per 1 MINUTE."}`, RateLimitExceededErrType, NetworkError()},
+		{`{"code":-1001,"msg":"Internal error; unable to process your request. Please try again."}`, ExchangeNotAvailableErrType, OperationFailed()},
+	}
+	for _, c := range cases {
+		exchange, transport := newMockedBinanceSubAccounts(nil)
+		transport.body = c.body
+		transport.status = 400
+		result := <-exchange.PrivateGetOrder(map[string]interface{}{"symbol": "BTCUSDT", "orderId": "1"})
+		if !IsError(result) {
+			t.Fatalf("%s: expected an error, got %v", c.body, result)
+		}
+		err := CreateReturnError(result)
+		if !errors.Is(err, CreateError(string(c.expected))) || !IsErrorType(err, c.expected) {
+			t.Fatalf("%s: expected a %s error, got %v", c.body, c.expected, err)
+		}
+		if !errors.Is(err, c.parent) {
+			t.Fatalf("%s: expected the %s error to derive from %v", c.body, c.expected, c.parent.(*Error).Type)
+		}
+		if errors.Is(err, BadRequest()) {
+			t.Fatalf("%s: expected the %s error not to be a BadRequest", c.body, c.expected)
+		}
+		var typed *Error
+		if !errors.As(err, &typed) || typed.Type != c.expected {
+			t.Fatalf("%s: expected errors.As to extract the %s error, got %v", c.body, c.expected, typed)
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// fetchMarkPrices / fetchIndexPrices: the premium index fills the mark and the index price apart
+// ---------------------------------------------------------------------------
+
+const binancePremiumIndex = `[{"symbol":"BTCUSDT","markPrice":"11793.63104562","indexPrice":"11781.80495970","estimatedSettlePrice":"11781.16138815",` +
+	`"lastFundingRate":"0.00038246","nextFundingTime":1597392000000,"interestRate":"0.00010000","time":1597370495002}]`
+
+func TestBinanceFetchMarkAndIndexPrices(t *testing.T) {
+	for _, method := range []string{"fetchMarkPrices", "fetchIndexPrices"} {
+		exchange, transport := newMockedBinance(binancePremiumIndex)
+		var result interface{}
+		if method == "fetchMarkPrices" {
+			result = <-exchange.FetchMarkPrices([]interface{}{"BTC/USDT:USDT"})
+		} else {
+			result = <-exchange.FetchIndexPrices([]interface{}{"BTC/USDT:USDT"})
+		}
+		if IsError(result) {
+			t.Fatal(result)
+		}
+		if len(transport.requests) != 1 || !strings.HasSuffix(transport.requests[0].URL.Path, "/fapi/v1/premiumIndex") {
+			t.Fatalf("%s: expected a single premiumIndex request, got %v", method, transport.requests)
+		}
+		ticker, ok := NewTickers(result).Tickers["BTC/USDT:USDT"]
+		if !ok {
+			t.Fatalf("%s: expected a BTC/USDT:USDT price, got %v", method, result)
+		}
+		if *ticker.MarkPrice != 11793.63104562 || *ticker.IndexPrice != 11781.8049597 || *ticker.Timestamp != 1597370495002 {
+			t.Fatalf("%s: unexpected prices mark %v index %v", method, *ticker.MarkPrice, *ticker.IndexPrice)
+		}
+		if ticker.Last != nil {
+			t.Fatalf("%s: expected no last price, got %v", method, *ticker.Last)
+		}
+	}
+}
+
+func TestFetchIndexPricesNotSupportedByDefault(t *testing.T) {
+	exchange := &Exchange{}
+	exchange.Init(map[string]interface{}{})
+	result := <-exchange.FetchIndexPrices(nil)
+	if !IsError(result) || !errors.Is(CreateReturnError(result), NotSupported()) {
+		t.Fatalf("expected NotSupported, got %v", result)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// createStopOrder / createTrailingStopOrder: stop and trailing distances map to the binance order params
+// ---------------------------------------------------------------------------
+
+func TestBinanceCreateStopAndTrailingStopOrders(t *testing.T) {
+	exchange, transport := newMockedBinanceClientId()
+	market := GetValue(exchange.Markets, "BTC/USDT:USDT")
+	AddElementToObject(market, "precision", map[string]interface{}{"amount": 0.001, "price": 0.1})
+	AddElementToObject(market, "info", map[string]interface{}{
+		"orderTypes": []interface{}{"LIMIT", "MARKET", "STOP", "STOP_MARKET", "TAKE_PROFIT", "TAKE_PROFIT_MARKET", "TRAILING_STOP_MARKET"},
+	})
+	result := <-exchange.CreateStopOrder("BTC/USDT:USDT", "limit", "sell", 0.4, 29000, 29500)
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	params := binanceRequestParams(t, transport.requests[0])
+	// linear conditional orders go through the algo order api
+	if params.Get("type") != "STOP" || params.Get("algoType") != "CONDITIONAL" || params.Get("triggerPrice") != "29500" || params.Get("price") != "29000" || params.Get("side") != "SELL" {
+		t.Fatalf("unexpected stop order params %v", params)
+	}
+
+	result = <-exchange.CreateTrailingStopOrder("BTC/USDT:USDT", "market", "sell", 0.4, nil, map[string]interface{}{
+		"trailingPercent":      1.5,
+		"trailingTriggerPrice": 30000,
+	})
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	params = binanceRequestParams(t, transport.requests[1])
+	if params.Get("type") != "TRAILING_STOP_MARKET" || params.Get("callbackRate") != "1.5" || params.Get("activationPrice") != "30000" ||
+		params.Has("trailingPercent") || params.Has("trailingTriggerPrice") {
+		t.Fatalf("unexpected trailing stop order params %v", params)
+	}
+
+	// binance trails by a percentage only
+	result = <-exchange.CreateTrailingStopOrder("BTC/USDT:USDT", "market", "sell", 0.4, nil, map[string]interface{}{"trailingAmount": 100})
+	if !IsError(result) || !IsErrorType(CreateReturnError(result), "NotSupported") {
+		t.Fatalf("expected NotSupported for a trailing amount, got %v", result)
+	}
+	result = <-exchange.CreateTrailingStopOrder("BTC/USDT:USDT", "market", "sell", 0.4, nil, map[string]interface{}{"trailingAmount": 100, "trailingPercent": 1.5})
+	if !IsError(result) || !IsErrorType(CreateReturnError(result), "InvalidOrder") {
+		t.Fatalf("expected InvalidOrder for both trailing distances, got %v", result)
+	}
+	result = <-exchange.CreateTrailingStopOrder("BTC/USDT:USDT", "market", "sell", 0.4)
+	if !IsError(result) || !IsErrorType(CreateReturnError(result), "ArgumentsRequired") {
+		t.Fatalf("expected ArgumentsRequired without a trailing distance, got %v", result)
+	}
+	if len(transport.requests) != 2 {
+		t.Fatalf("expected the rejected orders not to be sent, got %d requests", len(transport.requests))
+	}
+}
+
+// ---------------------------------------------------------------------------
+// transfer: unified account names are mapped to the universal transfer type
+// ---------------------------------------------------------------------------
+
+func TestBinanceTransferSpotToFuture(t *testing.T) {
+	exchange, transport := newMockedBinanceSubAccounts(map[string]string{
+		"/sapi/v1/asset/transfer": `{"tranId":13526853623}`,
+	})
+	result := <-exchange.Transfer("USDT", 25, "spot", "future")
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if len(transport.requests) != 1 {
+		t.Fatalf("expected one request, got %d", len(transport.requests))
+	}
+	params := binanceRequestParams(t, transport.requests[0])
+	if params.Get("type") != "MAIN_UMFUTURE" || params.Get("asset") != "USDT" || params.Get("amount") != "25" {
+		t.Fatalf("unexpected transfer params %v", params)
+	}
+	transfer := NewTransferEntry(result)
+	if *transfer.Id != "13526853623" || *transfer.Currency != "USDT" {
+		t.Fatalf("unexpected transfer %v", result)
+	}
+}
+
+func TestBinanceTransferRejectsUnsupportedPairs(t *testing.T) {
+	exchange, transport := newMockedBinanceSubAccounts(map[string]string{
+		"/sapi/v1/asset/transfer": `{"tranId":13526853623}`,
+	})
+	for _, accounts := range [][2]string{{"future", "delivery"}, {"option", "inverse"}, {"spot", "main"}} {
+		result := <-exchange.Transfer("USDT", 25, accounts[0], accounts[1])
+		if !IsError(result) || !IsErrorType(CreateReturnError(result), "BadRequest") {
+			t.Fatalf("expected a BadRequest for a %s to %s transfer, got %v", accounts[0], accounts[1], result)
+		}
+	}
+	if len(transport.requests) != 0 {
+		t.Fatalf("expected no request for unsupported transfers, got %d", len(transport.requests))
+	}
+}
+
+// ---------------------------------------------------------------------------
+// fetchTransfers: every transfer type is queried and the rows are merged by timestamp
+// ---------------------------------------------------------------------------
+
+func TestBinanceFetchTransfersMergesTypes(t *testing.T) {
+	exchange, transport := newMockedBinanceSubAccounts(map[string]string{
+		"/sapi/v1/asset/transfer": `{"total":0,"rows":[]}`,
+	})
+	transport.queue = []string{
+		`{"total":2,"rows":[
+			{"timestamp":1614640878000,"asset":"USDT","amount":"25","type":"MAIN_UMFUTURE","status":"CONFIRMED","tranId":43000126248},
+			{"timestamp":1614640800000,"asset":"BTC","amount":"0.1","type":"MAIN_UMFUTURE","status":"CONFIRMED","tranId":43000126247}
+		]}`,
+		`{"total":1,"rows":[
+			{"timestamp":1614640850000,"asset":"USDT","amount":"10","type":"UMFUTURE_MAIN","status":"PENDING","tranId":43000126250}
+		]}`,
+	}
+	result := <-exchange.FetchTransfers("USDT")
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	types := []string{}
+	for _, request := range transport.requests {
+		types = append(types, binanceRequestParams(t, request).Get("type"))
+	}
+	if strings.Join(types, ",") != "MAIN_UMFUTURE,UMFUTURE_MAIN,MAIN_CMFUTURE,CMFUTURE_MAIN,MAIN_MARGIN,MARGIN_MAIN,MAIN_FUNDING,FUNDING_MAIN" {
+		t.Fatalf("unexpected transfer types %v", types)
+	}
+	transfers := NewTransferEntryArray(result)
+	if len(transfers) != 2 {
+		t.Fatalf("expected the two USDT transfers, got %v", result)
+	}
+	first, second := transfers[0], transfers[1]
+	if *first.Id != "43000126250" || *first.FromAccount != "linear" || *first.ToAccount != "spot" || *first.Status != "pending" {
+		t.Fatalf("unexpected first transfer %v", GetValue(result, 0))
+	}
+	if *second.Id != "43000126248" || *second.FromAccount != "spot" || *second.ToAccount != "linear" || *second.Status != "ok" || *second.Amount != 25 {
+		t.Fatalf("unexpected second transfer %v", GetValue(result, 1))
+	}
+}
+
+func TestBinanceFetchTransfersByAccounts(t *testing.T) {
+	exchange, transport := newMockedBinanceSubAccounts(map[string]string{
+		"/sapi/v1/asset/transfer": `{"total":0,"rows":[]}`,
+	})
+	result := <-exchange.FetchTransfers(nil, nil, 5, map[string]interface{}{"fromAccount": "funding", "toAccount": "spot"})
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if len(transport.requests) != 1 {
+		t.Fatalf("expected one request, got %d", len(transport.requests))
+	}
+	params := binanceRequestParams(t, transport.requests[0])
+	if params.Get("type") != "FUNDING_MAIN" || params.Get("size") != "5" || params.Get("fromAccount") != "" {
+		t.Fatalf("unexpected params %v", params)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// fetchBorrowInterest: isolated margin interest is requested and reported per market
+// ---------------------------------------------------------------------------
+
+func TestBinanceFetchBorrowInterestIsolated(t *testing.T) {
+	exchange, transport := newMockedBinanceLeverage(map[string]string{
+		"/sapi/v1/margin/interestHistory": `{"rows":[
+			{"isolatedSymbol":"BTCUSDT","asset":"USDT","interest":"0.02414667","interestAccuredTime":1566813600000,"interestRate":"0.01600000","principal":"36.22000000","type":"ON_BORROW"},
+			{"isolatedSymbol":"BTCUSDT","asset":"USDT","interest":"0.00100000","interestAccuredTime":1566817200000,"interestRate":"0.01600000","principal":"36.22000000","type":"PERIODIC"}
+		],"total":2}`,
+	})
+	result := <-exchange.FetchBorrowInterest("USDT", "BTC/USDT", 1566813600000)
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	params := binanceRequestParams(t, transport.requests[0])
+	if params.Get("isolatedSymbol") != "BTCUSDT" || params.Get("asset") != "USDT" || params.Get("startTime") != "1566813600000" {
+		t.Fatalf("unexpected params %v", params)
+	}
+	interests := NewBorrowInterestArray(result)
+	if len(interests) != 2 {
+		t.Fatalf("expected 2 interest records, got %v", result)
+	}
+	first := interests[0]
+	if *first.Symbol != "BTC/USDT" || *first.MarginMode != "isolated" || *first.Currency != "USDT" || *first.Interest != 0.02414667 || *first.InterestRate != 0.016 || *first.AmountBorrowed != 36.22 || *first.Timestamp != 1566813600000 {
+		t.Fatalf("unexpected interest %v", GetValue(result, 0))
+	}
+}
+
+func TestBinanceFetchBorrowInterestMarginMode(t *testing.T) {
+	exchange, transport := newMockedBinanceLeverage(map[string]string{
+		"/sapi/v1/margin/interestHistory": `{"rows":[{"asset":"USDT","interest":"0.1","interestAccuredTime":1566813600000,"interestRate":"0.016","principal":"100","type":"PERIODIC"}],"total":1}`,
+	})
+	result := <-exchange.FetchBorrowInterest("USDT", "BTC/USDT", nil, nil, map[string]interface{}{"marginMode": "cross"})
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if params := binanceRequestParams(t, transport.requests[0]); params.Has("isolatedSymbol") || params.Has("marginMode") {
+		t.Fatalf("expected a cross margin request, got %v", params)
+	}
+	if interest := NewBorrowInterestArray(result)[0]; interest.Symbol != nil || *interest.MarginMode != "cross" {
+		t.Fatalf("unexpected interest %v", GetValue(result, 0))
+	}
+	result = <-exchange.FetchBorrowInterest("USDT", nil, nil, nil, map[string]interface{}{"marginMode": "isolated"})
+	if !IsError(result) || !IsErrorType(CreateReturnError(result), "ArgumentsRequired") {
+		t.Fatalf("expected ArgumentsRequired for isolated margin without a symbol, got %v", result)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// borrowMargin / repayMargin: loans are sent to the borrow-repay endpoint and read back as margin loans
+// ---------------------------------------------------------------------------
+
+func TestBinanceBorrowAndRepayMargin(t *testing.T) {
+	exchange, transport := newMockedBinanceLeverage(map[string]string{
+		"/sapi/v1/margin/borrow-repay": `{"tranId":108988250265,"clientTag":""}`,
+	})
+	result := <-exchange.BorrowCrossMargin("USDT", 100)
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	params := binanceRequestParams(t, transport.requests[0])
+	if params.Get("asset") != "USDT" || params.Get("amount") != "100" || params.Get("isIsolated") != "FALSE" || params.Get("type") != "BORROW" {
+		t.Fatalf("unexpected borrow params %v", params)
+	}
+	loan := result.(map[string]interface{})
+	if loan["id"] != int64(108988250265) || loan["currency"] != "USDT" || loan["amount"] != 100.0 || loan["symbol"] != nil {
+		t.Fatalf("unexpected cross margin loan %v", result)
+	}
+	result = <-exchange.RepayIsolatedMargin("BTC/USDT", "USDT", 40)
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	params = binanceRequestParams(t, transport.requests[1])
+	if params.Get("symbol") != "BTCUSDT" || params.Get("amount") != "40" || params.Get("isIsolated") != "TRUE" || params.Get("type") != "REPAY" {
+		t.Fatalf("unexpected repay params %v", params)
+	}
+	loan = result.(map[string]interface{})
+	if loan["symbol"] != "BTC/USDT" || loan["amount"] != 40.0 {
+		t.Fatalf("unexpected isolated margin loan %v", result)
+	}
+}
+
+func TestBinanceBorrowMarginErrors(t *testing.T) {
+	exchange, transport := newMockedBinanceLeverage(map[string]string{
+		"/sapi/v1/margin/borrow-repay": `{"code":-3006,"msg":"Your borrow amount has exceed maximum borrow amount."}`,
+	})
+	result := <-exchange.BorrowIsolatedMargin("BTC/USDT", "USDT", 0)
+	if !IsError(result) || !IsErrorType(CreateReturnError(result), "BadRequest") {
+		t.Fatalf("expected a BadRequest for a zero amount, got %v", result)
+	}
+	if len(transport.requests) != 0 {
+		t.Fatalf("expected no request for a zero amount, got %d", len(transport.requests))
+	}
+	transport.status = 400
+	result = <-exchange.BorrowCrossMargin("USDT", 1000000)
+	if !IsError(result) || !IsErrorType(CreateReturnError(result), "InsufficientFunds") {
+		t.Fatalf("expected InsufficientFunds when the collateral does not cover the loan, got %v", result)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// fetchCrossBorrowRate / fetchIsolatedBorrowRate: daily and hourly rates, cached for borrowRatesExpires
+// ---------------------------------------------------------------------------
+
+func TestBinanceFetchCrossBorrowRate(t *testing.T) {
+	exchange, transport := newMockedBinanceLeverage(map[string]string{
+		"/sapi/v1/margin/interestRateHistory":       `[{"asset":"USDT","timestamp":1638230400000,"dailyInterestRate":"0.0006","vipLevel":0}]`,
+		"/sapi/v1/margin/next-hourly-interest-rate": `[{"asset":"USDT","nextHourlyInterestRate":"0.00000571"}]`,
+	})
+	for i := 0; i < 2; i++ {
+		result := <-exchange.FetchCrossBorrowRate("USDT")
+		if IsError(result) {
+			t.Fatal(result)
+		}
+		rate := NewCrossBorrowRate(result)
+		if *rate.Currency != "USDT" || *rate.Rate != 0.0006 || *rate.Period != 86400000 || *rate.Timestamp != 1638230400000 {
+			t.Fatalf("unexpected daily rate %v", result)
+		}
+	}
+	if len(transport.requests) != 1 {
+		t.Fatalf("expected the daily rate to be cached, got %d requests", len(transport.requests))
+	}
+	// the cached rate is a copy
+	cached := <-exchange.FetchCrossBorrowRate("USDT")
+	AddElementToObject(cached, "rate", 1.0)
+	if rate := NewCrossBorrowRate(<-exchange.FetchCrossBorrowRate("USDT")); *rate.Rate != 0.0006 {
+		t.Fatalf("expected the cached rate to be unchanged, got %v", *rate.Rate)
+	}
+	// other params are another request
+	<-exchange.FetchCrossBorrowRate("USDT", map[string]interface{}{"vipLevel": 1})
+	if len(transport.requests) != 2 || binanceRequestParams(t, transport.requests[1]).Get("vipLevel") != "1" {
+		t.Fatalf("expected the rate of the vip level to be fetched, got %d requests", len(transport.requests))
+	}
+	result := <-exchange.FetchCrossBorrowRate("USDT", map[string]interface{}{"period": "1h"})
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	params := binanceRequestParams(t, transport.requests[2])
+	if params.Get("assets") != "USDT" || params.Get("isIsolated") != "FALSE" || params.Has("period") {
+		t.Fatalf("unexpected hourly params %v", params)
+	}
+	rate := NewCrossBorrowRate(result)
+	if *rate.Currency != "USDT" || *rate.Rate != 0.00000571 || *rate.Period != 3600000 {
+		t.Fatalf("unexpected hourly rate %v", result)
+	}
+	exchange.Options.Store("borrowRatesExpires", 0)
+	<-exchange.FetchCrossBorrowRate("USDT")
+	if len(transport.requests) != 4 {
+		t.Fatalf("expected no caching without borrowRatesExpires, got %d requests", len(transport.requests))
+	}
+}
+
+func TestBinanceFetchIsolatedBorrowRate(t *testing.T) {
+	exchange, transport := newMockedBinanceLeverage(map[string]string{
+		"/sapi/v1/margin/isolatedMarginData": `[{"vipLevel":0,"symbol":"BTCUSDT","leverage":"10","data":[
+			{"coin":"BTC","dailyInterest":"0.00026125","borrowLimit":"270"},
+			{"coin":"USDT","dailyInterest":"0.000475","borrowLimit":"2100000"}
+		]}]`,
+		"/sapi/v1/margin/next-hourly-interest-rate": `[{"asset":"BTC","nextHourlyInterestRate":"0.00000571"},{"asset":"USDT","nextHourlyInterestRate":"0.0000198"}]`,
+	})
+	result := <-exchange.FetchIsolatedBorrowRate("BTC/USDT")
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	rate := NewIsolatedBorrowRate(result)
+	if *rate.Symbol != "BTC/USDT" || *rate.Base != "BTC" || *rate.BaseRate != 0.00026125 || *rate.Quote != "USDT" || *rate.QuoteRate != 0.000475 || *rate.Period != 86400000 {
+		t.Fatalf("unexpected daily rate %v", result)
+	}
+	result = <-exchange.FetchIsolatedBorrowRate("BTC/USDT", map[string]interface{}{"period": "1h"})
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	params := binanceRequestParams(t, transport.requests[1])
+	if params.Get("assets") != "BTC,USDT" || params.Get("isIsolated") != "TRUE" {
+		t.Fatalf("unexpected hourly params %v", params)
+	}
+	rate = NewIsolatedBorrowRate(result)
+	if *rate.Symbol != "BTC/USDT" || *rate.BaseRate != 0.00000571 || *rate.QuoteRate != 0.0000198 || *rate.Period != 3600000 {
+		t.Fatalf("unexpected hourly rate %v", result)
+	}
+	<-exchange.FetchIsolatedBorrowRate("BTC/USDT", map[string]interface{}{"period": "1h"})
+	if len(transport.requests) != 2 {
+		t.Fatalf("expected the hourly rate to be cached, got %d requests", len(transport.requests))
+	}
+}
+
+// ---------------------------------------------------------------------------
+// fetchStatus: the system status reports normal operation or maintenance
+// ---------------------------------------------------------------------------
+
+func TestBinanceFetchStatus(t *testing.T) {
+	exchange, transport := newMockedBinance(`{"status":0,"msg":"normal"}`)
+	result := <-exchange.FetchStatus()
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if !strings.HasSuffix(transport.requests[0].URL.Path, "/sapi/v1/system/status") {
+		t.Fatalf("unexpected request %s", transport.requests[0].URL)
+	}
+	status := result.(map[string]interface{})
+	if status["status"] != "ok" || status["updated"] == nil || status["eta"] != nil {
+		t.Fatalf("unexpected status %v", result)
+	}
+	transport.body = `{"status":1,"msg":"system_maintenance"}`
+	result = <-exchange.FetchStatus()
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if status := result.(map[string]interface{}); status["status"] != "maintenance" {
+		t.Fatalf("expected maintenance, got %v", result)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// fetchCurrencies: the networks of a coin from capital/config/getall
+// ---------------------------------------------------------------------------
+
+func TestBinanceFetchCurrenciesNetworks(t *testing.T) {
+	exchange, transport := newMockedBinanceSubAccounts(map[string]string{
+		"/sapi/v1/capital/config/getall": `[
+			{"coin":"USDT","name":"TetherUS","isLegalMoney":false,"trading":true,"depositAllEnable":true,"withdrawAllEnable":true,"networkList":[
+				{"network":"ETH","coin":"USDT","withdrawIntegerMultiple":"0.000001","isDefault":true,"depositEnable":true,"withdrawEnable":false,"withdrawFee":"4.5","withdrawMin":"10","withdrawMax":"10000000000","minConfirm":"6"},
+				{"network":"TRX","coin":"USDT","withdrawIntegerMultiple":"0.000001","isDefault":false,"depositEnable":true,"withdrawEnable":true,"withdrawFee":"1","withdrawMin":"2","withdrawMax":"10000000000","minConfirm":"1"}
+			]},
+			{"coin":"XYZ","name":"Delisted","isLegalMoney":false,"trading":false,"depositAllEnable":false,"withdrawAllEnable":false,"networkList":[
+				{"network":"BSC","coin":"XYZ","withdrawIntegerMultiple":"0.00000001","isDefault":true,"depositEnable":false,"withdrawEnable":false,"withdrawFee":"0.1","withdrawMin":"0.2","withdrawMax":"1000","minConfirm":"15"}
+			]}
+		]`,
+	})
+	result := <-exchange.FetchCurrencies()
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if len(transport.requests) == 0 || !strings.HasSuffix(transport.requests[0].URL.Path, "/capital/config/getall") {
+		t.Fatalf("expected the currencies from capital/config/getall, got %d requests", len(transport.requests))
+	}
+	exchange.SetMarkets(ObjectValues(exchange.Markets), result)
+	usdt := exchange.GetCurrency("USDT")
+	if !*usdt.Deposit || !*usdt.Withdraw || *usdt.Fee != 4.5 || len(usdt.Networks) != 2 {
+		t.Fatalf("unexpected USDT currency %v", GetValue(result, "USDT"))
+	}
+	erc20, trc20 := usdt.Networks["ERC20"], usdt.Networks["TRC20"]
+	if *erc20.Id != "ETH" || !*erc20.Deposit || *erc20.Withdraw || *erc20.Active || *erc20.Fee != 4.5 || *erc20.Confirmations != 6 || *erc20.Limits.Withdraw.Min != 10 {
+		t.Fatalf("unexpected ERC20 network %v", GetValue(GetValue(GetValue(result, "USDT"), "networks"), "ERC20"))
+	}
+	if *trc20.Id != "TRX" || !*trc20.Withdraw || !*trc20.Active || *trc20.Fee != 1 || *trc20.Confirmations != 1 || *trc20.Precision != 0.000001 {
+		t.Fatalf("unexpected TRC20 network %v", GetValue(GetValue(GetValue(result, "USDT"), "networks"), "TRC20"))
+	}
+	// a coin whose networks are all closed cannot be deposited or withdrawn
+	xyz := exchange.GetCurrency("XYZ")
+	if *xyz.Deposit || *xyz.Withdraw || *xyz.Active {
+		t.Fatalf("expected XYZ to be closed, got %v", GetValue(result, "XYZ"))
+	}
+}
+
+// ---------------------------------------------------------------------------
+// fetchDepositAddressesByNetwork: one address per network a coin can be deposited on
+// ---------------------------------------------------------------------------
+
+func TestBinanceFetchDepositAddressesByNetwork(t *testing.T) {
+	exchange, transport := newMockedBinanceSubAccounts(map[string]string{
+		"/sapi/v1/capital/config/getall": `[
+			{"coin":"USDT","name":"TetherUS","isLegalMoney":false,"trading":true,"depositAllEnable":true,"withdrawAllEnable":true,"networkList":[
+				{"network":"ETH","coin":"USDT","withdrawIntegerMultiple":"0.000001","isDefault":true,"depositEnable":true,"withdrawEnable":true,"withdrawFee":"4.5","withdrawMin":"10","withdrawMax":"10000000000","minConfirm":"6"},
+				{"network":"SOL","coin":"USDT","withdrawIntegerMultiple":"0.000001","isDefault":false,"depositEnable":false,"withdrawEnable":true,"withdrawFee":"1","withdrawMin":"2","withdrawMax":"10000000000","minConfirm":"1"},
+				{"network":"TRX","coin":"USDT","withdrawIntegerMultiple":"0.000001","isDefault":false,"depositEnable":true,"withdrawEnable":true,"withdrawFee":"1","withdrawMin":"2","withdrawMax":"10000000000","minConfirm":"1"}
+			]},
+			{"coin":"XYZ","name":"Delisted","isLegalMoney":false,"trading":false,"depositAllEnable":false,"withdrawAllEnable":false,"networkList":[
+				{"network":"BSC","coin":"XYZ","withdrawIntegerMultiple":"0.00000001","isDefault":true,"depositEnable":false,"withdrawEnable":false,"withdrawFee":"0.1","withdrawMin":"0.2","withdrawMax":"1000","minConfirm":"15"}
+			]}
+		]`,
+	})
+	currencies := <-exchange.FetchCurrencies()
+	if IsError(currencies) {
+		t.Fatal(currencies)
+	}
+	exchange.SetMarkets(ObjectValues(exchange.Markets), currencies)
+	transport.requests = nil
+	// the networks are requested in the order of their codes
+	transport.queue = []string{
+		`{"coin":"USDT","address":"0xd9e1cd77afa0e50b452a62fbb68a3340602286c3","tag":"","url":"https://etherscan.io/address/0xd9e1cd77afa0e50b452a62fbb68a3340602286c3"}`,
+		`{"coin":"USDT","address":"TLmU8w5dkw6Vr3zo3gsPRNpvyhFAnYTiCQ","tag":"","url":"https://tronscan.org/#/address/TLmU8w5dkw6Vr3zo3gsPRNpvyhFAnYTiCQ"}`,
+	}
+	result := <-exchange.FetchDepositAddressesByNetwork("USDT")
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if len(transport.requests) != 2 {
+		t.Fatalf("expected a request per network open to deposits, got %d", len(transport.requests))
+	}
+	for i, network := range []string{"ETH", "TRX"} {
+		query := transport.requests[i].URL.Query()
+		if !strings.HasSuffix(transport.requests[i].URL.Path, "/capital/deposit/address") || query.Get("coin") != "USDT" || query.Get("network") != network {
+			t.Fatalf("request %d: expected the %s address, got %s", i, network, transport.requests[i].URL)
+		}
+	}
+	addresses := NewDepositAddressesByNetwork(result)
+	erc20, trc20 := addresses["ERC20"], addresses["TRC20"]
+	if len(addresses) != 2 || *erc20.Address != "0xd9e1cd77afa0e50b452a62fbb68a3340602286c3" || *erc20.Network != "ERC20" || *erc20.Currency != "USDT" || erc20.Tag != nil {
+		t.Fatalf("unexpected ERC20 address %v", result)
+	}
+	if *trc20.Address != "TLmU8w5dkw6Vr3zo3gsPRNpvyhFAnYTiCQ" || *trc20.Network != "TRC20" {
+		t.Fatalf("unexpected TRC20 address %v", result)
+	}
+	if list := NewDepositAddressArray(result); len(list) != 2 || *list[0].Network != "ERC20" || *list[1].Network != "TRC20" {
+		t.Fatalf("expected the addresses sorted by network, got %v", list)
+	}
+	transport.queue = []string{
+		`{"coin":"USDT","address":"0xd9e1cd77afa0e50b452a62fbb68a3340602286c3","tag":"","url":""}`,
+		`{"coin":"USDT","address":"TLmU8w5dkw6Vr3zo3gsPRNpvyhFAnYTiCQ","tag":"","url":""}`,
+	}
+	if byNetwork, err := NewBinanceFromCore(exchange).FetchDepositAddressesIndexedByNetwork("USDT"); err != nil || len(byNetwork) != 2 || *byNetwork["TRC20"].Address != "TLmU8w5dkw6Vr3zo3gsPRNpvyhFAnYTiCQ" {
+		t.Fatalf("expected the typed addresses indexed by network, got %v, %v", byNetwork, err)
+	}
+	// a coin closed to deposits is rejected before any request
+	transport.requests = nil
+	if result := <-exchange.FetchDepositAddressesByNetwork("XYZ"); !IsError(result) || !IsErrorType(CreateReturnError(result), "BadRequest") || len(transport.requests) != 0 {
+		t.Fatalf("expected a BadRequest without requests, got %v", result)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// fetchDeposits / fetchWithdrawals: 90 days window, unified status filter
+// ---------------------------------------------------------------------------
+
+func TestBinanceParseTransactionStatuses(t *testing.T) {
+	exchange, _ := newMockedBinance(`{}`)
+	for _, test := range []struct {
+		typeVar  string
+		statuses map[int]string
+	}{
+		{"deposit", map[int]string{0: "pending", 1: "ok", 2: "failed", 6: "ok", 7: "failed", 8: "pending"}},
+		{"withdrawal", map[int]string{0: "pending", 1: "canceled", 2: "pending", 3: "failed", 4: "pending", 5: "failed", 6: "ok"}},
+	} {
+		for code, expected := range test.statuses {
+			transaction := exchange.ParseTransaction(map[string]interface{}{
+				"id":         "b6ae22b3aa844210a7041aee7589627c",
+				"amount":     "0.00999800",
+				"coin":       "PAXG",
+				"network":    "ETH",
+				"status":     code,
+				"address":    "0x788cabe9236ce061e5a892e1a59395a81fc8d62c",
+				"txId":       "0xaad4654a3234aa6118af9b4b335f5ae81c360b2394721c019b5d1e75328b09f3",
+				"insertTime": 1599621997000,
+				"applyTime":  "2020-09-09 03:26:37",
+				"type":       test.typeVar,
+			})
+			if status := GetValue(transaction, "status"); status != expected {
+				t.Fatalf("%s status %d: expected %s, got %v", test.typeVar, code, expected, status)
+			}
+		}
+	}
+}
+
+func TestBinanceFetchDepositsWindowAndStatus(t *testing.T) {
+	exchange, transport := newMockedBinanceSubAccounts(nil)
+	deposit := func(id string, status int, insertTime int64) string {
+		return fmt.Sprintf(`{"id":"%s","amount":"1","coin":"USDT","network":"TRX","status":%d,"address":"TLmU8w5dkw6Vr3zo3gsPRNpvyhFAnYTiCQ","txId":"%s","insertTime":%d}`, id, status, id, insertTime)
+	}
+	const day = int64(24 * 60 * 60 * 1000)
+	since := int64(1700000000000)
+	transport.queue = []string{
+		`[` + deposit("1", 1, since+day) + `,` + deposit("2", 7, since+2*day) + `,` + deposit("3", 2, since+80*day) + `]`,
+	}
+	result := <-exchange.FetchDeposits(nil, since, nil, map[string]interface{}{"until": since + 200*day, "status": "failed"})
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	// a single request covers the 90 days allowed from since, longer ranges are left to params.paginate
+	if len(transport.requests) != 1 {
+		t.Fatalf("expected one request, got %d", len(transport.requests))
+	}
+	params := binanceRequestParams(t, transport.requests[0])
+	if !strings.HasSuffix(transport.requests[0].URL.Path, "/capital/deposit/hisrec") || params.Get("startTime") != fmt.Sprint(since) || params.Get("endTime") != fmt.Sprint(since+90*day) || params.Has("status") {
+		t.Fatalf("expected the 90 days window without a status, got %v", params)
+	}
+	deposits := result.([]interface{})
+	if len(deposits) != 2 || GetValue(deposits[0], "id") != "2" || GetValue(deposits[1], "id") != "3" {
+		t.Fatalf("expected the failed deposits only, got %v", deposits)
+	}
+	// an earlier until ends the window
+	transport.requests = nil
+	transport.queue = []string{`[]`}
+	if result := <-exchange.FetchWithdrawals(nil, since, nil, map[string]interface{}{"until": since + 10*day}); IsError(result) {
+		t.Fatal(result)
+	}
+	if params := binanceRequestParams(t, transport.requests[0]); params.Get("startTime") != fmt.Sprint(since) || params.Get("endTime") != fmt.Sprint(since+10*day) {
+		t.Fatalf("expected the window to end at until, got %v", params)
+	}
+	// a binance status code is sent as is
+	transport.requests = nil
+	transport.queue = []string{`[` + deposit("1", 1, since+day) + `]`}
+	result = <-exchange.FetchWithdrawals(nil, nil, nil, map[string]interface{}{"status": 6})
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if params := binanceRequestParams(t, transport.requests[0]); !strings.HasSuffix(transport.requests[0].URL.Path, "/capital/withdraw/history") || params.Get("status") != "6" || params.Has("startTime") {
+		t.Fatalf("expected the status code to be sent, got %v", params)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// setPositionMode / fetchPositionMode: hedge mode of the futures accounts
+// ---------------------------------------------------------------------------
+
+func TestBinanceSetPositionMode(t *testing.T) {
+	for _, hedged := range []bool{true, false} {
+		exchange, transport := newMockedBinanceLeverage(map[string]string{
+			"/positionSide/dual": `{"code":200,"msg":"success"}`,
+		})
+		result := <-exchange.SetPositionMode(hedged, "BTC/USDT:USDT")
+		if IsError(result) {
+			t.Fatal(result)
+		}
+		request := transport.requests[0]
+		if request.Method != http.MethodPost || !strings.HasSuffix(request.URL.Path, "/fapi/v1/positionSide/dual") {
+			t.Fatalf("expected the linear position mode endpoint, got %s %s", request.Method, request.URL.Path)
+		}
+		if value := binanceRequestParams(t, request).Get("dualSidePosition"); value != strconv.FormatBool(hedged) {
+			t.Fatalf("expected dualSidePosition=%t, got %s", hedged, value)
+		}
+	}
+	// the inverse markets have their own position mode
+	exchange, transport := newMockedBinanceLeverage(map[string]string{
+		"/positionSide/dual": `{"code":200,"msg":"success"}`,
+	})
+	if result := <-exchange.SetPositionMode(true, "BTC/USD:BTC"); IsError(result) {
+		t.Fatal(result)
+	}
+	if !strings.HasSuffix(transport.requests[0].URL.Path, "/dapi/v1/positionSide/dual") {
+		t.Fatalf("expected the inverse position mode endpoint, got %s", transport.requests[0].URL.Path)
+	}
+}
+
+func TestBinanceSetPositionModeWithOpenPositions(t *testing.T) {
+	for _, code := range []string{"-4068", "-4067"} {
+		for _, subType := range []string{"linear", "inverse"} {
+			body := `{"code":` + code + `,"msg":"Position side cannot be changed."}`
+			exchange, transport := newMockedBinanceLeverage(map[string]string{"/positionSide/dual": body})
+			transport.status = 400
+			result := <-exchange.SetPositionMode(false, nil, map[string]interface{}{"subType": subType})
+			if err := CreateReturnError(result); !IsErrorType(err, "OperationRejected") {
+				t.Fatalf("expected an OperationRejected for the %s %s response, got %v", subType, code, result)
+			}
+		}
+	}
+}
+
+func TestBinanceFetchPositionMode(t *testing.T) {
+	for _, hedged := range []bool{true, false} {
+		exchange, transport := newMockedBinanceLeverage(map[string]string{
+			"/positionSide/dual": `{"dualSidePosition":` + strconv.FormatBool(hedged) + `}`,
+		})
+		result := <-exchange.FetchPositionMode("BTC/USDT:USDT")
+		if IsError(result) {
+			t.Fatal(result)
+		}
+		request := transport.requests[0]
+		if request.Method != http.MethodGet || !strings.HasSuffix(request.URL.Path, "/fapi/v1/positionSide/dual") {
+			t.Fatalf("expected the linear position mode endpoint, got %s %s", request.Method, request.URL.Path)
+		}
+		if GetValue(result, "hedged") != hedged {
+			t.Fatalf("expected hedged to be %t, got %v", hedged, result)
+		}
+	}
+	exchange, transport := newMockedBinanceLeverage(map[string]string{
+		"/positionSide/dual": `{"dualSidePosition":true}`,
+	})
+	result := <-exchange.FetchPositionMode(nil, map[string]interface{}{"subType": "linear", "portfolioMargin": true})
+	if IsError(result) || !strings.HasSuffix(transport.requests[0].URL.Path, "/papi/v1/um/positionSide/dual") {
+		t.Fatalf("expected the portfolio margin endpoint, got %v", result)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// setLeverage: the leverage is checked against the leverage tiers before the request
+// ---------------------------------------------------------------------------
+
+func TestBinanceSetLeverageChecksLeverageTiers(t *testing.T) {
+	exchange, transport := newMockedBinanceLeverage(map[string]string{
+		"/leverageBracket": `[{"symbol":"BTCUSDT","brackets":[` +
+			`{"bracket":1,"initialLeverage":50,"notionalCap":50000,"notionalFloor":0,"maintMarginRatio":0.004,"cum":0.0},` +
+			`{"bracket":2,"initialLeverage":20,"notionalCap":250000,"notionalFloor":50000,"maintMarginRatio":0.005,"cum":50.0}]}]`,
+		"/fapi/v1/leverage": `{"leverage":20,"maxNotionalValue":"1000000","symbol":"BTCUSDT"}`,
+	})
+	count := func() (brackets int, leverages int) {
+		for _, request := range transport.requests {
+			if strings.HasSuffix(request.URL.Path, "/leverageBracket") {
+				brackets++
+			} else if strings.HasSuffix(request.URL.Path, "/fapi/v1/leverage") {
+				leverages++
+			}
+		}
+		return brackets, leverages
+	}
+	result := <-exchange.SetLeverage(75, "BTC/USDT:USDT")
+	err := CreateReturnError(result)
+	if !IsErrorType(err, "BadRequest") || !strings.Contains(err.Error(), "maximum leverage of 50") {
+		t.Fatalf("expected a BadRequest for a leverage above the tiers, got %v", result)
+	}
+	if _, leverages := count(); leverages != 0 {
+		t.Fatal("expected the leverage to be rejected before the request")
+	}
+	if result := <-exchange.SetLeverage(0, "BTC/USDT:USDT"); !IsErrorType(CreateReturnError(result), "BadRequest") {
+		t.Fatalf("expected a BadRequest for a leverage under 1, got %v", result)
+	}
+	// the typed and the dynamic calls go through the same check
+	if _, err := NewBinanceFromCore(exchange).SetLeverage(75, WithSetLeverageSymbol("BTC/USDT:USDT")); !IsErrorType(err, "BadRequest") {
+		t.Fatalf("expected the typed call to be checked, got %v", err)
+	}
+	if result := <-exchange.CallInternal("setLeverage", 75, "BTC/USDT:USDT"); !IsErrorType(CreateReturnError(result), "BadRequest") {
+		t.Fatalf("expected the dynamic call to be checked, got %v", result)
+	}
+
+	result = <-exchange.SetLeverage(10, "BTC/USDT:USDT")
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	// the tiers are fetched once for the same params
+	if brackets, leverages := count(); brackets != 1 || leverages != 1 {
+		t.Fatalf("expected one leverage tiers request and one leverage request, got %d and %d", brackets, leverages)
+	}
+	// other params have their own tiers, they are sent with the tiers request
+	result = <-exchange.SetLeverage(10, "BTC/USDT:USDT", map[string]interface{}{"recvWindow": 6000})
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if brackets, _ := count(); brackets != 2 {
+		t.Fatalf("expected the tiers to be fetched for the other params, got %d requests", brackets)
+	}
+	if query := transport.requests[len(transport.requests)-2].URL.RawQuery; !strings.Contains(query, "recvWindow=6000") {
+		t.Fatalf("expected the params in the tiers request, got %s", query)
+	}
+	// the tiers expire after options.leverageTiersTTL
+	AddElementToObject(exchange.Options, "leverageTiersTTL", 0)
+	if result := <-exchange.SetLeverage(10, "BTC/USDT:USDT"); IsError(result) {
+		t.Fatal(result)
+	}
+	if brackets, _ := count(); brackets != 3 {
+		t.Fatalf("expected the expired tiers to be fetched again, got %d requests", brackets)
+	}
+	// the check can be disabled
+	AddElementToObject(exchange.Options, "checkLeverageTiers", false)
+	if result := <-exchange.SetLeverage(75, "BTC/USDT:USDT"); IsError(result) {
+		t.Fatalf("expected the check to be skipped, got %v", result)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// fetchMarketLeverageTiers: the brackets of a single market are requested
+// ---------------------------------------------------------------------------
+
+func TestBinanceFetchMarketLeverageTiers(t *testing.T) {
+	exchange, transport := newMockedBinanceLeverage(map[string]string{
+		"/leverageBracket": `[{"symbol":"ETHUSDT","brackets":[` +
+			`{"bracket":1,"initialLeverage":100,"notionalCap":10000,"notionalFloor":0,"maintMarginRatio":0.005,"cum":0.0}]},` +
+			`{"symbol":"BTCUSDT","brackets":[` +
+			`{"bracket":1,"initialLeverage":125,"notionalCap":50000,"notionalFloor":0,"maintMarginRatio":0.004,"cum":0.0},` +
+			`{"bracket":2,"initialLeverage":100,"notionalCap":250000,"notionalFloor":50000,"maintMarginRatio":0.005,"cum":50.0}]}]`,
+	})
+	result := <-exchange.FetchMarketLeverageTiers("BTC/USDT:USDT")
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	tiers := NewLeverageTierArray(result)
+	if len(tiers) != 2 {
+		t.Fatalf("expected the two tiers of BTC/USDT:USDT, got %v", result)
+	}
+	for _, tier := range tiers {
+		if *tier.Symbol != "BTC/USDT:USDT" {
+			t.Fatalf("expected only the tiers of BTC/USDT:USDT, got %s", *tier.Symbol)
+		}
+	}
+	if *tiers[0].MaxLeverage != 125 || *tiers[1].MinNotional != 50000 {
+		t.Fatalf("unexpected tiers %v", result)
+	}
+	request := transport.requests[0]
+	if !strings.HasSuffix(request.URL.Path, "/fapi/v1/leverageBracket") || request.URL.Query().Get("symbol") != "BTCUSDT" {
+		t.Fatalf("expected the brackets of BTCUSDT to be requested, got %s", request.URL)
+	}
+
+	// inverse markets use the coin-margined endpoint
+	<-exchange.FetchMarketLeverageTiers("BTC/USD:BTC")
+	request = transport.requests[len(transport.requests)-1]
+	if !strings.HasSuffix(request.URL.Path, "/dapi/v2/leverageBracket") || request.URL.Query().Get("symbol") != "BTCUSD_PERP" {
+		t.Fatalf("expected the brackets of BTCUSD_PERP to be requested, got %s", request.URL)
+	}
+
+	if result := <-exchange.FetchMarketLeverageTiers("BTC/USDT"); !IsErrorType(CreateReturnError(result), "BadSymbol") {
+		t.Fatalf("expected a BadSymbol for a spot market, got %v", result)
+	}
+}
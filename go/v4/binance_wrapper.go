@@ -374,6 +374,41 @@ func (this *Binance) FetchMarkPrices(options ...FetchMarkPricesOptions) (Tickers
 	return NewTickers(res), nil
 }
 
+/**
+ * @method
+ * @name binance#fetchIndexPrices
+ * @description fetches index prices for multiple markets
+ * @see https://developers.binance.com/docs/derivatives/coin-margined-futures/market-data/rest-api/Index-Price-and-Mark-Price
+ * @see https://developers.binance.com/docs/derivatives/usds-margined-futures/market-data/rest-api/Mark-Price
+ * @param {string[]} [symbols] unified symbols of the markets to fetch the index price for, all markets are returned if not assigned
+ * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @param {string} [params.subType] "linear" or "inverse"
+ * @returns {object} a dictionary of [ticker structures]{@link https://docs.ccxt.com/?id=ticker-structure}
+ */
+func (this *Binance) FetchIndexPrices(options ...FetchIndexPricesOptions) (Tickers, error) {
+
+	opts := FetchIndexPricesOptionsStruct{}
+
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	var symbols interface{} = nil
+	if opts.Symbols != nil {
+		symbols = *opts.Symbols
+	}
+
+	var params interface{} = nil
+	if opts.Params != nil {
+		params = *opts.Params
+	}
+	res := <-this.Core.FetchIndexPrices(symbols, params)
+	if IsError(res) {
+		return Tickers{}, CreateReturnError(res)
+	}
+	return NewTickers(res), nil
+}
+
 /**
  * @method
  * @name binance#fetchOHLCV
@@ -1487,11 +1522,12 @@ func (this *Binance) FetchMyDustTrades(options ...FetchMyDustTradesOptions) (map
  * @see https://developers.binance.com/docs/wallet/capital/deposite-history
  * @see https://developers.binance.com/docs/fiat/rest-api/Get-Fiat-Deposit-Withdraw-History
  * @param {string} code unified currency code
- * @param {int} [since] the earliest time in ms to fetch deposits for
+ * @param {int} [since] the earliest time in ms to fetch deposits for, at most 90 days are fetched from it unless params.paginate is set
  * @param {int} [limit] the maximum number of deposits structures to retrieve
  * @param {object} [params] extra parameters specific to the exchange API endpoint
  * @param {bool} [params.fiat] if true, only fiat deposits will be returned
  * @param {int} [params.until] the latest time in ms to fetch entries for
+ * @param {string} [params.status] 'pending', 'ok', 'failed' or 'canceled', or a binance status code
  * @param {boolean} [params.paginate] default false, when true will automatically paginate by calling this endpoint multiple times. See in the docs all the [available parameters](https://github.com/ccxt/ccxt/wiki/Manual#pagination-params)
  * @returns {object[]} a list of [transaction structures]{@link https://docs.ccxt.com/?id=transaction-structure}
  */
@@ -1536,11 +1572,12 @@ func (this *Binance) FetchDeposits(options ...FetchDepositsOptions) ([]Transacti
  * @see https://developers.binance.com/docs/wallet/capital/withdraw-history
  * @see https://developers.binance.com/docs/fiat/rest-api/Get-Fiat-Deposit-Withdraw-History
  * @param {string} code unified currency code
- * @param {int} [since] the earliest time in ms to fetch withdrawals for
+ * @param {int} [since] the earliest time in ms to fetch withdrawals for, at most 90 days are fetched from it unless params.paginate is set
  * @param {int} [limit] the maximum number of withdrawals structures to retrieve
  * @param {object} [params] extra parameters specific to the exchange API endpoint
  * @param {bool} [params.fiat] if true, only fiat withdrawals will be returned
  * @param {int} [params.until] the latest time in ms to fetch withdrawals for
+ * @param {string} [params.status] 'pending', 'ok', 'failed' or 'canceled', or a binance status code
  * @param {boolean} [params.paginate] default false, when true will automatically paginate by calling this endpoint multiple times. See in the docs all the [available parameters](https://github.com/ccxt/ccxt/wiki/Manual#pagination-params)
  * @returns {object[]} a list of [transaction structures]{@link https://docs.ccxt.com/?id=transaction-structure}
  */
@@ -1688,6 +1725,34 @@ func (this *Binance) FetchDepositAddress(code string, options ...FetchDepositAdd
 	return NewDepositAddress(res), nil
 }
 
+/**
+ * @method
+ * @name binance#fetchDepositAddressesByNetwork
+ * @description fetch the deposit addresses of every network a currency can be deposited on
+ * @see https://developers.binance.com/docs/wallet/capital/deposite-address
+ * @param {string} code unified currency code
+ * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @returns {object} a dictionary of [address structures]{@link https://docs.ccxt.com/?id=address-structure} indexed by the network code
+ */
+func (this *Binance) FetchDepositAddressesByNetwork(code string, options ...FetchDepositAddressesByNetworkOptions) ([]DepositAddress, error) {
+
+	opts := FetchDepositAddressesByNetworkOptionsStruct{}
+
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	var params interface{} = nil
+	if opts.Params != nil {
+		params = *opts.Params
+	}
+	res := <-this.Core.FetchDepositAddressesByNetwork(code, params)
+	if IsError(res) {
+		return nil, CreateReturnError(res)
+	}
+	return NewDepositAddressArray(res), nil
+}
+
 /**
  * @method
  * @name binance#fetchTransactionFees
@@ -1996,6 +2061,38 @@ func (this *Binance) FetchLeverageTiers(options ...FetchLeverageTiersOptions) (L
 	return NewLeverageTiers(res), nil
 }
 
+/**
+ * @method
+ * @name binance#fetchMarketLeverageTiers
+ * @description retrieve information on the maximum leverage, and maintenance margin for trades of varying trade sizes for a single market
+ * @see https://developers.binance.com/docs/derivatives/usds-margined-futures/account/rest-api/Notional-and-Leverage-Brackets
+ * @see https://developers.binance.com/docs/derivatives/coin-margined-futures/account/rest-api/Notional-Bracket-for-Pair
+ * @see https://developers.binance.com/docs/derivatives/portfolio-margin/account/UM-Notional-and-Leverage-Brackets
+ * @see https://developers.binance.com/docs/derivatives/portfolio-margin/account/CM-Notional-and-Leverage-Brackets
+ * @param {string} symbol unified market symbol
+ * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @param {boolean} [params.portfolioMargin] set to true if you would like to fetch the leverage tiers for a portfolio margin account
+ * @returns {object[]} a list of [leverage tiers structures]{@link https://docs.ccxt.com/?id=leverage-tiers-structure}
+ */
+func (this *Binance) FetchMarketLeverageTiers(symbol string, options ...FetchMarketLeverageTiersOptions) ([]LeverageTier, error) {
+
+	opts := FetchMarketLeverageTiersOptionsStruct{}
+
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	var params interface{} = nil
+	if opts.Params != nil {
+		params = *opts.Params
+	}
+	res := <-this.Core.FetchMarketLeverageTiers(symbol, params)
+	if IsError(res) {
+		return nil, CreateReturnError(res)
+	}
+	return NewLeverageTierArray(res), nil
+}
+
 /**
  * @method
  * @name binance#fetchPosition
@@ -2180,6 +2277,50 @@ func (this *Binance) FetchPositionsRisk(options ...FetchPositionsRiskOptions) ([
 	return NewPositionArray(res), nil
 }
 
+/**
+ * @method
+ * @name binance#fetchPositionHistory
+ * @description fetches the closed positions of a contract market, they are rebuilt from the account trades
+ * @see https://developers.binance.com/docs/derivatives/usds-margined-futures/trade/rest-api/Account-Trade-List
+ * @see https://developers.binance.com/docs/derivatives/coin-margined-futures/trade/rest-api/Account-Trade-List
+ * @see https://developers.binance.com/docs/derivatives/usds-margined-futures/account/rest-api/Get-Income-History
+ * @see https://developers.binance.com/docs/derivatives/coin-margined-futures/account/rest-api/Get-Income-History
+ * @param {string} symbol unified contract symbol
+ * @param {int} [since] the earliest time in ms to fetch positions for, default is 7 days before until
+ * @param {int} [limit] the maximum number of position structures to retrieve
+ * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @param {int} [params.until] the latest time in ms to fetch positions for, default is now
+ * @returns {object[]} a list of [position structures]{@link https://docs.ccxt.com/?id=position-structure}, the fees and the funding of each position are in its info
+ */
+func (this *Binance) FetchPositionHistory(symbol string, options ...FetchPositionHistoryOptions) ([]Position, error) {
+
+	opts := FetchPositionHistoryOptionsStruct{}
+
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	var since interface{} = nil
+	if opts.Since != nil {
+		since = *opts.Since
+	}
+
+	var limit interface{} = nil
+	if opts.Limit != nil {
+		limit = *opts.Limit
+	}
+
+	var params interface{} = nil
+	if opts.Params != nil {
+		params = *opts.Params
+	}
+	res := <-this.Core.FetchPositionHistory(symbol, since, limit, params)
+	if IsError(res) {
+		return nil, CreateReturnError(res)
+	}
+	return NewPositionArray(res), nil
+}
+
 /**
  * @method
  * @name binance#fetchFundingHistory
@@ -2317,7 +2458,7 @@ func (this *Binance) SetMarginMode(marginMode string, options ...SetMarginModeOp
  * @param {object} [params] extra parameters specific to the exchange API endpoint
  * @param {boolean} [params.portfolioMargin] set to true if you would like to set the position mode for a portfolio margin account
  * @param {string} [params.subType] "linear" or "inverse"
- * @returns {object} response from the exchange
+ * @returns {object} response from the exchange, an OperationRejected is thrown while there are open positions or orders
  */
 func (this *Binance) SetPositionMode(hedged bool, options ...SetPositionModeOptions) (map[string]interface{}, error) {
 
@@ -2343,6 +2484,38 @@ func (this *Binance) SetPositionMode(hedged bool, options ...SetPositionModeOpti
 	return res.(map[string]interface{}), nil
 }
 
+/**
+ * @method
+ * @name binance#fetchLeverage
+ * @description fetch the set leverage for a market
+ * @see https://developers.binance.com/docs/derivatives/usds-margined-futures/account/rest-api/Symbol-Config
+ * @see https://developers.binance.com/docs/derivatives/coin-margined-futures/trade/rest-api/Position-Information
+ * @see https://developers.binance.com/docs/derivatives/portfolio-margin/account/Query-UM-Position-Information
+ * @see https://developers.binance.com/docs/derivatives/portfolio-margin/account/Query-CM-Position-Information
+ * @param {string} symbol unified market symbol
+ * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @param {boolean} [params.portfolioMargin] set to true if you would like to fetch the leverage for a portfolio margin account
+ * @returns {object} a [leverage structure]{@link https://docs.ccxt.com/?id=leverage-structure}
+ */
+func (this *Binance) FetchLeverage(symbol string, options ...FetchLeverageOptions) (Leverage, error) {
+
+	opts := FetchLeverageOptionsStruct{}
+
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	var params interface{} = nil
+	if opts.Params != nil {
+		params = *opts.Params
+	}
+	res := <-this.Core.FetchLeverage(symbol, params)
+	if IsError(res) {
+		return Leverage{}, CreateReturnError(res)
+	}
+	return NewLeverage(res), nil
+}
+
 /**
  * @method
  * @name binance#fetchLeverages
@@ -2982,9 +3155,12 @@ func (this *Binance) FetchTradingLimits(options ...FetchTradingLimitsOptions) (m
  * @description fetchs the position mode, hedged or one way, hedged for binance is set identically for all linear markets or all inverse markets
  * @see https://developers.binance.com/docs/derivatives/usds-margined-futures/account/rest-api/Get-Current-Position-Mode
  * @see https://developers.binance.com/docs/derivatives/coin-margined-futures/account/rest-api/Get-Current-Position-Mode
- * @param {string} symbol unified symbol of the market to fetch the order book for
+ * @see https://developers.binance.com/docs/derivatives/portfolio-margin/account/Get-UM-Current-Position-Mode
+ * @see https://developers.binance.com/docs/derivatives/portfolio-margin/account/Get-CM-Current-Position-Mode
+ * @param {string} symbol unified symbol of the market to fetch the position mode for
  * @param {object} [params] extra parameters specific to the exchange API endpoint
  * @param {string} [params.subType] "linear" or "inverse"
+ * @param {boolean} [params.portfolioMargin] set to true if you would like to fetch the position mode for a portfolio margin account
  * @returns {object} an object detailing whether the market is in hedged or one-way mode
  */
 func (this *Binance) FetchPositionMode(options ...FetchPositionModeOptions) (map[string]interface{}, error) {
@@ -3574,9 +3750,6 @@ func (this *Binance) FetchCrossBorrowRates(params ...interface{}) (CrossBorrowRa
 func (this *Binance) FetchDepositAddresses(options ...FetchDepositAddressesOptions) ([]DepositAddress, error) {
 	return this.exchangeTyped.FetchDepositAddresses(options...)
 }
-func (this *Binance) FetchDepositAddressesByNetwork(code string, options ...FetchDepositAddressesByNetworkOptions) ([]DepositAddress, error) {
-	return this.exchangeTyped.FetchDepositAddressesByNetwork(code, options...)
-}
 func (this *Binance) FetchDepositsWithdrawals(options ...FetchDepositsWithdrawalsOptions) ([]Transaction, error) {
 	return this.exchangeTyped.FetchDepositsWithdrawals(options...)
 }
@@ -3592,18 +3765,12 @@ func (this *Binance) FetchFundingInterval(symbol string, options ...FetchFunding
 func (this *Binance) FetchIndexOHLCV(symbol string, options ...FetchIndexOHLCVOptions) ([]OHLCV, error) {
 	return this.exchangeTyped.FetchIndexOHLCV(symbol, options...)
 }
-func (this *Binance) FetchLeverage(symbol string, options ...FetchLeverageOptions) (Leverage, error) {
-	return this.exchangeTyped.FetchLeverage(symbol, options...)
-}
 func (this *Binance) FetchLiquidations(symbol string, options ...FetchLiquidationsOptions) ([]Liquidation, error) {
 	return this.exchangeTyped.FetchLiquidations(symbol, options...)
 }
 func (this *Binance) FetchLongShortRatio(symbol string, options ...FetchLongShortRatioOptions) (LongShortRatio, error) {
 	return this.exchangeTyped.FetchLongShortRatio(symbol, options...)
 }
-func (this *Binance) FetchMarketLeverageTiers(symbol string, options ...FetchMarketLeverageTiersOptions) ([]LeverageTier, error) {
-	return this.exchangeTyped.FetchMarketLeverageTiers(symbol, options...)
-}
 func (this *Binance) FetchMarkOHLCV(symbol string, options ...FetchMarkOHLCVOptions) ([]OHLCV, error) {
 	return this.exchangeTyped.FetchMarkOHLCV(symbol, options...)
 }
@@ -3625,9 +3792,6 @@ func (this *Binance) FetchOrderStatus(id string, options ...FetchOrderStatusOpti
 func (this *Binance) FetchPaymentMethods(params ...interface{}) (map[string]interface{}, error) {
 	return this.exchangeTyped.FetchPaymentMethods(params...)
 }
-func (this *Binance) FetchPositionHistory(symbol string, options ...FetchPositionHistoryOptions) ([]Position, error) {
-	return this.exchangeTyped.FetchPositionHistory(symbol, options...)
-}
 func (this *Binance) FetchPositionsForSymbol(symbol string, options ...FetchPositionsForSymbolOptions) ([]Position, error) {
 	return this.exchangeTyped.FetchPositionsForSymbol(symbol, options...)
 }
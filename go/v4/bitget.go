@@ -10137,6 +10137,8 @@ func (this *BitgetCore) SetLeverage(leverage interface{}, optionalArgs ...interf
 		_ = symbol
 		params := GetArg(optionalArgs, 1, map[string]interface{}{})
 		_ = params
+		retRes101407 := (<-this.CheckLeverage(leverage, symbol, params))
+		PanicOnError(retRes101407)
 		if IsTrue(IsEqual(symbol, nil)) {
 			panic(ArgumentsRequired(Add(this.Id, " setLeverage() requires a symbol argument")))
 		}
@@ -1712,6 +1712,25 @@ func (this *BitmexCore) ParseTransaction(transaction interface{}, optionalArgs .
 	}
 }
 
+// HandleIndexMarket returns the market of the symbol and the params without isIndex, index symbols
+// such as .BXBT are not returned by fetchMarkets so a market structure is built for them when isIndex is set
+func (this *BitmexCore) HandleIndexMarket(symbol interface{}, params interface{}) interface{} {
+	var isIndex interface{} = this.SafeBool(params, "isIndex", false)
+	params = this.Omit(params, "isIndex")
+	if IsTrue(IsTrue(!IsTrue(isIndex)) || IsTrue(InOp(this.Markets, symbol))) {
+		return []interface{}{this.Market(symbol), params}
+	}
+	var market interface{} = this.SafeMarketStructure(map[string]interface{}{
+		"id":     symbol,
+		"symbol": symbol,
+		"type":   "index",
+		"index":  true,
+		"active": true,
+		"info":   map[string]interface{}{},
+	})
+	return []interface{}{market, params}
+}
+
 /**
  * @method
  * @name bitmex#fetchTicker
@@ -1719,6 +1738,7 @@ func (this *BitmexCore) ParseTransaction(transaction interface{}, optionalArgs .
  * @see https://www.bitmex.com/api/explorer/#!/Instrument/Instrument_get
  * @param {string} symbol unified symbol of the market to fetch the ticker for
  * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @param {boolean} [params.isIndex] default false, set to true to query an index symbol like .BXBT that is not one of the tradable markets
  * @returns {object} a [ticker structure]{@link https://docs.ccxt.com/?id=ticker-structure}
  */
 func (this *BitmexCore) FetchTicker(symbol interface{}, optionalArgs ...interface{}) <-chan interface{} {
@@ -1731,7 +1751,10 @@ func (this *BitmexCore) FetchTicker(symbol interface{}, optionalArgs ...interfac
 
 		retRes15408 := (<-this.LoadMarkets())
 		PanicOnError(retRes15408)
-		var market interface{} = this.Market(symbol)
+		var market interface{} = nil
+		marketparamsVariable := this.HandleIndexMarket(symbol, params)
+		market = GetValue(marketparamsVariable, 0)
+		params = GetValue(marketparamsVariable, 1)
 		var request interface{} = map[string]interface{}{
 			"symbol": GetValue(market, "id"),
 		}
@@ -1861,6 +1884,7 @@ func (this *BitmexCore) ParseOHLCV(ohlcv interface{}, optionalArgs ...interface{
  * @param {int} [limit] the maximum amount of candles to fetch
  * @param {object} [params] extra parameters specific to the exchange API endpoint
  * @param {boolean} [params.paginate] default false, when true will automatically paginate by calling this endpoint multiple times. See in the docs all the [availble parameters](https://github.com/ccxt/ccxt/wiki/Manual#pagination-params)
+ * @param {boolean} [params.isIndex] default false, set to true to query an index symbol like .BXBT that is not one of the tradable markets
  * @returns {int[][]} A list of candles ordered as timestamp, open, high, low, close, volume
  */
 func (this *BitmexCore) FetchOHLCV(symbol interface{}, optionalArgs ...interface{}) <-chan interface{} {
@@ -1896,7 +1920,10 @@ func (this *BitmexCore) FetchOHLCV(symbol interface{}, optionalArgs ...interface
 		// send a bare series (e.g. XBU) to nearest expiring contract in that series
 		// you can also send a timeframe, e.g. XBU:monthly
 		// timeframes: daily, weekly, monthly, quarterly, and biquarterly
-		var market interface{} = this.Market(symbol)
+		var market interface{} = nil
+		marketparamsVariable := this.HandleIndexMarket(symbol, params)
+		market = GetValue(marketparamsVariable, 0)
+		params = GetValue(marketparamsVariable, 1)
 		var request interface{} = map[string]interface{}{
 			"symbol":  GetValue(market, "id"),
 			"binSize": this.SafeString(this.Timeframes, timeframe, timeframe),
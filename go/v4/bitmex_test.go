@@ -0,0 +1,93 @@
+package ccxt
+
+import (
+	"strings"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// index symbols: .BXBT and friends are not part of the tradable markets
+// ---------------------------------------------------------------------------
+
+func newMockedBitmex(bodies map[string]string) (*BitmexCore, *mockTransport) {
+	exchange := NewBitmexCore()
+	exchange.Init(map[string]interface{}{})
+	transport := &mockTransport{body: `[]`, bodies: bodies}
+	exchange.httpClient.Transport = transport
+	exchange.SetMarkets([]interface{}{
+		exchange.SafeMarketStructure(map[string]interface{}{
+			"id":           "XBTUSD",
+			"symbol":       "BTC/USD:BTC",
+			"base":         "BTC",
+			"quote":        "USD",
+			"settle":       "BTC",
+			"baseId":       "XBT",
+			"quoteId":      "USD",
+			"settleId":     "XBt",
+			"type":         "swap",
+			"swap":         true,
+			"contract":     true,
+			"linear":       false,
+			"inverse":      true,
+			"contractSize": 1.0,
+			"active":       true,
+		}),
+	})
+	return exchange, transport
+}
+
+func TestBitmexFetchTickerForIndexSymbol(t *testing.T) {
+	exchange, transport := newMockedBitmex(map[string]string{
+		"/instrument": `[{"symbol":".BXBT","state":"Unlisted","typ":"MRCXXX","timestamp":"2024-05-01T12:00:00.000Z","lastPrice":60123.45,"prevPrice24h":59000.1,"markPrice":60123.45}]`,
+	})
+	if IsTrue(InOp(exchange.Markets_by_id, ".BXBT")) {
+		t.Fatal("expected .BXBT not to be a tradable market")
+	}
+
+	result := <-exchange.FetchTicker(".BXBT", map[string]interface{}{"isIndex": true})
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	ticker := NewTicker(result)
+	if *ticker.Symbol != ".BXBT" || *ticker.Last != 60123.45 || *ticker.Open != 59000.1 {
+		t.Fatalf("unexpected ticker %v", result)
+	}
+	query := transport.requests[0].URL.Query()
+	if query.Get("symbol") != ".BXBT" || query.Has("isIndex") {
+		t.Fatalf("unexpected query %s", transport.requests[0].URL.RawQuery)
+	}
+
+	// without the flag the market-not-found guard still applies
+	result = <-exchange.FetchTicker(".BXBT")
+	if err, ok := CreateReturnError(result).(*Error); !ok || err.Type != "BadSymbol" {
+		t.Fatalf("expected a BadSymbol error, got %v", result)
+	}
+}
+
+func TestBitmexFetchOHLCVForIndexSymbol(t *testing.T) {
+	exchange, transport := newMockedBitmex(map[string]string{
+		"/trade/bucketed": `[
+			{"timestamp":"2024-05-01T12:01:00.000Z","symbol":".BXBT","open":60100,"high":60150.5,"low":60090,"close":60120,"trades":0,"volume":0},
+			{"timestamp":"2024-05-01T12:02:00.000Z","symbol":".BXBT","open":60120,"high":60130,"low":60080.5,"close":60085,"trades":0,"volume":0}
+		]`,
+	})
+	result := <-exchange.FetchOHLCV(".BXBT", "1m", nil, 2, map[string]interface{}{"isIndex": true})
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	request := transport.requests[0]
+	if !strings.HasSuffix(request.URL.Path, "/trade/bucketed") || request.URL.Query().Get("symbol") != ".BXBT" {
+		t.Fatalf("unexpected request %s", request.URL)
+	}
+	candles := result.([]interface{})
+	if len(candles) != 2 {
+		t.Fatalf("expected 2 candles, got %d", len(candles))
+	}
+	// bitmex returns the close time of the candle, it is shifted to the open time
+	expected := []interface{}{int64(1714564800000), 60100.0, 60150.5, 60090.0, 60120.0}
+	for i, value := range expected {
+		if !IsEqual(GetValue(candles[0], i), value) {
+			t.Fatalf("candle field %d: expected %v, got %v", i, value, GetValue(candles[0], i))
+		}
+	}
+}
@@ -422,6 +422,7 @@ func (this *Bitmex) FetchDepositsWithdrawals(options ...FetchDepositsWithdrawals
  * @see https://www.bitmex.com/api/explorer/#!/Instrument/Instrument_get
  * @param {string} symbol unified symbol of the market to fetch the ticker for
  * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @param {boolean} [params.isIndex] default false, set to true to query an index symbol like .BXBT that is not one of the tradable markets
  * @returns {object} a [ticker structure]{@link https://docs.ccxt.com/?id=ticker-structure}
  */
 func (this *Bitmex) FetchTicker(symbol string, options ...FetchTickerOptions) (Ticker, error) {
@@ -487,6 +488,7 @@ func (this *Bitmex) FetchTickers(options ...FetchTickersOptions) (Tickers, error
  * @param {int} [limit] the maximum amount of candles to fetch
  * @param {object} [params] extra parameters specific to the exchange API endpoint
  * @param {boolean} [params.paginate] default false, when true will automatically paginate by calling this endpoint multiple times. See in the docs all the [availble parameters](https://github.com/ccxt/ccxt/wiki/Manual#pagination-params)
+ * @param {boolean} [params.isIndex] default false, set to true to query an index symbol like .BXBT that is not one of the tradable markets
  * @returns {int[][]} A list of candles ordered as timestamp, open, high, low, close, volume
  */
 func (this *Bitmex) FetchOHLCV(symbol string, options ...FetchOHLCVOptions) ([]OHLCV, error) {
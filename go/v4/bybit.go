@@ -1577,6 +1577,21 @@ func (this *BybitCore) GetBybitType(method interface{}, market interface{}, opti
 	}
 	return []interface{}{subType, params}
 }
+func (this *BybitCore) GetBybitSettleCoins(subType interface{}) interface{} {
+	// the settle coins of the loaded linear or inverse contracts, the default settle coin if none is loaded
+	var settleCoins interface{} = []interface{}{}
+	for i := 0; IsLessThan(i, GetArrayLength(this.Symbols)); i++ {
+		var market interface{} = this.Market(GetValue(this.Symbols, i))
+		var settleId interface{} = this.SafeString(market, "settleId")
+		if IsTrue(IsTrue(IsTrue(GetValue(market, subType)) && IsTrue(!IsEqual(settleId, nil))) && !IsTrue(this.InArray(settleId, settleCoins))) {
+			AppendToArray(&settleCoins, settleId)
+		}
+	}
+	if IsTrue(IsEqual(GetArrayLength(settleCoins), 0)) {
+		return []interface{}{this.SafeString(this.Options, "defaultSettle", "USDT")}
+	}
+	return this.Sort(settleCoins)
+}
 func (this *BybitCore) GetAmount(symbol interface{}, amount interface{}) interface{} {
 	// some markets like options might not have the precision available
 	// and we shouldn't crash in those cases
@@ -4370,6 +4385,7 @@ func (this *BybitCore) CreateOrderRequest(symbol interface{}, typeVar interface{
 	_ = isUTA
 	var market interface{} = this.Market(symbol)
 	symbol = GetValue(market, "symbol")
+	params = this.NormalizeTimeInForce(market, typeVar, params)
 	var lowerCaseType interface{} = ToLower(typeVar)
 	var request interface{} = map[string]interface{}{
 		"symbol": GetValue(market, "id"),
@@ -4638,6 +4654,87 @@ func (this *BybitCore) CreateOrderRequest(symbol interface{}, typeVar interface{
 	return this.Extend(request, params)
 }
 
+/**
+ * @method
+ * @name bybit#createOrderWithTakeProfitAndStopLoss
+ * @description create an order with a take profit and a stop loss attached in the same request
+ * @see https://bybit-exchange.github.io/docs/v5/order/create-order
+ * @param {string} symbol unified symbol of the market to create an order in
+ * @param {string} type 'market' or 'limit'
+ * @param {string} side 'buy' or 'sell'
+ * @param {float} amount how much you want to trade in units of the base currency or the number of contracts
+ * @param {float} [price] the price to fulfill the order, in units of the quote currency, ignored in market orders
+ * @param {float} [takeProfit] the take profit price, in units of the quote currency
+ * @param {float} [stopLoss] the stop loss price, in units of the quote currency
+ * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @param {string} [params.tpslMode] *contract only* 'Full' (default) or 'Partial'
+ * @returns {object} an [order structure]{@link https://docs.ccxt.com/?id=order-structure} with the attached takeProfitPrice and stopLossPrice
+ */
+func (this *BybitCore) CreateOrderWithTakeProfitAndStopLoss(symbol interface{}, typeVar interface{}, side interface{}, amount interface{}, optionalArgs ...interface{}) <-chan interface{} {
+	ch := make(chan interface{})
+	go func() interface{} {
+		defer close(ch)
+		defer ReturnPanicError(ch)
+		price := GetArg(optionalArgs, 0, nil)
+		_ = price
+		takeProfit := GetArg(optionalArgs, 1, nil)
+		_ = takeProfit
+		stopLoss := GetArg(optionalArgs, 2, nil)
+		_ = stopLoss
+		params := GetArg(optionalArgs, 3, map[string]interface{}{})
+		_ = params
+
+		retRes43601 := (<-this.LoadMarkets())
+		PanicOnError(retRes43601)
+		var market interface{} = this.Market(symbol)
+		var isBuy interface{} = IsEqual(side, "buy")
+		// a long takes profit above the entry and stops the loss below it, a short the other way round
+		var entryPrice interface{} = Ternary(IsTrue(IsEqual(typeVar, "limit")), price, nil)
+		var takeProfitString interface{} = this.NumberToString(takeProfit)
+		var stopLossString interface{} = this.NumberToString(stopLoss)
+		var entryString interface{} = this.NumberToString(entryPrice)
+		if IsTrue(IsTrue(!IsEqual(takeProfit, nil)) && IsTrue(!IsEqual(entryPrice, nil))) {
+			var wrongSide interface{} = Ternary(IsTrue(isBuy), Precise.StringLe(takeProfitString, entryString), Precise.StringGe(takeProfitString, entryString))
+			if IsTrue(wrongSide) {
+				panic(InvalidOrder(Add(Add(Add(Add(this.Id, " createOrderWithTakeProfitAndStopLoss() the takeProfit of a "), side), " order must be "), Ternary(IsTrue(isBuy), "above the price", "below the price"))))
+			}
+		}
+		if IsTrue(IsTrue(!IsEqual(stopLoss, nil)) && IsTrue(!IsEqual(entryPrice, nil))) {
+			var wrongSide interface{} = Ternary(IsTrue(isBuy), Precise.StringGe(stopLossString, entryString), Precise.StringLe(stopLossString, entryString))
+			if IsTrue(wrongSide) {
+				panic(InvalidOrder(Add(Add(Add(Add(this.Id, " createOrderWithTakeProfitAndStopLoss() the stopLoss of a "), side), " order must be "), Ternary(IsTrue(isBuy), "below the price", "above the price"))))
+			}
+		}
+		if IsTrue(IsTrue(!IsEqual(takeProfit, nil)) && IsTrue(!IsEqual(stopLoss, nil))) {
+			var wrongSide interface{} = Ternary(IsTrue(isBuy), Precise.StringLe(takeProfitString, stopLossString), Precise.StringGe(takeProfitString, stopLossString))
+			if IsTrue(wrongSide) {
+				panic(InvalidOrder(Add(Add(Add(Add(this.Id, " createOrderWithTakeProfitAndStopLoss() the takeProfit of a "), side), " order must be "), Ternary(IsTrue(isBuy), "above the stopLoss", "below the stopLoss"))))
+			}
+		}
+		var hasLimitPrice interface{} = IsTrue(!IsEqual(this.SafeValue(params, "takeProfitLimitPrice"), nil)) || IsTrue(!IsEqual(this.SafeValue(params, "stopLossLimitPrice"), nil))
+		if IsTrue(IsTrue(IsTrue(GetValue(market, "contract")) && !IsTrue(hasLimitPrice)) && !IsTrue((InOp(params, "tpslMode")))) {
+			params = this.Extend(params, map[string]interface{}{
+				"tpslMode": "Full",
+			})
+		}
+
+		order := (<-this.Exchange.CreateOrderWithTakeProfitAndStopLoss(symbol, typeVar, side, amount, price, takeProfit, stopLoss, params))
+		PanicOnError(order)
+		// the acknowledgement only has the ids, the attached prices are those that were sent
+		if IsTrue(IsEqual(this.SafeValue(order, "takeProfitPrice"), nil)) {
+			AddElementToObject(order, "takeProfitPrice", this.ParseNumber(this.GetPrice(symbol, takeProfitString)))
+		}
+		if IsTrue(IsEqual(this.SafeValue(order, "stopLossPrice"), nil)) {
+			AddElementToObject(order, "stopLossPrice", this.ParseNumber(this.GetPrice(symbol, stopLossString)))
+		}
+
+		ch <- order
+		return nil
+
+	}()
+	return ch
+}
+
 /**
  * @method
  * @name bybit#createOrders
@@ -4645,7 +4742,7 @@ func (this *BybitCore) CreateOrderRequest(symbol interface{}, typeVar interface{
  * @see https://bybit-exchange.github.io/docs/v5/order/batch-place
  * @param {Array} orders list of orders to create, each object should contain the parameters required by createOrder, namely symbol, type, side, amount, price and params
  * @param {object} [params] extra parameters specific to the exchange API endpoint
- * @returns {object} an [order structure]{@link https://docs.ccxt.com/?id=order-structure}
+ * @returns {object[]} a list of [order structures]{@link https://docs.ccxt.com/?id=order-structure} in the order of the input, the orders refused by the exchange and those of a failed batch have the rejected status, with the error in info.code and info.msg
  */
 func (this *BybitCore) CreateOrders(orders interface{}, optionalArgs ...interface{}) <-chan interface{} {
 	ch := make(chan interface{})
@@ -4686,24 +4783,78 @@ func (this *BybitCore) CreateOrders(orders interface{}, optionalArgs ...interfac
 		if IsTrue(IsTrue((IsEqual(category, "inverse"))) && IsTrue((IsLessThan(unifiedMarginStatus, 5)))) {
 			panic(NotSupported(Add(this.Id, " createOrders does not allow inverse orders for non UTA2.0 account")))
 		}
-		var request interface{} = map[string]interface{}{
-			"category": category,
-			"request":  ordersRequests,
+		// a batch takes up to 20 orders (10 for spot), larger lists are sent in several batches in their original order
+		var maxOrdersPerRequest interface{} = Ternary(IsTrue((IsEqual(category, "spot"))), 10, 20)
+		var chunks interface{} = []interface{}{}
+		for i := 0; IsLessThan(i, GetArrayLength(ordersRequests)); i++ {
+			if IsTrue(IsEqual(Mod(i, maxOrdersPerRequest), 0)) {
+				var end interface{} = MathMin(Add(i, maxOrdersPerRequest), GetArrayLength(ordersRequests))
+				AppendToArray(&chunks, this.ArraySlice(ordersRequests, i, end))
+			}
 		}
+		var data interface{} = []interface{}{}
+		for i := 0; IsLessThan(i, GetArrayLength(chunks)); i++ {
+			var chunk interface{} = GetValue(chunks, i)
+			var request interface{} = map[string]interface{}{
+				"category": category,
+				"request":  chunk,
+			}
+			var chunkData interface{} = nil
+
+			{
+				func(this *BybitCore) (ret_ interface{}) {
+					defer func() {
+						if e := recover(); e != nil {
+							if e == "break" {
+								return
+							}
+							ret_ = func(this *BybitCore) interface{} {
+								// catch block:
+								// the orders of a failed batch are not placed, they are returned as rejected with the error
+								// so that the orders of the previous batches are not lost
+								var errorType interface{} = "ExchangeError"
+								var message interface{} = ToString(e)
+								if failure, ok := CreateReturnError(message).(*Error); ok {
+									errorType = string(failure.Type)
+									message = failure.Message
+								}
+								chunkData = []interface{}{}
+								for j := 0; IsLessThan(j, GetArrayLength(chunk)); j++ {
+									var orderRequest interface{} = GetValue(chunk, j)
+									AppendToArray(&chunkData, map[string]interface{}{
+										"category":    category,
+										"symbol":      this.SafeString(orderRequest, "symbol"),
+										"orderId":     "",
+										"orderLinkId": this.SafeString(orderRequest, "orderLinkId", ""),
+										"code":        errorType,
+										"msg":         message,
+									})
+								}
+								return nil
+							}(this)
+						}
+					}()
+					// try block:
+
+					response := (<-this.PrivatePostV5OrderCreateBatch(this.Extend(request, params)))
+					PanicOnError(response)
+					var result interface{} = this.SafeDict(response, "result", map[string]interface{}{})
+					chunkData = this.SafeList(result, "list", []interface{}{})
+					var retInfo interface{} = this.SafeDict(response, "retExtInfo", map[string]interface{}{})
+					var codes interface{} = this.SafeList(retInfo, "list", []interface{}{})
+					// extend the error with the unsuccessful orders
+					for j := 0; IsLessThan(j, GetArrayLength(codes)); j++ {
+						var code interface{} = GetValue(codes, j)
+						var retCode interface{} = this.SafeInteger(code, "code")
+						if IsTrue(!IsEqual(retCode, 0)) {
+							AddElementToObject(chunkData, j, this.Extend(GetValue(chunkData, j), code))
+						}
+					}
+					return nil
+				}(this)
 
-		response := (<-this.PrivatePostV5OrderCreateBatch(this.Extend(request, params)))
-		PanicOnError(response)
-		var result interface{} = this.SafeDict(response, "result", map[string]interface{}{})
-		var data interface{} = this.SafeList(result, "list", []interface{}{})
-		var retInfo interface{} = this.SafeDict(response, "retExtInfo", map[string]interface{}{})
-		var codes interface{} = this.SafeList(retInfo, "list", []interface{}{})
-		// extend the error with the unsuccessful orders
-		for i := 0; IsLessThan(i, GetArrayLength(codes)); i++ {
-			var code interface{} = GetValue(codes, i)
-			var retCode interface{} = this.SafeInteger(code, "code")
-			if IsTrue(!IsEqual(retCode, 0)) {
-				AddElementToObject(data, i, this.Extend(GetValue(data, i), code))
 			}
+			data = this.ArrayConcat(data, chunkData)
 		}
 
 		//
@@ -6167,7 +6318,7 @@ func (this *BybitCore) FetchCanceledOrders(optionalArgs ...interface{}) <-chan i
  * @param {string} [params.type] market type, ['swap', 'option', 'spot']
  * @param {string} [params.subType] market subType, ['linear', 'inverse']
  * @param {string} [params.baseCoin] Base coin. Supports linear, inverse & option
- * @param {string} [params.settleCoin] Settle coin. Supports linear, inverse & option
+ * @param {string} [params.settleCoin] Settle coin. Supports linear, inverse & option, without a symbol the orders of every settle coin are fetched by default
  * @param {string} [params.orderFilter] 'Order' or 'StopOrder' or 'tpslOrder'
  * @param {boolean} [params.paginate] default false, when true will automatically paginate by calling this endpoint multiple times. See in the docs all the [availble parameters](https://github.com/ccxt/ccxt/wiki/Manual#pagination-params)
  * @returns {Order[]} a list of [order structures]{@link https://docs.ccxt.com/?id=order-structure}
@@ -6209,12 +6360,18 @@ func (this *BybitCore) FetchOpenOrders(optionalArgs ...interface{}) <-chan inter
 		typeVarparamsVariable := this.GetBybitType("fetchOpenOrders", market, params)
 		typeVar = GetValue(typeVarparamsVariable, 0)
 		params = GetValue(typeVarparamsVariable, 1)
+		// without a symbol the contracts are requested once per settle coin, all of them unless one is given
+		var settleCoins interface{} = []interface{}{nil}
 		if IsTrue(IsTrue(IsEqual(typeVar, "linear")) || IsTrue(IsEqual(typeVar, "inverse"))) {
 			var baseCoin interface{} = this.SafeString(params, "baseCoin")
 			if IsTrue(IsTrue(IsEqual(symbol, nil)) && IsTrue(IsEqual(baseCoin, nil))) {
-				var defaultSettle interface{} = this.SafeString(this.Options, "defaultSettle", "USDT")
-				var settleCoin interface{} = this.SafeString(params, "settleCoin", defaultSettle)
-				AddElementToObject(request, "settleCoin", settleCoin)
+				var settleCoin interface{} = this.SafeString(params, "settleCoin")
+				params = this.Omit(params, "settleCoin")
+				if IsTrue(!IsEqual(settleCoin, nil)) {
+					settleCoins = []interface{}{settleCoin}
+				} else {
+					settleCoins = this.GetBybitSettleCoins(typeVar)
+				}
 			}
 		}
 		AddElementToObject(request, "category", typeVar)
@@ -6226,6 +6383,43 @@ func (this *BybitCore) FetchOpenOrders(optionalArgs ...interface{}) <-chan inter
 		if IsTrue(!IsEqual(limit, nil)) {
 			AddElementToObject(request, "limit", limit)
 		}
+		if IsTrue(IsTrue(IsEqual(symbol, nil)) && IsTrue(IsEqual(this.SafeString(params, "cursor"), nil))) {
+			// every page of every settle coin is merged
+			var orders interface{} = []interface{}{}
+			for i := 0; IsLessThan(i, GetArrayLength(settleCoins)); i++ {
+				var settleCoin interface{} = GetValue(settleCoins, i)
+				var settleRequest interface{} = this.Extend(request, map[string]interface{}{})
+				if IsTrue(!IsEqual(settleCoin, nil)) {
+					AddElementToObject(settleRequest, "settleCoin", settleCoin)
+				}
+				var cursor interface{} = nil
+				for true {
+					if IsTrue(!IsEqual(cursor, nil)) {
+						AddElementToObject(settleRequest, "cursor", cursor)
+					}
+
+					page := (<-this.PrivateGetV5OrderRealtime(this.Extend(settleRequest, params)))
+					PanicOnError(page)
+					var pageResult interface{} = this.SafeDict(page, "result", map[string]interface{}{})
+					var list interface{} = this.SafeList(pageResult, "list", []interface{}{})
+					orders = this.ArrayConcat(orders, list)
+					var nextCursor interface{} = this.SafeString(pageResult, "nextPageCursor")
+					if IsTrue(IsTrue(IsTrue(IsTrue(IsEqual(GetArrayLength(list), 0)) || IsTrue(IsEqual(nextCursor, nil))) || IsTrue(IsEqual(nextCursor, ""))) || IsTrue(IsEqual(nextCursor, cursor))) {
+						break
+					}
+					if IsTrue(IsTrue(!IsEqual(limit, nil)) && IsTrue(IsGreaterThanOrEqual(GetArrayLength(orders), limit))) {
+						break
+					}
+					cursor = nextCursor
+				}
+			}
+
+			ch <- this.ParseOrders(orders, market, since, limit)
+			return nil
+		}
+		if IsTrue(!IsEqual(GetValue(settleCoins, 0), nil)) {
+			AddElementToObject(request, "settleCoin", GetValue(settleCoins, 0))
+		}
 
 		response := (<-this.PrivateGetV5OrderRealtime(this.Extend(request, params)))
 		PanicOnError(response)
@@ -7927,6 +8121,8 @@ func (this *BybitCore) SetLeverage(leverage interface{}, optionalArgs ...interfa
 		_ = symbol
 		params := GetArg(optionalArgs, 1, map[string]interface{}{})
 		_ = params
+		retRes81247 := (<-this.CheckLeverage(leverage, symbol, params))
+		PanicOnError(retRes81247)
 		if IsTrue(IsEqual(symbol, nil)) {
 			panic(ArgumentsRequired(Add(this.Id, " setLeverage() requires a symbol argument")))
 		}
@@ -9944,6 +10140,9 @@ func (this *BybitCore) GetLeverageTiersPaginated(optionalArgs ...interface{}) <-
 		var request interface{} = map[string]interface{}{
 			"category": subType,
 		}
+		if IsTrue(!IsEqual(market, nil)) {
+			AddElementToObject(request, "symbol", GetValue(market, "id"))
+		}
 
 		response := (<-this.PublicGetV5MarketRiskLimit(this.Extend(request, params)))
 		PanicOnError(response)
@@ -9970,10 +10169,11 @@ func (this *BybitCore) GetLeverageTiersPaginated(optionalArgs ...interface{}) <-
  * @name bybit#fetchLeverageTiers
  * @description retrieve information on the maximum leverage, for different trade sizes
  * @see https://bybit-exchange.github.io/docs/v5/market/risk-limit
- * @param {string[]} [symbols] a list of unified market symbols
+ * @param {string[]} [symbols] a list of unified market symbols, the linear and inverse ones are requested from their own category
  * @param {object} [params] extra parameters specific to the exchange API endpoint
- * @param {string} [params.subType] market subType, ['linear', 'inverse'], default is 'linear'
- * @param {boolean} [params.paginate] default false, when true will automatically paginate by calling this endpoint multiple times. See in the docs all the [available parameters](https://github.com/ccxt/ccxt/wiki/Manual#pagination-params)
+ * @param {string} [params.subType] market subType without symbols, ['linear', 'inverse'], default is 'linear'
+ * @param {boolean} [params.paginate] default true, set to false to fetch a single page of risk limits per category. See in the docs all the [available parameters](https://github.com/ccxt/ccxt/wiki/Manual#pagination-params)
+ * @param {int} [params.paginationCalls] the maximum number of pages followed per category, default 50
  * @returns {object} a dictionary of [leverage tiers structures]{@link https://docs.ccxt.com/?id=leverage-tiers-structure}, indexed by market symbols
  */
 func (this *BybitCore) FetchLeverageTiers(optionalArgs ...interface{}) <-chan interface{} {
@@ -9988,21 +10188,49 @@ func (this *BybitCore) FetchLeverageTiers(optionalArgs ...interface{}) <-chan in
 
 		retRes85908 := (<-this.LoadMarkets())
 		PanicOnError(retRes85908)
-		var market interface{} = nil
-		var symbol interface{} = nil
-		if IsTrue(!IsEqual(symbols, nil)) {
-			market = this.Market(GetValue(symbols, 0))
-			if IsTrue(GetValue(market, "spot")) {
-				panic(NotSupported(Add(this.Id, " fetchLeverageTiers() is not supported for spot market")))
-			}
-			symbol = GetValue(market, "symbol")
-		}
-
-		data := (<-this.GetLeverageTiersPaginated(symbol, this.Extend(map[string]interface{}{
+		var paginationParams interface{} = map[string]interface{}{
 			"paginate":        true,
 			"paginationCalls": 50,
-		}, params)))
-		PanicOnError(data)
+		}
+		var data interface{} = []interface{}{}
+		if IsTrue(IsEqual(symbols, nil)) {
+
+			data = (<-this.GetLeverageTiersPaginated(nil, this.Extend(paginationParams, params)))
+			PanicOnError(data)
+		} else {
+			// the risk limits are listed by category, the symbols are grouped by subType
+			var symbolsBySubType interface{} = map[string]interface{}{}
+			for i := 0; IsLessThan(i, GetArrayLength(symbols)); i++ {
+				var market interface{} = this.Market(GetValue(symbols, i))
+				if IsTrue(GetValue(market, "spot")) {
+					panic(NotSupported(Add(this.Id, " fetchLeverageTiers() is not supported for spot market")))
+				}
+				var subType interface{} = "linear"
+				if IsTrue(GetValue(market, "inverse")) {
+					subType = "inverse"
+				}
+				var subTypeSymbols interface{} = this.SafeList(symbolsBySubType, subType, []interface{}{})
+				AppendToArray(&subTypeSymbols, GetValue(market, "symbol"))
+				AddElementToObject(symbolsBySubType, subType, subTypeSymbols)
+			}
+			var subTypes interface{} = this.Sort(ObjectKeys(symbolsBySubType))
+			for i := 0; IsLessThan(i, GetArrayLength(subTypes)); i++ {
+				var subType interface{} = GetValue(subTypes, i)
+				var subTypeSymbols interface{} = GetValue(symbolsBySubType, subType)
+				// a single symbol is requested alone, several symbols page through the whole category
+				var symbol interface{} = nil
+				if IsTrue(IsEqual(GetArrayLength(subTypeSymbols), 1)) {
+					symbol = GetValue(subTypeSymbols, 0)
+				}
+				// the category of the markets wins over params.subType
+				var pageParams interface{} = this.Extend(paginationParams, params)
+				AddElementToObject(pageParams, "subType", subType)
+
+				page := (<-this.GetLeverageTiersPaginated(symbol, pageParams))
+				PanicOnError(page)
+				data = this.ArrayConcat(data, page)
+			}
+		}
 		symbols = this.MarketSymbols(symbols)
 
 		ch <- this.ParseLeverageTiers(data, symbols, "symbol")
@@ -10066,8 +10294,8 @@ func (this *BybitCore) ParseMarketLeverageTiers(info interface{}, optionalArgs .
 	var tiers interface{} = []interface{}{}
 	for i := 0; IsLessThan(i, GetArrayLength(info)); i++ {
 		var tier interface{} = GetValue(info, i)
-		var marketId interface{} = this.SafeString(info, "symbol")
-		market = this.SafeMarket(marketId)
+		var marketId interface{} = this.SafeString(tier, "symbol")
+		market = this.SafeMarket(marketId, market, nil, "contract")
 		var minNotional interface{} = this.ParseNumber("0")
 		if IsTrue(!IsEqual(i, 0)) {
 			minNotional = this.SafeNumber(GetValue(info, Subtract(i, 1)), "riskLimitValue")
@@ -10090,12 +10318,14 @@ func (this *BybitCore) ParseMarketLeverageTiers(info interface{}, optionalArgs .
  * @method
  * @name bybit#fetchFundingHistory
  * @description fetch the history of funding payments paid and received on this account
+ * @see https://bybit-exchange.github.io/docs/v5/account/transaction-log
  * @see https://bybit-exchange.github.io/docs/api-explorer/v5/position/execution
  * @param {string} [symbol] unified market symbol
  * @param {int} [since] the earliest time in ms to fetch funding history for
  * @param {int} [limit] the maximum number of funding history structures to retrieve
  * @param {object} [params] extra parameters specific to the exchange API endpoint
  * @param {boolean} [params.paginate] default false, when true will automatically paginate by calling this endpoint multiple times. See in the docs all the [available parameters](https://github.com/ccxt/ccxt/wiki/Manual#pagination-params)
+ * @param {string} [params.method] 'privateGetV5ExecutionList' (default) or 'privateGetV5AccountTransactionLog'
  * @returns {object} a [funding history structure]{@link https://docs.ccxt.com/?id=funding-history-structure}
  */
 func (this *BybitCore) FetchFundingHistory(optionalArgs ...interface{}) <-chan interface{} {
@@ -10114,48 +10344,72 @@ func (this *BybitCore) FetchFundingHistory(optionalArgs ...interface{}) <-chan i
 
 		retRes86888 := (<-this.LoadMarkets())
 		PanicOnError(retRes86888)
+		var method interface{} = nil
+		methodparamsVariable := this.HandleOptionAndParams(params, "fetchFundingHistory", "method", "privateGetV5ExecutionList")
+		method = GetValue(methodparamsVariable, 0)
+		params = GetValue(methodparamsVariable, 1)
+		var isTransactionLog interface{} = IsEqual(method, "privateGetV5AccountTransactionLog")
 		var paginate interface{} = false
 		paginateparamsVariable := this.HandleOptionAndParams(params, "fetchFundingHistory", "paginate")
 		paginate = GetValue(paginateparamsVariable, 0)
 		params = GetValue(paginateparamsVariable, 1)
 		if IsTrue(paginate) {
+			var maxEntriesPerRequest interface{} = Ternary(IsTrue(isTransactionLog), 50, 100)
 
-			retRes869219 := (<-this.FetchPaginatedCallCursor("fetchFundingHistory", symbol, since, limit, params, "nextPageCursor", "cursor", nil, 100))
+			retRes869219 := (<-this.FetchPaginatedCallCursor("fetchFundingHistory", symbol, since, limit, this.Extend(params, map[string]interface{}{
+				"method": method,
+			}), "nextPageCursor", "cursor", nil, maxEntriesPerRequest))
 			PanicOnError(retRes869219)
 			ch <- retRes869219
 			return nil
 		}
-		var request interface{} = map[string]interface{}{
-			"execType": "Funding",
-		}
 		var market interface{} = nil
 		if IsTrue(!IsEqual(symbol, nil)) {
 			market = this.Market(symbol)
-			AddElementToObject(request, "symbol", GetValue(market, "id"))
 		}
 		var typeVar interface{} = nil
 		typeVarparamsVariable := this.GetBybitType("fetchFundingHistory", market, params)
 		typeVar = GetValue(typeVarparamsVariable, 0)
 		params = GetValue(typeVarparamsVariable, 1)
-		AddElementToObject(request, "category", typeVar)
-		if IsTrue(!IsEqual(symbol, nil)) {
-			AddElementToObject(request, "symbol", GetValue(market, "id"))
+		var request interface{} = map[string]interface{}{
+			"category": typeVar,
 		}
 		if IsTrue(!IsEqual(since, nil)) {
 			AddElementToObject(request, "startTime", since)
 		}
-		if IsTrue(!IsEqual(limit, nil)) {
-			AddElementToObject(request, "size", limit)
-		} else {
-			AddElementToObject(request, "size", 100)
-		}
 		requestparamsVariable := this.HandleUntilOption("endTime", request, params)
 		request = GetValue(requestparamsVariable, 0)
 		params = GetValue(requestparamsVariable, 1)
+		var response interface{} = nil
+		if IsTrue(isTransactionLog) {
+			// the funding fees are settled as SETTLEMENT records, the log cannot be filtered by symbol,
+			// a full page is requested as the other records are only dropped after the response
+			AddElementToObject(request, "type", "SETTLEMENT")
+			if IsTrue(!IsEqual(market, nil)) {
+				AddElementToObject(request, "baseCoin", GetValue(market, "baseId"))
+			}
+			AddElementToObject(request, "limit", 50)
 
-		response := (<-this.PrivateGetV5ExecutionList(this.Extend(request, params)))
-		PanicOnError(response)
+			response = (<-this.PrivateGetV5AccountTransactionLog(this.Extend(request, params)))
+			PanicOnError(response)
+		} else {
+			AddElementToObject(request, "execType", "Funding")
+			if IsTrue(!IsEqual(market, nil)) {
+				AddElementToObject(request, "symbol", GetValue(market, "id"))
+			}
+			if IsTrue(!IsEqual(limit, nil)) {
+				AddElementToObject(request, "size", limit)
+			} else {
+				AddElementToObject(request, "size", 100)
+			}
+
+			response = (<-this.PrivateGetV5ExecutionList(this.Extend(request, params)))
+			PanicOnError(response)
+		}
 		var fundings interface{} = this.AddPaginationCursorToResult(response)
+		if IsTrue(isTransactionLog) {
+			fundings = this.FilterFundingTransactions(fundings, market)
+		}
 
 		ch <- this.ParseIncomes(fundings, market, since, limit)
 		return nil
@@ -10163,6 +10417,34 @@ func (this *BybitCore) FetchFundingHistory(optionalArgs ...interface{}) <-chan i
 	}()
 	return ch
 }
+
+// FilterFundingTransactions keeps the transaction log records that settle a funding fee of the
+// market, if any, the pagination cursor of the page is carried over to the first record kept
+// unless it is empty, which marks the last page
+func (this *BybitCore) FilterFundingTransactions(transactions interface{}, optionalArgs ...interface{}) interface{} {
+	market := GetArg(optionalArgs, 0, nil)
+	_ = market
+	var result interface{} = []interface{}{}
+	var cursor interface{} = nil
+	for i := 0; IsLessThan(i, GetArrayLength(transactions)); i++ {
+		var transaction interface{} = GetValue(transactions, i)
+		if IsTrue(IsEqual(i, 0)) {
+			cursor = this.SafeString(transaction, "nextPageCursor")
+			AddElementToObject(transaction, "nextPageCursor", nil)
+		}
+		var funding interface{} = this.SafeString(transaction, "funding")
+		if IsTrue(IsTrue(!IsEqual(market, nil)) && IsTrue(!IsEqual(this.SafeString(transaction, "symbol"), GetValue(market, "id")))) {
+			continue
+		}
+		if IsTrue(IsTrue(IsEqual(this.SafeString(transaction, "type"), "SETTLEMENT")) && IsTrue(!IsEqual(funding, nil)) && IsTrue(!IsTrue(Precise.StringEq(funding, "0")))) {
+			AppendToArray(&result, transaction)
+		}
+	}
+	if IsTrue(IsTrue(IsTrue(!IsEqual(cursor, nil)) && IsTrue(!IsEqual(cursor, ""))) && IsTrue(IsGreaterThan(GetArrayLength(result), 0))) {
+		AddElementToObject(GetValue(result, 0), "nextPageCursor", cursor)
+	}
+	return result
+}
 func (this *BybitCore) ParseIncome(income interface{}, optionalArgs ...interface{}) interface{} {
 	//
 	// {
@@ -10198,23 +10480,54 @@ func (this *BybitCore) ParseIncome(income interface{}, optionalArgs ...interface
 	//     "nextPageCursor": "5774437%3A0%2C5771289%3A0"
 	// }
 	//
+	// transaction log
+	//
+	// {
+	//     "id": "592324_XRPUSDT_161440249321",
+	//     "symbol": "XRPUSDT",
+	//     "category": "linear",
+	//     "side": "Buy",
+	//     "transactionTime": "1672128000000",
+	//     "type": "SETTLEMENT",
+	//     "qty": "100",
+	//     "size": "100",
+	//     "currency": "USDT",
+	//     "tradePrice": "0.3615",
+	//     "funding": "0.00361500",
+	//     "fee": "0",
+	//     "cashFlow": "0",
+	//     "change": "-0.003615",
+	//     "cashBalance": "5086.57739822",
+	//     "feeRate": "0.0001",
+	//     "tradeId": ""
+	// }
+	//
 	market := GetArg(optionalArgs, 0, nil)
 	_ = market
 	var marketId interface{} = this.SafeString(income, "symbol")
 	market = this.SafeMarket(marketId, market, nil, "contract")
+	var currencyId interface{} = this.SafeString(income, "currency")
 	var code interface{} = "USDT"
-	if IsTrue(GetValue(market, "inverse")) {
+	if IsTrue(!IsEqual(currencyId, nil)) {
+		code = this.SafeCurrencyCode(currencyId)
+	} else if IsTrue(GetValue(market, "inverse")) {
 		code = GetValue(market, "quote")
 	}
-	var timestamp interface{} = this.SafeInteger(income, "execTime")
+	var timestamp interface{} = this.SafeInteger2(income, "execTime", "transactionTime")
+	// execFee and funding are both positive when the account pays, the unified amount is positive when it is received
+	var paid interface{} = this.SafeString2(income, "funding", "execFee")
+	var amount interface{} = nil
+	if IsTrue(!IsEqual(paid, nil)) {
+		amount = this.ParseNumber(Precise.StringNeg(paid))
+	}
 	return map[string]interface{}{
 		"info":      income,
 		"symbol":    this.SafeSymbol(marketId, market, "-", "swap"),
 		"code":      code,
 		"timestamp": timestamp,
 		"datetime":  this.Iso8601(timestamp),
-		"id":        this.SafeString(income, "execId"),
-		"amount":    this.SafeNumber(income, "execFee"),
+		"id":        this.SafeString2(income, "execId", "id"),
+		"amount":    amount,
 		"rate":      this.SafeNumber(income, "feeRate"),
 	}
 }
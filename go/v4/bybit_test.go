@@ -0,0 +1,503 @@
+package ccxt
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// createOrders: batches of more than 20 orders are split, rejections are kept in place
+// ---------------------------------------------------------------------------
+
+func newMockedBybit() (*BybitCore, *mockTransport) {
+	exchange := NewBybitCore()
+	exchange.Init(map[string]interface{}{
+		"apiKey": "key",
+		"secret": "secret",
+		"options": map[string]interface{}{
+			"enableUnifiedMargin":  false,
+			"enableUnifiedAccount": true,
+			"unifiedMarginStatus":  6,
+		},
+	})
+	transport := &mockTransport{body: `{"retCode":0,"retMsg":"OK","result":{}}`}
+	exchange.httpClient.Transport = transport
+	market := newTimeInForceTestMarket(&exchange.Exchange, "BTCUSDT", "BTC/USDT:USDT", "swap")
+	market["linear"] = true
+	market["inverse"] = false
+	exchange.SetMarkets([]interface{}{market})
+	return exchange, transport
+}
+
+// bybitBatchResponse acknowledges the orders first to first+count-1, the rejected ones get an error code
+func bybitBatchResponse(first int, count int, rejected map[int]string) string {
+	list := []string{}
+	codes := []string{}
+	for i := first; i < first+count; i++ {
+		if message, ok := rejected[i]; ok {
+			list = append(list, `{"category":"linear","symbol":"BTCUSDT","orderId":"","orderLinkId":"","createAt":""}`)
+			codes = append(codes, `{"code":10001,"msg":"`+message+`"}`)
+		} else {
+			list = append(list, fmt.Sprintf(`{"category":"linear","symbol":"BTCUSDT","orderId":"id-%d","orderLinkId":"link-%d","createAt":"1698075516029"}`, i, i))
+			codes = append(codes, `{"code":0,"msg":"OK"}`)
+		}
+	}
+	return `{"retCode":0,"retMsg":"OK","result":{"list":[` + strings.Join(list, ",") + `]},"retExtInfo":{"list":[` + strings.Join(codes, ",") + `]},"time":1698075516029}`
+}
+
+func batchRequestLength(t *testing.T, transport *mockTransport, index int) int {
+	body, err := transport.requests[index].GetBody()
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := io.ReadAll(body)
+	var payload struct {
+		Category string        `json:"category"`
+		Request  []interface{} `json:"request"`
+	}
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload.Category != "linear" {
+		t.Fatalf("expected the linear category, got %s", raw)
+	}
+	return len(payload.Request)
+}
+
+func TestBybitCreateOrdersSplitsBatchesAndKeepsRejections(t *testing.T) {
+	exchange, transport := newMockedBybit()
+	transport.queue = []string{
+		bybitBatchResponse(0, 20, map[int]string{3: "The number of contracts exceeds maximum limit allowed: too large"}),
+		bybitBatchResponse(20, 1, nil),
+	}
+	orders := []interface{}{}
+	for i := 0; i < 21; i++ {
+		orders = append(orders, map[string]interface{}{
+			"symbol": "BTC/USDT:USDT",
+			"type":   "limit",
+			"side":   "buy",
+			"amount": 0.01,
+			"price":  100.0 + float64(i),
+		})
+	}
+	result := <-exchange.CreateOrders(orders)
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if len(transport.requests) != 2 {
+		t.Fatalf("expected 2 batch requests, got %d", len(transport.requests))
+	}
+	for i, expected := range []int{20, 1} {
+		if !strings.HasSuffix(transport.requests[i].URL.Path, "/v5/order/create-batch") {
+			t.Fatalf("unexpected request %s", transport.requests[i].URL.Path)
+		}
+		if length := batchRequestLength(t, transport, i); length != expected {
+			t.Fatalf("expected %d orders in batch %d, got %d", expected, i, length)
+		}
+	}
+	parsed := NewOrderArray(result)
+	if len(parsed) != 21 {
+		t.Fatalf("expected 21 orders, got %d", len(parsed))
+	}
+	for i, order := range parsed {
+		if i == 3 {
+			info := GetValue(GetValue(result, i), "info")
+			if *order.Status != "rejected" || GetValue(info, "msg") != "The number of contracts exceeds maximum limit allowed: too large" {
+				t.Fatalf("expected the 4th order to be rejected with the exchange message, got %v", info)
+			}
+			continue
+		}
+		if order.Id == nil || *order.Id != fmt.Sprintf("id-%d", i) || *order.ClientOrderId != fmt.Sprintf("link-%d", i) {
+			t.Fatalf("expected order %d to keep its position, got %+v", i, order.Info)
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// fetchFundingHistory: the funding executions, or the funding settlements of the transaction log
+// ---------------------------------------------------------------------------
+
+const bybitTransactionLog = `{"retCode":0,"retMsg":"OK","result":{"nextPageCursor":"%s","list":[
+	{"id":"1","symbol":"BTCUSDT","category":"linear","side":"Buy","transactionTime":"1672128000000","type":"SETTLEMENT","qty":"0.1","size":"0.1","currency":"USDT","tradePrice":"16800","funding":"0.168","fee":"0","cashFlow":"0","change":"-0.168","cashBalance":"5000.832","feeRate":"0.0001","tradeId":""},
+	{"id":"2","symbol":"BTCUSDT","category":"linear","side":"Buy","transactionTime":"1672121182224","type":"TRADE","qty":"0.1","size":"0.1","currency":"USDT","tradePrice":"16799","funding":"","fee":"1.0079","cashFlow":"0","change":"-1.0079","cashBalance":"5001","feeRate":"0.0006","tradeId":"8569c10f"},
+	{"id":"3","symbol":"","category":"linear","side":"None","transactionTime":"1672120000000","type":"TRANSFER_IN","qty":"0","size":"0","currency":"USDT","tradePrice":"","funding":"","fee":"","cashFlow":"1000","change":"1000","cashBalance":"5002.0079","feeRate":"","tradeId":""},
+	{"id":"4","symbol":"BTCUSDT","category":"linear","side":"Sell","transactionTime":"1672156800000","type":"SETTLEMENT","qty":"0.1","size":"0.1","currency":"USDT","tradePrice":"16900","funding":"-0.0845","fee":"0","cashFlow":"0","change":"0.0845","cashBalance":"5000.9165","feeRate":"-0.00005","tradeId":""},
+	{"id":"6","symbol":"BTCPERP","category":"linear","side":"Buy","transactionTime":"1672128000000","type":"SETTLEMENT","qty":"0.1","size":"0.1","currency":"USDC","tradePrice":"16800","funding":"0.168","fee":"0","cashFlow":"0","change":"-0.168","cashBalance":"100","feeRate":"0.0001","tradeId":""}
+]},"retExtInfo":{},"time":1672160000000}`
+
+func TestBybitFetchFundingHistoryFromTransactionLog(t *testing.T) {
+	exchange, transport := newMockedBybit()
+	transport.body = fmt.Sprintf(bybitTransactionLog, "")
+	transactionLog := map[string]interface{}{"method": "privateGetV5AccountTransactionLog"}
+	result := <-exchange.FetchFundingHistory("BTC/USDT:USDT", 1672000000000, nil, transactionLog)
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if len(transport.requests) != 1 {
+		t.Fatalf("expected a single request, got %d", len(transport.requests))
+	}
+	request := transport.requests[0]
+	query := request.URL.Query()
+	if !strings.HasSuffix(request.URL.Path, "/v5/account/transaction-log") || query.Get("type") != "SETTLEMENT" ||
+		query.Get("category") != "linear" || query.Get("baseCoin") != "BTC" || query.Get("startTime") != "1672000000000" || query.Get("limit") != "50" {
+		t.Fatalf("unexpected request %s", request.URL.String())
+	}
+	fundings := NewFundingHistoryArray(result)
+	if len(fundings) != 2 {
+		t.Fatalf("expected the 2 funding settlements, got %v", result)
+	}
+	paid, received := fundings[0], fundings[1]
+	if *paid.Id != "1" || *paid.Symbol != "BTC/USDT:USDT" || *paid.Code != "USDT" || *paid.Timestamp != 1672128000000 || *paid.Amount != -0.168 {
+		t.Fatalf("unexpected paid funding %+v", paid.Info)
+	}
+	if *received.Id != "4" || *received.Timestamp != 1672156800000 || *received.Amount != 0.0845 {
+		t.Fatalf("unexpected received funding %+v", received.Info)
+	}
+	// the limit applies to the funding settlements of the market, a full page is requested for them
+	result = <-exchange.FetchFundingHistory("BTC/USDT:USDT", 1672000000000, 1, transactionLog)
+	if fundings := NewFundingHistoryArray(result); len(fundings) != 1 || *fundings[0].Id != "1" {
+		t.Fatalf("expected the first funding settlement, got %v", result)
+	}
+	if limit := transport.requests[1].URL.Query().Get("limit"); limit != "50" {
+		t.Fatalf("expected a full page to be requested, got a limit of %s", limit)
+	}
+}
+
+func TestBybitFetchFundingHistoryFromExecutions(t *testing.T) {
+	exchange, transport := newMockedBybit()
+	transport.body = `{"retCode":0,"retMsg":"OK","result":{"nextPageCursor":"","category":"linear","list":[
+		{"symbol":"BTCUSDT","execFee":"0.168","execId":"e1","execTime":"1672128000000","execType":"Funding","feeRate":"0.0001"},
+		{"symbol":"BTCUSDT","execFee":"-0.0845","execId":"e2","execTime":"1672156800000","execType":"Funding","feeRate":"-0.00005"}
+	]},"retExtInfo":{},"time":1672160000000}`
+	// the execution list stays the default
+	result := <-exchange.FetchFundingHistory("BTC/USDT:USDT", nil, 10)
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	query := transport.requests[0].URL.Query()
+	if !strings.HasSuffix(transport.requests[0].URL.Path, "/v5/execution/list") || query.Get("execType") != "Funding" || query.Get("symbol") != "BTCUSDT" || query.Get("size") != "10" {
+		t.Fatalf("unexpected request %s", transport.requests[0].URL.String())
+	}
+	// the amounts follow the sign of the transaction log, positive when the funding is received
+	fundings := NewFundingHistoryArray(result)
+	if len(fundings) != 2 || *fundings[0].Id != "e1" || *fundings[0].Amount != -0.168 || *fundings[1].Amount != 0.0845 {
+		t.Fatalf("unexpected fundings %v", result)
+	}
+}
+
+func TestBybitFetchFundingHistoryPaginatesWithCursor(t *testing.T) {
+	exchange, transport := newMockedBybit()
+	transport.queue = []string{
+		fmt.Sprintf(bybitTransactionLog, "page2"),
+		`{"retCode":0,"retMsg":"OK","result":{"nextPageCursor":"","list":[
+			{"id":"5","symbol":"BTCUSDT","category":"linear","side":"Buy","transactionTime":"1672099200000","type":"SETTLEMENT","currency":"USDT","funding":"0.2","fee":"0","cashFlow":"0","change":"-0.2","cashBalance":"4001","feeRate":"0.0001"}
+		]},"retExtInfo":{},"time":1672160000000}`,
+	}
+	result := <-exchange.FetchFundingHistory("BTC/USDT:USDT", nil, nil, map[string]interface{}{"paginate": true, "method": "privateGetV5AccountTransactionLog"})
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if len(transport.requests) != 2 {
+		t.Fatalf("expected 2 pages, got %d requests", len(transport.requests))
+	}
+	if cursor := transport.requests[1].URL.Query().Get("cursor"); cursor != "page2" {
+		t.Fatalf("expected the second page to be requested with the cursor, got %s", transport.requests[1].URL.String())
+	}
+	ids := []string{}
+	for _, funding := range NewFundingHistoryArray(result) {
+		ids = append(ids, *funding.Id)
+	}
+	// cursor paginated results are sorted from the newest
+	if strings.Join(ids, ",") != "4,1,5" {
+		t.Fatalf("expected the funding settlements of both pages, got %v", ids)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// createStopOrder / createTrailingStopOrder: stop and trailing distances map to the bybit order params
+// ---------------------------------------------------------------------------
+
+func bybitRequestBody(t *testing.T, transport *mockTransport, index int) map[string]interface{} {
+	body, err := transport.requests[index].GetBody()
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := io.ReadAll(body)
+	var request map[string]interface{}
+	if err := json.Unmarshal(raw, &request); err != nil {
+		t.Fatalf("expected a json object, got %s", raw)
+	}
+	return request
+}
+
+func TestBybitCreateStopAndTrailingStopOrders(t *testing.T) {
+	exchange, transport := newMockedBybit()
+	transport.body = `{"retCode":0,"retMsg":"OK","result":{"orderId":"1321003749386327552","orderLinkId":"spot-test-postonly"},"retExtInfo":{},"time":1672211918471}`
+	result := <-exchange.CreateStopOrder("BTC/USDT:USDT", "market", "sell", 0.01, nil, 29500, map[string]interface{}{"triggerDirection": "below"})
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	request := transport.requests[0]
+	body := bybitRequestBody(t, transport, 0)
+	if !strings.HasSuffix(request.URL.Path, "/v5/order/create") || body["triggerPrice"] != "29500" || body["triggerDirection"] != float64(2) || body["orderType"] != "Market" {
+		t.Fatalf("unexpected stop order request %s %v", request.URL.Path, body)
+	}
+
+	result = <-exchange.CreateTrailingStopOrder("BTC/USDT:USDT", "market", "sell", 0.01, nil, map[string]interface{}{
+		"trailingAmount":       100,
+		"trailingTriggerPrice": 30000,
+	})
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	request = transport.requests[1]
+	body = bybitRequestBody(t, transport, 1)
+	if !strings.HasSuffix(request.URL.Path, "/v5/position/trading-stop") || body["trailingStop"] != "100" || body["activePrice"] != "30000" {
+		t.Fatalf("unexpected trailing stop order request %s %v", request.URL.Path, body)
+	}
+
+	// bybit trails by a price distance only
+	result = <-exchange.CreateTrailingStopOrder("BTC/USDT:USDT", "market", "sell", 0.01, nil, map[string]interface{}{"trailingPercent": 1.5})
+	if !IsError(result) || !IsErrorType(CreateReturnError(result), "NotSupported") {
+		t.Fatalf("expected NotSupported for a trailing percent, got %v", result)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// createOrderWithTakeProfitAndStopLoss: the take profit and the stop loss are attached to the order
+// ---------------------------------------------------------------------------
+
+func TestBybitCreateOrderWithTakeProfitAndStopLoss(t *testing.T) {
+	exchange, transport := newMockedBybit()
+	transport.body = `{"retCode":0,"retMsg":"OK","result":{"orderId":"1321003749386327552","orderLinkId":"tpsl-1"},"retExtInfo":{},"time":1672211918471}`
+	result := <-exchange.CreateOrderWithTakeProfitAndStopLoss("BTC/USDT:USDT", "limit", "buy", 0.01, 30000, 32000, 29000)
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	body := bybitRequestBody(t, transport, 0)
+	if body["takeProfit"] != "32000" || body["stopLoss"] != "29000" || body["tpslMode"] != "Full" || body["price"] != "30000" || body["side"] != "Buy" {
+		t.Fatalf("unexpected order request %v", body)
+	}
+	order := NewOrder(result)
+	if *order.Id != "1321003749386327552" || *order.TakeProfitPrice != 32000 || *order.StopLossPrice != 29000 {
+		t.Fatalf("expected the order with its take profit and stop loss, got %v", result)
+	}
+
+	for _, prices := range [][]float64{{29500, 29000}, {32000, 30500}, {30000, 29000}} {
+		result = <-exchange.CreateOrderWithTakeProfitAndStopLoss("BTC/USDT:USDT", "limit", "buy", 0.01, 30000, prices[0], prices[1])
+		if !IsError(result) || !IsErrorType(CreateReturnError(result), "InvalidOrder") {
+			t.Fatalf("expected take profit %v and stop loss %v to be rejected for a long at 30000, got %v", prices[0], prices[1], result)
+		}
+	}
+	if len(transport.requests) != 1 {
+		t.Fatalf("expected the rejected orders not to be sent, got %d requests", len(transport.requests))
+	}
+}
+
+// ---------------------------------------------------------------------------
+// fetchStatus: without a status endpoint the exchange is reported as ok at the server time
+// ---------------------------------------------------------------------------
+
+func TestBybitFetchStatusDefault(t *testing.T) {
+	exchange, transport := newMockedBybit()
+	transport.body = `{"retCode":0,"retMsg":"OK","result":{"timeSecond":"1688639403","timeNano":"1688639403423213947"},"retExtInfo":{},"time":1688639403423}`
+	result := <-exchange.FetchStatus()
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if len(transport.requests) != 1 || !strings.HasSuffix(transport.requests[0].URL.Path, "/v5/market/time") {
+		t.Fatalf("expected the server time to be requested, got %d requests", len(transport.requests))
+	}
+	status := result.(map[string]interface{})
+	if status["status"] != "ok" || status["updated"] != int64(1688639403423) || status["eta"] != nil || status["url"] != nil {
+		t.Fatalf("unexpected status %v", result)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// fetchOpenOrders: without a symbol every settle coin and every page is fetched
+// ---------------------------------------------------------------------------
+
+func TestBybitFetchOpenOrdersAcrossSymbols(t *testing.T) {
+	exchange, transport := newMockedBybit()
+	eth := newTimeInForceTestMarket(&exchange.Exchange, "ETHUSDT", "ETH/USDT:USDT", "swap")
+	eth["base"], eth["baseId"] = "ETH", "ETH"
+	perp := newTimeInForceTestMarket(&exchange.Exchange, "BTCPERP", "BTC/USDC:USDC", "swap")
+	perp["quote"], perp["quoteId"], perp["settle"], perp["settleId"] = "USDC", "USDC", "USDC", "USDC"
+	exchange.SetMarkets(append(ObjectValues(exchange.Markets), eth, perp))
+	order := func(symbol string, id string) string {
+		return fmt.Sprintf(`{"symbol":"%s","orderId":"%s","orderLinkId":"","orderType":"Limit","side":"Buy","price":"100","qty":"1","cumExecQty":"0","leavesQty":"1","orderStatus":"New","timeInForce":"GTC","createdTime":"1757837618905","updatedTime":"1757837618909"}`, symbol, id)
+	}
+	page := func(cursor string, orders ...string) string {
+		return `{"retCode":0,"retMsg":"OK","result":{"nextPageCursor":"` + cursor + `","category":"linear","list":[` + strings.Join(orders, ",") + `]},"retExtInfo":{},"time":1758187806376}`
+	}
+	// the settle coins are requested in alphabetical order
+	transport.queue = []string{
+		page("", order("BTCPERP", "1")),
+		page("c1", order("BTCUSDT", "2"), order("ETHUSDT", "3")),
+		page("", order("ETHUSDT", "4")),
+	}
+	result := <-exchange.FetchOpenOrders()
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	expected := []struct{ settleCoin, cursor string }{{"USDC", ""}, {"USDT", ""}, {"USDT", "c1"}}
+	if len(transport.requests) != len(expected) {
+		t.Fatalf("expected %d requests, got %d", len(expected), len(transport.requests))
+	}
+	for i, want := range expected {
+		query := transport.requests[i].URL.Query()
+		if !strings.HasSuffix(transport.requests[i].URL.Path, "/v5/order/realtime") || query.Get("category") != "linear" || query.Get("settleCoin") != want.settleCoin || query.Get("cursor") != want.cursor || query.Has("symbol") {
+			t.Fatalf("request %d: expected %s from cursor %q, got %s", i, want.settleCoin, want.cursor, transport.requests[i].URL)
+		}
+	}
+	orders := NewOrderArray(result)
+	symbols := map[string]int{}
+	for _, order := range orders {
+		symbols[*order.Symbol]++
+	}
+	if len(orders) != 4 || symbols["BTC/USDC:USDC"] != 1 || symbols["BTC/USDT:USDT"] != 1 || symbols["ETH/USDT:USDT"] != 2 {
+		t.Fatalf("expected the orders of the 3 markets, got %v", symbols)
+	}
+
+	// a symbol keeps a single request
+	transport.requests = nil
+	transport.queue = []string{page("c2", order("BTCUSDT", "2"))}
+	result = <-exchange.FetchOpenOrders("BTC/USDT:USDT")
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if query := transport.requests[0].URL.Query(); len(transport.requests) != 1 || query.Get("symbol") != "BTCUSDT" || query.Has("settleCoin") {
+		t.Fatalf("expected a single request for the symbol, got %d %s", len(transport.requests), transport.requests[0].URL)
+	}
+	if orders := NewOrderArray(result); len(orders) != 1 || *orders[0].Id != "2" {
+		t.Fatalf("unexpected orders %v", result)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// fetchLeverageTiers: the risk limits of each category as unified tiers
+// ---------------------------------------------------------------------------
+
+func bybitRiskLimits(category string, cursor string, limits ...string) string {
+	return `{"retCode":0,"retMsg":"OK","result":{"category":"` + category + `","list":[` + strings.Join(limits, ",") +
+		`],"nextPageCursor":"` + cursor + `"},"retExtInfo":{},"time":1672054488010}`
+}
+
+func bybitRiskLimit(id int, symbol string, value string, maintenance string, leverage string) string {
+	return fmt.Sprintf(`{"id":%d,"symbol":"%s","riskLimitValue":"%s","maintenanceMargin":"%s","initialMargin":"0.01","isLowestRisk":%d,"maxLeverage":"%s","mmDeduction":""}`,
+		id, symbol, value, maintenance, map[bool]int{true: 1, false: 0}[id == 1], leverage)
+}
+
+func TestBybitParseLeverageTiers(t *testing.T) {
+	exchange, _ := newMockedBybit()
+	response := ParseJSON("[" + strings.Join([]string{
+		bybitRiskLimit(2, "BTCUSDT", "4000000", "0.0055", "80.00"),
+		bybitRiskLimit(1, "BTCUSDT", "2000000", "0.005", "100.00"),
+		bybitRiskLimit(3, "BTCUSDT", "6000000", "0.006", "66.67"),
+	}, ",") + "]")
+	tiers := exchange.ParseLeverageTiers(response, nil, "symbol")
+	list := NewLeverageTiers(tiers).Tiers["BTC/USDT:USDT"]
+	if len(list) != 3 {
+		t.Fatalf("expected 3 tiers, got %v", tiers)
+	}
+	expected := []struct {
+		tier                    int64
+		min, max, mmr, leverage float64
+	}{
+		{1, 0, 2000000, 0.005, 100},
+		{2, 2000000, 4000000, 0.0055, 80},
+		{3, 4000000, 6000000, 0.006, 66.67},
+	}
+	for i, want := range expected {
+		tier := list[i]
+		if *tier.Tier != want.tier || *tier.MinNotional != want.min || *tier.MaxNotional != want.max ||
+			*tier.MaintenanceMarginRate != want.mmr || *tier.MaxLeverage != want.leverage {
+			t.Fatalf("tier %d: unexpected %+v", i, tier)
+		}
+		if *tier.Symbol != "BTC/USDT:USDT" || *tier.Currency != "USDT" {
+			t.Fatalf("tier %d: expected the symbol and settle currency of the market, got %v %v", i, tier.Symbol, tier.Currency)
+		}
+	}
+}
+
+func TestBybitFetchLeverageTiersByCategory(t *testing.T) {
+	exchange, transport := newMockedBybit()
+	eth := newTimeInForceTestMarket(&exchange.Exchange, "ETHUSDT", "ETH/USDT:USDT", "swap")
+	eth["base"], eth["baseId"], eth["linear"], eth["inverse"] = "ETH", "ETH", true, false
+	inverse := newTimeInForceTestMarket(&exchange.Exchange, "BTCUSD", "BTC/USD:BTC", "swap")
+	inverse["quote"], inverse["quoteId"], inverse["settle"], inverse["settleId"] = "USD", "USD", "BTC", "BTC"
+	inverse["linear"], inverse["inverse"] = false, true
+	exchange.SetMarkets(append(ObjectValues(exchange.Markets), eth, inverse))
+	transport.queue = []string{
+		bybitRiskLimits("inverse", "", bybitRiskLimit(1, "BTCUSD", "150", "0.5", "100.00")),
+		bybitRiskLimits("linear", "p2", bybitRiskLimit(1, "BTCUSDT", "2000000", "0.005", "100.00"), bybitRiskLimit(1, "ETHUSDT", "1000000", "0.005", "100.00")),
+		bybitRiskLimits("linear", "", bybitRiskLimit(2, "BTCUSDT", "4000000", "0.0055", "80.00"), bybitRiskLimit(1, "SOLUSDT", "200000", "0.01", "50.00")),
+	}
+	// the category of each market wins over the subType of the params
+	result := <-exchange.FetchLeverageTiers([]interface{}{"BTC/USDT:USDT", "ETH/USDT:USDT", "BTC/USD:BTC"}, map[string]interface{}{"subType": "inverse"})
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	expected := []struct{ category, symbol, cursor string }{{"inverse", "BTCUSD", ""}, {"linear", "", ""}, {"linear", "", "p2"}}
+	if len(transport.requests) != len(expected) {
+		t.Fatalf("expected %d requests, got %d", len(expected), len(transport.requests))
+	}
+	for i, want := range expected {
+		query := transport.requests[i].URL.Query()
+		if !strings.HasSuffix(transport.requests[i].URL.Path, "/v5/market/risk-limit") || query.Get("category") != want.category || query.Get("symbol") != want.symbol || query.Get("cursor") != want.cursor {
+			t.Fatalf("request %d: expected %s %q from cursor %q, got %s", i, want.category, want.symbol, want.cursor, transport.requests[i].URL)
+		}
+	}
+	tiers := NewLeverageTiers(result).Tiers
+	if len(tiers) != 3 || len(tiers["BTC/USDT:USDT"]) != 2 || len(tiers["ETH/USDT:USDT"]) != 1 || len(tiers["BTC/USD:BTC"]) != 1 {
+		t.Fatalf("expected the tiers of the 3 requested markets, got %v", result)
+	}
+	if *tiers["BTC/USDT:USDT"][1].MinNotional != 2000000 || *tiers["BTC/USD:BTC"][0].Currency != "BTC" {
+		t.Fatalf("unexpected tiers %v", result)
+	}
+}
+
+func TestBybitCreateOrdersKeepsPlacedOrdersWhenABatchFails(t *testing.T) {
+	exchange, transport := newMockedBybit()
+	transport.queue = []string{
+		bybitBatchResponse(0, 20, nil),
+		`{"retCode":10006,"retMsg":"Too many visits!","result":{},"retExtInfo":{},"time":1698075516029}`,
+	}
+	orders := []interface{}{}
+	for i := 0; i < 22; i++ {
+		orders = append(orders, map[string]interface{}{
+			"symbol": "BTC/USDT:USDT",
+			"type":   "limit",
+			"side":   "buy",
+			"amount": 0.01,
+			"price":  100.0 + float64(i),
+			"params": map[string]interface{}{"clientOrderId": fmt.Sprintf("link-%d", i)},
+		})
+	}
+	result := <-exchange.CreateOrders(orders)
+	if IsError(result) {
+		t.Fatalf("expected the placed orders to be returned, got %v", result)
+	}
+	parsed := NewOrderArray(result)
+	if len(parsed) != 22 {
+		t.Fatalf("expected 22 orders, got %d", len(parsed))
+	}
+	for i, order := range parsed[:20] {
+		if order.Id == nil || *order.Id != fmt.Sprintf("id-%d", i) {
+			t.Fatalf("expected order %d to be placed, got %+v", i, order.Info)
+		}
+	}
+	for i, order := range parsed[20:] {
+		info := GetValue(GetValue(result, 20+i), "info")
+		if *order.Status != "rejected" || *order.ClientOrderId != fmt.Sprintf("link-%d", 20+i) || *order.Symbol != "BTC/USDT:USDT" {
+			t.Fatalf("expected order %d of the failed batch to be rejected, got %+v", 20+i, order.Info)
+		}
+		if GetValue(info, "code") != "RateLimitExceeded" || !strings.Contains(ToString(GetValue(info, "msg")), "Too many visits!") {
+			t.Fatalf("expected the error of the failed batch, got %v", info)
+		}
+	}
+}
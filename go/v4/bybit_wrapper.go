@@ -532,7 +532,7 @@ func (this *Bybit) CreateOrder(symbol string, typeVar string, side string, amoun
  * @see https://bybit-exchange.github.io/docs/v5/order/batch-place
  * @param {Array} orders list of orders to create, each object should contain the parameters required by createOrder, namely symbol, type, side, amount, price and params
  * @param {object} [params] extra parameters specific to the exchange API endpoint
- * @returns {object} an [order structure]{@link https://docs.ccxt.com/?id=order-structure}
+ * @returns {object[]} a list of [order structures]{@link https://docs.ccxt.com/?id=order-structure} in the order of the input, the orders refused by the exchange and those of a failed batch have the rejected status, with the error in info.code and info.msg
  */
 func (this *Bybit) CreateOrders(orders []OrderRequest, options ...CreateOrdersOptions) ([]Order, error) {
 
@@ -1228,7 +1228,7 @@ func (this *Bybit) FetchCanceledOrders(options ...FetchCanceledOrdersOptions) ([
  * @param {string} [params.type] market type, ['swap', 'option', 'spot']
  * @param {string} [params.subType] market subType, ['linear', 'inverse']
  * @param {string} [params.baseCoin] Base coin. Supports linear, inverse & option
- * @param {string} [params.settleCoin] Settle coin. Supports linear, inverse & option
+ * @param {string} [params.settleCoin] Settle coin. Supports linear, inverse & option, without a symbol the orders of every settle coin are fetched by default
  * @param {string} [params.orderFilter] 'Order' or 'StopOrder' or 'tpslOrder'
  * @param {boolean} [params.paginate] default false, when true will automatically paginate by calling this endpoint multiple times. See in the docs all the [availble parameters](https://github.com/ccxt/ccxt/wiki/Manual#pagination-params)
  * @returns {Order[]} a list of [order structures]{@link https://docs.ccxt.com/?id=order-structure}
@@ -2469,10 +2469,10 @@ func (this *Bybit) FetchMyLiquidations(options ...FetchMyLiquidationsOptions) ([
  * @name bybit#fetchLeverageTiers
  * @description retrieve information on the maximum leverage, for different trade sizes
  * @see https://bybit-exchange.github.io/docs/v5/market/risk-limit
- * @param {string[]} [symbols] a list of unified market symbols
+ * @param {string[]} [symbols] a list of unified market symbols, the linear and inverse ones are requested from their own category
  * @param {object} [params] extra parameters specific to the exchange API endpoint
- * @param {string} [params.subType] market subType, ['linear', 'inverse'], default is 'linear'
- * @param {boolean} [params.paginate] default false, when true will automatically paginate by calling this endpoint multiple times. See in the docs all the [available parameters](https://github.com/ccxt/ccxt/wiki/Manual#pagination-params)
+ * @param {string} [params.subType] market subType without symbols, ['linear', 'inverse'], default is 'linear'
+ * @param {int} [params.paginationCalls] the maximum number of pages followed per category, default 50
  * @returns {object} a dictionary of [leverage tiers structures]{@link https://docs.ccxt.com/?id=leverage-tiers-structure}, indexed by market symbols
  */
 func (this *Bybit) FetchLeverageTiers(options ...FetchLeverageTiersOptions) (LeverageTiers, error) {
@@ -2503,12 +2503,14 @@ func (this *Bybit) FetchLeverageTiers(options ...FetchLeverageTiersOptions) (Lev
  * @method
  * @name bybit#fetchFundingHistory
  * @description fetch the history of funding payments paid and received on this account
+ * @see https://bybit-exchange.github.io/docs/v5/account/transaction-log
  * @see https://bybit-exchange.github.io/docs/api-explorer/v5/position/execution
  * @param {string} [symbol] unified market symbol
  * @param {int} [since] the earliest time in ms to fetch funding history for
  * @param {int} [limit] the maximum number of funding history structures to retrieve
  * @param {object} [params] extra parameters specific to the exchange API endpoint
  * @param {boolean} [params.paginate] default false, when true will automatically paginate by calling this endpoint multiple times. See in the docs all the [available parameters](https://github.com/ccxt/ccxt/wiki/Manual#pagination-params)
+ * @param {string} [params.method] 'privateGetV5ExecutionList' (default) or 'privateGetV5AccountTransactionLog'
  * @returns {object} a [funding history structure]{@link https://docs.ccxt.com/?id=funding-history-structure}
  */
 func (this *Bybit) FetchFundingHistory(options ...FetchFundingHistoryOptions) ([]FundingHistory, error) {
@@ -2966,7 +2968,37 @@ func (this *Bybit) CreateMarketSellOrder(symbol string, amount float64, options
 	return this.exchangeTyped.CreateMarketSellOrder(symbol, amount, options...)
 }
 func (this *Bybit) CreateOrderWithTakeProfitAndStopLoss(symbol string, typeVar string, side string, amount float64, options ...CreateOrderWithTakeProfitAndStopLossOptions) (Order, error) {
-	return this.exchangeTyped.CreateOrderWithTakeProfitAndStopLoss(symbol, typeVar, side, amount, options...)
+
+	opts := CreateOrderWithTakeProfitAndStopLossOptionsStruct{}
+
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	var price interface{} = nil
+	if opts.Price != nil {
+		price = *opts.Price
+	}
+
+	var takeProfit interface{} = nil
+	if opts.TakeProfit != nil {
+		takeProfit = *opts.TakeProfit
+	}
+
+	var stopLoss interface{} = nil
+	if opts.StopLoss != nil {
+		stopLoss = *opts.StopLoss
+	}
+
+	var params interface{} = nil
+	if opts.Params != nil {
+		params = *opts.Params
+	}
+	res := <-this.Core.CreateOrderWithTakeProfitAndStopLoss(symbol, typeVar, side, amount, price, takeProfit, stopLoss, params)
+	if IsError(res) {
+		return Order{}, CreateReturnError(res)
+	}
+	return NewOrder(res), nil
 }
 func (this *Bybit) CreatePostOnlyOrder(symbol string, typeVar string, side string, amount float64, options ...CreatePostOnlyOrderOptions) (Order, error) {
 	return this.exchangeTyped.CreatePostOnlyOrder(symbol, typeVar, side, amount, options...)
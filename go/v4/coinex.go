@@ -3893,6 +3893,8 @@ func (this *CoinexCore) SetLeverage(leverage interface{}, optionalArgs ...interf
 		_ = symbol
 		params := GetArg(optionalArgs, 1, map[string]interface{}{})
 		_ = params
+		retRes38967 := (<-this.CheckLeverage(leverage, symbol, params))
+		PanicOnError(retRes38967)
 		if IsTrue(IsEqual(symbol, nil)) {
 			panic(ArgumentsRequired(Add(this.Id, " setLeverage() requires a symbol argument")))
 		}
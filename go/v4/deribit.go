@@ -4138,6 +4138,9 @@ func (this *DeribitCore) FetchGreeks(symbol interface{}, optionalArgs ...interfa
 		retRes34958 := (<-this.LoadMarkets())
 		PanicOnError(retRes34958)
 		var market interface{} = this.Market(symbol)
+		if !IsTrue(GetValue(market, "option")) {
+			panic(BadSymbol(Add(this.Id, " fetchGreeks() supports option markets only")))
+		}
 		var request interface{} = map[string]interface{}{
 			"instrument_name": GetValue(market, "id"),
 		}
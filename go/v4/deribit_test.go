@@ -0,0 +1,106 @@
+package ccxt
+
+import (
+	"strings"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// fetchGreeks: the greeks of an option are read from its ticker
+// ---------------------------------------------------------------------------
+
+func newMockedDeribit(body string) (*DeribitCore, *mockTransport) {
+	exchange := NewDeribitCore()
+	exchange.Init(map[string]interface{}{})
+	transport := &mockTransport{body: body}
+	exchange.httpClient.Transport = transport
+	exchange.SetMarkets([]interface{}{
+		exchange.SafeMarketStructure(map[string]interface{}{
+			"id":         "BTC-27SEP24-40000-C",
+			"symbol":     "BTC/USD:BTC-240927-40000-C",
+			"base":       "BTC",
+			"quote":      "USD",
+			"settle":     "BTC",
+			"type":       "option",
+			"option":     true,
+			"contract":   true,
+			"strike":     40000,
+			"optionType": "call",
+			"active":     true,
+		}),
+		exchange.SafeMarketStructure(map[string]interface{}{
+			"id":       "BTC-PERPETUAL",
+			"symbol":   "BTC/USD:BTC",
+			"base":     "BTC",
+			"quote":    "USD",
+			"settle":   "BTC",
+			"type":     "swap",
+			"swap":     true,
+			"contract": true,
+			"active":   true,
+		}),
+	})
+	return exchange, transport
+}
+
+func TestDeribitFetchGreeks(t *testing.T) {
+	exchange, transport := newMockedDeribit(`{"jsonrpc":"2.0","result":{"estimated_delivery_price":36552.72,"best_bid_amount":0.2,` +
+		`"best_ask_amount":9.1,"interest_rate":0.0,"best_bid_price":0.214,"best_ask_price":0.219,"open_interest":368.8,` +
+		`"settlement_price":0.22103022,"last_price":0.215,"bid_iv":60.51,"ask_iv":61.88,"mark_iv":61.27,` +
+		`"underlying_index":"BTC-27SEP24","underlying_price":38992.71,"min_price":0.1515,"max_price":0.326,"mark_price":0.2168,` +
+		`"instrument_name":"BTC-27SEP24-40000-C","index_price":36552.72,` +
+		`"greeks":{"rho":130.63998,"theta":-13.48784,"vega":141.90146,"gamma":0.00002,"delta":0.59621},` +
+		`"stats":{"volume_usd":100453.9,"volume":12.0,"price_change":-2.2727,"low":0.2065,"high":0.238},` +
+		`"state":"open","timestamp":1699578548021},"usIn":1699578548308414,"usOut":1699578548308606,"usDiff":192,"testnet":false}`)
+
+	result := <-exchange.FetchGreeks("BTC/USD:BTC-240927-40000-C")
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	request := transport.requests[0].URL
+	if !strings.HasSuffix(request.Path, "/public/ticker") || request.Query().Get("instrument_name") != "BTC-27SEP24-40000-C" {
+		t.Fatalf("expected the ticker of the option, got %s", request)
+	}
+	greeks := NewGreeks(result)
+	if *greeks.Symbol != "BTC/USD:BTC-240927-40000-C" || *greeks.Timestamp != 1699578548021 {
+		t.Fatalf("unexpected greeks %+v", greeks)
+	}
+	expected := map[string]*float64{
+		"delta":           greeks.Delta,
+		"gamma":           greeks.Gamma,
+		"theta":           greeks.Theta,
+		"vega":            greeks.Vega,
+		"rho":             greeks.Rho,
+		"underlyingPrice": greeks.UnderlyingPrice,
+		"markIV":          greeks.MarkImpliedVolatility,
+		"bidIV":           greeks.BidImpliedVolatility,
+		"askIV":           greeks.AskImpliedVolatility,
+	}
+	values := map[string]float64{
+		"delta":           0.59621,
+		"gamma":           0.00002,
+		"theta":           -13.48784,
+		"vega":            141.90146,
+		"rho":             130.63998,
+		"underlyingPrice": 38992.71,
+		"markIV":          61.27,
+		"bidIV":           60.51,
+		"askIV":           61.88,
+	}
+	for field, value := range expected {
+		if value == nil || *value != values[field] {
+			t.Fatalf("expected %s to be %v, got %v", field, values[field], value)
+		}
+	}
+}
+
+func TestDeribitFetchGreeksRequiresAnOption(t *testing.T) {
+	exchange, transport := newMockedDeribit(`{"jsonrpc":"2.0","result":{}}`)
+	result := <-exchange.FetchGreeks("BTC/USD:BTC")
+	if err, ok := CreateReturnError(result).(*Error); !ok || err.Type != "BadSymbol" {
+		t.Fatalf("expected a BadSymbol error, got %v", result)
+	}
+	if len(transport.requests) != 0 {
+		t.Fatalf("expected no request, got %d", len(transport.requests))
+	}
+}
@@ -4439,6 +4439,8 @@ func (this *DigifinexCore) SetLeverage(leverage interface{}, optionalArgs ...int
 		_ = symbol
 		params := GetArg(optionalArgs, 1, map[string]interface{}{})
 		_ = params
+		retRes44427 := (<-this.CheckLeverage(leverage, symbol, params))
+		PanicOnError(retRes44427)
 		if IsTrue(IsEqual(symbol, nil)) {
 			panic(ArgumentsRequired(Add(this.Id, " setLeverage() requires a symbol argument")))
 		}
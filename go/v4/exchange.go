@@ -1,6 +1,7 @@
 package ccxt
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
@@ -32,6 +33,11 @@ type Exchange struct {
 	marketsLoading         bool
 	marketsLoaded          bool
 	loadMarketsSubscribers []chan interface{}
+	marketsFetchMu         sync.Mutex
+	marketsFetch           *marketsFetch
+	marketsLoadedAt        int64
+	marketsRefreshing      bool
+	MarketsTTL             int64 // milliseconds after which cached markets are refreshed in the background, 0 disables it
 	Itf                    interface{}
 	DerivedExchange        IDerivedExchange
 	methodCache            sync.Map
@@ -45,6 +51,9 @@ type Exchange struct {
 	TransformedApi         map[string]interface{}
 	Markets                *sync.Map
 	Markets_by_id          *sync.Map
+	MarketsByType          interface{}
+	MarketsByBase          interface{}
+	MarketsByQuote         interface{}
 	Currencies_by_id       *sync.Map
 	Currencies             *sync.Map
 	RequiredCredentials    map[string]interface{}
@@ -63,6 +72,9 @@ type Exchange struct {
 	RateLimiterAlgorithm   string
 	TokenBucket            map[string]interface{}
 	Throttler              *Throttler
+	RateLimiter            RateLimiter // takes precedence over Throttler when set
+	ReconnectPolicy        *ReconnectPolicy
+	PingConfig             *PingConfig
 	NewUpdates             bool
 	Alias                  bool
 	Verbose                bool
@@ -114,7 +126,9 @@ type Exchange struct {
 	PrivateKey    string
 	WalletAddress string
 
-	httpClient *http.Client
+	httpClient       *http.Client
+	customHttpClient bool          // set by SetHTTPClient, the proxy settings leave it untouched
+	ProxyRotator     *ProxyRotator // optional, picks the proxy and the user agent of every rest request
 
 	HttpProxy            interface{}
 	HttpsProxy           interface{}
@@ -189,6 +203,10 @@ type Exchange struct {
 	Clients     map[string]interface{}
 	newUpdates  bool
 	streaming   map[string]interface{}
+	// WsOrderThrottler paces the orders sent over the websocket api, it is built on first use
+	// from options.ws.orderThrottle and can be replaced to tune the rule
+	WsOrderThrottler   *Throttler
+	wsOrderThrottlerMu sync.Mutex
 
 	// id lock
 	idMutex sync.Mutex
@@ -266,15 +284,40 @@ func (this *Exchange) InitParent(userConfig map[string]interface{}, exchangeConf
 	this.AfterConstruct()
 
 	this.streaming = this.SafeDict(extendedProperties, "streaming", map[string]interface{}{}).(map[string]interface{})
+	wsOptions := this.SafeDict(this.Options, "ws", map[string]interface{}{})
+	this.ReconnectPolicy = NewReconnectPolicy(this.SafeDict(wsOptions, "reconnect", map[string]interface{}{}).(map[string]interface{}))
 	this.transformApiNew(this.Api)
-	transport := &http.Transport{}
+	this.httpClient = newDefaultHttpClient()
+}
 
-	this.httpClient = &http.Client{
-		Timeout:   30 * time.Second,
-		Transport: transport,
+// newDefaultHttpClient returns the client of the rest requests unless one is set with SetHTTPClient
+func newDefaultHttpClient() *http.Client {
+	return &http.Client{
+		Timeout: 30 * time.Second,
+		Transport: &http.Transport{
+			TLSHandshakeTimeout: 10 * time.Second,
+			IdleConnTimeout:     90 * time.Second,
+		},
 	}
 }
 
+// SetHTTPClient replaces the client of the rest requests, e.g. for a custom tls config or an httptest transport,
+// it is used as is so the proxy settings of the exchange do not apply to it. nil restores the default client
+func (this *Exchange) SetHTTPClient(client *http.Client) {
+	if client == nil {
+		this.httpClient = newDefaultHttpClient()
+		this.customHttpClient = false
+		return
+	}
+	this.httpClient = client
+	this.customHttpClient = true
+}
+
+// HTTPClient returns the client of the rest requests
+func (this *Exchange) HTTPClient() *http.Client {
+	return this.httpClient
+}
+
 func (this *Exchange) Init(userConfig map[string]interface{}) {
 	if this.Options == nil {
 		this.Options = &sync.Map{} // by default sync.map is nil
@@ -342,6 +385,7 @@ func (this *Exchange) LoadMarkets(params ...interface{}) <-chan interface{} {
 	this.loadMu.Lock()
 
 	if this.marketsLoaded && !reload {
+		this.refreshStaleMarkets(params...)
 		out := make(chan interface{}, 1)
 		out <- this.Markets
 		close(out)
@@ -368,62 +412,138 @@ func (this *Exchange) LoadMarkets(params ...interface{}) <-chan interface{} {
 	return ch
 }
 
+// marketsFetch is a markets fetch in flight, concurrent LoadMarketsHelper
+// calls subscribe to it instead of hitting fetchMarkets again
+type marketsFetch struct {
+	subscribers []chan interface{}
+}
+
 func (this *Exchange) LoadMarketsHelper(params ...interface{}) <-chan interface{} {
-	ch := make(chan interface{})
+	ch := make(chan interface{}, 1)
+	reload := GetArg(params, 0, false).(bool)
+
+	if !reload {
+		this.refreshStaleMarkets(params...)
+	}
+
+	this.marketsFetchMu.Lock()
+	if this.marketsFetch != nil && !reload {
+		this.marketsFetch.subscribers = append(this.marketsFetch.subscribers, ch)
+		this.marketsFetchMu.Unlock()
+		return ch
+	}
+	// a forced reload always starts a new fetch, the previous one still resolves its own subscribers
+	fetch := &marketsFetch{subscribers: []chan interface{}{ch}}
+	this.marketsFetch = fetch
+	this.marketsFetchMu.Unlock()
 
 	go func() {
-		defer close(ch)
-		defer func() {
-			if r := recover(); r != nil {
-				stack := debug.Stack()
-				panicMsg := fmt.Sprintf("panic: %v\nStack trace:\n%s", r, stack)
-				ch <- panicMsg
-			}
-		}()
-		reload := GetArg(params, 0, false).(bool)
-		params := GetArg(params, 1, map[string]interface{}{})
-		if !reload {
-			if this.Markets != nil {
-				if this.Markets_by_id == nil {
-					// Only lock when writing
-					this.MarketsMutex.Lock()
-					result := this.SetMarkets(this.Markets, nil)
-					this.MarketsMutex.Unlock()
-					ch <- result
-					return
-				}
-				ch <- this.Markets
-				return
-			}
+		value := this.fetchMarketsHelper(reload, GetArg(params, 1, map[string]interface{}{}))
+		this.marketsFetchMu.Lock()
+		if this.marketsFetch == fetch {
+			this.marketsFetch = nil
 		}
-
-		var currencies interface{} = nil
-		hasFetchCurrencies := this.Has["fetchCurrencies"]
-		if IsBool(hasFetchCurrencies) && IsTrue(hasFetchCurrencies) {
-			currencies = <-this.DerivedExchange.FetchCurrencies(params)
-			// this.cachedCurrenciesMutex.Lock()
-			// this.Options["cachedCurrencies"] = currencies
-			this.Options.Store("cachedCurrencies", currencies)
-			// this.cachedCurrenciesMutex.Unlock()
+		subscribers := fetch.subscribers
+		this.marketsFetchMu.Unlock()
+		for _, subscriber := range subscribers {
+			subscriber <- value
+			close(subscriber)
 		}
+	}()
+	return ch
+}
+
+// refreshStaleMarkets reloads the markets in the background once they are older than MarketsTTL,
+// callers keep getting the cached markets until the refresh completes
+func (this *Exchange) refreshStaleMarkets(params ...interface{}) {
+	if this.MarketsTTL <= 0 {
+		return
+	}
+	this.marketsFetchMu.Lock()
+	stale := this.marketsLoadedAt > 0 && Milliseconds()-this.marketsLoadedAt >= this.MarketsTTL
+	if !stale || this.marketsRefreshing {
+		this.marketsFetchMu.Unlock()
+		return
+	}
+	this.marketsRefreshing = true
+	this.marketsFetchMu.Unlock()
+	go func() {
+		<-this.LoadMarketsHelper(true, GetArg(params, 1, map[string]interface{}{}))
+		this.marketsFetchMu.Lock()
+		this.marketsRefreshing = false
+		this.marketsFetchMu.Unlock()
+	}()
+}
 
-		markets := <-this.DerivedExchange.FetchMarkets(params)
-		PanicOnError(markets)
+func (this *Exchange) fetchMarketsHelper(reload bool, params interface{}) (result interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			stack := debug.Stack()
+			result = fmt.Sprintf("panic: %v\nStack trace:\n%s", r, stack)
+		}
+	}()
+	if !reload {
+		if this.Markets != nil {
+			if this.Markets_by_id == nil {
+				// Only lock when writing
+				this.MarketsMutex.Lock()
+				result := this.SetMarkets(this.Markets, nil)
+				this.MarketsMutex.Unlock()
+				return result
+			}
+			return this.Markets
+		}
+	}
 
+	var currencies interface{} = nil
+	hasFetchCurrencies := this.Has["fetchCurrencies"]
+	// options.loadCurrencies false skips the currencies request, the currencies are then derived from the markets
+	loadCurrencies := this.SafeBool(this.Options, "loadCurrencies", true)
+	if IsTrue(loadCurrencies) && IsBool(hasFetchCurrencies) && IsTrue(hasFetchCurrencies) {
+		currencies = <-this.DerivedExchange.FetchCurrencies(params)
 		// this.cachedCurrenciesMutex.Lock()
-		// delete(this.Options, "cachedCurrencies")
-		// this.Options.Del
-		this.Options.Delete("cachedCurrencies")
+		// this.Options["cachedCurrencies"] = currencies
+		this.Options.Store("cachedCurrencies", currencies)
 		// this.cachedCurrenciesMutex.Unlock()
+	}
 
-		// Lock only for writing
-		this.MarketsMutex.Lock()
-		result := this.SetMarkets(markets, currencies)
-		this.MarketsMutex.Unlock()
+	markets := <-this.DerivedExchange.FetchMarkets(params)
+	PanicOnError(markets)
 
-		ch <- result
-	}()
-	return ch
+	// this.cachedCurrenciesMutex.Lock()
+	// delete(this.Options, "cachedCurrencies")
+	// this.Options.Del
+	this.Options.Delete("cachedCurrencies")
+	// this.cachedCurrenciesMutex.Unlock()
+
+	// Lock only for writing
+	this.MarketsMutex.Lock()
+	defer this.MarketsMutex.Unlock()
+	result = this.SetMarkets(markets, currencies)
+	this.marketsFetchMu.Lock()
+	this.marketsLoadedAt = Milliseconds()
+	this.marketsFetchMu.Unlock()
+	return result
+}
+
+// SetMarketsCopy is SetMarkets on a deep copy of the markets and currencies.
+// Sharing (SetMarketsFromExchange, or SetMarkets with another exchange's markets) keeps
+// references to the donor structures, which is cheap and fine as long as the markets are
+// treated as read-only. Prefer the copy when the receiving exchange mutates its markets
+// (precision, limits, info, ...) and the changes must not leak into the donor exchange.
+func (this *Exchange) SetMarketsCopy(markets interface{}, optionalArgs ...interface{}) interface{} {
+	currencies := GetArg(optionalArgs, 0, nil)
+	return this.SetMarkets(this.cloneMarketsStructure(markets), this.cloneMarketsStructure(currencies))
+}
+
+func (this *Exchange) cloneMarketsStructure(value interface{}) interface{} {
+	if syncMap, ok := value.(*sync.Map); ok {
+		if syncMap == nil {
+			return nil
+		}
+		return this.Clone(this.SafeMapToMap(syncMap))
+	}
+	return this.Clone(value)
 }
 
 func (this *Exchange) Throttle(cost interface{}) <-chan interface{} {
@@ -431,6 +551,14 @@ func (this *Exchange) Throttle(cost interface{}) <-chan interface{} {
 	ch := make(chan interface{})
 	go func() {
 		defer close(ch)
+		if this.RateLimiter != nil {
+			if err := this.RateLimiter.Acquire(context.Background(), map[string]float64{"cost": ToFloat64(cost)}); err != nil {
+				ch <- RateLimitExceeded(err.Error())
+				return
+			}
+			ch <- true
+			return
+		}
 		task := <-this.Throttler.Throttle(cost)
 		ch <- task
 	}()
@@ -514,7 +642,20 @@ func (this *Exchange) callEndpoint(endpoint2 interface{}, parameters interface{}
 			if valCost, ok := endPointData["cost"]; ok {
 				cost = valCost.(float64)
 			}
-			res := <-this.Fetch2(path, api, method, parameters, map[string]interface{}{}, nil, map[string]interface{}{"cost": cost})
+			config := map[string]interface{}{"cost": cost}
+			if endpointConfig, ok := endPointData["config"].(map[string]interface{}); ok && endpointConfig != nil {
+				config = this.Extend(endpointConfig, config)
+			}
+			res := <-this.Fetch2(path, api, method, parameters, map[string]interface{}{}, nil, config)
+			if this.isTimestampError(res) {
+				// the clock drifted from the server, the synced offset is kept in options.timeDifference for the next requests
+				PanicOnError(<-this.LoadTimeDifference())
+				res = <-this.Fetch2(path, api, method, parameters, map[string]interface{}{}, nil, config)
+			}
+			// retrying rate limited requests is opt-in through options.maxRetriesOnRateLimit
+			res = this.retryRateLimits(res, func() interface{} {
+				return <-this.Fetch2(path, api, method, parameters, map[string]interface{}{}, nil, config)
+			})
 			PanicOnError(res)
 			ch <- res
 		} else {
@@ -524,6 +665,16 @@ func (this *Exchange) callEndpoint(endpoint2 interface{}, parameters interface{}
 	return ch
 }
 
+// isTimestampError reports whether a signed request was rejected for its timestamp
+// and options.adjustForTimeDifference allows the clock to be synced before retrying
+func (this *Exchange) isTimestampError(res interface{}) bool {
+	if !IsError(res) || !IsTrue(this.SafeBool(this.Options, "adjustForTimeDifference", false)) {
+		return false
+	}
+	err, ok := CreateReturnError(res).(*Error)
+	return ok && err.Type == "InvalidNonce"
+}
+
 func (this *Exchange) ConvertToBigInt(data interface{}) interface{} {
 	return ParseInt(data)
 }
@@ -778,39 +929,11 @@ func (this *Exchange) ParseTimeframe(timeframe interface{}) interface{} {
 	if !ok {
 		return nil
 	}
-
-	if len(str) < 2 {
-		return nil
-	}
-
-	amount, err := strconv.Atoi(str[:len(str)-1])
+	seconds, err := ParseTimeframeChecked(str)
 	if err != nil {
 		return nil
 	}
-
-	unit := str[len(str)-1:]
-	scale := 0
-	switch unit {
-	case "y":
-		scale = 60 * 60 * 24 * 365
-	case "M":
-		scale = 60 * 60 * 24 * 30
-	case "w":
-		scale = 60 * 60 * 24 * 7
-	case "d":
-		scale = 60 * 60 * 24
-	case "h":
-		scale = 60 * 60
-	case "m":
-		scale = 60
-	case "s":
-		scale = 1
-	default:
-		return nil
-	}
-
-	result := amount * scale
-	return result
+	return seconds
 }
 
 func Totp(secret interface{}) string {
@@ -851,9 +974,12 @@ func (this *Exchange) transformApiNew(api Dict, paths ...string) {
 
 			for _, endpoint := range endpoints {
 				cost := 1.0
+				// the rest of the config (byLimit, noSymbol, ...) is for CalculateRateLimiterCost
+				var endpointConfig map[string]interface{}
 				if dictValue, ok := value.(map[string]interface{}); ok {
 					if config, ok := dictValue[endpoint]; ok {
 						if dictConfig, ok := config.(map[string]interface{}); ok {
+							endpointConfig = dictConfig
 							if rl, success := dictConfig["cost"]; success {
 								if rlFloat, ok := rl.(float64); ok {
 									cost = rlFloat
@@ -896,6 +1022,7 @@ func (this *Exchange) transformApiNew(api Dict, paths ...string) {
 					"path":   endpoint,
 					"api":    apiObj,
 					"cost":   cost,
+					"config": endpointConfig,
 				}
 			}
 		} else {
@@ -1031,6 +1158,7 @@ func (this *Exchange) callInternal(name2 string, args ...interface{}) <-chan int
 			}
 		}()
 
+		this.GuardCapability(name2)
 		this.WarmUpCache()
 
 		res := <-CallInternalMethod(&this.methodCache, this.Itf, name2, args...)
@@ -1125,16 +1253,43 @@ func (this *Exchange) StringToCharsArray(value interface{}) []string {
 	return chars
 }
 
+// GetMarket returns the loaded market of a unified symbol or of an exchange id (BTCUSDT), an empty MarketInterface
+// when there is no such market. It panics when the markets are not loaded, TryGetMarket returns both as errors
 func (this *Exchange) GetMarket(symbol string) MarketInterface {
 	if this.Markets == nil {
 		panic("Markets not loaded, please call LoadMarkets() first")
 	}
-	// market := this.Markets[symbol]
+	market, _ := this.TryGetMarket(symbol)
+	return market
+}
+
+// TryGetMarket is GetMarket with an error instead of a panic or an empty market, an id shared by several
+// markets resolves like Market() does, through options.defaultType
+func (this *Exchange) TryGetMarket(symbol string) (MarketInterface, error) {
+	if this.Markets == nil {
+		return MarketInterface{}, ExchangeError(this.Id + " markets not loaded, please call LoadMarkets() first")
+	}
 	market, ok := this.Markets.Load(symbol)
+	if !ok && this.Markets_by_id != nil && IsTrue(InOp(this.Markets_by_id, symbol)) {
+		market, ok = this.DerivedExchange.Market(symbol), true
+	}
 	if !ok {
-		return NewMarketInterface(nil)
+		return MarketInterface{}, BadSymbol(this.Id + " does not have market symbol " + symbol)
+	}
+	return NewMarketInterface(market), nil
+}
+
+// MarketCurrencies returns the loaded base and quote currencies of a market, with their precision and networks
+// when the currencies were fetched by LoadMarkets
+func (this *Exchange) MarketCurrencies(symbol string) (Currency, Currency, error) {
+	market, err := this.TryGetMarket(symbol)
+	if err != nil {
+		return Currency{}, Currency{}, err
 	}
-	return NewMarketInterface(market)
+	if this.Currencies == nil || market.BaseCurrency == nil || market.QuoteCurrency == nil {
+		return Currency{}, Currency{}, ExchangeError(this.Id + " currencies not loaded, please call LoadMarkets() first")
+	}
+	return this.GetCurrency(*market.BaseCurrency), this.GetCurrency(*market.QuoteCurrency), nil
 }
 
 func (this *Exchange) GetMarketsList() []MarketInterface {
@@ -1440,6 +1595,9 @@ func (this *Exchange) RandNumber(size interface{}) int64 {
 }
 
 func (this *Exchange) UpdateProxySettings() {
+	if this.customHttpClient {
+		return
+	}
 	proxyUrl := this.CheckProxyUrlSettings(nil, nil, nil, nil)
 	proxies := this.CheckProxySettings(nil, "", nil, nil)
 	httProxy := this.SafeString(proxies, 0)
@@ -1515,8 +1673,9 @@ func (this *Exchange) Watch(args ...interface{}) <-chan interface{} {
 	}
 
 	client := this.Client(url)
-	// todo: calculate the backoff using the clients cache
-	backoffDelay := 0
+	if this.releaseSharedSubscription(client, subscription) {
+		return resolvedFuture(true)
+	}
 	//
 	//  watchOrderBook ---- future ----+---------------+----→ user
 	//                                 |               |
@@ -1551,12 +1710,15 @@ func (this *Exchange) Watch(args ...interface{}) <-chan interface{} {
 		}
 	}
 	client.SubscriptionsMu.Unlock()
+	if !isUnsubscription(subscription) {
+		client.AddWatcher(subscribeHash.(string), future, clientSubscription == nil)
+	}
 	// we intentionally do not use await here to avoid unhandled exceptions
 	// the policy is to make sure that 100% of promises are resolved or rejected
 	// either with a call to client.resolve or client.reject with
 	//  a proper exception class instance
 	client.ConnectMu.Lock()
-	connected, err := client.Connect(backoffDelay)
+	connected, err := this.ConnectClient(client)
 	client.ConnectMu.Unlock()
 	if err != nil {
 		client.SubscriptionsMu.Lock()
@@ -1596,6 +1758,8 @@ func (this *Exchange) Watch(args ...interface{}) <-chan interface{} {
 					client.SubscriptionsMu.Lock()
 					delete(client.Subscriptions, subscribeHash.(string))
 					client.SubscriptionsMu.Unlock()
+				} else if !isUnsubscription(subscription) {
+					client.RecordSubscription([]string{subscribeHash.(string)}, message)
 				}
 			}
 		}()
@@ -1610,6 +1774,7 @@ func (this *Exchange) OrderBook(optionalArgs ...interface{}) *WsOrderBook {
 	snapshot := GetArg(optionalArgs, 0, map[string]interface{}{})
 	depth := GetArg(optionalArgs, 1, math.MaxInt32)
 	orderBook := NewWsOrderBook(snapshot, depth)
+	orderBook.SetMaxDepth(this.orderBookMaxDepth())
 	return orderBook
 }
 
@@ -1618,6 +1783,7 @@ func (this *Exchange) IndexedOrderBook(optionalArgs ...interface{}) *IndexedOrde
 	snapshot := GetArg(optionalArgs, 0, map[string]interface{}{})
 	depth := GetArg(optionalArgs, 1, 9007199254740991)
 	orderBook := NewIndexedOrderBook(snapshot, depth)
+	orderBook.SetMaxDepth(this.orderBookMaxDepth())
 	return orderBook
 }
 
@@ -1625,9 +1791,20 @@ func (this *Exchange) CountedOrderBook(optionalArgs ...interface{}) *CountedOrde
 	snapshot := GetArg(optionalArgs, 0, map[string]interface{}{})
 	depth := GetArg(optionalArgs, 1, 9007199254740991)
 	orderBook := NewCountedOrderBook(snapshot, depth)
+	orderBook.SetMaxDepth(this.orderBookMaxDepth())
 	return orderBook
 }
 
+// orderBookMaxDepth reads options.watchOrderBook.maxDepth, the number of levels per side
+// the books maintained by watchOrderBook are trimmed to, 0 when the full book is kept
+func (this *Exchange) orderBookMaxDepth() int {
+	maxDepth := this.HandleOption("watchOrderBook", "maxDepth")
+	if maxDepth == nil {
+		return 0
+	}
+	return int(ParseInt(maxDepth))
+}
+
 // func (this *Exchange) setOwner(cli *WSClient) {
 // 	if this.DerivedExchange != nil {
 // 		cli.Owner = this.DerivedExchange.(*Exchange)
@@ -1701,15 +1878,50 @@ func (this *Exchange) HandleMessage(client interface{}, message interface{}) {
 func (this *Exchange) OnConnected(client interface{}, message interface{}) {
 	// for user hooks
 	// fmt.Println('Connected to', client.url)
+	if this.ReconnectPolicy != nil {
+		this.ReconnectPolicy.OnConnected(client.(ClientInterface).GetUrl())
+	}
 }
 
 func (this *Exchange) OnError(client interface{}, err interface{}) {
+	url := client.(ClientInterface).GetUrl()
+	// the clients closed by the user are not registered anymore
+	registered := false
 	this.WsClientsMu.Lock()
-	if c, ok := this.Clients[client.(ClientInterface).GetUrl()]; ok && c.(ClientInterface).GetError() != nil {
-		delete(this.Clients, client.(ClientInterface).GetUrl())
+	if c, ok := this.Clients[url]; ok && c.(ClientInterface).GetError() != nil {
+		registered = c.(*WSClient).Client == client
+		delete(this.Clients, url)
 	}
 	this.WsClientsMu.Unlock()
 	client.(ClientInterface).SetError(fmt.Errorf("%v", err))
+	if this.ReconnectPolicy != nil {
+		// closing the exchange is not a failed connection
+		if e, ok := err.(*Error); !ok || e.Type != "ExchangeClosedByUser" {
+			this.ReconnectPolicy.OnDisconnected(url)
+			// a connection that stopped answering the pings is dialed again in any case
+			if dropped, ok := client.(*Client); ok && registered && (this.ReconnectPolicy.AutoReconnect() || dropped.PongTimedOut) {
+				this.Resubscribe(dropped)
+			}
+		}
+	}
+}
+
+// ConnectClient connects the client applying the backoff of the exchange ReconnectPolicy,
+// once the policy gives up the terminal error is returned to the caller
+func (this *Exchange) ConnectClient(client *WSClient) (*Future, error) {
+	backoffDelay := 0
+	if this.ReconnectPolicy != nil && !client.StartedConnecting {
+		delay, err := this.ReconnectPolicy.NextDelay(client.Url)
+		if err != nil {
+			return nil, err
+		}
+		backoffDelay = delay
+	}
+	connected, err := client.Connect(backoffDelay)
+	if err != nil && this.ReconnectPolicy != nil {
+		this.ReconnectPolicy.OnDisconnected(client.Url)
+	}
+	return connected, err
 }
 
 func (this *Exchange) OnClose(client interface{}, err interface{}) {
@@ -1759,10 +1971,11 @@ func (this *Exchange) Client(url interface{}) *WSClient {
 	options := DeepExtend(
 		this.streaming,
 		map[string]interface{}{
-			"Log":      this.Log,
-			"Ping":     this.DerivedExchange.Ping,
-			"Verbose":  this.Verbose,
-			"Throttle": NewThrottler(this.TokenBucket),
+			"Log":           this.Log,
+			"Ping":          this.DerivedExchange.Ping,
+			"ClassifyError": this.DerivedExchange.ClassifyWsError,
+			"Verbose":       this.Verbose,
+			"Throttle":      NewThrottler(this.TokenBucket),
 			"Options": map[string]interface{}{
 				"Agent": finalAgent,
 			},
@@ -1770,6 +1983,9 @@ func (this *Exchange) Client(url interface{}) *WSClient {
 		},
 		wsOptions,
 	)
+	if pingConfig := this.WsPingConfig(); pingConfig != nil {
+		options["PingConfig"] = pingConfig
+	}
 	var proxyUrl string = this.getWsProxy()
 	client := NewWSClient(url.(string), this.DerivedExchange.HandleMessage, this.DerivedExchange.OnError, this.DerivedExchange.OnClose, this.DerivedExchange.OnConnected, proxyUrl, options)
 
@@ -1828,8 +2044,9 @@ func (this *Exchange) WatchMultiple(args ...interface{}) <-chan interface{} {
 	}
 
 	client := this.Client(url)
-	// todo: calculate the backoff using the clients cache
-	backoffDelay := 0
+	if this.releaseSharedSubscription(client, subscription) {
+		return resolvedFuture(true)
+	}
 	//
 	//  watchOrderBook ---- future ----+---------------+----→ user
 	//                                 |               |
@@ -1864,7 +2081,8 @@ func (this *Exchange) WatchMultiple(args ...interface{}) <-chan interface{} {
 
 		for _, subscribeHash := range subscribeHashesList {
 			if hashStr, ok := subscribeHash.(string); ok {
-				if _, exists := client.Subscriptions[hashStr]; !exists {
+				_, exists := client.Subscriptions[hashStr]
+				if !exists {
 					missingSubscriptions = append(missingSubscriptions, hashStr)
 					if subscription != nil {
 						client.Subscriptions[hashStr] = subscription
@@ -1872,6 +2090,9 @@ func (this *Exchange) WatchMultiple(args ...interface{}) <-chan interface{} {
 						client.Subscriptions[hashStr] = make(chan interface{})
 					}
 				}
+				if !isUnsubscription(subscription) && len(futures) > 0 {
+					client.AddWatcher(hashStr, futures[0], !exists)
+				}
 			}
 		}
 	}
@@ -1881,7 +2102,7 @@ func (this *Exchange) WatchMultiple(args ...interface{}) <-chan interface{} {
 	// either with a call to client.resolve or client.reject with
 	//  a proper exception class instance
 	client.ConnectMu.Lock()
-	connected, err := client.Connect(backoffDelay)
+	connected, err := this.ConnectClient(client)
 	client.ConnectMu.Unlock()
 	if err != nil {
 		future.Reject(err)
@@ -1926,6 +2147,8 @@ func (this *Exchange) WatchMultiple(args ...interface{}) <-chan interface{} {
 					}
 					client.SubscriptionsMu.Unlock()
 					future.Reject(err)
+				} else if !isUnsubscription(subscription) {
+					client.RecordSubscription(missingSubscriptions, message)
 				}
 			}
 		}()
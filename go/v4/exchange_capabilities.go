@@ -0,0 +1,27 @@
+package ccxt
+
+import "strings"
+
+// GuardCapability panics with NotSupported when methodName is a unified method that the exchange
+// flags as false in has, it is meant to run first thing in a method, before any dereference. Only the
+// dynamic dispatch (CallInternal) is guarded, the typed methods and the methods of the core exchanges
+// are called directly and do not run it
+func (this *Exchange) GuardCapability(methodName string) {
+	if err := this.checkCapability(methodName); err != nil {
+		panic(err)
+	}
+}
+
+// checkCapability returns a NotSupported error for the unified methods that are explicitly false in has,
+// the undefined ones (nil, the capability is not known) and the names that are not listed in has
+// (helpers, implicit api methods) are always allowed
+func (this *Exchange) checkCapability(methodName string) error {
+	if methodName == "" {
+		return nil
+	}
+	name := strings.ToLower(methodName[:1]) + methodName[1:]
+	if value, ok := this.Has[name].(bool); !ok || value {
+		return nil
+	}
+	return NotSupported(this.Id + " " + name + "() is not supported yet")
+}
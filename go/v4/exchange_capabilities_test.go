@@ -0,0 +1,97 @@
+package ccxt
+
+import "testing"
+
+// ---------------------------------------------------------------------------
+// GuardCapability: unsupported unified methods fail with NotSupported
+// ---------------------------------------------------------------------------
+
+// halfImplementedExchange overrides a method it does not flag in has and dereferences state it never set up
+type halfImplementedExchange struct {
+	*BinanceCore
+	leverages map[string]interface{}
+}
+
+func (this *halfImplementedExchange) FetchLeverages(optionalArgs ...interface{}) <-chan interface{} {
+	ch := make(chan interface{})
+	go func() interface{} {
+		defer close(ch)
+		defer ReturnPanicError(ch)
+		this.leverages["BTC/USDT"] = 1
+		ch <- this.leverages
+		return nil
+	}()
+	return ch
+}
+
+func newHalfImplementedExchange() *halfImplementedExchange {
+	core := NewBinanceCore()
+	core.Init(map[string]interface{}{})
+	exchange := &halfImplementedExchange{BinanceCore: core}
+	core.DerivedExchange = exchange
+	core.Itf = exchange
+	core.Has["fetchLeverages"] = false
+	return exchange
+}
+
+func assertNotSupported(t *testing.T, res interface{}, method string) {
+	t.Helper()
+	if !IsError(res) {
+		t.Fatalf("expected an error, got %v", res)
+	}
+	err, ok := CreateReturnError(res).(*Error)
+	if !ok || err.Type != "NotSupported" {
+		t.Fatalf("expected a NotSupported error, got %v", CreateReturnError(res))
+	}
+	if err.Message != "binance "+method+"() is not supported yet" {
+		t.Fatalf("expected the error to name the exchange and the method, got %q", err.Message)
+	}
+}
+
+func TestCallInternalUnsupportedMethodReturnsNotSupported(t *testing.T) {
+	exchange := newHalfImplementedExchange()
+	assertNotSupported(t, <-exchange.CallInternal("fetchLeverages", []interface{}{"BTC/USDT"}), "fetchLeverages")
+	assertNotSupported(t, <-exchange.CallInternal("FetchLeverages"), "fetchLeverages")
+
+	exchange.Has["fetchTicker"] = false
+	assertNotSupported(t, <-exchange.CallInternal("fetchTicker", "BTC/USDT"), "fetchTicker")
+}
+
+func TestCallInternalSupportedMethodsAreDispatched(t *testing.T) {
+	exchange := newHalfImplementedExchange()
+	// not a unified method, so it is not listed in has
+	if res := <-exchange.CallInternal("parseTimeframe", "1h"); !IsEqual(res, 3600) {
+		t.Fatalf("expected parseTimeframe to be dispatched, got %v", res)
+	}
+	// without the guard the half implemented method panics on the nil map
+	exchange.Has["fetchLeverages"] = true
+	res := <-exchange.CallInternal("fetchLeverages")
+	if !IsError(res) {
+		t.Fatalf("expected the half implemented method to fail, got %v", res)
+	}
+	if err := CreateReturnError(res).(*Error); err.Type == "NotSupported" {
+		t.Fatal("expected the supported method to be dispatched")
+	}
+}
+
+func TestGuardCapability(t *testing.T) {
+	exchange := newHalfImplementedExchange()
+	exchange.Has["fetchTicker"] = "emulated"
+	exchange.GuardCapability("fetchTicker")
+	exchange.GuardCapability("sign")
+	// an undefined capability is not known to be missing
+	exchange.Has["fetchTicker"] = nil
+	exchange.GuardCapability("fetchTicker")
+
+	ch := make(chan interface{}, 1)
+	func() {
+		defer ReturnPanicError(ch)
+		exchange.GuardCapability("fetchLeverages")
+	}()
+	assertNotSupported(t, <-ch, "fetchLeverages")
+}
+
+func TestBaseFetchTimeIsNotSupported(t *testing.T) {
+	exchange := &Exchange{Id: "binance"}
+	assertNotSupported(t, <-exchange.FetchTime(), "fetchTime")
+}
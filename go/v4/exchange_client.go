@@ -59,8 +59,14 @@ type Client struct {
 
 	Subscriptions   map[string]interface{} // map[string]chan interface{}
 	SubscriptionsMu sync.RWMutex
-	ConnectMu       sync.RWMutex // protects Connect calls
+	Watchers        map[string]*wsWatchers // reference counts of the shared subscriptions
+	WatchersMu      sync.Mutex
+	Resubscriptions map[string]*wsResubscription // subscribe messages sent again after a reconnect, guarded by SubscriptionsMu
+	ConnectMu       sync.RWMutex                 // protects Connect calls
 	ReadLoopClosed  chan struct{}
+	Streams         map[string][]*wsStream // buffered consumers of every update of a message hash, see Stream
+	StreamsMu       sync.RWMutex           // also guards closeReasons
+	closeReasons    map[string]error       // errors that ended the subscriptions, see SubscriptionError
 
 	Error error // last error, nil if connection considered healthy
 
@@ -71,21 +77,25 @@ type Client struct {
 	ConnectionTimeout     interface{}            // e.g. *time.Timer or context.CancelFunc
 	Verbose               bool                   // default false
 	DecompressBinary      bool
-	ConnectionTimer       interface{}                                   // e.g. *time.Timer or custom timer
-	LastPong              interface{}                                   // time or timestamp type recommended
-	MaxPingPongMisses     interface{}                                   // int or counter type
-	PingInterval          interface{}                                   // time.Duration recommended
-	ConnectionEstablished interface{}                                   // signal or state variable
-	Gunzip                interface{}                                   // gzip decompressor (e.g. io.Reader)
-	Inflate               interface{}                                   // zlib inflater or similar
-	URL                   string                                        // URL string
-	IsConnected           interface{}                                   // bool or state variable
-	OnConnectedCallback   func(client interface{}, message interface{}) // callback function signature - adjust as needed
-	OnMessageCallback     func(client interface{}, message interface{}) // example callback with message
-	OnErrorCallback       func(client interface{}, err interface{})     // error callback
-	OnCloseCallback       func(client interface{}, err interface{})     // connection closed callback
-	Ping                  interface{}                                   // e.g. timer or pong/ping state
-	Throttle              interface{}                                   // throttling mechanism (rate limiter, etc.)
+	ConnectionTimer       interface{}                                               // e.g. *time.Timer or custom timer
+	LastPong              interface{}                                               // time or timestamp type recommended
+	MaxPingPongMisses     interface{}                                               // int or counter type
+	PingInterval          interface{}                                               // time.Duration recommended
+	ConnectionEstablished interface{}                                               // signal or state variable
+	Gunzip                interface{}                                               // gzip decompressor (e.g. io.Reader)
+	Inflate               interface{}                                               // zlib inflater or similar
+	URL                   string                                                    // URL string
+	IsConnected           interface{}                                               // bool or state variable
+	OnConnectedCallback   func(client interface{}, message interface{})             // callback function signature - adjust as needed
+	OnMessageCallback     func(client interface{}, message interface{})             // example callback with message
+	OnErrorCallback       func(client interface{}, err interface{})                 // error callback
+	OnCloseCallback       func(client interface{}, err interface{})                 // connection closed callback
+	ClassifyError         func(client interface{}, message interface{}) interface{} // typed error of an error frame, see ClassifyWsError
+	Ping                  interface{}                                               // e.g. timer or pong/ping state
+	PingConfig            *PingConfig                                               // replaces KeepAlive and Ping when set
+	PongTimedOut          bool                                                      // the keepalive gave up waiting for a pong
+	Throttle              interface{}                                               // throttling mechanism (rate limiter, etc.)
+	metrics               wsMetrics                                                 // dispatch counters, see Metrics
 	// Owner interface{} 											// pointer to the exchange that created the client
 }
 
@@ -97,12 +107,15 @@ func (this *Client) Resolve(data interface{}, subHash interface{}) interface{} {
 
 	// Send to Future channel for ongoing updates (non-blocking)
 	this.FuturesMu.Lock()
-	if fut, exists := this.Futures[hash]; exists {
+	fut, exists := this.Futures[hash]
+	if exists {
 		// Print("Inside resolve, existed future for hash: " + hash)
 		fut.(*Future).Resolve(data)
 		delete(this.Futures, hash)
 	}
 	this.FuturesMu.Unlock()
+	this.metrics.delivery(hash, exists)
+	this.publish(hash, data)
 	return data
 }
 
@@ -124,6 +137,7 @@ func (this *Client) NewFuture(messageHash interface{}) *Future {
 	}
 	future := this.Futures[hash]
 	this.FuturesMu.Unlock()
+	this.clearCloseReason(hash)
 	if err, ok := this.Rejections[hash]; ok {
 		future.(*Future).Reject(err.(error))
 		delete(this.Rejections, hash)
@@ -131,15 +145,19 @@ func (this *Client) NewFuture(messageHash interface{}) *Future {
 	return future.(*Future)
 }
 
-// Reject rejects specific future or all
+// Reject rejects specific future or all, the streams of the rejected message hashes end with err
 func (this *Client) Reject(err interface{}, messageHash ...interface{}) {
+	reason, _ := err.(error)
 	this.FuturesMu.Lock()
 	if len(messageHash) == 0 {
+		hashes := make([]string, 0, len(this.Futures))
 		for hash := range this.Futures {
 			this.Futures[hash].(*Future).Reject(err.(error))
 			delete(this.Futures, hash)
+			hashes = append(hashes, hash)
 		}
 		this.FuturesMu.Unlock()
+		this.endSubscriptions(reason, hashes, true)
 		return
 	}
 	hash := messageHash[0]
@@ -148,6 +166,7 @@ func (this *Client) Reject(err interface{}, messageHash ...interface{}) {
 		delete(this.Futures, hash.(string))
 	}
 	this.FuturesMu.Unlock()
+	this.endSubscriptions(reason, []string{hash.(string)}, false)
 }
 
 // Close terminates the underlying websocket connection (if any)
@@ -229,6 +248,14 @@ func NewClient(url string, onMessageCallback func(client interface{}, err interf
 		ConnectionTimer:     finalConfig["ConnectionTimer"],
 		PingInterval:        finalConfig["PingInterval"],
 		Ping:                finalConfig["Ping"],
+		ClassifyError: func() func(client interface{}, message interface{}) interface{} {
+			classifyError, _ := finalConfig["ClassifyError"].(func(client interface{}, message interface{}) interface{})
+			return classifyError
+		}(),
+		PingConfig: func() *PingConfig {
+			pingConfig, _ := finalConfig["PingConfig"].(*PingConfig)
+			return pingConfig
+		}(),
 		Connection: func() *websocket.Conn {
 			if finalConfig["connection"] != nil {
 				if conn, ok := finalConfig["Connection"].(*websocket.Conn); ok {
@@ -316,7 +343,7 @@ func (this *Client) Reset(err interface{}) {
 	_ = this.Close()
 	// Reject all pending futures with provided error (or generic)
 	if err == nil {
-		err = "connection reset"
+		err = NetworkError("connection reset")
 	}
 	this.Reject(err)
 }
@@ -498,8 +525,10 @@ func (this *Client) OnMessage(messageEvent interface{}) {
 	var messageIsBinary = false
 	var messageBytes []byte
 	if str, ok := message.(string); ok {
+		this.metrics.frame(len(str))
 		messageStr = str
 	} else if bytes, ok := message.([]byte); ok {
+		this.metrics.frame(len(bytes))
 		// Handle binary data
 		if this.Gunzip != nil && this.Gunzip.(bool) {
 			// Would need to implement gzip decompression
@@ -520,6 +549,7 @@ func (this *Client) OnMessage(messageEvent interface{}) {
 		}
 	} else {
 		messageStr = fmt.Sprintf("%v", message)
+		this.metrics.frame(len(messageStr))
 	}
 
 	// Try to parse as JSON
@@ -531,6 +561,7 @@ func (this *Client) OnMessage(messageEvent interface{}) {
 
 		parsedMessage = ParseJSON(messageStr)
 		if parsedMessage == nil {
+			this.metrics.parseError()
 			if this.Verbose {
 				this.Log(time.Now(), "onMessage JSON.parse", "failed to parse message")
 			}
@@ -552,6 +583,9 @@ func (this *Client) OnMessage(messageEvent interface{}) {
 	if messageIsBinary && !this.DecompressBinary {
 		this.OnMessageCallback(this, messageBytes)
 	} else if parsedMessage != nil {
+		if this.classifyMessage(parsedMessage) {
+			return
+		}
 		this.OnMessageCallback(this, parsedMessage)
 	} else {
 		this.OnMessageCallback(this, messageStr)
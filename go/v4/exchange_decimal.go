@@ -0,0 +1,129 @@
+package ccxt
+
+import (
+	"math/big"
+	"strings"
+)
+
+// Decimal is a string-backed decimal number. Arithmetic goes through Precise and rounding through
+// big.Rat, so amounts and prices never pick up the float64 noise of values like 0.1 + 0.2
+type Decimal struct {
+	value string
+}
+
+// NewDecimal converts a number or a numeric string, floats use their shortest representation
+func NewDecimal(value interface{}) Decimal {
+	switch v := value.(type) {
+	case Decimal:
+		return v
+	case string:
+		return Decimal{value: v}
+	}
+	str := NumberToString(value)
+	if str == "" {
+		str = "0"
+	}
+	return Decimal{value: str}
+}
+
+func (d Decimal) String() string {
+	if d.value == "" {
+		return "0"
+	}
+	return d.value
+}
+
+func (d Decimal) Float64() float64 {
+	return ToFloat64(d.String())
+}
+
+func (d Decimal) rat() (*big.Rat, bool) {
+	return new(big.Rat).SetString(d.String())
+}
+
+// AddDecimal returns a + b without going through float64
+func AddDecimal(a interface{}, b interface{}) Decimal {
+	return Decimal{value: StringAdd(NewDecimal(a).String(), NewDecimal(b).String())}
+}
+
+// SubDecimal returns a - b without going through float64
+func SubDecimal(a interface{}, b interface{}) Decimal {
+	return Decimal{value: StringSub(NewDecimal(a).String(), NewDecimal(b).String())}
+}
+
+// MulDecimal returns a * b without going through float64
+func MulDecimal(a interface{}, b interface{}) Decimal {
+	return Decimal{value: StringMul(NewDecimal(a).String(), NewDecimal(b).String())}
+}
+
+// ToPrecision rounds d with the ROUND or TRUNCATE mode to a number of decimal places or to a tick size,
+// ok is false for the modes it does not cover (significant digits, negative decimal places)
+func (d Decimal) ToPrecision(roundingMode int, precision interface{}, countingMode int, paddingMode int) (string, bool) {
+	if roundingMode != ROUND && roundingMode != TRUNCATE {
+		return "", false
+	}
+	x, ok := d.rat()
+	if !ok {
+		return "", false
+	}
+	var step *big.Rat
+	var decimals int
+	switch countingMode {
+	case DECIMAL_PLACES:
+		decimals = int(ParseInt(precision))
+		if decimals < 0 {
+			return "", false
+		}
+		step = new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
+	case TICK_SIZE:
+		tick := NewDecimal(precision)
+		step, ok = tick.rat()
+		if !ok || step.Sign() <= 0 {
+			return "", false
+		}
+		decimals = decimalPlacesOf(NewPrecise(tick.String()).String())
+	default:
+		return "", false
+	}
+	// number of whole steps, truncated towards zero
+	quotient := new(big.Rat).Quo(x, step)
+	steps, remainder := new(big.Int).QuoRem(quotient.Num(), quotient.Denom(), new(big.Int))
+	if roundingMode == ROUND {
+		// round half away from zero, the remainder is compared against half of the denominator
+		twice := new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2))
+		if twice.Cmp(quotient.Denom()) >= 0 {
+			steps.Add(steps, big.NewInt(int64(x.Sign())))
+		}
+	}
+	result := new(big.Rat).Mul(new(big.Rat).SetInt(steps), step).FloatString(decimals)
+	if paddingMode != PAD_WITH_ZERO && strings.Contains(result, ".") {
+		result = strings.TrimRight(strings.TrimRight(result, "0"), ".")
+	}
+	if result == "-0" {
+		result = "0"
+	}
+	return result, true
+}
+
+func decimalPlacesOf(str string) int {
+	index := strings.Index(str, ".")
+	if index < 0 {
+		return 0
+	}
+	return len(str) - index - 1
+}
+
+// decimalToPrecisionExact rounds on the decimal representation of value instead of its float64,
+// ok is false for the modes Decimal.ToPrecision does not cover, DecimalToPrecision handles them on floats
+func decimalToPrecisionExact(value interface{}, roundingMode interface{}, precision interface{}, countingMode interface{}, paddingMode interface{}) (string, bool) {
+	if precision == nil || value == nil {
+		return "", false
+	}
+	if countingMode == nil {
+		countingMode = DECIMAL_PLACES
+	}
+	if paddingMode == nil {
+		paddingMode = NO_PADDING
+	}
+	return NewDecimal(value).ToPrecision(int(ParseInt(roundingMode)), precision, int(ParseInt(countingMode)), int(ParseInt(paddingMode)))
+}
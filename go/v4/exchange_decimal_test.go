@@ -0,0 +1,192 @@
+package ccxt
+
+import (
+	"strings"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// Decimal: string-backed arithmetic and the rounding used by priceToPrecision
+// ---------------------------------------------------------------------------
+
+func TestDecimalArithmetic(t *testing.T) {
+	if sum := AddDecimal(0.1, 0.2).String(); sum != "0.3" {
+		t.Fatalf("expected 0.1 + 0.2 to be 0.3, got %s", sum)
+	}
+	if diff := SubDecimal("0.3", 0.1).String(); diff != "0.2" {
+		t.Fatalf("expected 0.3 - 0.1 to be 0.2, got %s", diff)
+	}
+	if product := MulDecimal(1.1, "3").String(); product != "3.3" {
+		t.Fatalf("expected 1.1 * 3 to be 3.3, got %s", product)
+	}
+	if value := NewDecimal(1e-8).String(); value != "0.00000001" {
+		t.Fatalf("expected small floats without an exponent, got %s", value)
+	}
+}
+
+func TestDecimalToPrecision(t *testing.T) {
+	cases := []struct {
+		value        interface{}
+		roundingMode int
+		precision    interface{}
+		countingMode int
+		paddingMode  int
+		expected     string
+	}{
+		{0.1 + 0.2, ROUND, 2, DECIMAL_PLACES, NO_PADDING, "0.3"},
+		{"1.005", ROUND, 2, DECIMAL_PLACES, NO_PADDING, "1.01"},
+		{"-1.005", ROUND, 2, DECIMAL_PLACES, NO_PADDING, "-1.01"},
+		{"1.009", TRUNCATE, 2, DECIMAL_PLACES, NO_PADDING, "1"},
+		{"1.5", ROUND, 0, DECIMAL_PLACES, NO_PADDING, "2"},
+		{"0.3", ROUND, 2, DECIMAL_PLACES, PAD_WITH_ZERO, "0.30"},
+		{0.1 + 0.2, ROUND, 0.01, TICK_SIZE, NO_PADDING, "0.3"},
+		{"1.005", ROUND, "0.01", TICK_SIZE, NO_PADDING, "1.01"},
+		{"1.07", ROUND, 0.05, TICK_SIZE, NO_PADDING, "1.05"},
+		{"1.08", ROUND, 0.05, TICK_SIZE, NO_PADDING, "1.1"},
+		{"1.08", TRUNCATE, 0.05, TICK_SIZE, NO_PADDING, "1.05"},
+		{"1.1", ROUND, 0.05, TICK_SIZE, PAD_WITH_ZERO, "1.10"},
+		{"0.004", ROUND, 0.01, TICK_SIZE, NO_PADDING, "0"},
+	}
+	for _, c := range cases {
+		result, ok := NewDecimal(c.value).ToPrecision(c.roundingMode, c.precision, c.countingMode, c.paddingMode)
+		if !ok || result != c.expected {
+			t.Errorf("%v to precision %v (rounding %d, counting %d): expected %s, got %s", c.value, c.precision, c.roundingMode, c.countingMode, c.expected, result)
+		}
+	}
+	if _, ok := NewDecimal("123.456").ToPrecision(ROUND, 2, SIGNIFICANT_DIGITS, NO_PADDING); ok {
+		t.Fatal("expected significant digits to be left to DecimalToPrecision")
+	}
+}
+
+func TestPriceToPrecisionAvoidsFloatNoise(t *testing.T) {
+	exchange, _ := newMockedBinance(`{}`)
+	market := exchange.Market("BTC/USDT:USDT")
+	AddElementToObject(GetValue(market, "precision"), "price", 0.01)
+	if price := exchange.PriceToPrecision("BTC/USDT:USDT", 0.1+0.2); price != "0.3" {
+		t.Fatalf("expected 0.1 + 0.2 to round to 0.3 with a 0.01 tick, got %v", price)
+	}
+	if price := exchange.PriceToPrecision("BTC/USDT:USDT", 1.005); price != "1.01" {
+		t.Fatalf("expected 1.005 to round half up to 1.01, got %v", price)
+	}
+	exchange.PrecisionMode = DECIMAL_PLACES
+	AddElementToObject(GetValue(market, "precision"), "price", 2)
+	if price := exchange.PriceToPrecision("BTC/USDT:USDT", 0.1+0.2); price != "0.3" {
+		t.Fatalf("expected 0.1 + 0.2 to round to 0.3 on a 2-decimal market, got %v", price)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// amountToPrecision/priceToPrecision: configurable ROUND and TRUNCATE on a tick-size market
+// ---------------------------------------------------------------------------
+
+func newPrecisionTestBinance(config map[string]interface{}) *BinanceCore {
+	exchange := NewBinanceCore()
+	exchange.Init(config)
+	exchange.SetMarkets([]interface{}{
+		exchange.SafeMarketStructure(map[string]interface{}{
+			"id":        "BTCUSDT",
+			"symbol":    "BTC/USDT",
+			"base":      "BTC",
+			"quote":     "USDT",
+			"baseId":    "BTC",
+			"quoteId":   "USDT",
+			"type":      "spot",
+			"spot":      true,
+			"active":    true,
+			"precision": map[string]interface{}{"amount": 0.00001, "price": 0.01},
+		}),
+	})
+	return exchange
+}
+
+func TestAmountAndPriceToPrecisionModes(t *testing.T) {
+	// defaults: amounts are truncated and prices rounded to the tick size
+	exchange := newPrecisionTestBinance(map[string]interface{}{})
+	if amount := exchange.AmountToPrecision("BTC/USDT", 0.123456789); amount != "0.12345" {
+		t.Fatalf("expected the amount truncated to 0.12345, got %v", amount)
+	}
+	if price := exchange.PriceToPrecision("BTC/USDT", 27123.456); price != "27123.46" {
+		t.Fatalf("expected the price rounded to 27123.46, got %v", price)
+	}
+	// venues that round amounts and truncate prices
+	exchange = newPrecisionTestBinance(map[string]interface{}{
+		"options": map[string]interface{}{"amountRoundingMode": ROUND, "priceRoundingMode": "truncate"},
+	})
+	if amount := exchange.AmountToPrecision("BTC/USDT", 0.123456789); amount != "0.12346" {
+		t.Fatalf("expected the amount rounded to 0.12346, got %v", amount)
+	}
+	if price := exchange.PriceToPrecision("BTC/USDT", 27123.456); price != "27123.45" {
+		t.Fatalf("expected the price truncated to 27123.45, got %v", price)
+	}
+	// precision as decimal places with zero padding
+	exchange = newPrecisionTestBinance(map[string]interface{}{"precisionMode": DECIMAL_PLACES, "paddingMode": PAD_WITH_ZERO})
+	market := exchange.Market("BTC/USDT")
+	AddElementToObject(market, "precision", map[string]interface{}{"amount": 5, "price": 2})
+	if amount := exchange.AmountToPrecision("BTC/USDT", 0.1); amount != "0.10000" {
+		t.Fatalf("expected the amount padded to 0.10000, got %v", amount)
+	}
+	if price := exchange.PriceToPrecision("BTC/USDT", 27123.456); price != "27123.46" {
+		t.Fatalf("expected the price rounded to 27123.46, got %v", price)
+	}
+}
+
+func TestPrecisionRoundingModeRejectsUnknownModes(t *testing.T) {
+	for _, mode := range []interface{}{"ROUND_UP", 5} {
+		exchange := newPrecisionTestBinance(map[string]interface{}{
+			"options": map[string]interface{}{"amountRoundingMode": mode},
+		})
+		func() {
+			defer func() {
+				err, _ := recover().(error)
+				if err == nil || !IsErrorType(err, "BadRequest") || !strings.Contains(err.Error(), "got "+ToString(mode)) {
+					t.Fatalf("expected a BadRequest naming the unsupported rounding mode %v, got %v", mode, err)
+				}
+			}()
+			exchange.AmountToPrecision("BTC/USDT", 0.1)
+		}()
+	}
+}
+
+// ---------------------------------------------------------------------------
+// costToPrecision/currencyToPrecision: precision of the loaded market and currency
+// ---------------------------------------------------------------------------
+
+func TestCostAndCurrencyToPrecision(t *testing.T) {
+	exchange := newPrecisionTestBinance(map[string]interface{}{"precisionMode": DECIMAL_PLACES})
+	market := exchange.Market("BTC/USDT")
+	AddElementToObject(market, "precision", map[string]interface{}{"amount": 5, "price": 2})
+	currencies := map[string]interface{}{
+		"USDT": exchange.SafeCurrencyStructure(map[string]interface{}{
+			"id":        "USDT",
+			"code":      "USDT",
+			"precision": 2,
+			"networks": map[string]interface{}{
+				"TRC20": map[string]interface{}{"id": "TRX", "network": "TRC20", "precision": 1},
+			},
+		}),
+	}
+	exchange.SetMarkets(ObjectValues(exchange.Markets), currencies)
+	// costs are truncated to the price precision unless the market has a cost precision
+	if cost := exchange.CostToPrecision("BTC/USDT", 0.1+0.2+0.009); cost != "0.3" {
+		t.Fatalf("expected the cost truncated to 0.3, got %v", cost)
+	}
+	AddElementToObject(GetValue(exchange.Market("BTC/USDT"), "precision"), "cost", 3)
+	if cost := exchange.CostToPrecision("BTC/USDT", 0.1+0.2+0.009); cost != "0.309" {
+		t.Fatalf("expected the cost truncated to the cost precision, got %v", cost)
+	}
+	// binance truncates currency amounts, the network precision wins over the currency one
+	if amount := exchange.CurrencyToPrecision("USDT", 12.3456); amount != "12.34" {
+		t.Fatalf("expected the USDT amount truncated to 12.34, got %v", amount)
+	}
+	if amount := exchange.CurrencyToPrecision("USDT", 12.3456, "TRC20"); amount != "12.3" {
+		t.Fatalf("expected the TRC20 amount truncated to 12.3, got %v", amount)
+	}
+	AddElementToObject(exchange.Options, "currencyToPrecisionRoundingMode", "ROUND")
+	if amount := exchange.CurrencyToPrecision("USDT", 12.3456); amount != "12.35" {
+		t.Fatalf("expected the USDT amount rounded to 12.35, got %v", amount)
+	}
+	// currencies without a precision are returned as they are
+	if amount := exchange.CurrencyToPrecision("ETH", 0.123456789); amount != "0.123456789" {
+		t.Fatalf("expected an unknown currency to be left as is, got %v", amount)
+	}
+}
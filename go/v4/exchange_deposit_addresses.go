@@ -0,0 +1,24 @@
+package ccxt
+
+// FetchDepositAddressesIndexedByNetwork fetches the deposit addresses of every network of code
+// and returns them indexed by network code, as fetchDepositAddressesByNetwork does. The typed
+// FetchDepositAddressesByNetwork of the exchanges returns the same addresses as a list sorted by
+// network.
+func (this *Exchange) FetchDepositAddressesIndexedByNetwork(code string, options ...FetchDepositAddressesByNetworkOptions) (map[string]DepositAddress, error) {
+
+	opts := FetchDepositAddressesByNetworkOptionsStruct{}
+
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	var params interface{} = nil
+	if opts.Params != nil {
+		params = *opts.Params
+	}
+	res := <-this.DerivedExchange.FetchDepositAddressesByNetwork(code, params)
+	if IsError(res) {
+		return nil, CreateReturnError(res)
+	}
+	return NewDepositAddressesByNetwork(res), nil
+}
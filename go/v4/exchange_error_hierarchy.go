@@ -0,0 +1,73 @@
+package ccxt
+
+import "errors"
+
+// errorParents maps every error type to the type it derives from, the roots ExchangeError,
+// OperationFailed and UnsubscribeError have no parent
+var errorParents = map[ErrorType]ErrorType{
+	AuthenticationErrorErrType:      ExchangeErrorErrType,
+	PermissionDeniedErrType:         AuthenticationErrorErrType,
+	AccountNotEnabledErrType:        PermissionDeniedErrType,
+	AccountSuspendedErrType:         AuthenticationErrorErrType,
+	ArgumentsRequiredErrType:        ExchangeErrorErrType,
+	BadRequestErrType:               ExchangeErrorErrType,
+	BadSymbolErrType:                BadRequestErrType,
+	OperationRejectedErrType:        ExchangeErrorErrType,
+	NoChangeErrType:                 OperationRejectedErrType,
+	MarginModeAlreadySetErrType:     NoChangeErrType,
+	MarketClosedErrType:             OperationRejectedErrType,
+	ManualInteractionNeededErrType:  OperationRejectedErrType,
+	RestrictedLocationErrType:       OperationRejectedErrType,
+	InsufficientFundsErrType:        ExchangeErrorErrType,
+	InvalidAddressErrType:           ExchangeErrorErrType,
+	AddressPendingErrType:           InvalidAddressErrType,
+	InvalidOrderErrType:             ExchangeErrorErrType,
+	OrderNotFoundErrType:            InvalidOrderErrType,
+	OrderNotCachedErrType:           InvalidOrderErrType,
+	OrderImmediatelyFillableErrType: InvalidOrderErrType,
+	OrderNotFillableErrType:         InvalidOrderErrType,
+	DuplicateOrderIdErrType:         InvalidOrderErrType,
+	ContractUnavailableErrType:      InvalidOrderErrType,
+	NotSupportedErrType:             ExchangeErrorErrType,
+	InvalidProxySettingsErrType:     ExchangeErrorErrType,
+	ExchangeClosedByUserErrType:     ExchangeErrorErrType,
+	NetworkErrorErrType:             OperationFailedErrType,
+	DDoSProtectionErrType:           NetworkErrorErrType,
+	RateLimitExceededErrType:        NetworkErrorErrType,
+	ExchangeNotAvailableErrType:     NetworkErrorErrType,
+	OnMaintenanceErrType:            ExchangeNotAvailableErrType,
+	InvalidNonceErrType:             NetworkErrorErrType,
+	ChecksumErrorErrType:            InvalidNonceErrType,
+	RequestTimeoutErrType:           NetworkErrorErrType,
+	BadResponseErrType:              OperationFailedErrType,
+	NullResponseErrType:             BadResponseErrType,
+	CancelPendingErrType:            OperationFailedErrType,
+}
+
+// IsSubtypeOf reports whether errType is parent or derives from it, e.g. OrderNotFound is an InvalidOrder
+func (errType ErrorType) IsSubtypeOf(parent ErrorType) bool {
+	for current := errType; current != ""; current = errorParents[current] {
+		if current == parent {
+			return true
+		}
+	}
+	return false
+}
+
+// Is lets errors.Is match an error against the hierarchy, a target built by any of the
+// error constructors matches its own type and every type deriving from it:
+//
+//	errors.Is(err, ccxt.InvalidOrder()) // true for an OrderNotFound
+func (e *Error) Is(target error) bool {
+	var other *Error
+	if !errors.As(target, &other) {
+		return false
+	}
+	return e.Type.IsSubtypeOf(other.Type)
+}
+
+// IsErrorType reports whether err, or an error it wraps, is a ccxt error of errType or of a type deriving from it
+func IsErrorType(err error, errType ErrorType) bool {
+	var e *Error
+	return errors.As(err, &e) && e.Type.IsSubtypeOf(errType)
+}
@@ -0,0 +1,37 @@
+package ccxt
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// error hierarchy: errors.Is matches an error against its own type and every type it derives from
+// ---------------------------------------------------------------------------
+
+func TestErrorHierarchy(t *testing.T) {
+	err := OrderNotFound("binance order 1 does not exist")
+	for _, parent := range []error{OrderNotFound(), InvalidOrder(), ExchangeError()} {
+		if !errors.Is(err, parent) {
+			t.Fatalf("expected an OrderNotFound to be a %s", parent.(*Error).Type)
+		}
+	}
+	for _, other := range []error{InsufficientFunds(), OperationFailed(), DuplicateOrderId()} {
+		if errors.Is(err, other) {
+			t.Fatalf("expected an OrderNotFound not to be a %s", other.(*Error).Type)
+		}
+	}
+	if !OnMaintenanceErrType.IsSubtypeOf(OperationFailedErrType) || RateLimitExceededErrType.IsSubtypeOf(ExchangeErrorErrType) {
+		t.Fatal("expected the network errors to derive from OperationFailed only")
+	}
+
+	// the errors keep matching once wrapped
+	wrapped := fmt.Errorf("createOrder: %w", RateLimitExceeded("slow down"))
+	if !errors.Is(wrapped, NetworkError()) || !IsErrorType(wrapped, RateLimitExceededErrType) || IsErrorType(wrapped, DDoSProtectionErrType) {
+		t.Fatalf("expected the wrapped error to be matched by its type, got %v", wrapped)
+	}
+	if IsErrorType(errors.New("plain"), ExchangeErrorErrType) {
+		t.Fatal("expected a plain error not to be a ccxt error")
+	}
+}
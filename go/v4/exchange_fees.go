@@ -0,0 +1,64 @@
+package ccxt
+
+/**
+ * @method
+ * @name exchange#fetchTransactionFeesFromCurrencies
+ * @description derives the withdrawal fees from the networks of the loaded currencies, a fallback for exchanges without a dedicated fee endpoint that sends no request once the markets are loaded
+ * @param {string[]|undefined} codes list of unified currency codes, all the loaded currencies by default
+ * @returns {object} a dictionary with the withdraw fee of each currency, the fee of each of its networks is available in networks[code][network]
+ */
+func (this *Exchange) FetchTransactionFeesFromCurrencies(codes []string) <-chan interface{} {
+	ch := make(chan interface{})
+	go func() interface{} {
+		defer close(ch)
+		defer ReturnPanicError(ch)
+
+		retRes10 := (<-this.LoadMarkets())
+		PanicOnError(retRes10)
+		var selected interface{} = ObjectKeys(this.Currencies)
+		if codes != nil {
+			selected = codes
+		}
+		var withdrawFees interface{} = map[string]interface{}{}
+		var networkFees interface{} = map[string]interface{}{}
+		var info interface{} = map[string]interface{}{}
+		for i := 0; IsLessThan(i, GetArrayLength(selected)); i++ {
+			var code interface{} = GetValue(selected, i)
+			var currency interface{} = this.SafeDict(this.Currencies, code)
+			if IsTrue(IsEqual(currency, nil)) {
+				continue
+			}
+			var networks interface{} = this.SafeDict(currency, "networks", map[string]interface{}{})
+			var networkCodes interface{} = ObjectKeys(networks)
+			var feesByNetwork interface{} = map[string]interface{}{}
+			for j := 0; IsLessThan(j, GetArrayLength(networkCodes)); j++ {
+				var networkCode interface{} = GetValue(networkCodes, j)
+				var networkFee interface{} = this.SafeNumber(GetValue(networks, networkCode), "fee")
+				if IsTrue(!IsEqual(networkFee, nil)) {
+					AddElementToObject(feesByNetwork, networkCode, networkFee)
+				}
+			}
+			var fee interface{} = this.SafeNumber(currency, "fee")
+			if IsTrue(IsTrue(IsEqual(fee, nil)) && IsTrue(IsEqual(GetArrayLength(networkCodes), 1))) {
+				// a single network is the default one
+				fee = this.SafeNumber(feesByNetwork, GetValue(networkCodes, 0))
+			}
+			if IsTrue(IsTrue(IsEqual(fee, nil)) && IsTrue(IsEqual(GetArrayLength(ObjectKeys(feesByNetwork)), 0))) {
+				continue
+			}
+			AddElementToObject(withdrawFees, code, fee)
+			AddElementToObject(networkFees, code, feesByNetwork)
+			AddElementToObject(info, code, networks)
+		}
+
+		ch <- map[string]interface{}{
+			"info":     info,
+			"withdraw": withdrawFees,
+			"deposit":  map[string]interface{}{},
+			"networks": networkFees,
+		}
+		return nil
+
+	}()
+	return ch
+}
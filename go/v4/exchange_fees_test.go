@@ -0,0 +1,65 @@
+package ccxt
+
+import (
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// FetchTransactionFeesFromCurrencies: the withdrawal fees come from the networks of the loaded currencies
+// ---------------------------------------------------------------------------
+
+func TestFetchTransactionFeesFromCurrencies(t *testing.T) {
+	exchange, transport := newMockedBinance(`{}`)
+	network := func(code string, fee interface{}) interface{} {
+		return map[string]interface{}{"id": code, "network": code, "fee": fee, "withdraw": true, "deposit": true}
+	}
+	currencies := map[string]interface{}{
+		"USDT": exchange.SafeCurrencyStructure(map[string]interface{}{
+			"id":   "USDT",
+			"code": "USDT",
+			"networks": map[string]interface{}{
+				"ERC20": network("ERC20", 4.5),
+				"TRC20": network("TRC20", 1.0),
+				"SOL":   network("SOL", nil),
+			},
+		}),
+		"BTC": exchange.SafeCurrencyStructure(map[string]interface{}{
+			"id":   "BTC",
+			"code": "BTC",
+			"networks": map[string]interface{}{
+				"BTC": network("BTC", 0.0002),
+			},
+		}),
+		"EUR": exchange.SafeCurrencyStructure(map[string]interface{}{
+			"id":       "EUR",
+			"code":     "EUR",
+			"type":     "fiat",
+			"networks": map[string]interface{}{},
+		}),
+	}
+	exchange.SetMarkets(ObjectValues(exchange.Markets), currencies)
+
+	result := <-exchange.FetchTransactionFeesFromCurrencies(nil)
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if len(transport.requests) != 0 {
+		t.Fatalf("expected no request, got %d", len(transport.requests))
+	}
+	withdraw := GetValue(result, "withdraw").(map[string]interface{})
+	if len(withdraw) != 2 || withdraw["BTC"] != 0.0002 || withdraw["USDT"] != 1.0 {
+		t.Fatalf("expected the currency fees without the fiat currency, got %v", withdraw)
+	}
+	usdt := GetValue(GetValue(result, "networks"), "USDT").(map[string]interface{})
+	if len(usdt) != 2 || usdt["ERC20"] != 4.5 || usdt["TRC20"] != 1.0 {
+		t.Fatalf("expected the fee of each USDT network, got %v", usdt)
+	}
+
+	result = <-exchange.FetchTransactionFeesFromCurrencies([]string{"USDT", "XYZ"})
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if withdraw := GetValue(result, "withdraw").(map[string]interface{}); len(withdraw) != 1 {
+		t.Fatalf("expected the requested known currencies only, got %v", withdraw)
+	}
+}
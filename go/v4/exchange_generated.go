@@ -129,6 +129,7 @@ func (this *Exchange) Describe() interface{} {
 			"fetchFundingRates":                      nil,
 			"fetchGreeks":                            nil,
 			"fetchIndexOHLCV":                        nil,
+			"fetchIndexPrices":                       nil,
 			"fetchIsolatedBorrowRate":                nil,
 			"fetchIsolatedBorrowRates":               nil,
 			"fetchMarginAdjustmentHistory":           nil,
@@ -1296,7 +1297,9 @@ func (this *Exchange) FetchRestOrderBookSafe(symbol interface{}, optionalArgs ..
 		params := GetArg(optionalArgs, 1, map[string]interface{}{})
 		_ = params
 		var fetchSnapshotMaxRetries interface{} = this.HandleOption("watchOrderBook", "maxRetries", 3)
-		for i := 0; IsLessThan(i, fetchSnapshotMaxRetries); i++ {
+		// the return of the try block only leaves its closure, fetched stops the retries once the snapshot is sent
+		var fetched interface{} = false
+		for i := 0; IsTrue(IsLessThan(i, fetchSnapshotMaxRetries)) && !IsTrue(fetched); i++ {
 
 			{
 				func(this *Exchange) (ret_ interface{}) {
@@ -1318,6 +1321,7 @@ func (this *Exchange) FetchRestOrderBookSafe(symbol interface{}, optionalArgs ..
 
 					orderBook := <-this.DerivedExchange.FetchOrderBook(symbol, limit, params)
 					PanicOnError(orderBook)
+					fetched = true
 
 					ch <- orderBook
 					return nil
@@ -2563,8 +2567,25 @@ func (this *Exchange) SetMarkets(markets interface{}, optionalArgs ...interface{
 	this.Currencies_by_id = this.IndexBySafe(this.Currencies, "id")
 	var currenciesSortedByCode interface{} = this.Keysort(this.Currencies)
 	this.Codes = ObjectKeys(currenciesSortedByCode)
+	// the symbols indexed by type, base and quote, rebuilt with the markets
+	this.MarketsByType = this.IndexSymbolsBy("type")
+	this.MarketsByBase = this.IndexSymbolsBy("base")
+	this.MarketsByQuote = this.IndexSymbolsBy("quote")
 	return this.Markets
 }
+func (this *Exchange) IndexSymbolsBy(key interface{}) interface{} {
+	var result interface{} = map[string]interface{}{}
+	for i := 0; IsLessThan(i, GetArrayLength(this.Symbols)); i++ {
+		var symbol interface{} = GetValue(this.Symbols, i)
+		var value interface{} = this.SafeString(GetValue(this.Markets, symbol), key)
+		if IsTrue(!IsEqual(value, nil)) {
+			var symbols interface{} = this.SafeList(result, value, []interface{}{})
+			AppendToArray(&symbols, symbol)
+			AddElementToObject(result, value, symbols)
+		}
+	}
+	return result
+}
 func (this *Exchange) SetMarketsFromExchange(sourceExchange *Exchange) interface{} {
 	// Validate that both exchanges are of the same type
 	if IsTrue(!IsEqual(this.Id, sourceExchange.Id)) {
@@ -2584,6 +2605,9 @@ func (this *Exchange) SetMarketsFromExchange(sourceExchange *Exchange) interface
 	this.BaseCurrencies = sourceExchange.BaseCurrencies
 	this.QuoteCurrencies = sourceExchange.QuoteCurrencies
 	this.Codes = sourceExchange.Codes
+	this.MarketsByType = sourceExchange.MarketsByType
+	this.MarketsByBase = sourceExchange.MarketsByBase
+	this.MarketsByQuote = sourceExchange.MarketsByQuote
 	// check marketHelperProps
 	var sourceExchangeHelpers interface{} = this.SafeList(sourceExchange.Options, "marketHelperProps", []interface{}{})
 	for i := 0; IsLessThan(i, GetArrayLength(sourceExchangeHelpers)); i++ {
@@ -4569,7 +4593,7 @@ func (this *Exchange) Fetch2(path interface{}, optionalArgs ...interface{}) <-ch
 		config := GetArg(optionalArgs, 5, map[string]interface{}{})
 		_ = config
 		if IsTrue(this.EnableRateLimit) {
-			var cost interface{} = this.CalculateRateLimiterCost(api, method, path, params, config)
+			var cost interface{} = this.DerivedExchange.CalculateRateLimiterCost(api, method, path, params, config)
 
 			retRes565012 := (<-this.Throttle(cost))
 			PanicOnError(retRes565012)
@@ -4623,7 +4647,7 @@ func (this *Exchange) Fetch2(path interface{}, optionalArgs ...interface{}) <-ch
 					}()
 					// try block:
 
-					retRes566323 := (<-this.Fetch(GetValue(request, "url"), GetValue(request, "method"), GetValue(request, "headers"), GetValue(request, "body")))
+					retRes566323 := (<-this.fetchRacing(api, GetValue(request, "url"), GetValue(request, "method"), GetValue(request, "headers"), GetValue(request, "body")))
 					PanicOnError(retRes566323)
 					ch <- retRes566323
 					return nil
@@ -5332,7 +5356,22 @@ func (this *Exchange) FetchStatus(optionalArgs ...interface{}) <-chan interface{
 		defer ReturnPanicError(ch)
 		params := GetArg(optionalArgs, 0, map[string]interface{}{})
 		_ = params
-		panic(NotSupported(Add(this.Id, " fetchStatus() is not supported yet")))
+		// without a status endpoint the exchange is assumed to be operational
+		var updated interface{} = this.Milliseconds()
+		if IsTrue(GetValue(this.Has, "fetchTime")) {
+
+			updated = <-this.DerivedExchange.FetchTime(params)
+			PanicOnError(updated)
+		}
+
+		ch <- map[string]interface{}{
+			"status":  "ok",
+			"updated": updated,
+			"eta":     nil,
+			"url":     nil,
+			"info":    nil,
+		}
+		return nil
 
 	}()
 	return ch
@@ -5816,6 +5855,20 @@ func (this *Exchange) FetchMarkPrices(optionalArgs ...interface{}) <-chan interf
 	}()
 	return ch
 }
+func (this *Exchange) FetchIndexPrices(optionalArgs ...interface{}) <-chan interface{} {
+	ch := make(chan interface{})
+	go func() interface{} {
+		defer close(ch)
+		defer ReturnPanicError(ch)
+		symbols := GetArg(optionalArgs, 0, nil)
+		_ = symbols
+		params := GetArg(optionalArgs, 1, map[string]interface{}{})
+		_ = params
+		panic(NotSupported(Add(this.Id, " fetchIndexPrices() is not supported yet")))
+
+	}()
+	return ch
+}
 func (this *Exchange) FetchTickersWs(optionalArgs ...interface{}) <-chan interface{} {
 	ch := make(chan interface{})
 	go func() interface{} {
@@ -5933,6 +5986,7 @@ func (this *Exchange) FetchOrderWithClientOrderId(clientOrderId interface{}, opt
 		_ = symbol
 		params := GetArg(optionalArgs, 1, map[string]interface{}{})
 		_ = params
+		this.GuardCapability("fetchOrderWithClientOrderId")
 		var extendedParams interface{} = this.Extend(params, map[string]interface{}{
 			"clientOrderId": clientOrderId,
 		})
@@ -6955,6 +7009,7 @@ func (this *Exchange) CancelOrderWithClientOrderId(clientOrderId interface{}, op
 		_ = symbol
 		params := GetArg(optionalArgs, 1, map[string]interface{}{})
 		_ = params
+		this.GuardCapability("cancelOrderWithClientOrderId")
 		var extendedParams interface{} = this.Extend(params, map[string]interface{}{
 			"clientOrderId": clientOrderId,
 		})
@@ -7318,6 +7373,33 @@ func (this *Exchange) FetchCanceledAndClosedOrders(optionalArgs ...interface{})
 		_ = limit
 		params := GetArg(optionalArgs, 3, map[string]interface{}{})
 		_ = params
+		if IsTrue(IsTrue(GetValue(this.Has, "fetchClosedOrders")) && IsTrue(GetValue(this.Has, "fetchCanceledOrders"))) {
+
+			closed := <-this.DerivedExchange.FetchClosedOrders(symbol, since, limit, params)
+			PanicOnError(closed)
+
+			canceled := <-this.DerivedExchange.FetchCanceledOrders(symbol, since, limit, params)
+			PanicOnError(canceled)
+			// an order canceled after a partial fill can be reported by both endpoints
+			var orders interface{} = []interface{}{}
+			var seen interface{} = map[string]interface{}{}
+			var merged interface{} = this.ArrayConcat(closed, canceled)
+			for i := 0; IsLessThan(i, GetArrayLength(merged)); i++ {
+				var order interface{} = GetValue(merged, i)
+				var id interface{} = this.SafeString(order, "id")
+				if IsTrue(!IsEqual(id, nil)) {
+					if IsTrue(InOp(seen, id)) {
+						continue
+					}
+					AddElementToObject(seen, id, true)
+				}
+				AppendToArray(&orders, order)
+			}
+			orders = this.SortBy(orders, "timestamp")
+			// without since the limit keeps the latest orders
+			ch <- this.FilterBySinceLimit(orders, since, limit, "timestamp", IsEqual(since, nil))
+			return nil
+		}
 		panic(NotSupported(Add(this.Id, " fetchCanceledAndClosedOrders() is not supported yet")))
 
 	}()
@@ -7506,6 +7588,16 @@ func (this *Exchange) FetchDepositsWithdrawals(optionalArgs ...interface{}) <-ch
 	go func() interface{} {
 		defer close(ch)
 		defer ReturnPanicError(ch)
+		/**
+		 * @method
+		 * @name exchange#fetchDepositsWithdrawals
+		 * @description fetch the deposits and the withdrawals of an account sorted by timestamp, exchanges without an endpoint for both fetch the deposits and the withdrawals separately
+		 * @param {string} code unified currency code for the currency of the deposit/withdrawals, default is undefined
+		 * @param {int} [since] timestamp in ms of the earliest deposit/withdrawal, default is undefined
+		 * @param {int} [limit] max number of deposit/withdrawals to return, default is undefined
+		 * @param {object} [params] extra parameters specific to the exchange API endpoint
+		 * @returns {object} a list of [transaction structures]{@link https://docs.ccxt.com/?id=transaction-structure}
+		 */
 		code := GetArg(optionalArgs, 0, nil)
 		_ = code
 		since := GetArg(optionalArgs, 1, nil)
@@ -7514,6 +7606,19 @@ func (this *Exchange) FetchDepositsWithdrawals(optionalArgs ...interface{}) <-ch
 		_ = limit
 		params := GetArg(optionalArgs, 3, map[string]interface{}{})
 		_ = params
+		if IsTrue(IsTrue(GetValue(this.Has, "fetchDeposits")) && IsTrue(GetValue(this.Has, "fetchWithdrawals"))) {
+
+			deposits := <-this.DerivedExchange.FetchDeposits(code, since, limit, params)
+			PanicOnError(deposits)
+
+			withdrawals := <-this.DerivedExchange.FetchWithdrawals(code, since, limit, params)
+			PanicOnError(withdrawals)
+			var transactions interface{} = this.SortBy(this.ArrayConcat(deposits, withdrawals), "timestamp")
+
+			// without since the limit keeps the latest transactions
+			ch <- this.FilterBySinceLimit(transactions, since, limit, "timestamp", IsEqual(since, nil))
+			return nil
+		}
 		panic(NotSupported(Add(this.Id, " fetchDepositsWithdrawals() is not supported yet")))
 
 	}()
@@ -8017,7 +8122,8 @@ func (this *Exchange) CostToPrecision(symbol interface{}, cost interface{}) inte
 
 	var market interface{} = this.DerivedExchange.Market(symbol)
 	PanicOnError(market)
-	return this.DecimalToPrecision(cost, TRUNCATE, GetValue(GetValue(market, "precision"), "price"), this.PrecisionMode, this.PaddingMode)
+	var precision interface{} = this.SafeValue2(GetValue(market, "precision"), "cost", "price")
+	return this.DecimalToPrecision(cost, this.PrecisionRoundingMode("costRoundingMode", TRUNCATE), precision, this.PrecisionMode, this.PaddingMode)
 }
 func (this *Exchange) PriceToPrecision(symbol interface{}, price interface{}) interface{} {
 	if IsTrue(IsEqual(price, nil)) {
@@ -8026,7 +8132,7 @@ func (this *Exchange) PriceToPrecision(symbol interface{}, price interface{}) in
 
 	var market interface{} = this.DerivedExchange.Market(symbol)
 	PanicOnError(market)
-	var result interface{} = this.DecimalToPrecision(price, ROUND, GetValue(GetValue(market, "precision"), "price"), this.PrecisionMode, this.PaddingMode)
+	var result interface{} = this.DecimalToPrecision(price, this.PrecisionRoundingMode("priceRoundingMode", ROUND), GetValue(GetValue(market, "precision"), "price"), this.PrecisionMode, this.PaddingMode)
 	if IsTrue(IsEqual(result, "0")) {
 		panic(InvalidOrder(Add(Add(Add(Add(this.Id, " price of "), GetValue(market, "symbol")), " must be greater than minimum price precision of "), this.NumberToString(GetValue(GetValue(market, "precision"), "price")))))
 	}
@@ -8039,7 +8145,7 @@ func (this *Exchange) AmountToPrecision(symbol interface{}, amount interface{})
 
 	var market interface{} = this.DerivedExchange.Market(symbol)
 	PanicOnError(market)
-	var result interface{} = this.DecimalToPrecision(amount, TRUNCATE, GetValue(GetValue(market, "precision"), "amount"), this.PrecisionMode, this.PaddingMode)
+	var result interface{} = this.DecimalToPrecision(amount, this.PrecisionRoundingMode("amountRoundingMode", TRUNCATE), GetValue(GetValue(market, "precision"), "amount"), this.PrecisionMode, this.PaddingMode)
 	if IsTrue(IsEqual(result, "0")) {
 		panic(InvalidOrder(Add(Add(Add(Add(this.Id, " amount of "), GetValue(market, "symbol")), " must be greater than minimum amount precision of "), this.NumberToString(GetValue(GetValue(market, "precision"), "amount")))))
 	}
@@ -8067,10 +8173,32 @@ func (this *Exchange) CurrencyToPrecision(code interface{}, fee interface{}, opt
 	if IsTrue(IsEqual(precision, nil)) {
 		return this.ForceString(fee)
 	} else {
-		var roundingMode interface{} = this.SafeInteger(this.Options, "currencyToPrecisionRoundingMode", ROUND)
+		var roundingMode interface{} = this.PrecisionRoundingMode("currencyToPrecisionRoundingMode", ROUND)
 		return this.DecimalToPrecision(fee, roundingMode, precision, this.PrecisionMode, this.PaddingMode)
 	}
 }
+func (this *Exchange) PrecisionRoundingMode(key interface{}, defaultMode interface{}) interface{} {
+	// options[key] is ROUND/TRUNCATE or their names, venues that round amounts set options.amountRoundingMode to ROUND
+	var value interface{} = this.SafeValue(this.Options, key)
+	if IsTrue(IsEqual(value, nil)) {
+		return defaultMode
+	}
+	if IsTrue(IsString(value)) {
+		var modes interface{} = map[string]interface{}{
+			"ROUND":    ROUND,
+			"TRUNCATE": TRUNCATE,
+		}
+		var mode interface{} = this.SafeInteger(modes, ToUpper(value))
+		if IsTrue(IsEqual(mode, nil)) {
+			panic(BadRequest(Add(Add(Add(Add(this.Id, " options."), key), " must be ROUND or TRUNCATE, got "), value)))
+		}
+		return mode
+	}
+	if IsTrue(IsTrue((!IsEqual(value, ROUND))) && IsTrue((!IsEqual(value, TRUNCATE)))) {
+		panic(BadRequest(Add(Add(Add(Add(this.Id, " options."), key), " must be ROUND or TRUNCATE, got "), this.NumberToString(value))))
+	}
+	return value
+}
 func (this *Exchange) ForceString(value interface{}) interface{} {
 	if IsTrue(!IsString(value)) {
 		return this.NumberToString(value)
@@ -8188,10 +8316,10 @@ func (this *Exchange) FetchMarketLeverageTiers(symbol interface{}, optionalArgs
 				panic(BadSymbol(Add(this.Id, " fetchMarketLeverageTiers() supports contract markets only")))
 			}
 
-			tiers := <-this.DerivedExchange.FetchLeverageTiers([]interface{}{symbol})
+			tiers := <-this.DerivedExchange.FetchLeverageTiers([]interface{}{GetValue(market, "symbol")}, params)
 			PanicOnError(tiers)
 
-			ch <- this.SafeValue(tiers, symbol)
+			ch <- this.SafeValue(tiers, GetValue(market, "symbol"))
 			return nil
 		} else {
 			panic(NotSupported(Add(this.Id, " fetchMarketLeverageTiers() is not supported yet")))
@@ -8200,6 +8328,124 @@ func (this *Exchange) FetchMarketLeverageTiers(symbol interface{}, optionalArgs
 	}()
 	return ch
 }
+func (this *Exchange) CheckLeverage(leverage interface{}, optionalArgs ...interface{}) <-chan interface{} {
+	ch := make(chan interface{})
+	go func() interface{} {
+		defer close(ch)
+		defer ReturnPanicError(ch)
+		/**
+		 * @method
+		 * @name exchange#checkLeverage
+		 * @description checks a leverage against the leverage tiers of the market before setLeverage sends it, the tiers are fetched with fetchMarketLeverageTiers and cached for options.leverageTiersTTL milliseconds
+		 * @param {float} leverage the leverage to set
+		 * @param {string} symbol unified market symbol, nothing is checked without it
+		 * @param {object} [params] the parameters of setLeverage, they are sent with fetchMarketLeverageTiers
+		 * @param {string} [params.marginMode] 'cross' or 'isolated', defaults to options.defaultMarginMode
+		 * @returns {undefined} throws a BadRequest when the leverage is below 1 or above the maximum leverage of the market, options.checkLeverageTiers = false disables the check
+		 */
+		symbol := GetArg(optionalArgs, 0, nil)
+		_ = symbol
+		params := GetArg(optionalArgs, 1, map[string]interface{}{})
+		_ = params
+		if IsTrue(IsTrue((IsEqual(symbol, nil))) || IsTrue((IsEqual(leverage, nil)))) {
+
+			return nil
+		}
+		if IsTrue(IsLessThan(leverage, 1)) {
+			panic(BadRequest(Add(Add(this.Id, " setLeverage() leverage should be at least 1, got "), ToString(leverage))))
+		}
+		var checkLeverageTiers interface{} = this.SafeBool(this.Options, "checkLeverageTiers", true)
+		var hasTiers interface{} = IsTrue(GetValue(this.Has, "fetchMarketLeverageTiers")) || IsTrue(GetValue(this.Has, "fetchLeverageTiers"))
+		if IsTrue(!IsTrue(checkLeverageTiers) || !IsTrue(hasTiers)) {
+
+			return nil
+		}
+
+		retRes83112 := (<-this.LoadMarkets())
+		PanicOnError(retRes83112)
+
+		tiers := <-this.FetchCachedLeverageTiers(symbol, params)
+		PanicOnError(tiers)
+		var maxLeverage interface{} = nil
+		for i := 0; IsLessThan(i, GetArrayLength(tiers)); i++ {
+			var tierMaxLeverage interface{} = this.SafeNumber(GetValue(tiers, i), "maxLeverage")
+			if IsTrue(IsTrue((!IsEqual(tierMaxLeverage, nil))) && IsTrue((IsTrue((IsEqual(maxLeverage, nil))) || IsTrue((IsGreaterThan(tierMaxLeverage, maxLeverage)))))) {
+				maxLeverage = tierMaxLeverage
+			}
+		}
+		if IsTrue(IsTrue((!IsEqual(maxLeverage, nil))) && IsTrue((IsGreaterThan(leverage, maxLeverage)))) {
+			panic(BadRequest(Add(Add(Add(Add(Add(Add(this.Id, " setLeverage() leverage of "), ToString(leverage)), " exceeds the maximum leverage of "), this.NumberToString(maxLeverage)), " for "), symbol)))
+		}
+
+		return nil
+	}()
+	return ch
+}
+func (this *Exchange) FetchCachedLeverageTiers(symbol interface{}, optionalArgs ...interface{}) <-chan interface{} {
+	ch := make(chan interface{})
+	go func() interface{} {
+		defer close(ch)
+		defer ReturnPanicError(ch)
+		params := GetArg(optionalArgs, 0, map[string]interface{}{})
+		_ = params
+		// the brackets can differ between the margin modes and the accounts, the tiers are cached by symbol, margin mode and params
+		var marginModeAndParams interface{} = this.HandleMarginModeAndParams("setLeverage", params)
+		var marginMode interface{} = this.SafeString(marginModeAndParams, 0, "")
+		var key interface{} = Add(Add(Add(Add(symbol, ":"), marginMode), ":"), this.Json(params))
+		var cache interface{} = this.SafeDict(this.Options, "leverageTiersCache", map[string]interface{}{})
+		var cached interface{} = this.SafeDict(cache, key)
+		var ttl interface{} = this.SafeInteger(this.Options, "leverageTiersTTL", 3600000)
+		if IsTrue(IsTrue((!IsEqual(cached, nil))) && IsTrue((IsLessThan((Subtract(this.Milliseconds(), GetValue(cached, "timestamp"))), ttl)))) {
+
+			ch <- GetValue(cached, "tiers")
+			return nil
+		}
+		var tiers interface{} = nil
+
+		{
+			func(this *Exchange) (ret_ interface{}) {
+				defer func() {
+					if e := recover(); e != nil {
+						if e == "break" {
+							return
+						}
+						ret_ = func(this *Exchange) interface{} {
+							// catch block:
+							// the exchange validates the leverage itself when the tiers can not be fetched
+							if IsTrue(this.Verbose) {
+								this.Log(Add(Add(Add("checkLeverage() could not fetch the leverage tiers of ", symbol), ": "), ToString(e)))
+							}
+							return nil
+						}(this)
+					}
+				}()
+				// try block:
+
+				tiers = (<-this.DerivedExchange.FetchMarketLeverageTiers(symbol, params))
+				PanicOnError(tiers)
+				return nil
+			}(this)
+
+		}
+		if !IsTrue(IsArray(tiers)) {
+
+			ch <- []interface{}{}
+			return nil
+		}
+		// the cache is replaced instead of updated in place, it is shared by the concurrent calls
+		var entry interface{} = map[string]interface{}{}
+		AddElementToObject(entry, key, map[string]interface{}{
+			"tiers":     tiers,
+			"timestamp": this.Milliseconds(),
+		})
+		AddElementToObject(this.Options, "leverageTiersCache", this.Extend(cache, entry))
+
+		ch <- tiers
+		return nil
+
+	}()
+	return ch
+}
 func (this *Exchange) CreatePostOnlyOrder(symbol interface{}, typeVar interface{}, side interface{}, amount interface{}, optionalArgs ...interface{}) <-chan interface{} {
 	ch := make(chan interface{})
 	go func() interface{} {
@@ -8642,7 +8888,7 @@ func (this *Exchange) ParseIsolatedBorrowRates(info interface{}) interface{} {
 	var result interface{} = map[string]interface{}{}
 	for i := 0; IsLessThan(i, GetArrayLength(info)); i++ {
 		var item interface{} = GetValue(info, i)
-		var borrowRate interface{} = this.ParseIsolatedBorrowRate(item)
+		var borrowRate interface{} = this.DerivedExchange.ParseIsolatedBorrowRate(item)
 		var symbol interface{} = this.SafeString(borrowRate, "symbol")
 		AddElementToObject(result, symbol, borrowRate)
 	}
@@ -9766,6 +10012,8 @@ func (this *Exchange) FetchPaginatedCallCursor(method interface{}, optionalArgs
 		var i interface{} = 0
 		var errors interface{} = 0
 		var result interface{} = []interface{}{}
+		// the breaks of the try block only leave its closure, done ends the loop as well
+		var done interface{} = false
 		var timeframe interface{} = this.SafeString(params, "timeframe")
 		params = this.Omit(params, "timeframe") // reading the timeframe from the method arguments to avoid changing the signature
 		for IsLessThan(i, maxCalls) {
@@ -9821,6 +10069,7 @@ func (this *Exchange) FetchPaginatedCallCursor(method interface{}, optionalArgs
 						this.Log(cursorMessage)
 					}
 					if IsTrue(IsEqual(responseLength, 0)) {
+						done = true
 						panic("break")
 					}
 					result = this.ArrayConcat(result, response)
@@ -9834,20 +10083,25 @@ func (this *Exchange) FetchPaginatedCallCursor(method interface{}, optionalArgs
 						var cursor interface{} = this.SafeValue(info, cursorReceived)
 						if IsTrue(!IsEqual(cursor, nil)) {
 							cursorValue = cursor
-							panic("break")
+							break
 						}
 					}
 					if IsTrue(IsEqual(cursorValue, nil)) {
+						done = true
 						panic("break")
 					}
 					var lastTimestamp interface{} = this.SafeInteger(last, "timestamp")
 					if IsTrue(IsTrue(!IsEqual(lastTimestamp, nil)) && IsTrue(IsLessThan(lastTimestamp, since))) {
+						done = true
 						panic("break")
 					}
 					return nil
 				}(this)
 
 			}
+			if IsTrue(done) {
+				break
+			}
 			i = Add(i, 1)
 		}
 		var sorted interface{} = this.SortCursorPaginatedResult(result)
@@ -10548,10 +10802,9 @@ func (this *Exchange) CleanUnsubscription(client *Client, subHash interface{}, u
 		if IsTrue(InOp(client.Subscriptions, subHash)) {
 			Remove(client.Subscriptions, subHash)
 		}
-		if IsTrue(InOp(client.Futures, subHash)) {
-			error := UnsubscribeError(Add(Add(this.Id, " "), subHash))
-			client.Reject(error, subHash)
-		}
+		// also ends the streams of subHash when no future is waiting
+		error := UnsubscribeError(Add(Add(this.Id, " "), subHash))
+		client.Reject(error, subHash)
 	} else {
 		var clientSubscriptions interface{} = ObjectKeys(client.Subscriptions)
 		for i := 0; IsLessThan(i, GetArrayLength(clientSubscriptions)); i++ {
@@ -10618,7 +10871,7 @@ func (this *Exchange) CleanCache(subscription interface{}) {
 			var clients interface{} = ObjectValues(this.Clients)
 			for i := 0; IsLessThan(i, GetArrayLength(clients)); i++ {
 				var client interface{} = GetValue(clients, i)
-				var futures interface{} = (client.(Client)).Futures
+				var futures interface{} = client.(ClientInterface).GetFutures()
 				if IsTrue(IsTrue((!IsEqual(futures, nil))) && IsTrue((InOp(futures, "fetchPositionsSnapshot")))) {
 					Remove(futures, "fetchPositionsSnapshot")
 				}
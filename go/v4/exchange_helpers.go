@@ -590,6 +590,8 @@ func GetArrayLength(value interface{}) int {
 	switch v := value.(type) {
 	case [][]interface{}: // TODO: double/triple arrays of all the types
 		return len(v)
+	case [][]string:
+		return len(v)
 	case []interface{}:
 		return len(v)
 	case []string:
@@ -0,0 +1,66 @@
+package ccxt
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// ---------------------------------------------------------------------------
+// SetHTTPClient: the rest requests go through the injected client, the proxy settings leave it untouched
+// ---------------------------------------------------------------------------
+
+// recordingRoundTripper answers every request with the same body and keeps the requests it was sent
+type recordingRoundTripper struct {
+	mu       sync.Mutex
+	body     string
+	requests []*http.Request
+}
+
+func (r *recordingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, req)
+	return &http.Response{
+		StatusCode: 200,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(r.body)),
+		Request:    req,
+	}, nil
+}
+
+func TestSetHTTPClientRecordsOutgoingRequests(t *testing.T) {
+	exchange := NewBinanceCore()
+	exchange.Init(map[string]interface{}{
+		"httpProxy": "http://127.0.0.1:1",
+	})
+	recorder := &recordingRoundTripper{body: `{"serverTime":1499827319559}`}
+	client := &http.Client{Transport: recorder, Timeout: 5 * time.Second}
+	exchange.SetHTTPClient(client)
+
+	result := <-exchange.FetchTime()
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if result != int64(1499827319559) {
+		t.Fatalf("expected the server time of the recorded response, got %v", result)
+	}
+	if len(recorder.requests) != 1 {
+		t.Fatalf("expected a single request, got %d", len(recorder.requests))
+	}
+	request := recorder.requests[0]
+	if request.Method != "GET" || request.URL.Host != "api.binance.com" || request.URL.Path != "/api/v3/time" {
+		t.Fatalf("expected the server time request, got %s %s", request.Method, request.URL)
+	}
+	if exchange.HTTPClient() != client || client.Transport != recorder {
+		t.Fatal("expected the injected client to be used as is")
+	}
+
+	exchange.SetHTTPClient(nil)
+	if restored := exchange.HTTPClient(); restored == client || restored.Timeout != 30*time.Second {
+		t.Fatalf("expected the default client to be restored, got %+v", restored)
+	}
+}
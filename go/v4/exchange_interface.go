@@ -50,6 +50,7 @@ type IBaseExchange interface {
 	Market(symbol interface{}) interface{}
 	Currency(code interface{}) interface{}
 	GetMarket(symbol string) MarketInterface
+	TryGetMarket(symbol string) (MarketInterface, error)
 	GetMarketsList() []MarketInterface
 	GetCurrency(currencyId string) Currency
 	GetCurrenciesList() []Currency
@@ -168,6 +169,9 @@ type ICoreExchange interface {
 	FetchLedger(optionalArgs ...interface{}) <-chan interface{}
 	ArrayConcat(aa, bb interface{}) interface{}
 	FetchAccounts(optionalArgs ...interface{}) <-chan interface{}
+	FetchSubAccounts(optionalArgs ...interface{}) <-chan interface{}
+	FetchSubAccountBalance(subAccountId interface{}, optionalArgs ...interface{}) <-chan interface{}
+	FetchAllBalances(optionalArgs ...interface{}) <-chan interface{}
 	FetchBorrowInterest(optionalArgs ...interface{}) <-chan interface{}
 	FetchL2OrderBook(symbol interface{}, optionalArgs ...interface{}) <-chan interface{}
 	FetchLiquidations(symbol interface{}, optionalArgs ...interface{}) <-chan interface{}
@@ -367,6 +371,7 @@ type IDerivedExchange interface {
 	SafeMarket(optionalArgs ...interface{}) interface{}
 	FetchTickers(optionalArgs ...interface{}) <-chan interface{}
 	Sign(path interface{}, optionalArgs ...interface{}) interface{}
+	CalculateRateLimiterCost(api interface{}, method interface{}, path interface{}, params interface{}, optionalArgs ...interface{}) interface{}
 	FetchBalance(optionalArgs ...interface{}) <-chan interface{}
 	CancelOrder(id interface{}, optionalArgs ...interface{}) <-chan interface{}
 	CancelOrders(ids interface{}, optionalArgs ...interface{}) <-chan interface{}
@@ -378,13 +383,17 @@ type IDerivedExchange interface {
 	FetchOrder(id interface{}, optionalArgs ...interface{}) <-chan interface{}
 	FetchOrderWithClientOrderId(clientOrderId interface{}, optionalArgs ...interface{}) <-chan interface{}
 	FetchOrders(optionalArgs ...interface{}) <-chan interface{}
+	FetchClosedOrders(optionalArgs ...interface{}) <-chan interface{}
+	FetchCanceledOrders(optionalArgs ...interface{}) <-chan interface{}
 	CreateExpiredOptionMarket(symbol interface{}) interface{}
 	FetchTime(optionalArgs ...interface{}) <-chan interface{}
 	FetchLeverageTiers(optionalArgs ...interface{}) <-chan interface{}
+	FetchMarketLeverageTiers(symbol interface{}, optionalArgs ...interface{}) <-chan interface{}
 	ParseDepositAddresses(addresses interface{}, optionalArgs ...interface{}) interface{}
 	FetchTradingFees(optionalArgs ...interface{}) <-chan interface{}
 	ParseDepositAddress(depositAddress interface{}, optionalArgs ...interface{}) interface{}
 	ParseBorrowRate(info interface{}, optionalArgs ...interface{}) interface{}
+	ParseIsolatedBorrowRate(info interface{}, optionalArgs ...interface{}) interface{}
 	ParseFundingRateHistory(info interface{}, optionalArgs ...interface{}) interface{}
 	ParseFundingRate(contract interface{}, optionalArgs ...interface{}) interface{}
 	FetchOHLCV(symbol interface{}, optionalArgs ...interface{}) <-chan interface{}
@@ -392,10 +401,15 @@ type IDerivedExchange interface {
 	FetchFundingIntervals(optionalArgs ...interface{}) <-chan interface{}
 	FetchPositionsHistory(optionalArgs ...interface{}) <-chan interface{}
 	FetchDepositsWithdrawals(optionalArgs ...interface{}) <-chan interface{}
+	FetchDeposits(optionalArgs ...interface{}) <-chan interface{}
+	FetchWithdrawals(optionalArgs ...interface{}) <-chan interface{}
 	ParseMarginModification(data interface{}, optionalArgs ...interface{}) interface{}
 	FetchMarkets(optionalArgs ...interface{}) <-chan interface{}
 	FetchCurrencies(optionalArgs ...interface{}) <-chan interface{}
 	FetchAccounts(optionalArgs ...interface{}) <-chan interface{}
+	FetchSubAccounts(optionalArgs ...interface{}) <-chan interface{}
+	FetchSubAccountBalance(subAccountId interface{}, optionalArgs ...interface{}) <-chan interface{}
+	FetchAllBalances(optionalArgs ...interface{}) <-chan interface{}
 	SetSandboxMode(enabled interface{})
 	Market(symbol interface{}) interface{}
 	ParseConversion(conversion interface{}, optionalArgs ...interface{}) interface{}
@@ -405,12 +419,14 @@ type IDerivedExchange interface {
 	OnError(client interface{}, err interface{})
 	OnClose(client interface{}, err interface{})
 	OnConnected(client interface{}, err interface{})
+	ClassifyWsError(client interface{}, message interface{}) interface{}
 	CancelOrderWs(id interface{}, optionalArgs ...interface{}) <-chan interface{}
 	CreateOrderWs(symbol interface{}, typeVar interface{}, side interface{}, amount interface{}, optionalArgs ...interface{}) <-chan interface{}
 	WatchPositions(optionalArgs ...interface{}) <-chan interface{}
 	FetchTickersWs(optionalArgs ...interface{}) <-chan interface{}
 	WatchLiquidationsForSymbols(symbols interface{}, optionalArgs ...interface{}) <-chan interface{}
 	WatchMyLiquidationsForSymbols(symbols interface{}, optionalArgs ...interface{}) <-chan interface{}
+	WatchTrades(symbol interface{}, optionalArgs ...interface{}) <-chan interface{}
 	FetchOrdersWs(optionalArgs ...interface{}) <-chan interface{}
 	ParseWsTrade(trade interface{}, optionalArgs ...interface{}) interface{}
 	FetchPositionsADLRank(optionalArgs ...interface{}) <-chan interface{}
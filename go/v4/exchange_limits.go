@@ -0,0 +1,61 @@
+package ccxt
+
+import "fmt"
+
+// Order limits
+// ------------
+// The venues reject orders outside of the limits of the market with an
+// InvalidOrder, ClampToLimits checks an order against the loaded limits
+// before it is sent so the rejection does not cost a request.
+
+// ClampToLimits checks amount and price against limits.amount, limits.price
+// and limits.cost of the market. Values under a minimum are an InvalidOrder,
+// values over a maximum are lowered to it unless options.clampToMaxLimits is
+// false, in which case they are an InvalidOrder too. A price of 0 (market
+// orders) skips the price and cost checks.
+func (this *Exchange) ClampToLimits(symbol string, amount float64, price float64) (clampedAmount float64, clampedPrice float64, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(error); ok {
+				err = e
+			} else {
+				err = ExchangeError(fmt.Sprintf("%v", r))
+			}
+		}
+	}()
+	market := this.DerivedExchange.Market(symbol)
+	PanicOnError(market)
+	clamp := IsTrue(this.SafeBool(this.Options, "clampToMaxLimits", true))
+	limits := this.SafeDict(market, "limits", map[string]interface{}{})
+	amount, err = this.clampToLimit(symbol, "amount", amount, this.SafeDict(limits, "amount", map[string]interface{}{}), clamp)
+	if err != nil {
+		return 0, 0, err
+	}
+	if price > 0 {
+		price, err = this.clampToLimit(symbol, "price", price, this.SafeDict(limits, "price", map[string]interface{}{}), clamp)
+		if err != nil {
+			return 0, 0, err
+		}
+		minCost := this.SafeNumber(this.SafeDict(limits, "cost", map[string]interface{}{}), "min")
+		contractSize := ToFloat64(this.SafeNumber(market, "contractSize", 1.0))
+		if minCost != nil && amount*price*contractSize < ToFloat64(minCost) {
+			return 0, 0, InvalidOrder(fmt.Sprintf("%s order cost of %s must be greater than minimum cost of %s", this.Id, symbol, NumberToString(minCost)))
+		}
+	}
+	return amount, price, nil
+}
+
+func (this *Exchange) clampToLimit(symbol string, name string, value float64, limit interface{}, clamp bool) (float64, error) {
+	minimum := this.SafeNumber(limit, "min")
+	if minimum != nil && value < ToFloat64(minimum) {
+		return 0, InvalidOrder(fmt.Sprintf("%s order %s of %s must be greater than minimum %s of %s", this.Id, name, symbol, name, NumberToString(minimum)))
+	}
+	maximum := this.SafeNumber(limit, "max")
+	if maximum != nil && value > ToFloat64(maximum) {
+		if !clamp {
+			return 0, InvalidOrder(fmt.Sprintf("%s order %s of %s must be less than maximum %s of %s", this.Id, name, symbol, name, NumberToString(maximum)))
+		}
+		return ToFloat64(maximum), nil
+	}
+	return value, nil
+}
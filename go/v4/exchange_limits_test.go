@@ -0,0 +1,70 @@
+package ccxt
+
+import (
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// ClampToLimits: orders are checked against the limits of the market before they are sent
+// ---------------------------------------------------------------------------
+
+func newLimitsTestBinance(options map[string]interface{}) *BinanceCore {
+	exchange := NewBinanceCore()
+	exchange.Init(map[string]interface{}{"options": options})
+	exchange.SetMarkets([]interface{}{
+		exchange.SafeMarketStructure(map[string]interface{}{
+			"id":      "BTCUSDT",
+			"symbol":  "BTC/USDT",
+			"base":    "BTC",
+			"quote":   "USDT",
+			"baseId":  "BTC",
+			"quoteId": "USDT",
+			"type":    "spot",
+			"spot":    true,
+			"active":  true,
+			"limits": map[string]interface{}{
+				"amount": map[string]interface{}{"min": 0.001, "max": 9000.0},
+				"price":  map[string]interface{}{"min": 0.01, "max": 1000000.0},
+				"cost":   map[string]interface{}{"min": 5.0, "max": nil},
+			},
+		}),
+	})
+	return exchange
+}
+
+func TestClampToLimits(t *testing.T) {
+	exchange := newLimitsTestBinance(map[string]interface{}{})
+	amount, price, err := exchange.ClampToLimits("BTC/USDT", 0.5, 30000)
+	if err != nil || amount != 0.5 || price != 30000 {
+		t.Fatalf("expected an order within the limits to be unchanged, got %v %v %v", amount, price, err)
+	}
+	amount, price, err = exchange.ClampToLimits("BTC/USDT", 10000, 2000000)
+	if err != nil || amount != 9000 || price != 1000000 {
+		t.Fatalf("expected the amount and the price lowered to the maximums, got %v %v %v", amount, price, err)
+	}
+	// market orders have no price to check
+	amount, _, err = exchange.ClampToLimits("BTC/USDT", 0.001, 0)
+	if err != nil || amount != 0.001 {
+		t.Fatalf("expected the minimum amount to be accepted, got %v %v", amount, err)
+	}
+}
+
+func TestClampToLimitsRejections(t *testing.T) {
+	exchange := newLimitsTestBinance(map[string]interface{}{})
+	if _, _, err := exchange.ClampToLimits("BTC/USDT", 0.0005, 30000); err == nil || !IsErrorType(err, "InvalidOrder") {
+		t.Fatalf("expected an amount under 0.001 to be an InvalidOrder, got %v", err)
+	}
+	if _, _, err := exchange.ClampToLimits("BTC/USDT", 0.5, 0.001); err == nil || !IsErrorType(err, "InvalidOrder") {
+		t.Fatalf("expected a price under the minimum to be an InvalidOrder, got %v", err)
+	}
+	if _, _, err := exchange.ClampToLimits("BTC/USDT", 0.001, 1000); err == nil || !IsErrorType(err, "InvalidOrder") {
+		t.Fatalf("expected a cost under 5 USDT to be an InvalidOrder, got %v", err)
+	}
+	if _, _, err := exchange.ClampToLimits("ETH/USDT", 1, 1000); err == nil || !IsErrorType(err, "BadSymbol") {
+		t.Fatalf("expected an unknown market to be a BadSymbol, got %v", err)
+	}
+	exchange = newLimitsTestBinance(map[string]interface{}{"clampToMaxLimits": false})
+	if _, _, err := exchange.ClampToLimits("BTC/USDT", 10000, 30000); err == nil || !IsErrorType(err, "InvalidOrder") {
+		t.Fatalf("expected an amount over the maximum to be an InvalidOrder when clamping is disabled, got %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package ccxt
+
+import (
+	"sort"
+)
+
+// Market indexes
+// --------------
+// SetMarkets indexes the symbols of the markets by type, base and quote in
+// MarketsByType, MarketsByBase and MarketsByQuote so that FilterMarkets does
+// not scan every market. They are rebuilt with the markets (reload,
+// SetMarketsFromExchange).
+
+// FilterMarkets returns the loaded markets matching a type (spot, swap, future, option), a base and a quote
+// currency, sorted by symbol. An empty argument matches any value, e.g. FilterMarkets("", "", "USDT")
+// returns every market quoted in USDT
+func (this *Exchange) FilterMarkets(marketType string, base string, quote string) []Market {
+	markets := this.Markets
+	if markets == nil {
+		return []Market{}
+	}
+	var candidates []interface{}
+	filters := []map[string]bool{}
+	for _, filter := range []struct {
+		index interface{}
+		value string
+	}{{this.MarketsByType, marketType}, {this.MarketsByBase, base}, {this.MarketsByQuote, quote}} {
+		if filter.value == "" {
+			continue
+		}
+		symbols, _ := this.SafeList(filter.index, filter.value, []interface{}{}).([]interface{})
+		if candidates == nil || len(symbols) < len(candidates) {
+			candidates = symbols
+		}
+		set := make(map[string]bool, len(symbols))
+		for _, symbol := range symbols {
+			set[ToString(symbol)] = true
+		}
+		filters = append(filters, set)
+	}
+	if len(filters) == 0 {
+		candidates = []interface{}{}
+		markets.Range(func(key, value interface{}) bool {
+			candidates = append(candidates, key)
+			return true
+		})
+	}
+	matches := []string{}
+	for _, candidate := range candidates {
+		symbol := ToString(candidate)
+		matched := true
+		for _, set := range filters {
+			if !set[symbol] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			matches = append(matches, symbol)
+		}
+	}
+	sort.Strings(matches)
+	result := make([]Market, 0, len(matches))
+	for _, symbol := range matches {
+		if market, ok := markets.Load(symbol); ok {
+			result = append(result, NewMarket(market))
+		}
+	}
+	return result
+}
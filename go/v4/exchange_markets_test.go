@@ -0,0 +1,512 @@
+package ccxt
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// ---------------------------------------------------------------------------
+// LoadMarkets: concurrent callers share a single fetchMarkets
+// ---------------------------------------------------------------------------
+
+type countingMarketsExchange struct {
+	*BinanceCore
+	FetchMarketsCallCount int32
+}
+
+func (this *countingMarketsExchange) FetchMarkets(optionalArgs ...interface{}) <-chan interface{} {
+	ch := make(chan interface{}, 1)
+	atomic.AddInt32(&this.FetchMarketsCallCount, 1)
+	go func() {
+		defer close(ch)
+		time.Sleep(50 * time.Millisecond)
+		ch <- []interface{}{
+			this.SafeMarketStructure(map[string]interface{}{
+				"id":      "BTCUSDT",
+				"symbol":  "BTC/USDT",
+				"base":    "BTC",
+				"quote":   "USDT",
+				"baseId":  "BTC",
+				"quoteId": "USDT",
+				"type":    "spot",
+				"spot":    true,
+				"active":  true,
+			}),
+		}
+	}()
+	return ch
+}
+
+func newCountingMarketsExchange(config ...map[string]interface{}) *countingMarketsExchange {
+	userConfig := map[string]interface{}{}
+	if len(config) > 0 {
+		userConfig = config[0]
+	}
+	core := NewBinanceCore()
+	core.Init(userConfig)
+	core.Has["fetchCurrencies"] = false
+	exchange := &countingMarketsExchange{BinanceCore: core}
+	core.DerivedExchange = exchange
+	return exchange
+}
+
+func TestLoadMarketsConcurrentCallsShareOneFetch(t *testing.T) {
+	exchange := newCountingMarketsExchange()
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			markets := <-exchange.LoadMarkets()
+			if GetValue(markets, "BTC/USDT") == nil {
+				t.Errorf("expected BTC/USDT in the loaded markets, got %v", markets)
+			}
+		}()
+	}
+	wg.Wait()
+	if count := atomic.LoadInt32(&exchange.FetchMarketsCallCount); count != 1 {
+		t.Fatalf("expected FetchMarketsCallCount == 1, got %d", count)
+	}
+}
+
+func TestLoadMarketsHelperCoalescesInFlightFetch(t *testing.T) {
+	exchange := newCountingMarketsExchange()
+	channels := []<-chan interface{}{}
+	for i := 0; i < 20; i++ {
+		channels = append(channels, exchange.LoadMarketsHelper(false))
+	}
+	for _, ch := range channels {
+		if GetValue(<-ch, "BTC/USDT") == nil {
+			t.Fatal("expected every caller to receive the markets")
+		}
+	}
+	if count := atomic.LoadInt32(&exchange.FetchMarketsCallCount); count != 1 {
+		t.Fatalf("expected FetchMarketsCallCount == 1, got %d", count)
+	}
+}
+
+func TestLoadMarketsReloadIssuesNewFetch(t *testing.T) {
+	exchange := newCountingMarketsExchange()
+	<-exchange.LoadMarkets()
+	<-exchange.LoadMarkets()
+	if count := atomic.LoadInt32(&exchange.FetchMarketsCallCount); count != 1 {
+		t.Fatalf("expected cached markets to be reused, got %d fetches", count)
+	}
+	<-exchange.LoadMarkets(true)
+	if count := atomic.LoadInt32(&exchange.FetchMarketsCallCount); count != 2 {
+		t.Fatalf("expected a forced reload to fetch again, got %d fetches", count)
+	}
+}
+
+func TestLoadMarketsTTLRefreshesInBackground(t *testing.T) {
+	exchange := newCountingMarketsExchange(map[string]interface{}{"marketsTTL": 100})
+	if exchange.MarketsTTL != 100 {
+		t.Fatalf("expected marketsTTL to be read from the config, got %d", exchange.MarketsTTL)
+	}
+	cached := <-exchange.LoadMarkets()
+	<-exchange.LoadMarkets()
+	if count := atomic.LoadInt32(&exchange.FetchMarketsCallCount); count != 1 {
+		t.Fatalf("expected fresh markets to be reused, got %d fetches", count)
+	}
+
+	time.Sleep(120 * time.Millisecond)
+	for i := 0; i < 10; i++ {
+		// the stale cache is still returned immediately
+		if markets := <-exchange.LoadMarkets(); markets != cached {
+			t.Fatal("expected the cached markets while the refresh is in flight")
+		}
+	}
+	time.Sleep(100 * time.Millisecond)
+	if count := atomic.LoadInt32(&exchange.FetchMarketsCallCount); count != 2 {
+		t.Fatalf("expected exactly one background refresh, got %d fetches", count)
+	}
+	if markets := <-exchange.LoadMarkets(); markets == cached {
+		t.Fatal("expected the refreshed markets once the refresh completed")
+	}
+}
+
+func TestLoadMarketsWithoutTTLNeverRefreshes(t *testing.T) {
+	exchange := newCountingMarketsExchange()
+	<-exchange.LoadMarkets()
+	time.Sleep(20 * time.Millisecond)
+	<-exchange.LoadMarkets()
+	<-exchange.LoadMarketsHelper(false)
+	time.Sleep(80 * time.Millisecond)
+	if count := atomic.LoadInt32(&exchange.FetchMarketsCallCount); count != 1 {
+		t.Fatalf("expected no refresh with marketsTTL disabled, got %d fetches", count)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// SetMarketsCopy: the recipient does not share structures with the donor
+// ---------------------------------------------------------------------------
+
+func newMarketsDonor() *BinanceCore {
+	donor := NewBinanceCore()
+	donor.Init(map[string]interface{}{})
+	donor.SetMarkets([]interface{}{
+		donor.SafeMarketStructure(map[string]interface{}{
+			"id":      "BTCUSDT",
+			"symbol":  "BTC/USDT",
+			"base":    "BTC",
+			"quote":   "USDT",
+			"baseId":  "BTC",
+			"quoteId": "USDT",
+			"type":    "spot",
+			"spot":    true,
+			"active":  true,
+			"info":    map[string]interface{}{"status": "TRADING"},
+			"precision": map[string]interface{}{
+				"amount": 0.001,
+				"price":  0.01,
+			},
+		}),
+	})
+	return donor
+}
+
+func TestSetMarketsCopyDoesNotMutateDonor(t *testing.T) {
+	donor := newMarketsDonor()
+	donorCurrencyPrecision := GetValue(donor.Currency("BTC"), "precision")
+	recipient := NewBinanceCore()
+	recipient.Init(map[string]interface{}{})
+	recipient.SetMarketsCopy(donor.Markets, donor.Currencies)
+
+	market := recipient.Market("BTC/USDT")
+	AddElementToObject(GetValue(market, "precision"), "amount", 0.1)
+	AddElementToObject(GetValue(market, "info"), "status", "BREAK")
+	AddElementToObject(recipient.Currency("BTC"), "precision", 0.5)
+
+	if amount := GetValue(GetValue(donor.Market("BTC/USDT"), "precision"), "amount"); amount != 0.001 {
+		t.Fatalf("expected the donor precision to stay 0.001, got %v", amount)
+	}
+	if status := GetValue(GetValue(donor.Market("BTC/USDT"), "info"), "status"); status != "TRADING" {
+		t.Fatalf("expected the donor info to stay untouched, got %v", status)
+	}
+	if precision := GetValue(donor.Currency("BTC"), "precision"); precision != donorCurrencyPrecision {
+		t.Fatalf("expected the donor currency precision to stay %v, got %v", donorCurrencyPrecision, precision)
+	}
+	if amount := GetValue(GetValue(recipient.Market("BTC/USDT"), "precision"), "amount"); amount != 0.1 {
+		t.Fatalf("expected the recipient precision to be 0.1, got %v", amount)
+	}
+}
+
+func TestSetMarketsFromExchangeSharesWithDonor(t *testing.T) {
+	donor := newMarketsDonor()
+	recipient := NewBinanceCore()
+	recipient.Init(map[string]interface{}{})
+	recipient.SetMarketsFromExchange(&donor.Exchange)
+
+	AddElementToObject(GetValue(recipient.Market("BTC/USDT"), "precision"), "amount", 0.1)
+
+	if amount := GetValue(GetValue(donor.Market("BTC/USDT"), "precision"), "amount"); amount != 0.1 {
+		t.Fatalf("expected SetMarketsFromExchange to share the market structures, got %v", amount)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// GetMarket/TryGetMarket: typed access to the loaded markets
+// ---------------------------------------------------------------------------
+
+func TestGetMarketParsesLoadedMarkets(t *testing.T) {
+	exchange := NewBinanceCore()
+	exchange.Init(map[string]interface{}{})
+	if _, err := exchange.TryGetMarket("BTC/USDT"); err == nil {
+		t.Fatal("expected an error before the markets are loaded")
+	}
+	exchange.SetMarkets([]interface{}{
+		exchange.SafeMarketStructure(map[string]interface{}{
+			"id":      "BTCUSDT",
+			"symbol":  "BTC/USDT",
+			"base":    "BTC",
+			"quote":   "USDT",
+			"baseId":  "BTC",
+			"quoteId": "USDT",
+			"type":    "spot",
+			"spot":    true,
+			"margin":  true,
+			"active":  true,
+			"info":    map[string]interface{}{"symbol": "BTCUSDT", "status": "TRADING"},
+			"precision": map[string]interface{}{
+				"amount": 0.00001,
+				"price":  0.01,
+			},
+			"limits": map[string]interface{}{
+				"amount": map[string]interface{}{"min": 0.00001, "max": 9000.0},
+				"cost":   map[string]interface{}{"min": 5.0},
+			},
+		}),
+		exchange.SafeMarketStructure(map[string]interface{}{
+			"id":           "ETHUSDT",
+			"symbol":       "ETH/USDT:USDT",
+			"base":         "ETH",
+			"quote":        "USDT",
+			"settle":       "USDT",
+			"baseId":       "ETH",
+			"quoteId":      "USDT",
+			"settleId":     "USDT",
+			"type":         "swap",
+			"spot":         false,
+			"swap":         true,
+			"contract":     true,
+			"linear":       true,
+			"inverse":      false,
+			"contractSize": 1.0,
+			"active":       false,
+			"precision": map[string]interface{}{
+				"amount": 0.001,
+				"price":  0.01,
+			},
+		}),
+	})
+
+	btc, err := exchange.TryGetMarket("BTC/USDT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *btc.Id != "BTCUSDT" || *btc.BaseCurrency != "BTC" || *btc.QuoteCurrency != "USDT" || !*btc.Active {
+		t.Fatalf("unexpected BTC/USDT market %+v", btc)
+	}
+	if *btc.Type != "spot" || !*btc.Spot || !*btc.Margin || *btc.Swap || *btc.Contract {
+		t.Fatalf("unexpected BTC/USDT type flags %+v", btc)
+	}
+	if *btc.Precision.Amount != 0.00001 || *btc.Precision.Price != 0.01 {
+		t.Fatalf("unexpected BTC/USDT precision %+v", btc.Precision)
+	}
+	if *btc.Limits.Amount.Min != 0.00001 || *btc.Limits.Amount.Max != 9000 || *btc.Limits.Cost.Min != 5 || btc.Limits.Cost.Max != nil {
+		t.Fatalf("unexpected BTC/USDT limits %+v", btc.Limits)
+	}
+	// Info is the unified market, its info the raw market of the exchange
+	if GetValue(btc.Info["info"], "status") != "TRADING" {
+		t.Fatalf("expected the raw exchange info, got %v", btc.Info)
+	}
+
+	eth, err := exchange.TryGetMarket("ETH/USDT:USDT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *eth.Symbol != "ETH/USDT:USDT" || *eth.Settle != "USDT" || *eth.Active {
+		t.Fatalf("unexpected ETH/USDT:USDT market %+v", eth)
+	}
+	if !*eth.Swap || !*eth.Contract || !*eth.Linear || *eth.Inverse || *eth.Spot || *eth.ContractSize != 1 {
+		t.Fatalf("unexpected ETH/USDT:USDT type flags %+v", eth)
+	}
+	if *eth.Precision.Amount != 0.001 {
+		t.Fatalf("unexpected ETH/USDT:USDT precision %+v", eth.Precision)
+	}
+
+	_, err = exchange.TryGetMarket("DOGE/USDT")
+	if e, ok := err.(*Error); !ok || e.Type != "BadSymbol" {
+		t.Fatalf("expected a BadSymbol error for an unknown symbol, got %v", err)
+	}
+
+	// GetMarket keeps returning the same market, and an empty one for an unknown symbol
+	if market := exchange.GetMarket("BTC/USDT"); market.Symbol == nil || *market.Symbol != "BTC/USDT" || *market.Precision.Price != 0.01 {
+		t.Fatalf("expected GetMarket to return BTC/USDT, got %+v", market)
+	}
+	if market := exchange.GetMarket("DOGE/USDT"); market.Symbol != nil {
+		t.Fatalf("expected an empty market for an unknown symbol, got %+v", market)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Market/GetMarket: exchange ids resolve to the same market as the unified symbols
+// ---------------------------------------------------------------------------
+
+func TestMarketResolvesExchangeIds(t *testing.T) {
+	exchange := NewBinanceCore()
+	exchange.Init(map[string]interface{}{})
+	exchange.SetMarkets([]interface{}{
+		exchange.SafeMarketStructure(map[string]interface{}{
+			"id":      "BTCUSDT",
+			"symbol":  "BTC/USDT",
+			"base":    "BTC",
+			"quote":   "USDT",
+			"baseId":  "BTC",
+			"quoteId": "USDT",
+			"type":    "spot",
+			"spot":    true,
+			"active":  true,
+		}),
+		exchange.SafeMarketStructure(map[string]interface{}{
+			"id":           "BTCUSDT",
+			"symbol":       "BTC/USDT:USDT",
+			"base":         "BTC",
+			"quote":        "USDT",
+			"settle":       "USDT",
+			"baseId":       "BTC",
+			"quoteId":      "USDT",
+			"settleId":     "USDT",
+			"type":         "swap",
+			"swap":         true,
+			"contract":     true,
+			"linear":       true,
+			"inverse":      false,
+			"contractSize": 1.0,
+			"active":       true,
+		}),
+	})
+	if bySymbol, byId := exchange.Market("BTC/USDT"), exchange.Market("BTCUSDT"); GetValue(bySymbol, "symbol") != "BTC/USDT" || GetValue(byId, "symbol") != "BTC/USDT" {
+		t.Fatalf("expected BTC/USDT and BTCUSDT to resolve to the spot market, got %v and %v", GetValue(bySymbol, "symbol"), GetValue(byId, "symbol"))
+	}
+	bySymbol, err := exchange.TryGetMarket("BTC/USDT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	byId, err := exchange.TryGetMarket("BTCUSDT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *bySymbol.Symbol != "BTC/USDT" || *byId.Symbol != "BTC/USDT" {
+		t.Fatalf("expected GetMarket to resolve both forms to BTC/USDT, got %s and %s", *bySymbol.Symbol, *byId.Symbol)
+	}
+	// the id is shared with the swap market, the default type picks between them
+	AddElementToObject(exchange.Options, "defaultType", "swap")
+	swap, err := exchange.TryGetMarket("BTCUSDT")
+	if err != nil || *swap.Symbol != "BTC/USDT:USDT" {
+		t.Fatalf("expected BTCUSDT to resolve to the swap market with defaultType swap, got %v %v", swap, err)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// FilterMarkets: markets by type, base and quote from the indexes built by SetMarkets
+// ---------------------------------------------------------------------------
+
+func TestFilterMarkets(t *testing.T) {
+	exchange := NewBinanceCore()
+	exchange.Init(map[string]interface{}{})
+	market := func(id string, base string, quote string, marketType string) interface{} {
+		symbol := base + "/" + quote
+		values := map[string]interface{}{
+			"id": id, "symbol": symbol, "base": base, "quote": quote, "baseId": base, "quoteId": quote,
+			"type": marketType, "spot": marketType == "spot", "active": true,
+		}
+		if marketType == "swap" {
+			values["symbol"] = symbol + ":" + quote
+			values["settle"] = quote
+			values["swap"] = true
+			values["contract"] = true
+			values["linear"] = true
+		}
+		return exchange.SafeMarketStructure(values)
+	}
+	exchange.SetMarkets([]interface{}{
+		market("BTCUSDT", "BTC", "USDT", "spot"),
+		market("ETHUSDT", "ETH", "USDT", "spot"),
+		market("ETHBTC", "ETH", "BTC", "spot"),
+		market("BTCUSDT", "BTC", "USDT", "swap"),
+	})
+	symbols := func(markets []Market) []string {
+		result := []string{}
+		for _, market := range markets {
+			result = append(result, *market.Symbol)
+		}
+		return result
+	}
+	if usdt := symbols(exchange.FilterMarkets("", "", "USDT")); len(usdt) != 3 || usdt[0] != "BTC/USDT" || usdt[1] != "BTC/USDT:USDT" || usdt[2] != "ETH/USDT" {
+		t.Fatalf("expected the three USDT markets sorted by symbol, got %v", usdt)
+	}
+	if spot := symbols(exchange.FilterMarkets("spot", "", "USDT")); len(spot) != 2 || spot[0] != "BTC/USDT" || spot[1] != "ETH/USDT" {
+		t.Fatalf("expected the two USDT spot markets, got %v", spot)
+	}
+	if eth := symbols(exchange.FilterMarkets("", "ETH", "")); len(eth) != 2 || eth[0] != "ETH/BTC" {
+		t.Fatalf("expected the two ETH markets, got %v", eth)
+	}
+	if all := exchange.FilterMarkets("", "", ""); len(all) != 4 {
+		t.Fatalf("expected every market without filters, got %d", len(all))
+	}
+	if none := exchange.FilterMarkets("option", "", ""); len(none) != 0 {
+		t.Fatalf("expected no option market, got %v", symbols(none))
+	}
+	// the indexes follow a reload of the markets
+	exchange.SetMarkets([]interface{}{market("SOLUSDT", "SOL", "USDT", "spot")})
+	if usdt := symbols(exchange.FilterMarkets("", "", "USDT")); len(usdt) != 1 || usdt[0] != "SOL/USDT" {
+		t.Fatalf("expected the indexes rebuilt on reload, got %v", usdt)
+	}
+	shared := NewBinanceCore()
+	shared.Init(map[string]interface{}{})
+	shared.SetMarketsFromExchange(&exchange.Exchange)
+	if sol := symbols(shared.FilterMarkets("spot", "SOL", "")); len(sol) != 1 {
+		t.Fatalf("expected the shared markets to be indexed, got %v", sol)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// LoadMarkets: the currencies with their networks unless options.loadCurrencies is false
+// ---------------------------------------------------------------------------
+
+type currenciesMarketsExchange struct {
+	*countingMarketsExchange
+	FetchCurrenciesCallCount int32
+}
+
+func (this *currenciesMarketsExchange) FetchCurrencies(optionalArgs ...interface{}) <-chan interface{} {
+	ch := make(chan interface{}, 1)
+	atomic.AddInt32(&this.FetchCurrenciesCallCount, 1)
+	currency := func(code string, network string, precision float64) interface{} {
+		return map[string]interface{}{
+			"id":        code,
+			"code":      code,
+			"precision": precision,
+			"networks": map[string]interface{}{
+				network: map[string]interface{}{"id": network, "network": network, "fee": 1.0, "deposit": true, "withdraw": true, "precision": precision},
+			},
+		}
+	}
+	ch <- map[string]interface{}{
+		"BTC":  currency("BTC", "BTC", 0.00000001),
+		"USDT": currency("USDT", "TRC20", 0.000001),
+	}
+	close(ch)
+	return ch
+}
+
+func newCurrenciesMarketsExchange(options map[string]interface{}) *currenciesMarketsExchange {
+	exchange := &currenciesMarketsExchange{countingMarketsExchange: newCountingMarketsExchange(map[string]interface{}{"options": options})}
+	exchange.Has["fetchCurrencies"] = true
+	exchange.DerivedExchange = exchange
+	return exchange
+}
+
+func TestLoadMarketsLoadsCurrencies(t *testing.T) {
+	exchange := newCurrenciesMarketsExchange(map[string]interface{}{})
+	if result := <-exchange.LoadMarkets(); IsError(result) {
+		t.Fatal(result)
+	}
+	if calls := atomic.LoadInt32(&exchange.FetchCurrenciesCallCount); calls != 1 {
+		t.Fatalf("expected the currencies to be fetched once, got %d", calls)
+	}
+	base, quote, err := exchange.MarketCurrencies("BTC/USDT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *base.Code != "BTC" || *base.Precision != 0.00000001 || len(base.Networks) != 1 {
+		t.Fatalf("unexpected base currency %+v", base)
+	}
+	if network, ok := quote.Networks["TRC20"]; *quote.Code != "USDT" || !ok || *network.Fee != 1 {
+		t.Fatalf("unexpected quote currency %+v", quote)
+	}
+	if _, _, err := exchange.MarketCurrencies("ETH/USDT"); err == nil {
+		t.Fatal("expected an error for an unknown market")
+	}
+}
+
+func TestLoadMarketsSkipsCurrenciesWhenDisabled(t *testing.T) {
+	exchange := newCurrenciesMarketsExchange(map[string]interface{}{"loadCurrencies": false})
+	if result := <-exchange.LoadMarkets(); IsError(result) {
+		t.Fatal(result)
+	}
+	if calls := atomic.LoadInt32(&exchange.FetchCurrenciesCallCount); calls != 0 {
+		t.Fatalf("expected no currencies request, got %d", calls)
+	}
+	// the currencies are derived from the markets, without networks
+	base, _, err := exchange.MarketCurrencies("BTC/USDT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *base.Code != "BTC" || len(base.Networks) != 0 {
+		t.Fatalf("expected BTC derived from the markets, got %+v", base)
+	}
+}
@@ -3,7 +3,6 @@ package ccxt
 import (
 	"fmt"
 	"reflect"
-	"strconv"
 	"strings"
 	"time"
 )
@@ -47,48 +46,6 @@ func (this *Exchange) ImplodeParams(path interface{}, parameter interface{}) int
 	return pathStr
 }
 
-func ParseTimeframe(timeframe2 interface{}) int64 {
-	timeframe := timeframe2.(string)
-
-	if len(timeframe) < 2 {
-		return 0
-	}
-
-	// Split the timeframe into amount and unit
-	amountStr := timeframe[:len(timeframe)-1]
-	unit := timeframe[len(timeframe)-1:]
-
-	// Convert the amount to a float
-	amount, err := strconv.ParseFloat(amountStr, 64)
-	if err != nil {
-		return 0
-	}
-
-	// Define the unit scale
-	var scale int64
-	switch unit {
-	case "y":
-		scale = 60 * 60 * 24 * 365
-	case "M":
-		scale = 60 * 60 * 24 * 30
-	case "w":
-		scale = 60 * 60 * 24 * 7
-	case "d":
-		scale = 60 * 60 * 24
-	case "h":
-		scale = 60 * 60
-	case "m":
-		scale = 60
-	case "s":
-		scale = 1
-	default:
-		return 0
-	}
-
-	// Return the calculated timeframe in seconds
-	return int64(amount * float64(scale))
-}
-
 func (this *Exchange) RoundTimeframe(timeframe interface{}, timestamp interface{}, direction ...interface{}) interface{} {
 	// Default direction is ROUND_DOWN
 	roundDirection := ROUND_DOWN
@@ -99,7 +56,12 @@ func (this *Exchange) RoundTimeframe(timeframe interface{}, timestamp interface{
 	}
 
 	// Convert timeframe to milliseconds
-	ms := ParseTimeframe(timeframe) * 1000
+	tf, _ := timeframe.(string)
+	seconds, err := ParseTimeframeChecked(tf)
+	if err != nil {
+		return nil
+	}
+	ms := int64(seconds) * 1000
 
 	// Convert timestamp to int64
 	var ts int64
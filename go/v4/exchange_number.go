@@ -227,6 +227,9 @@ func roundToDecimalPlaces(num float64, decimalPlaces int) float64 {
 func (this *Exchange) DecimalToPrecision(value interface{}, roundingMode interface{}, numPrecisionDigits interface{}, args ...interface{}) interface{} {
 	countingMode := GetArg(args, 0, nil)
 	paddingMode := GetArg(args, 1, nil)
+	if result, ok := decimalToPrecisionExact(value, roundingMode, numPrecisionDigits, countingMode, paddingMode); ok {
+		return result
+	}
 	return this._decimalToPrecision(value, roundingMode, numPrecisionDigits, countingMode, paddingMode)
 }
 
@@ -0,0 +1,78 @@
+package ccxt
+
+import "sort"
+
+// ResampleOHLCV aggregates candles of timeframe fromTf into candles of timeframe toTf, for the
+// timeframes an exchange does not offer (2h from 1h, 5m from 1m, 1w from 1d). A candle is [timestamp,
+// open, high, low, close, volume], the buckets start like the candles of the exchanges, see
+// RoundTimestampToTimeframe: weeks on mondays, months and years on calendar months. The first and the
+// last bucket are kept when the candles start or end inside them, the last one then is the candle in
+// progress. nil is returned for an invalid timeframe or when the candles of fromTf do not fit in the
+// buckets of toTf (1M from 1w).
+func ResampleOHLCV(candles [][]float64, fromTf, toTf string) [][]float64 {
+	from, err := ParseTimeframeChecked(fromTf)
+	if err != nil {
+		return nil
+	}
+	to, err := ParseTimeframeChecked(toTf)
+	if err != nil || to < from || !timeframeFits(fromTf, from, toTf, to) {
+		return nil
+	}
+	sorted := make([][]float64, 0, len(candles))
+	for _, candle := range candles {
+		if len(candle) >= 6 {
+			sorted = append(sorted, candle)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i][0] < sorted[j][0] })
+	result := make([][]float64, 0, len(sorted)/(to/from)+1)
+	for _, candle := range sorted {
+		start := float64(RoundTimestampToTimeframe(int64(candle[0]), toTf))
+		last := len(result) - 1
+		if last < 0 || result[last][0] != start {
+			result = append(result, []float64{start, candle[1], candle[2], candle[3], candle[4], candle[5]})
+			continue
+		}
+		bucket := result[last]
+		if candle[2] > bucket[2] {
+			bucket[2] = candle[2]
+		}
+		if candle[3] < bucket[3] {
+			bucket[3] = candle[3]
+		}
+		bucket[4] = candle[4]
+		bucket[5] += candle[5]
+	}
+	return result
+}
+
+// timeframeFits reports whether every candle of fromTf falls into a single bucket of toTf, the
+// durations are the seconds of ParseTimeframe
+func timeframeFits(fromTf string, from int, toTf string, to int) bool {
+	const day = 24 * 60 * 60
+	fromMonths, toMonths := calendarMonths(fromTf, from), calendarMonths(toTf, to)
+	switch {
+	case toMonths > 0 && fromMonths > 0:
+		return toMonths%fromMonths == 0
+	case toMonths > 0:
+		// the months do not last a whole number of weeks or of several days
+		return day%from == 0
+	case fromMonths > 0:
+		return false
+	case toTf[len(toTf)-1] == 'w':
+		// the multiples of several days since the epoch do not start on mondays
+		return to%from == 0 && (day%from == 0 || fromTf[len(fromTf)-1] == 'w')
+	}
+	return to%from == 0
+}
+
+// calendarMonths returns the number of calendar months of a timeframe in months or years, 0 otherwise
+func calendarMonths(tf string, seconds int) int {
+	switch tf[len(tf)-1] {
+	case 'M':
+		return seconds / timeframeUnits['M']
+	case 'y':
+		return seconds / timeframeUnits['y'] * 12
+	}
+	return 0
+}
@@ -0,0 +1,99 @@
+package ccxt
+
+import (
+	"reflect"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// ResampleOHLCV: candles are aggregated into the buckets of the target timeframe
+// ---------------------------------------------------------------------------
+
+func TestResampleOHLCVMinutes(t *testing.T) {
+	minute := float64(60 * 1000)
+	start := float64(1700000100000) // 12:15 UTC, the start of a 5m bucket
+	candles := [][]float64{}
+	for i := 0; i < 12; i++ {
+		price := float64(100 + i)
+		candles = append(candles, []float64{start + float64(i)*minute, price, price + 2, price - 1, price + 1, 10})
+	}
+	result := ResampleOHLCV(candles, "1m", "5m")
+	expected := [][]float64{
+		{start, 100, 106, 99, 105, 50},
+		{start + 5*minute, 105, 111, 104, 110, 50},
+		// the trailing bucket only holds the two candles so far
+		{start + 10*minute, 110, 113, 109, 112, 20},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestResampleOHLCVHours(t *testing.T) {
+	hour := float64(60 * 60 * 1000)
+	day := float64(1699920000000) // 2023-11-14 00:00 UTC
+	candles := [][]float64{
+		// the candles start at 02:00, inside the 00:00 bucket, and are not sorted
+		{day + 3*hour, 21, 24, 20, 23, 3},
+		{day + 2*hour, 20, 22, 19, 21, 2},
+		{day + 4*hour, 23, 30, 22, 28, 4},
+		{day + 5*hour, 28, 29, 15, 16, 5},
+		{day + 6*hour, 16, 18, 16, 17, 6},
+		{day + 7*hour, 17, 19, 17, 18, 7},
+		{day + 8*hour, 18, 20, 18, 19, 8},
+	}
+	result := ResampleOHLCV(candles, "1h", "4h")
+	expected := [][]float64{
+		{day, 20, 24, 19, 23, 5},
+		{day + 4*hour, 23, 30, 15, 18, 22},
+		{day + 8*hour, 18, 20, 18, 19, 8},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+	if result := ResampleOHLCV(candles, "1h", "90m"); result != nil {
+		t.Fatalf("expected nil for a timeframe that is not a multiple, got %v", result)
+	}
+	if result := ResampleOHLCV(candles, "4h", "1h"); result != nil {
+		t.Fatalf("expected nil for a finer target timeframe, got %v", result)
+	}
+}
+
+func TestResampleOHLCVCalendar(t *testing.T) {
+	day := float64(24 * 60 * 60 * 1000)
+	start := float64(1564617600000) // 2019-08-01 00:00 UTC, a thursday
+	candles := [][]float64{}
+	for i := 0; i < 40; i++ {
+		price := float64(100 + i)
+		candles = append(candles, []float64{start + float64(i)*day, price, price + 2, price - 1, price + 1, 1})
+	}
+	// the weeks start on mondays, the first one is only covered from thursday
+	weeks := ResampleOHLCV(candles, "1d", "1w")
+	monday := start - 3*day // 2019-07-29
+	if len(weeks) != 7 {
+		t.Fatalf("expected 7 weeks, got %d", len(weeks))
+	}
+	if !reflect.DeepEqual(weeks[0], []float64{monday, 100, 105, 99, 104, 4}) {
+		t.Fatalf("expected the partial first week from thursday to sunday, got %v", weeks[0])
+	}
+	if !reflect.DeepEqual(weeks[1], []float64{monday + 7*day, 104, 112, 103, 111, 7}) {
+		t.Fatalf("expected the second week from monday 2019-08-05, got %v", weeks[1])
+	}
+	// the months follow the calendar, august has 31 days
+	months := ResampleOHLCV(candles, "1d", "1M")
+	expected := [][]float64{
+		{start, 100, 132, 99, 131, 31},
+		{start + 31*day, 131, 141, 130, 140, 9},
+	}
+	if !reflect.DeepEqual(months, expected) {
+		t.Fatalf("expected %v, got %v", expected, months)
+	}
+	for _, timeframes := range [][2]string{{"1w", "1M"}, {"3d", "1M"}, {"2d", "1w"}, {"1M", "1w"}} {
+		if result := ResampleOHLCV(candles, timeframes[0], timeframes[1]); result != nil {
+			t.Fatalf("expected nil from %s to %s, got %v", timeframes[0], timeframes[1], result)
+		}
+	}
+	if quarters := ResampleOHLCV(months, "1M", "3M"); len(quarters) != 1 || quarters[0][0] != 1561939200000 {
+		t.Fatalf("expected a single quarter from 2019-07-01, got %v", quarters)
+	}
+}
@@ -385,6 +385,7 @@ func (this *Exchange) initializeProperties(extendedProperties map[string]interfa
 	this.RateLimit = SafeFloat(extendedProperties, "rateLimit", -1).(float64)
 	this.RollingWindowSize = SafeFloat(extendedProperties, "rollingWindowSize", 0.0).(float64)
 	this.RateLimiterAlgorithm = SafeString(extendedProperties, "rateLimiterAlgorithm", "leakyBucket").(string)
+	this.MarketsTTL = SafeInteger(extendedProperties, "marketsTTL", 0).(int64)
 	// this.status = SafeValue(extendedProperties, "status",map[string]interface{}{}).(map[string]interface{})
 	this.PrecisionMode = int(SafeInteger(extendedProperties, "precisionMode", this.PrecisionMode).(int64))
 	this.PaddingMode = int(SafeInteger(extendedProperties, "paddingMode", this.PaddingMode).(int64))
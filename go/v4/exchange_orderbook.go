@@ -115,6 +115,13 @@ func (this *WsOrderBook) Limit() interface{} {
 	return this
 }
 
+// SetMaxDepth trims both sides of the maintained book to maxDepth levels after every update,
+// deltas outside of the kept levels are dropped, 0 keeps the full book
+func (this *WsOrderBook) SetMaxDepth(maxDepth int) {
+	this.Asks.SetMaxDepth(maxDepth)
+	this.Bids.SetMaxDepth(maxDepth)
+}
+
 func (this *WsOrderBook) Update(snapshot interface{}) interface{} {
 	// Convert JavaScript logic to Go
 	nonce := this.Nonce
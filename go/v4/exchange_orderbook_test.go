@@ -0,0 +1,143 @@
+package ccxt
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// maxDepth: the maintained book is trimmed after every delta
+// ---------------------------------------------------------------------------
+
+func newMaxDepthExchange(maxDepth int) *BinanceCore {
+	exchange := NewBinanceCore()
+	exchange.Init(map[string]interface{}{
+		"options": map[string]interface{}{
+			"watchOrderBook": map[string]interface{}{
+				"maxDepth": maxDepth,
+			},
+		},
+	})
+	return exchange
+}
+
+func sidePrices(side IOrderBookSide) []float64 {
+	prices := []float64{}
+	for _, level := range side.GetData() {
+		prices = append(prices, normalizeNumber(level[0]))
+	}
+	return prices
+}
+
+func TestOrderBookMaxDepthTrimsEveryUpdate(t *testing.T) {
+	exchange := newMaxDepthExchange(3)
+	orderbook := exchange.OrderBook(map[string]interface{}{
+		"bids": []interface{}{[]interface{}{100.0, 1.0}, []interface{}{99.0, 1.0}, []interface{}{98.0, 1.0}, []interface{}{97.0, 1.0}},
+		"asks": []interface{}{[]interface{}{101.0, 1.0}, []interface{}{102.0, 1.0}, []interface{}{103.0, 1.0}, []interface{}{104.0, 1.0}},
+	})
+	steps := []struct {
+		side     IOrderBookSide
+		delta    []interface{}
+		expected []float64
+	}{
+		{nil, nil, []float64{100, 99, 98}},
+		{orderbook.Bids, []interface{}{100.5, 2.0}, []float64{100.5, 100, 99}},
+		{orderbook.Bids, []interface{}{100.0, 0.0}, []float64{100.5, 99}},
+		{orderbook.Bids, []interface{}{97.0, 0.0}, []float64{100.5, 99}},
+		{orderbook.Bids, []interface{}{98.5, 3.0}, []float64{100.5, 99, 98.5}},
+		{orderbook.Bids, []interface{}{90.0, 3.0}, []float64{100.5, 99, 98.5}},
+		{orderbook.Asks, []interface{}{100.8, 1.0}, []float64{100.5, 99, 98.5}},
+	}
+	for i, step := range steps {
+		if step.side != nil {
+			step.side.StoreArray(step.delta)
+		}
+		prices := sidePrices(orderbook.Bids)
+		if !reflect.DeepEqual(prices, step.expected) {
+			t.Fatalf("step %d: expected bids %v, got %v", i, step.expected, prices)
+		}
+		if orderbook.Asks.Len() > 3 || len(orderbook.Asks.GetData()) > 3 {
+			t.Fatalf("step %d: expected at most 3 asks, got %v", i, sidePrices(orderbook.Asks))
+		}
+	}
+	if asks := sidePrices(orderbook.Asks); !reflect.DeepEqual(asks, []float64{100.8, 101, 102}) {
+		t.Fatalf("expected the asks to keep the best 3 levels, got %v", asks)
+	}
+	delivered := orderbook.Limit().(*WsOrderBook).ToMap()
+	if len(delivered["bids"].([][]interface{})) != 3 || len(delivered["asks"].([][]interface{})) != 3 {
+		t.Fatalf("expected a delivered book of 3 levels per side, got %v", delivered)
+	}
+}
+
+func TestOrderBookMaxDepthKeepsTopOfBook(t *testing.T) {
+	const maxDepth = 5
+	exchange := newMaxDepthExchange(maxDepth)
+	orderbook := exchange.OrderBook()
+	reference := map[float64]float64{}
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		price := float64(random.Intn(100) + 1)
+		size := float64(random.Intn(10))
+		orderbook.Bids.StoreArray([]interface{}{price, size})
+		if size == 0 {
+			delete(reference, price)
+		} else {
+			reference[price] = size
+		}
+		bids := orderbook.Bids.GetData()
+		if len(bids) > maxDepth {
+			t.Fatalf("delta %d: expected at most %d bids, got %d", i, maxDepth, len(bids))
+		}
+		if len(bids) == 0 {
+			continue
+		}
+		best := 0.0
+		for p := range reference {
+			if p > best {
+				best = p
+			}
+		}
+		if normalizeNumber(bids[0][0]) != best || normalizeNumber(bids[0][1]) != reference[best] {
+			t.Fatalf("delta %d: expected best bid %v@%v, got %v", i, best, reference[best], bids[0])
+		}
+		// every kept level mirrors the full book
+		prices := sidePrices(orderbook.Bids)
+		if !sort.SliceIsSorted(prices, func(a, b int) bool { return prices[a] > prices[b] }) {
+			t.Fatalf("delta %d: expected bids sorted descending, got %v", i, prices)
+		}
+		for _, level := range bids {
+			if reference[normalizeNumber(level[0])] != normalizeNumber(level[1]) {
+				t.Fatalf("delta %d: level %v does not match the full book", i, level)
+			}
+		}
+	}
+}
+
+func TestIndexedOrderBookMaxDepth(t *testing.T) {
+	exchange := newMaxDepthExchange(2)
+	orderbook := exchange.IndexedOrderBook()
+	orderbook.Asks.StoreArray([]interface{}{10.0, 1.0, "a"})
+	orderbook.Asks.StoreArray([]interface{}{11.0, 1.0, "b"})
+	orderbook.Asks.StoreArray([]interface{}{9.0, 1.0, "c"})
+	if asks := sidePrices(orderbook.Asks); !reflect.DeepEqual(asks, []float64{9, 10}) {
+		t.Fatalf("expected the 2 best asks, got %v", asks)
+	}
+	// the trimmed order is forgotten, a later update of it is a new insert
+	if _, ok := orderbook.Asks.(*IndexedOrderBookSide).Hashmap["b"]; ok {
+		t.Fatal("expected the trimmed order id to be removed")
+	}
+}
+
+func TestOrderBookWithoutMaxDepthKeepsEveryLevel(t *testing.T) {
+	exchange := NewBinanceCore()
+	exchange.Init(map[string]interface{}{})
+	orderbook := exchange.OrderBook()
+	for i := 1; i <= 50; i++ {
+		orderbook.Bids.StoreArray([]interface{}{float64(i), 1.0})
+	}
+	if orderbook.Bids.Len() != 50 {
+		t.Fatalf("expected 50 bids, got %d", orderbook.Bids.Len())
+	}
+}
@@ -54,16 +54,18 @@ type IOrderBookSide interface {
 	GetIndex() *[]float64
 	String() string
 	SetDepth(depth int)
+	SetMaxDepth(maxDepth int)
 	GetValue(key string, defaultValue interface{}) interface{}
 }
 
 type OrderBookSide struct {
-	Data   [][]interface{} `json:"data"` // equivalent to extending Array
-	Index  []float64       `json:"-"`    // string-keyed dictionary of price levels / ids / indices
-	Depth  int             `json:"-"`    // depth limit
-	Length int             `json:"-"`    // current Length
-	Side   bool            `json:"-"`    // false is asks, true is bids
-	Mutex  sync.RWMutex    `json:"-"`    // protects concurrent access
+	Data     [][]interface{} `json:"data"` // equivalent to extending Array
+	Index    []float64       `json:"-"`    // string-keyed dictionary of price levels / ids / indices
+	Depth    int             `json:"-"`    // depth limit
+	MaxDepth int             `json:"-"`    // levels kept after every delta, 0 keeps all of them
+	Length   int             `json:"-"`    // current Length
+	Side     bool            `json:"-"`    // false is asks, true is bids
+	Mutex    sync.RWMutex    `json:"-"`    // protects concurrent access
 }
 
 func (obs *OrderBookSide) GetValue(key string, defaultValue interface{}) interface{} {
@@ -226,6 +228,9 @@ func (obs *OrderBookSide) StoreArray(delta interface{}) {
 		obs.Data = obs.Data[:obs.Length-1]
 		obs.Length--
 	}
+	if obs.MaxDepth > 0 {
+		obs.truncate(obs.MaxDepth)
+	}
 }
 
 func normalizeNumber(value interface{}) float64 {
@@ -258,16 +263,18 @@ func (obs *OrderBookSide) Limit() {
 	obs.Mutex.Lock()
 	defer obs.Mutex.Unlock()
 
-	if obs.Length > obs.Depth {
-		for i := obs.Depth; i < obs.Length; i++ {
+	obs.truncate(obs.Depth)
+}
+
+// truncate drops the levels beyond depth, the caller must hold the mutex
+func (obs *OrderBookSide) truncate(depth int) {
+	if obs.Length > depth {
+		for i := depth; i < obs.Length; i++ {
 			obs.Index[i] = math.MaxFloat64
-			// obs.Length--
 		}
 		// Ensure Data array is synchronized with new Length
-		if obs.Length > obs.Depth {
-			obs.Data = obs.Data[:obs.Depth]
-		}
-		obs.Length = obs.Depth
+		obs.Data = obs.Data[:depth]
+		obs.Length = depth
 	}
 }
 
@@ -374,17 +381,21 @@ func (obs *CountedOrderBookSide) StoreArray(delta interface{}) {
 		obs.Data = obs.Data[:obs.Length-1]
 		obs.Length--
 	}
+	if obs.MaxDepth > 0 {
+		obs.truncate(obs.MaxDepth)
+	}
 }
 
 type IndexedOrderBookSide struct {
 	*OrderBookSide
-	Hashmap map[interface{}]float64 // string-keyed dictionary of price levels / ids / indices
-	Data    [][]interface{}         // equivalent to extending Array
-	Index   []float64               // string-keyed dictionary of price levels / ids / indices
-	Depth   int                     // depth limit
-	Length  int                     // current Length
-	Side    bool                    // false is asks, true is bids
-	Mutex   sync.RWMutex            // protects concurrent access
+	Hashmap  map[interface{}]float64 // string-keyed dictionary of price levels / ids / indices
+	Data     [][]interface{}         // equivalent to extending Array
+	Index    []float64               // string-keyed dictionary of price levels / ids / indices
+	Depth    int                     // depth limit
+	MaxDepth int                     // levels kept after every delta, 0 keeps all of them
+	Length   int                     // current Length
+	Side     bool                    // false is asks, true is bids
+	Mutex    sync.RWMutex            // protects concurrent access
 }
 
 func NewIndexedOrderBookSide(side bool, deltas interface{}, depth interface{}) *IndexedOrderBookSide {
@@ -545,7 +556,9 @@ func (obs *IndexedOrderBookSide) StoreArray(delta interface{}) {
 		obs.Length--
 		delete(obs.Hashmap, id)
 	}
-
+	if obs.MaxDepth > 0 {
+		obs.truncate(obs.MaxDepth)
+	}
 }
 
 // Limit replaces stored orders with new values
@@ -553,15 +566,18 @@ func (iobs *IndexedOrderBookSide) Limit() {
 	iobs.Mutex.Lock()
 	defer iobs.Mutex.Unlock()
 
-	if iobs.Length > iobs.Depth {
-		for i := iobs.Depth; i < iobs.Length; i++ {
+	iobs.truncate(iobs.Depth)
+}
+
+// truncate drops the orders beyond depth and forgets their ids, the caller must hold the mutex
+func (iobs *IndexedOrderBookSide) truncate(depth int) {
+	if iobs.Length > depth {
+		for i := depth; i < iobs.Length; i++ {
 			delete(iobs.Hashmap, iobs.Data[i][2])
 			iobs.Index[i] = math.MaxFloat64
 		}
-	}
-	if iobs.Length > iobs.Depth {
-		iobs.Data = iobs.Data[:iobs.Depth]
-		iobs.Length = iobs.Depth
+		iobs.Data = iobs.Data[:depth]
+		iobs.Length = depth
 	}
 }
 
@@ -719,6 +735,16 @@ func (obs *OrderBookSide) GetIndex() *[]float64 {
 func (obs *OrderBookSide) SetDepth(depth int) {
 	obs.Depth = depth
 }
+
+// SetMaxDepth keeps at most maxDepth levels after every stored delta, 0 disables the trimming
+func (obs *OrderBookSide) SetMaxDepth(maxDepth int) {
+	obs.Mutex.Lock()
+	defer obs.Mutex.Unlock()
+	obs.MaxDepth = maxDepth
+	if maxDepth > 0 {
+		obs.truncate(maxDepth)
+	}
+}
 func (obs *CountedOrderBookSide) SetLen(length int) {
 	obs.OrderBookSide.SetLen(length)
 }
@@ -770,6 +796,14 @@ func (obs *IndexedOrderBookSide) Len() int {
 func (obs *IndexedOrderBookSide) SetDepth(depth int) {
 	obs.Depth = depth
 }
+func (obs *IndexedOrderBookSide) SetMaxDepth(maxDepth int) {
+	obs.Mutex.Lock()
+	defer obs.Mutex.Unlock()
+	obs.MaxDepth = maxDepth
+	if maxDepth > 0 {
+		obs.truncate(maxDepth)
+	}
+}
 func (iobs *IndexedOrderBookSide) GetValue(key string, defaultValue interface{}) interface{} {
 	switch key {
 	case "Data":
@@ -0,0 +1,80 @@
+package ccxt
+
+import "fmt"
+
+// Time based pagination
+// ---------------------
+// Most fetchTrades, fetchOHLCV and fetchOrders implementations page through
+// the history the same way: request a page from since, move since to the
+// timestamp of the last entry and request again. The last entry of a page is
+// usually returned again as the first entry of the next one (the venues
+// filter with >= since), so the overlapping entries have to be dropped.
+
+// PaginateByTime calls fetchPage from since, advancing since to the latest
+// timestamp of each page, until a page is empty, an entry reaches until or a
+// page brings nothing new. Entries are unified structures or OHLCV arrays,
+// they are de-duplicated by id and timestamp. An until of 0 disables the
+// bound, limit is passed through to fetchPage as the page size.
+func (this *Exchange) PaginateByTime(fetchPage func(since int64, limit int64) ([]interface{}, error), since int64, until int64, limit int64) ([]interface{}, error) {
+	result := []interface{}{}
+	seen := map[string]bool{}
+	for {
+		page, err := fetchPage(since, limit)
+		if err != nil {
+			return nil, err
+		}
+		if len(page) == 0 {
+			break
+		}
+		added := 0
+		reachedUntil := false
+		latest := since
+		for _, entry := range page {
+			timestamp := this.SafeIntegerN(entry, []interface{}{"timestamp", 0})
+			if timestamp != nil {
+				if until > 0 && timestamp.(int64) > until {
+					reachedUntil = true
+					continue
+				}
+				if timestamp.(int64) > latest {
+					latest = timestamp.(int64)
+				}
+			}
+			key := fmt.Sprintf("%v:%v", this.SafeString(entry, "id"), timestamp)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			result = append(result, entry)
+			added++
+		}
+		// a page made only of known entries would be requested again forever
+		if reachedUntil || added == 0 || (until > 0 && latest >= until) {
+			break
+		}
+		since = latest
+	}
+	return result, nil
+}
+
+// PaginateByCursor calls fetchPage with the cursor returned by the previous
+// page, starting from an empty one, until a page returns no cursor or
+// maxPages pages have been fetched, and returns the pages concatenated. It is
+// meant for the venues that page through opaque cursors (or ids) instead of
+// timestamps, the entries are returned in the order of the pages.
+func (this *Exchange) PaginateByCursor(fetchPage func(cursor string) ([]interface{}, string, error), maxPages int) ([]interface{}, error) {
+	result := []interface{}{}
+	cursor := ""
+	for pages := 0; pages < maxPages; pages++ {
+		page, next, err := fetchPage(cursor)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, page...)
+		if next == "" || next == cursor {
+			break
+		}
+		cursor = next
+	}
+	return result, nil
+}
@@ -0,0 +1,115 @@
+package ccxt
+
+import (
+	"errors"
+	"testing"
+)
+
+// pagedTrades serves trades every 1000ms from 0 to 9000, a page starts at the first trade >= since
+// like most venues, so the last trade of a page is returned again as the first one of the next page
+func pagedTrades(calls *[]int64) func(since int64, limit int64) ([]interface{}, error) {
+	return func(since int64, limit int64) ([]interface{}, error) {
+		*calls = append(*calls, since)
+		page := []interface{}{}
+		for timestamp := int64(0); timestamp < 10000 && int64(len(page)) < limit; timestamp += 1000 {
+			if timestamp >= since {
+				page = append(page, map[string]interface{}{"id": ToString(timestamp / 1000), "timestamp": timestamp})
+			}
+		}
+		return page, nil
+	}
+}
+
+func TestPaginateByTimeRemovesOverlappingEntries(t *testing.T) {
+	exchange := &Exchange{}
+	calls := []int64{}
+	trades, err := exchange.PaginateByTime(pagedTrades(&calls), 0, 0, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trades) != 10 {
+		t.Fatalf("expected 10 trades, got %d", len(trades))
+	}
+	for i, trade := range trades {
+		if exchange.SafeInteger(trade, "timestamp") != int64(i*1000) {
+			t.Fatalf("expected the trades in order without duplicates, got %v", trades)
+		}
+	}
+	// 0-3000, 3000-6000, 6000-9000, then a page with the last trade only
+	expected := []int64{0, 3000, 6000, 9000}
+	if len(calls) != len(expected) {
+		t.Fatalf("expected the pages %v, got %v", expected, calls)
+	}
+	for i := range expected {
+		if calls[i] != expected[i] {
+			t.Fatalf("expected the pages %v, got %v", expected, calls)
+		}
+	}
+}
+
+func TestPaginateByTimeStopsAtUntil(t *testing.T) {
+	exchange := &Exchange{}
+	calls := []int64{}
+	trades, err := exchange.PaginateByTime(pagedTrades(&calls), 2000, 5500, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trades) != 4 || exchange.SafeInteger(trades[3], "timestamp") != int64(5000) {
+		t.Fatalf("expected the trades from 2000 to 5000, got %v", trades)
+	}
+	if len(calls) != 2 {
+		t.Fatalf("expected 2 pages, got %v", calls)
+	}
+}
+
+func TestPaginateByTimeOHLCV(t *testing.T) {
+	exchange := &Exchange{}
+	// the same candle is returned over and over, the venue has nothing newer
+	fetchPage := func(since int64, limit int64) ([]interface{}, error) {
+		return []interface{}{[]interface{}{int64(60000), 1.0, 2.0, 0.5, 1.5, 10.0}}, nil
+	}
+	candles, err := exchange.PaginateByTime(fetchPage, 0, 0, 100)
+	if err != nil || len(candles) != 1 {
+		t.Fatalf("expected one candle, got %v %v", candles, err)
+	}
+	failure := errors.New("rate limited")
+	_, err = exchange.PaginateByTime(func(since int64, limit int64) ([]interface{}, error) {
+		return nil, failure
+	}, 0, 0, 100)
+	if err != failure {
+		t.Fatalf("expected the page error, got %v", err)
+	}
+}
+
+func TestPaginateByCursorFollowsTheCursors(t *testing.T) {
+	exchange := &Exchange{}
+	pages := map[string]struct {
+		entries []interface{}
+		next    string
+	}{
+		"":   {[]interface{}{"a", "b"}, "c1"},
+		"c1": {[]interface{}{"c", "d"}, "c2"},
+		"c2": {[]interface{}{"e"}, ""},
+	}
+	cursors := []string{}
+	fetchPage := func(cursor string) ([]interface{}, string, error) {
+		cursors = append(cursors, cursor)
+		page := pages[cursor]
+		return page.entries, page.next, nil
+	}
+	entries, err := exchange.PaginateByCursor(fetchPage, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ToString(entries) != ToString([]interface{}{"a", "b", "c", "d", "e"}) {
+		t.Fatalf("expected the pages concatenated, got %v", entries)
+	}
+	if len(cursors) != 3 || cursors[1] != "c1" || cursors[2] != "c2" {
+		t.Fatalf("expected the cursors to be followed, got %v", cursors)
+	}
+	cursors = []string{}
+	entries, _ = exchange.PaginateByCursor(fetchPage, 2)
+	if len(entries) != 4 || len(cursors) != 2 {
+		t.Fatalf("expected to stop after 2 pages, got %v", entries)
+	}
+}
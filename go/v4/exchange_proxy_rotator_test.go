@@ -0,0 +1,89 @@
+package ccxt
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// ProxyRotator: sequential requests cycle through the proxies and the user agents
+// ---------------------------------------------------------------------------
+
+// newRotatedBinance sends its public requests over plain http so that the test proxies can answer them
+func newRotatedBinance(rotator *ProxyRotator) *BinanceCore {
+	exchange := NewBinanceCore()
+	exchange.Init(map[string]interface{}{})
+	AddElementToObject(GetValue(exchange.Urls, "api"), "public", "http://api.binance.test/api/v3")
+	exchange.ProxyRotator = rotator
+	return exchange
+}
+
+func TestProxyRotatorCyclesThroughProxies(t *testing.T) {
+	var mu sync.Mutex
+	served := []string{}
+	proxies := []string{}
+	for i := 0; i < 3; i++ {
+		name := fmt.Sprintf("proxy-%d", i)
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			served = append(served, name+" "+r.URL.String()+" "+r.Header.Get("User-Agent"))
+			mu.Unlock()
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"serverTime":1499827319559}`)
+		}))
+		defer proxy.Close()
+		proxies = append(proxies, proxy.URL)
+	}
+	exchange := newRotatedBinance(NewProxyRotator(proxies, ProxyRotationRoundRobin, "agent-a", "agent-b"))
+
+	for i := 0; i < 6; i++ {
+		if result := <-exchange.FetchTime(); IsError(result) {
+			t.Fatal(result)
+		}
+	}
+	expected := []string{
+		"proxy-0 http://api.binance.test/api/v3/time agent-a",
+		"proxy-1 http://api.binance.test/api/v3/time agent-b",
+		"proxy-2 http://api.binance.test/api/v3/time agent-a",
+		"proxy-0 http://api.binance.test/api/v3/time agent-b",
+		"proxy-1 http://api.binance.test/api/v3/time agent-a",
+		"proxy-2 http://api.binance.test/api/v3/time agent-b",
+	}
+	if fmt.Sprint(served) != fmt.Sprint(expected) {
+		t.Fatalf("expected the requests to cycle through the proxies\n%v\ngot\n%v", expected, served)
+	}
+}
+
+func TestProxyRotatorComposesWithSetHTTPClient(t *testing.T) {
+	exchange := newRotatedBinance(NewProxyRotator([]string{"http://10.0.0.1:3128", "http://10.0.0.2:3128"}, ""))
+	recorder := &recordingRoundTripper{body: `{"serverTime":1499827319559}`}
+	exchange.SetHTTPClient(&http.Client{Transport: recorder})
+	for i := 0; i < 3; i++ {
+		if result := <-exchange.FetchTime(); IsError(result) {
+			t.Fatal(result)
+		}
+	}
+	if len(recorder.requests) != 3 {
+		t.Fatalf("expected the injected client to send the requests, got %d", len(recorder.requests))
+	}
+	for i, proxy := range []string{"http://10.0.0.1:3128", "http://10.0.0.2:3128", "http://10.0.0.1:3128"} {
+		if actual := RotatedProxy(recorder.requests[i]); actual != proxy {
+			t.Fatalf("request %d: expected the proxy %s, got %q", i, proxy, actual)
+		}
+	}
+}
+
+func TestProxyRotatorRandomStrategy(t *testing.T) {
+	rotator := NewProxyRotator([]string{"http://10.0.0.1:3128", "http://10.0.0.2:3128"}, ProxyRotationRandom)
+	for i := 0; i < 20; i++ {
+		if proxy := rotator.NextProxy(); proxy != "http://10.0.0.1:3128" && proxy != "http://10.0.0.2:3128" {
+			t.Fatalf("expected one of the configured proxies, got %q", proxy)
+		}
+	}
+	if agent := rotator.NextUserAgent(); agent != "" {
+		t.Fatalf("expected no user agent without a pool, got %q", agent)
+	}
+}
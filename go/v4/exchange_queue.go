@@ -6,9 +6,10 @@ import (
 )
 
 type QueueElement struct {
-	Cost float64
-	Task chan bool
-	Id   string
+	Cost      float64
+	Task      chan bool
+	Id        string
+	Timestamp int64
 }
 
 type Queue struct {
@@ -0,0 +1,88 @@
+package ccxt
+
+import (
+	"context"
+	neturl "net/url"
+	"strings"
+)
+
+// fetchRacing is Fetch for the requests of Fetch2, the GET requests of the public apis are raced across
+// the alternate hosts, the private ones are signed for a single request and always go to the primary host
+func (this *Exchange) fetchRacing(api interface{}, url interface{}, method interface{}, headers interface{}, body interface{}) chan interface{} {
+	if isPublicApi(api) {
+		if urls := this.raceUrls(url, method); len(urls) > 1 {
+			return this.FetchRace(urls, method, headers, body)
+		}
+	}
+	return this.Fetch(url, method, headers, body)
+}
+
+// isPublicApi reports whether api, a name or the list of names of the nested apis, is a public api
+// (public, fapiPublic, ["v1", "public"])
+func isPublicApi(api interface{}) bool {
+	switch api := api.(type) {
+	case string:
+		return strings.Contains(strings.ToLower(api), "public")
+	case []interface{}:
+		for _, part := range api {
+			if isPublicApi(part) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// raceUrls returns the url followed by its copies on the alternate hosts of options.alternateHosts
+// when options.raceAlternateHosts is enabled, only read (GET) requests are raced
+func (this *Exchange) raceUrls(url interface{}, method interface{}) []string {
+	if this.Options == nil || !IsTrue(this.SafeBool(this.Options, "raceAlternateHosts", false)) {
+		return nil
+	}
+	urlStr, ok := url.(string)
+	if !ok || !strings.EqualFold(ToString(method), "GET") {
+		return nil
+	}
+	parsed, err := neturl.Parse(urlStr)
+	if err != nil {
+		return nil
+	}
+	alternateHosts := this.SafeList(this.SafeDict(this.Options, "alternateHosts", map[string]interface{}{}), parsed.Host, []interface{}{})
+	urls := []string{urlStr}
+	for _, host := range alternateHosts.([]interface{}) {
+		alternate := *parsed
+		alternate.Host = ToString(host)
+		urls = append(urls, alternate.String())
+	}
+	return urls
+}
+
+// FetchRace sends the same request to every url and returns the first successful response,
+// the slower requests are cancelled through their context. If every request fails the first error is returned
+func (this *Exchange) FetchRace(urls []string, method interface{}, headers interface{}, body interface{}) chan interface{} {
+	ch := make(chan interface{})
+	go func() {
+		defer close(ch)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		results := make(chan interface{}, len(urls))
+		for _, url := range urls {
+			go func(url string) {
+				results <- <-this.FetchWithContext(ctx, url, method, headers, body)
+			}(url)
+		}
+		var firstError interface{}
+		for range urls {
+			result := <-results
+			if !IsError(result) {
+				ch <- result
+				return
+			}
+			if firstError == nil {
+				firstError = result
+			}
+		}
+		ch <- firstError
+	}()
+	return ch
+}
@@ -0,0 +1,110 @@
+package ccxt
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// ---------------------------------------------------------------------------
+// raceAlternateHosts: public read requests go to every host, the first response wins
+// ---------------------------------------------------------------------------
+
+func newRaceExchange(primary *httptest.Server, alternate *httptest.Server) *BinanceCore {
+	exchange := NewBinanceCore()
+	exchange.Init(map[string]interface{}{
+		"options": map[string]interface{}{
+			"raceAlternateHosts": true,
+			"alternateHosts": map[string]interface{}{
+				strings.TrimPrefix(primary.URL, "http://"): []interface{}{strings.TrimPrefix(alternate.URL, "http://")},
+			},
+		},
+	})
+	return exchange
+}
+
+func TestFetchRaceUsesFastestHostAndCancelsSlower(t *testing.T) {
+	cancelled := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+			close(cancelled)
+		case <-time.After(5 * time.Second):
+			w.Write([]byte(`{"host":"slow"}`))
+		}
+	}))
+	defer slow.Close()
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"host":"fast","path":"` + r.URL.Path + `"}`))
+	}))
+	defer fast.Close()
+
+	exchange := newRaceExchange(slow, fast)
+	start := time.Now()
+	result := <-exchange.fetchRacing("public", slow.URL+"/api/v3/ticker/price?symbol=BTCUSDT", "GET", map[string]interface{}{}, nil)
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if host := GetValue(result, "host"); host != "fast" {
+		t.Fatalf("expected the fast host response, got %v", result)
+	}
+	if path := GetValue(result, "path"); path != "/api/v3/ticker/price" {
+		t.Fatalf("expected the same path on the alternate host, got %v", path)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("expected the fast response without waiting for the slow host, took %v", elapsed)
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the slow request to be cancelled")
+	}
+}
+
+func TestFetchRaceFallsBackWhenHostFails(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte(`{"host":"healthy"}`))
+	}))
+	defer healthy.Close()
+
+	exchange := newRaceExchange(failing, healthy)
+	result := <-exchange.fetchRacing([]interface{}{"v1", "public"}, failing.URL+"/api/v3/time", "GET", map[string]interface{}{}, nil)
+	if host := GetValue(result, "host"); host != "healthy" {
+		t.Fatalf("expected the response of the healthy host, got %v", result)
+	}
+}
+
+func TestFetchRaceOnlyRacesPublicReadRequests(t *testing.T) {
+	var alternateRequests int32
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"host":"primary"}`))
+	}))
+	defer primary.Close()
+	alternate := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&alternateRequests, 1)
+		w.Write([]byte(`{"host":"alternate"}`))
+	}))
+	defer alternate.Close()
+
+	exchange := newRaceExchange(primary, alternate)
+	result := <-exchange.fetchRacing("public", primary.URL+"/api/v3/order", "POST", map[string]interface{}{}, nil)
+	if host := GetValue(result, "host"); host != "primary" {
+		t.Fatalf("expected the primary host to serve the order, got %v", result)
+	}
+	// the signed requests of the private apis are sent once
+	result = <-exchange.fetchRacing("private", primary.URL+"/api/v3/account?signature=abc", "GET", map[string]interface{}{}, nil)
+	if host := GetValue(result, "host"); host != "primary" {
+		t.Fatalf("expected the primary host to serve the private request, got %v", result)
+	}
+	if count := atomic.LoadInt32(&alternateRequests); count != 0 {
+		t.Fatalf("expected no request to the alternate host, got %d", count)
+	}
+}
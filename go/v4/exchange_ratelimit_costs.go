@@ -0,0 +1,25 @@
+package ccxt
+
+// CostForMethod returns the cost an implicit api method, e.g. publicGetDepth, charges for the given params,
+// ready to be passed to RateLimiter.Acquire. It is computed by CalculateRateLimiterCost from the cost and the
+// config of the endpoint (byLimit, noSymbol, ...) like the request itself. Unknown methods cost 1
+func (this *Exchange) CostForMethod(method string, params ...interface{}) map[string]float64 {
+	endpoint, ok := this.TransformedApi[method].(map[string]interface{})
+	if !ok {
+		return map[string]float64{"cost": 1}
+	}
+	var cost float64 = 1
+	if valCost, ok := endpoint["cost"]; ok {
+		cost = ToFloat64(valCost)
+	}
+	config := map[string]interface{}{"cost": cost}
+	if endpointConfig, ok := endpoint["config"].(map[string]interface{}); ok && endpointConfig != nil {
+		config = this.Extend(endpointConfig, config)
+	}
+	parameters := GetArg(params, 0, map[string]interface{}{})
+	if parameters == nil {
+		parameters = map[string]interface{}{}
+	}
+	calculated := this.DerivedExchange.CalculateRateLimiterCost(endpoint["api"], endpoint["method"], endpoint["path"], parameters, config)
+	return map[string]float64{"cost": ToFloat64(calculated)}
+}
@@ -0,0 +1,49 @@
+package ccxt
+
+import (
+	"context"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// CostForMethod: the weight of an endpoint comes from its config, tiered by limit
+// ---------------------------------------------------------------------------
+
+func TestCostForMethodByLimit(t *testing.T) {
+	exchange := NewBinanceCore()
+	exchange.Init(map[string]interface{}{})
+	small := exchange.CostForMethod("publicGetDepth", map[string]interface{}{"limit": 100})
+	large := exchange.CostForMethod("publicGetDepth", map[string]interface{}{"limit": 5000})
+	if small["cost"] != 1 || large["cost"] != 50 || large["cost"] <= small["cost"] {
+		t.Fatalf("expected limit=5000 to weigh more than limit=100, got %v and %v", large, small)
+	}
+	for limit, weight := range map[int]float64{5: 1, 101: 5, 1000: 10} {
+		if cost := exchange.CostForMethod("publicGetDepth", map[string]interface{}{"limit": limit}); cost["cost"] != weight {
+			t.Fatalf("expected a weight of %v for limit=%d, got %v", weight, limit, cost)
+		}
+	}
+	if cost := exchange.CostForMethod("publicGetDepth"); cost["cost"] != 1 {
+		t.Fatalf("expected the endpoint cost without a limit, got %v", cost)
+	}
+	if cost := exchange.CostForMethod("unknownMethod"); len(cost) != 1 || cost["cost"] != 1 {
+		t.Fatalf("expected the default cost for an unknown method, got %v", cost)
+	}
+}
+
+func TestCostForMethodFeedsTheRateLimiter(t *testing.T) {
+	exchange := NewBinanceCore()
+	exchange.Init(map[string]interface{}{})
+	clock := &virtualClock{}
+	throttler := NewThrottler(map[string]interface{}{"refillRate": 1.0 / 50, "capacity": 1.0, "delay": 0.001})
+	throttler.Clock = clock
+	if err := throttler.Acquire(context.Background(), exchange.CostForMethod("publicGetDepth", map[string]interface{}{"limit": 5000})); err != nil {
+		t.Fatal(err)
+	}
+	if err := throttler.Acquire(context.Background(), exchange.CostForMethod("publicGetDepth", map[string]interface{}{"limit": 100})); err != nil {
+		t.Fatal(err)
+	}
+	metrics := throttler.GetMetrics()["leakyBucket"]
+	if metrics.ConsumedTokens != 51 || metrics.Requests != 2 {
+		t.Fatalf("expected the tiered weights to be consumed, got %+v", metrics)
+	}
+}
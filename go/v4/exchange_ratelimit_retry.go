@@ -0,0 +1,93 @@
+package ccxt
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultRateLimitRetryDelay is the first backoff in milliseconds when the exchange sends no Retry-After
+const defaultRateLimitRetryDelay = 1000
+
+// retryAfterPattern finds the delay withRetryAfter adds to the error of a rate limited response
+var retryAfterPattern = regexp.MustCompile(`\(retry after (\d+)ms\)`)
+
+// retryRateLimits calls fetch again while its result is rate limited, up to options.maxRetriesOnRateLimit
+// times. fetch goes through fetch2, so every attempt is throttled and signed again and the retries do not
+// reuse a spent nonce or timestamp. Before every retry it sleeps for the Retry-After of the rejected
+// response, seconds or an http date, or for options.maxRetriesOnRateLimitDelay doubled on every attempt
+func (this *Exchange) retryRateLimits(res interface{}, fetch func() interface{}) interface{} {
+	if this.Options == nil {
+		return res
+	}
+	maxRetries := ParseInt(this.SafeInteger(this.Options, "maxRetriesOnRateLimit", 0))
+	for attempt := int64(0); attempt < maxRetries && isRateLimitError(res); attempt++ {
+		delay := this.rateLimitRetryDelay(res, attempt)
+		if this.Verbose {
+			this.Log("Request rate limited, retrying", attempt+1, "of", maxRetries, "in", delay)
+		}
+		time.Sleep(delay)
+		res = fetch()
+	}
+	return res
+}
+
+// withRetryAfter adds the Retry-After of a rate limited response to its error, the error is all the
+// retry gets back from fetch2 and the last response headers belong to whichever request finished last
+func withRetryAfter(r interface{}, header http.Header) interface{} {
+	err, ok := r.(*Error)
+	if !ok || header == nil || (err.Type != RateLimitExceededErrType && err.Type != DDoSProtectionErrType) {
+		return r
+	}
+	if delay, ok := parseRetryAfter(header.Get("Retry-After"), time.Now()); ok {
+		err.Message += fmt.Sprintf(" (retry after %dms)", delay.Milliseconds())
+	}
+	return err
+}
+
+// isRateLimitError reports whether res is a RateLimitExceeded, or the DDoSProtection some exchanges
+// like binance raise for a 429 instead
+func isRateLimitError(res interface{}) bool {
+	if !IsError(res) {
+		return false
+	}
+	err := CreateReturnError(res)
+	return IsErrorType(err, RateLimitExceededErrType) || IsErrorType(err, DDoSProtectionErrType)
+}
+
+// rateLimitRetryDelay returns how long to wait before the retry following attempt
+func (this *Exchange) rateLimitRetryDelay(res interface{}, attempt int64) time.Duration {
+	if match := retryAfterPattern.FindStringSubmatch(ToString(res)); match != nil {
+		if milliseconds, err := strconv.ParseInt(match[1], 10, 64); err == nil {
+			return time.Duration(milliseconds) * time.Millisecond
+		}
+	}
+	base := ToFloat64(this.SafeInteger(this.Options, "maxRetriesOnRateLimitDelay", defaultRateLimitRetryDelay))
+	return time.Duration(base*math.Pow(2, float64(attempt))) * time.Millisecond
+}
+
+// parseRetryAfter parses a Retry-After header, either a number of seconds or an http date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds * float64(time.Second)), true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if delay := date.Sub(now); delay > 0 {
+		return delay, true
+	}
+	return 0, true
+}
@@ -0,0 +1,123 @@
+package ccxt
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// ---------------------------------------------------------------------------
+// maxRetriesOnRateLimit: a request rejected with a 429 is sent again after its Retry-After
+// ---------------------------------------------------------------------------
+
+// rateLimitedTransport rejects the first requests with a 429, the Retry-After is only sent
+// with the rejections
+type rateLimitedTransport struct {
+	mu         sync.Mutex
+	rejections int
+	retryAfter string
+	requests   []*http.Request
+}
+
+func (m *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests = append(m.requests, req)
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	if len(m.requests) <= m.rejections {
+		if m.retryAfter != "" {
+			header.Set("Retry-After", m.retryAfter)
+		}
+		return &http.Response{StatusCode: http.StatusTooManyRequests, Header: header, Body: io.NopCloser(strings.NewReader(`Too Many Requests`)), Request: req}, nil
+	}
+	return &http.Response{StatusCode: http.StatusOK, Header: header, Body: io.NopCloser(strings.NewReader(`{"canTrade":true}`)), Request: req}, nil
+}
+
+func newRateLimitedBinance(options map[string]interface{}, rejections int, retryAfter string) (*BinanceCore, *rateLimitedTransport) {
+	exchange := NewBinanceCore()
+	exchange.Init(map[string]interface{}{"apiKey": "key", "secret": "secret", "options": options})
+	transport := &rateLimitedTransport{rejections: rejections, retryAfter: retryAfter}
+	exchange.httpClient.Transport = transport
+	return exchange, transport
+}
+
+func TestFetchRetriesRateLimitsAfterRetryAfter(t *testing.T) {
+	exchange, transport := newRateLimitedBinance(map[string]interface{}{"maxRetriesOnRateLimit": 2}, 1, "1")
+
+	start := time.Now()
+	result := <-exchange.PrivateGetAccount()
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if GetValue(result, "canTrade") != true || len(transport.requests) != 2 {
+		t.Fatalf("expected the response of the retry, got %v after %d requests", result, len(transport.requests))
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("expected the retry to wait for the Retry-After of 1s, took %v", elapsed)
+	}
+	// the retry is signed again with a fresh timestamp
+	first, second := transport.requests[0].URL.Query(), transport.requests[1].URL.Query()
+	if first.Get("timestamp") == second.Get("timestamp") || first.Get("signature") == second.Get("signature") {
+		t.Fatalf("expected the retry to be signed again, got %s and %s", transport.requests[0].URL.RawQuery, transport.requests[1].URL.RawQuery)
+	}
+}
+
+func TestFetchRateLimitRetryIgnoresOtherResponses(t *testing.T) {
+	exchange, transport := newRateLimitedBinance(map[string]interface{}{"maxRetriesOnRateLimit": 1, "maxRetriesOnRateLimitDelay": 10}, 1, "")
+	// the headers of another request do not set the delay of the retry
+	exchange.LastResponseHeaders = map[string]interface{}{"Retry-After": "30"}
+
+	start := time.Now()
+	if result := <-exchange.PrivateGetAccount(); IsError(result) {
+		t.Fatal(result)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second || len(transport.requests) != 2 {
+		t.Fatalf("expected one retry after the backoff of 10ms, took %v for %d requests", elapsed, len(transport.requests))
+	}
+}
+
+func TestFetchRateLimitRetriesAreBounded(t *testing.T) {
+	exchange, transport := newRateLimitedBinance(map[string]interface{}{"maxRetriesOnRateLimit": 2, "maxRetriesOnRateLimitDelay": 10}, 10, "")
+
+	result := <-exchange.PrivateGetAccount()
+	if !isRateLimitError(result) {
+		t.Fatalf("expected the rate limit error once the retries are exhausted, got %v", result)
+	}
+	if len(transport.requests) != 3 {
+		t.Fatalf("expected the request and 2 retries, got %d requests", len(transport.requests))
+	}
+}
+
+func TestFetchDoesNotRetryRateLimitsByDefault(t *testing.T) {
+	exchange, transport := newRateLimitedBinance(map[string]interface{}{}, 1, "1")
+
+	result := <-exchange.PrivateGetAccount()
+	if !IsError(result) || len(transport.requests) != 1 {
+		t.Fatalf("expected the RateLimitExceeded without retries, got %v after %d requests", result, len(transport.requests))
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"2", 2 * time.Second, true},
+		{"0.5", 500 * time.Millisecond, true},
+		{"Mon, 01 Jan 2024 00:00:30 GMT", 30 * time.Second, true},
+		{"Sun, 31 Dec 2023 23:59:00 GMT", 0, true},
+		{"", 0, false},
+		{"-1", 0, false},
+		{"soon", 0, false},
+	}
+	for _, c := range cases {
+		if delay, ok := parseRetryAfter(c.value, now); delay != c.expected || ok != c.ok {
+			t.Fatalf("%q: expected %v %v, got %v %v", c.value, c.expected, c.ok, delay, ok)
+		}
+	}
+}
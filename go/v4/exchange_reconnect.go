@@ -0,0 +1,314 @@
+package ccxt
+
+import (
+	"math"
+	random2 "math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ReconnectPolicy computes the delay applied before (re)connecting a websocket
+// client. A single policy is shared by all the pro clients of an exchange and
+// keeps the attempt counter per url, so that a mass outage results in growing,
+// jittered delays instead of a reconnect storm.
+//
+// It is configured through exchange.options["ws"]["reconnect"]:
+//
+//	"initialDelay":   1000,   // milliseconds before the first reconnect
+//	"maxDelay":       30000,  // upper bound of the delay in milliseconds
+//	"multiplier":     2.0,    // growth factor applied on every attempt
+//	"jitter":         0.2,    // +/- fraction of randomness added to the delay
+//	"maxAttempts":    0,      // failed attempts before giving up, 0 disables the limit
+//	"stableDuration": 60000,  // a connection open this long resets the backoff
+//	"autoReconnect":  false,  // dial a dropped connection again and resubscribe
+type ReconnectPolicy struct {
+	Config map[string]interface{}
+	States map[string]*ReconnectState
+	Mutex  sync.Mutex
+}
+
+type ReconnectState struct {
+	Attempts    int
+	ConnectedAt int64
+}
+
+func NewReconnectPolicy(config map[string]interface{}) *ReconnectPolicy {
+	defaultConfig := map[string]interface{}{
+		"initialDelay":   1000.0,
+		"maxDelay":       30000.0,
+		"multiplier":     2.0,
+		"jitter":         0.2,
+		"maxAttempts":    0.0,
+		"stableDuration": 60000.0,
+		"autoReconnect":  false,
+	}
+	return &ReconnectPolicy{
+		Config: ExtendMap(defaultConfig, config),
+		States: map[string]*ReconnectState{},
+	}
+}
+
+func (p *ReconnectPolicy) state(url string) *ReconnectState {
+	state, ok := p.States[url]
+	if !ok {
+		state = &ReconnectState{}
+		p.States[url] = state
+	}
+	return state
+}
+
+// NextDelay returns the number of milliseconds to wait before connecting to url,
+// or an ExchangeNotAvailable error once the configured maxAttempts is exhausted,
+// the error is returned once and the next connection starts the backoff over
+func (p *ReconnectPolicy) NextDelay(url string) (int, error) {
+	p.Mutex.Lock()
+	defer p.Mutex.Unlock()
+	state := p.state(url)
+	if state.Attempts == 0 {
+		return 0, nil
+	}
+	maxAttempts := int(ToFloat64(p.Config["maxAttempts"]))
+	if maxAttempts > 0 && state.Attempts > maxAttempts {
+		state.Attempts = 0
+		return 0, ExchangeNotAvailable("giving up reconnecting to " + url + " after " + ToString(maxAttempts) + " attempts")
+	}
+	return p.delay(state.Attempts), nil
+}
+
+func (p *ReconnectPolicy) delay(attempts int) int {
+	initialDelay := ToFloat64(p.Config["initialDelay"])
+	maxDelay := ToFloat64(p.Config["maxDelay"])
+	multiplier := ToFloat64(p.Config["multiplier"])
+	jitter := ToFloat64(p.Config["jitter"])
+	delay := math.Min(initialDelay*math.Pow(multiplier, float64(attempts-1)), maxDelay)
+	if jitter > 0 {
+		delay = delay * (1 + jitter*(2*random2.Float64()-1))
+	}
+	return int(math.Max(delay, 0))
+}
+
+// OnConnected records the moment a connection to url was established
+func (p *ReconnectPolicy) OnConnected(url string) {
+	p.Mutex.Lock()
+	defer p.Mutex.Unlock()
+	p.state(url).ConnectedAt = Milliseconds()
+}
+
+// OnDisconnected counts a failed or dropped connection to url, a connection
+// that stayed open for at least stableDuration starts the backoff over
+func (p *ReconnectPolicy) OnDisconnected(url string) {
+	p.Mutex.Lock()
+	defer p.Mutex.Unlock()
+	state := p.state(url)
+	stableDuration := int64(ToFloat64(p.Config["stableDuration"]))
+	if state.ConnectedAt > 0 && Milliseconds()-state.ConnectedAt >= stableDuration {
+		state.Attempts = 0
+	}
+	state.ConnectedAt = 0
+	state.Attempts++
+}
+
+// Exhausted reports whether no further reconnects to url will be attempted
+func (p *ReconnectPolicy) Exhausted(url string) bool {
+	p.Mutex.Lock()
+	defer p.Mutex.Unlock()
+	maxAttempts := int(ToFloat64(p.Config["maxAttempts"]))
+	return maxAttempts > 0 && p.state(url).Attempts > maxAttempts
+}
+
+// Reset starts the backoff of url over, the policy gives up once per exhaustion
+func (p *ReconnectPolicy) Reset(url string) {
+	p.Mutex.Lock()
+	defer p.Mutex.Unlock()
+	delete(p.States, url)
+}
+
+// AutoReconnect reports whether the dropped connections are dialed again automatically
+func (p *ReconnectPolicy) AutoReconnect() bool {
+	p.Mutex.Lock()
+	defer p.Mutex.Unlock()
+	return IsTrue(p.Config["autoReconnect"])
+}
+
+// SetReconnectPolicy enables the automatic reconnection of the websocket clients: a connection
+// that drops unexpectedly is dialed again after initial, then after delays growing by factor up
+// to max, and the subscriptions that were active on it are sent again
+func (this *Exchange) SetReconnectPolicy(initial time.Duration, max time.Duration, factor float64) {
+	if this.ReconnectPolicy == nil {
+		this.ReconnectPolicy = NewReconnectPolicy(map[string]interface{}{})
+	}
+	this.ReconnectPolicy.Mutex.Lock()
+	defer this.ReconnectPolicy.Mutex.Unlock()
+	this.ReconnectPolicy.Config = ExtendMap(this.ReconnectPolicy.Config, map[string]interface{}{
+		"initialDelay":  float64(initial.Milliseconds()),
+		"maxDelay":      float64(max.Milliseconds()),
+		"multiplier":    factor,
+		"autoReconnect": true,
+	})
+}
+
+// Subscription registry
+// ---------------------
+// Every subscribe message sent by Watch and WatchMultiple is recorded on the
+// client with the subscribe hashes it covers. When the connection drops, the
+// messages whose subscriptions are still active are replayed on the new
+// connection. The messages are sent as they were, the private streams that
+// need a fresh login or listen key are not covered. ListSubscriptions exposes
+// the active subscriptions with the message that subscribed them.
+
+type wsResubscription struct {
+	subscribeHashes []string
+	message         interface{}
+	subscribedAt    int64
+}
+
+// Subscription is an active subscription of a websocket client, see ListSubscriptions
+type Subscription struct {
+	Url          string
+	Hash         string      // subscribe hash
+	Message      interface{} // subscribe message sent with the topics and the params, nil if none was sent
+	SubscribedAt int64       // milliseconds, 0 if no subscribe message was sent
+}
+
+// ListSubscriptions returns the active subscriptions of the client sorted by subscription time,
+// the unwatched streams and the pending unsubscriptions are left out
+func (this *Client) ListSubscriptions() []Subscription {
+	this.SubscriptionsMu.RLock()
+	defer this.SubscriptionsMu.RUnlock()
+	recorded := map[string]*wsResubscription{}
+	for _, resubscription := range this.Resubscriptions {
+		for _, hash := range resubscription.subscribeHashes {
+			if previous, ok := recorded[hash]; !ok || resubscription.subscribedAt > previous.subscribedAt {
+				recorded[hash] = resubscription
+			}
+		}
+	}
+	subscriptions := make([]Subscription, 0, len(this.Subscriptions))
+	for hash, subscription := range this.Subscriptions {
+		if isUnsubscription(subscription) {
+			continue
+		}
+		entry := Subscription{Url: this.Url, Hash: hash}
+		if resubscription, ok := recorded[hash]; ok {
+			entry.Message = resubscription.message
+			entry.SubscribedAt = resubscription.subscribedAt
+		}
+		subscriptions = append(subscriptions, entry)
+	}
+	sort.Slice(subscriptions, func(i, j int) bool {
+		if subscriptions[i].SubscribedAt != subscriptions[j].SubscribedAt {
+			return subscriptions[i].SubscribedAt < subscriptions[j].SubscribedAt
+		}
+		return subscriptions[i].Hash < subscriptions[j].Hash
+	})
+	return subscriptions
+}
+
+// RecordSubscription remembers the message that subscribed to subscribeHashes
+func (this *Client) RecordSubscription(subscribeHashes []string, message interface{}) {
+	if message == nil || len(subscribeHashes) == 0 {
+		return
+	}
+	this.SubscriptionsMu.Lock()
+	defer this.SubscriptionsMu.Unlock()
+	if this.Resubscriptions == nil {
+		this.Resubscriptions = make(map[string]*wsResubscription)
+	}
+	this.Resubscriptions[strings.Join(subscribeHashes, ",")] = &wsResubscription{subscribeHashes, message, Milliseconds()}
+}
+
+// activeResubscriptions returns the recorded messages that still have an active subscription
+// along with the subscriptions they cover, the unwatched streams are not subscribed again
+func (this *Client) activeResubscriptions() ([]*wsResubscription, map[string]interface{}) {
+	this.SubscriptionsMu.RLock()
+	defer this.SubscriptionsMu.RUnlock()
+	resubscriptions := []*wsResubscription{}
+	subscriptions := map[string]interface{}{}
+	for _, resubscription := range this.Resubscriptions {
+		active := false
+		for _, hash := range resubscription.subscribeHashes {
+			if subscription, ok := this.Subscriptions[hash]; ok && !isUnsubscription(subscription) {
+				subscriptions[hash] = subscription
+				active = true
+			}
+		}
+		if active {
+			resubscriptions = append(resubscriptions, resubscription)
+		}
+	}
+	return resubscriptions, subscriptions
+}
+
+// Resubscribe dials the url of a dropped client again, applying the backoff of the ReconnectPolicy,
+// and replays its active subscriptions once connected. A failed attempt goes through OnError again,
+// so the attempts go on until the connection succeeds or the policy gives up.
+func (this *Exchange) Resubscribe(dropped *Client) {
+	url := dropped.Url
+	resubscriptions, subscriptions := dropped.activeResubscriptions()
+	if len(resubscriptions) == 0 {
+		return
+	}
+	if this.ReconnectPolicy.Exhausted(url) {
+		this.ReconnectPolicy.Reset(url)
+		if this.Verbose {
+			this.Log(time.Now(), "giving up reconnecting to", url)
+		}
+		return
+	}
+	client := this.Client(url)
+	if client == nil {
+		return
+	}
+	// a watch call may have subscribed again on the new client in the meantime
+	pending := []*wsResubscription{}
+	client.SubscriptionsMu.Lock()
+	for _, resubscription := range resubscriptions {
+		missing := false
+		for _, hash := range resubscription.subscribeHashes {
+			if _, ok := client.Subscriptions[hash]; !ok {
+				if subscription, active := subscriptions[hash]; active {
+					client.Subscriptions[hash] = subscription
+					missing = true
+				}
+			}
+		}
+		if missing {
+			pending = append(pending, resubscription)
+		}
+	}
+	client.SubscriptionsMu.Unlock()
+	for _, resubscription := range pending {
+		client.RecordSubscription(resubscription.subscribeHashes, resubscription.message)
+	}
+	if len(pending) == 0 {
+		return
+	}
+	client.ConnectMu.Lock()
+	connected, err := this.ConnectClient(client)
+	client.ConnectMu.Unlock()
+	if err != nil {
+		client.OnError(err)
+		return
+	}
+	go func() {
+		if _, ok := (<-connected.Await()).(error); ok {
+			return
+		}
+		options := SafeValue(this.Options, "ws", make(map[string]interface{}))
+		cost := SafeValue(options, "cost", 1)
+		for _, resubscription := range pending {
+			if throttler, ok := client.Throttle.(*Throttler); ok && this.EnableRateLimit {
+				<-throttler.Throttle(cost)
+			}
+			if this.Verbose {
+				this.Log(time.Now(), "resubscribing to", url, resubscription.subscribeHashes)
+			}
+			if err, ok := (<-client.Send(resubscription.message)).(error); ok {
+				client.OnError(err)
+				return
+			}
+		}
+	}()
+}
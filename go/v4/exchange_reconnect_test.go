@@ -0,0 +1,188 @@
+package ccxt
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// ---------------------------------------------------------------------------
+// ReconnectPolicy: backoff growth, jitter, stability reset, max attempts
+// ---------------------------------------------------------------------------
+
+func TestReconnectPolicyDelaysGrowWithJitter(t *testing.T) {
+	p := NewReconnectPolicy(map[string]interface{}{
+		"initialDelay": 100.0,
+		"maxDelay":     100000.0,
+		"multiplier":   2.0,
+		"jitter":       0.1,
+		"maxAttempts":  0.0,
+	})
+	url := "wss://example.com/ws"
+
+	delay, err := p.NextDelay(url)
+	if err != nil || delay != 0 {
+		t.Fatalf("expected no delay before the first failure, got %d, %v", delay, err)
+	}
+
+	previous := 0
+	for attempt := 1; attempt <= 6; attempt++ {
+		p.OnDisconnected(url)
+		delay, err := p.NextDelay(url)
+		if err != nil {
+			t.Fatalf("unexpected error on attempt %d: %v", attempt, err)
+		}
+		base := 100.0 * float64(int(1)<<(attempt-1))
+		if float64(delay) < base*0.9-1 || float64(delay) > base*1.1+1 {
+			t.Fatalf("attempt %d: delay %d outside of jitter range around %v", attempt, delay, base)
+		}
+		if delay <= previous {
+			t.Fatalf("attempt %d: delay %d did not grow from %d", attempt, delay, previous)
+		}
+		previous = delay
+	}
+}
+
+func TestReconnectPolicyJitterVaries(t *testing.T) {
+	p := NewReconnectPolicy(map[string]interface{}{
+		"initialDelay": 1000.0,
+		"jitter":       0.5,
+	})
+	seen := map[int]bool{}
+	for i := 0; i < 20; i++ {
+		seen[p.delay(1)] = true
+	}
+	if len(seen) < 2 {
+		t.Fatal("expected jitter to produce different delays")
+	}
+}
+
+func TestReconnectPolicyMaxDelay(t *testing.T) {
+	p := NewReconnectPolicy(map[string]interface{}{
+		"initialDelay": 100.0,
+		"maxDelay":     500.0,
+		"jitter":       0.0,
+		"maxAttempts":  0.0,
+	})
+	if delay := p.delay(20); delay != 500 {
+		t.Fatalf("expected delay capped at 500, got %d", delay)
+	}
+}
+
+func TestReconnectPolicyStableConnectionResetsBackoff(t *testing.T) {
+	p := NewReconnectPolicy(map[string]interface{}{
+		"initialDelay":   100.0,
+		"jitter":         0.0,
+		"stableDuration": 20.0,
+	})
+	url := "wss://example.com/ws"
+
+	for i := 0; i < 4; i++ {
+		p.OnDisconnected(url)
+	}
+	if delay, _ := p.NextDelay(url); delay != 800 {
+		t.Fatalf("expected 800ms after 4 failures, got %d", delay)
+	}
+
+	// a short-lived connection keeps growing the backoff
+	p.OnConnected(url)
+	p.OnDisconnected(url)
+	if delay, _ := p.NextDelay(url); delay != 1600 {
+		t.Fatalf("expected 1600ms after an unstable connection, got %d", delay)
+	}
+
+	// a connection that outlived stableDuration starts over
+	p.OnConnected(url)
+	time.Sleep(30 * time.Millisecond)
+	p.OnDisconnected(url)
+	if delay, _ := p.NextDelay(url); delay != 100 {
+		t.Fatalf("expected backoff reset to 100ms, got %d", delay)
+	}
+}
+
+func TestReconnectPolicyMaxAttempts(t *testing.T) {
+	p := NewReconnectPolicy(map[string]interface{}{
+		"initialDelay": 1.0,
+		"maxAttempts":  3.0,
+	})
+	url := "wss://example.com/ws"
+
+	for i := 0; i < 3; i++ {
+		p.OnDisconnected(url)
+		if _, err := p.NextDelay(url); err != nil {
+			t.Fatalf("unexpected error after %d failures: %v", i+1, err)
+		}
+	}
+	p.OnDisconnected(url)
+	if !p.Exhausted(url) {
+		t.Fatal("expected the policy to be exhausted")
+	}
+	_, err := p.NextDelay(url)
+	if err == nil || !strings.Contains(err.Error(), "ExchangeNotAvailable") {
+		t.Fatalf("expected a terminal ExchangeNotAvailable error, got %v", err)
+	}
+	// the terminal error is returned once, the next connection starts over
+	if delay, err := p.NextDelay(url); delay != 0 || err != nil {
+		t.Fatalf("expected the backoff to start over, got %v, %v", delay, err)
+	}
+	// other urls are tracked independently
+	if _, err := p.NextDelay("wss://example.com/other"); err != nil {
+		t.Fatalf("unexpected error for another url: %v", err)
+	}
+}
+
+func TestConnectClientSurfacesReconnectExhaustion(t *testing.T) {
+	exchange := &Exchange{}
+	exchange.Init(map[string]interface{}{})
+	exchange.ReconnectPolicy = NewReconnectPolicy(map[string]interface{}{"maxAttempts": 1.0})
+	url := "wss://example.com/ws"
+	exchange.ReconnectPolicy.OnDisconnected(url)
+	exchange.ReconnectPolicy.OnDisconnected(url)
+
+	client := NewWSClient(url, nil, nil, nil, nil, "")
+	future, err := exchange.ConnectClient(client)
+	if future != nil || err == nil {
+		t.Fatalf("expected a terminal error, got %v, %v", future, err)
+	}
+}
+
+func TestReconnectPolicyUnlimitedByDefault(t *testing.T) {
+	p := NewReconnectPolicy(map[string]interface{}{"initialDelay": 1.0, "maxDelay": 1.0})
+	url := "wss://example.com/ws"
+	for i := 0; i < 50; i++ {
+		p.OnDisconnected(url)
+	}
+	if _, err := p.NextDelay(url); err != nil || p.Exhausted(url) {
+		t.Fatalf("expected no attempt cap by default, got %v", err)
+	}
+}
+
+func TestConnectClientAfterReconnectExhaustion(t *testing.T) {
+	server, _ := newPingTestServer(t, true)
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	exchange := &Exchange{}
+	exchange.Init(map[string]interface{}{})
+	exchange.ReconnectPolicy = NewReconnectPolicy(map[string]interface{}{"initialDelay": 1.0, "maxAttempts": 1.0})
+	exchange.ReconnectPolicy.OnDisconnected(url)
+	exchange.ReconnectPolicy.OnDisconnected(url)
+	newClient := func() *WSClient {
+		noop := func(interface{}, interface{}) {}
+		return NewWSClient(url, noop, noop, noop, nil, "")
+	}
+	if _, err := exchange.ConnectClient(newClient()); err == nil {
+		t.Fatal("expected the exhausted policy to return a terminal error")
+	}
+	// once the outage is over the url can be connected again
+	connected, err := exchange.ConnectClient(newClient())
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case result := <-connected.Await():
+		if err, ok := result.(error); ok {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the connection")
+	}
+}
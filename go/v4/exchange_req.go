@@ -3,6 +3,7 @@ package ccxt
 import (
 	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -13,12 +14,18 @@ import (
 )
 
 func (this *Exchange) Fetch(url interface{}, method interface{}, headers interface{}, body interface{}) chan interface{} {
+	return this.FetchWithContext(context.Background(), url, method, headers, body)
+}
+
+// FetchWithContext is Fetch with a context that cancels the http request
+func (this *Exchange) FetchWithContext(ctx context.Context, url interface{}, method interface{}, headers interface{}, body interface{}) chan interface{} {
 	ch := make(chan interface{})
 	go func() {
 		defer close(ch)
+		var responseHeader http.Header
 		defer func() {
 			if r := recover(); r != nil {
-				ch <- "panic:" + ToString(r)
+				ch <- "panic:" + ToString(withRetryAfter(r, responseHeader))
 			}
 		}()
 
@@ -113,7 +120,7 @@ func (this *Exchange) Fetch(url interface{}, method interface{}, headers interfa
 				// 		data.Set(key, value)
 				// 	}
 				// }
-				req, err = http.NewRequest(methodStr, urlStr, strings.NewReader(v))
+				req, err = http.NewRequestWithContext(ctx, methodStr, urlStr, strings.NewReader(v))
 				if err != nil {
 					panic("error creating request")
 				}
@@ -122,13 +129,13 @@ func (this *Exchange) Fetch(url interface{}, method interface{}, headers interfa
 				if err != nil {
 					panic("error marshalling JSON")
 				}
-				req, err = http.NewRequest(methodStr, urlStr, bytes.NewBuffer(requestBody))
+				req, err = http.NewRequestWithContext(ctx, methodStr, urlStr, bytes.NewBuffer(requestBody))
 				if err != nil {
 					panic("error creating request")
 				}
 			}
 		} else {
-			req, err = http.NewRequest(methodStr, urlStr, nil)
+			req, err = http.NewRequestWithContext(ctx, methodStr, urlStr, nil)
 			if err != nil {
 				panic("error creating request")
 			}
@@ -161,7 +168,12 @@ func (this *Exchange) Fetch(url interface{}, method interface{}, headers interfa
 
 		// strings.NewReader()
 		// Send the request
-		resp, err := this.httpClient.Do(req)
+		client := this.httpClient
+		if this.ProxyRotator != nil {
+			req = this.ProxyRotator.apply(req)
+			client = this.ProxyRotator.clientFor(client)
+		}
+		resp, err := client.Do(req)
 
 		// Read the response body
 		// respBody, err := ioutil.ReadAll(resp.Body)
@@ -172,6 +184,7 @@ func (this *Exchange) Fetch(url interface{}, method interface{}, headers interfa
 			networkError := NetworkError(fmt.Sprintf("Network error: %v", err))
 			panic(networkError)
 		}
+		responseHeader = resp.Header
 		this.Last_response_headers = HeaderToMap(resp.Header)
 		this.LastResponseHeaders = HeaderToMap(resp.Header)
 		if err == nil {
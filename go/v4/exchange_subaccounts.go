@@ -0,0 +1,105 @@
+package ccxt
+
+func (this *Exchange) FetchSubAccounts(optionalArgs ...interface{}) <-chan interface{} {
+	ch := make(chan interface{})
+	go func() interface{} {
+		defer close(ch)
+		defer ReturnPanicError(ch)
+		panic(NotSupported(Add(this.Id, " fetchSubAccounts() is not supported yet")))
+
+	}()
+	return ch
+}
+
+func (this *Exchange) FetchSubAccountBalance(subAccountId interface{}, optionalArgs ...interface{}) <-chan interface{} {
+	ch := make(chan interface{})
+	go func() interface{} {
+		defer close(ch)
+		defer ReturnPanicError(ch)
+		panic(NotSupported(Add(this.Id, " fetchSubAccountBalance() is not supported yet")))
+
+	}()
+	return ch
+}
+
+/**
+ * @method
+ * @name exchange#fetchAllBalances
+ * @description fetches the balance of the master account and of every sub-account and sums them up
+ * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @returns {object} a [balance structure]{@link https://docs.ccxt.com/?id=balance-structure} with the combined totals, the balances of each account are available in info.master and info.subAccounts
+ */
+func (this *Exchange) FetchAllBalances(optionalArgs ...interface{}) <-chan interface{} {
+	ch := make(chan interface{})
+	go func() interface{} {
+		defer close(ch)
+		defer ReturnPanicError(ch)
+		params := GetArg(optionalArgs, 0, map[string]interface{}{})
+		_ = params
+		if !IsTrue(GetValue(this.Has, "fetchSubAccounts")) {
+			panic(NotSupported(Add(this.Id, " fetchAllBalances() is not supported yet")))
+		}
+		master := <-this.DerivedExchange.FetchBalance(params)
+		PanicOnError(master)
+		subAccounts := <-this.DerivedExchange.FetchSubAccounts(params)
+		this.PanicOnSubAccountError("fetchAllBalances", subAccounts)
+		subAccountBalances := map[string]interface{}{}
+		balances := []interface{}{master}
+		for i := 0; IsLessThan(i, GetArrayLength(subAccounts)); i++ {
+			subAccountId := this.SafeString(GetValue(subAccounts, i), "id")
+			balance := <-this.DerivedExchange.FetchSubAccountBalance(subAccountId, params)
+			this.PanicOnSubAccountError("fetchAllBalances", balance)
+			subAccountBalances[subAccountId.(string)] = balance
+			balances = append(balances, balance)
+		}
+		result := this.AggregateBalances(balances)
+		result["info"] = map[string]interface{}{
+			"master":      master,
+			"subAccounts": subAccountBalances,
+		}
+		ch <- this.SafeBalance(result)
+		return nil
+
+	}()
+	return ch
+}
+
+// AggregateBalances sums the free, used and total amounts per currency of unified balance structures
+func (this *Exchange) AggregateBalances(balances []interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	for _, balance := range balances {
+		codes := ObjectKeys(this.Omit(balance, []interface{}{"info", "timestamp", "datetime", "free", "used", "total", "debt"}))
+		for i := 0; IsLessThan(i, GetArrayLength(codes)); i++ {
+			code := GetValue(codes, i).(string)
+			entry := GetValue(balance, code)
+			account, ok := result[code].(map[string]interface{})
+			if !ok {
+				account = map[string]interface{}{}
+				result[code] = account
+			}
+			for _, key := range []string{"free", "used", "total"} {
+				value := this.SafeString(entry, key)
+				if value == nil {
+					continue
+				}
+				if previous := this.SafeString(account, key); previous != nil {
+					value = Precise.StringAdd(previous, value)
+				}
+				account[key] = value
+			}
+		}
+	}
+	return result
+}
+
+// PanicOnSubAccountError is PanicOnError for sub-account endpoints, which are only reachable with
+// a master account key, authentication and permission errors are raised as a PermissionDenied that says so
+func (this *Exchange) PanicOnSubAccountError(methodName interface{}, response interface{}) {
+	if IsError(response) {
+		err, ok := CreateReturnError(response).(*Error)
+		if ok && (err.Type == "AuthenticationError" || err.Type == "PermissionDenied") {
+			panic(PermissionDenied(Add(Add(Add(Add(this.Id, " "), methodName), "() requires a master account API key with sub-account permissions: "), err.Message)))
+		}
+	}
+	PanicOnError(response)
+}
@@ -1,23 +1,70 @@
 package ccxt
 
 import (
+	"context"
+	"fmt"
 	"sync"
 	"time"
 
 	u "github.com/google/uuid"
 )
 
+// RateLimiter is implemented by every throttler the exchange can be configured with,
+// the cost is keyed by rule so that multi-rule limiters can charge each of them
+type RateLimiter interface {
+	Acquire(ctx context.Context, cost map[string]float64) error
+	GetMetrics() map[string]RuleMetrics
+}
+
 type TimestampedCost struct {
-	Timestamp int64
-	Cost      float64
+	Timestamp int64   `json:"timestamp"`
+	Cost      float64 `json:"cost"`
+}
+
+// ThrottleRuleConfig is the json serializable form of a throttling rule, Interval is the rolling
+// window size in milliseconds and Delay the seconds a leaky bucket waits before checking the queue again.
+// Tokens and Timestamps hold the live state and are only exported on request
+type ThrottleRuleConfig struct {
+	Id         string            `json:"id"`
+	Capacity   float64           `json:"capacity"`
+	RefillRate float64           `json:"refillRate"`
+	Interval   float64           `json:"interval"`
+	Delay      float64           `json:"delay"`
+	Cost       float64           `json:"cost"`
+	RateLimit  float64           `json:"rateLimit"`
+	Tokens     *float64          `json:"tokens,omitempty"`
+	Timestamps []TimestampedCost `json:"timestamps,omitempty"`
+}
+
+// RuleMetrics holds the cumulative counters of a throttling rule,
+// WaitTime is the total time in milliseconds requests spent queued
+type RuleMetrics struct {
+	ConsumedTokens float64
+	Requests       int64
+	WaitTime       int64
+}
+
+// Clock is the time source of the throttler, tests and benchmarks inject a virtual
+// clock so that the waiting is deterministic and takes no real time
+type Clock interface {
+	Milliseconds() int64
+	Sleep(d time.Duration)
 }
 
+type systemClock struct{}
+
+func (systemClock) Milliseconds() int64 { return Milliseconds() }
+
+func (systemClock) Sleep(d time.Duration) { time.Sleep(d) }
+
 type Throttler struct {
 	Queue      Queue
 	Running    bool
 	Config     map[string]interface{}
 	Timestamps []TimestampedCost
+	Metrics    RuleMetrics
 	Mutex      sync.Mutex
+	Clock      Clock
 }
 
 func NewThrottler(config map[string]interface{}) *Throttler {
@@ -42,9 +89,67 @@ func NewThrottler(config map[string]interface{}) *Throttler {
 		Running:    false,
 		Config:     config,
 		Timestamps: []TimestampedCost{},
+		Clock:      systemClock{},
 	}
 }
 
+// clock returns the injected clock, the system clock for a zero Throttler
+func (t *Throttler) clock() Clock {
+	if t.Clock == nil {
+		return systemClock{}
+	}
+	return t.Clock
+}
+
+// NewThrottlerFromConfig rebuilds a throttler from the rules returned by ExportConfig,
+// the live state is restored when it was exported
+func NewThrottlerFromConfig(rules []ThrottleRuleConfig) (*Throttler, error) {
+	if len(rules) != 1 {
+		return nil, ArgumentsRequired(fmt.Sprintf("NewThrottlerFromConfig() requires exactly one rule, got %d", len(rules)))
+	}
+	rule := rules[0]
+	if rule.Id != "leakyBucket" && rule.Id != "rollingWindow" {
+		return nil, BadRequest("NewThrottlerFromConfig() does not support the " + rule.Id + " rule")
+	}
+	config := map[string]interface{}{
+		"algorithm":  rule.Id,
+		"capacity":   rule.Capacity,
+		"refillRate": rule.RefillRate,
+		"windowSize": rule.Interval,
+		"delay":      rule.Delay,
+		"cost":       rule.Cost,
+		"rateLimit":  rule.RateLimit,
+	}
+	if rule.Tokens != nil {
+		config["tokens"] = *rule.Tokens
+	}
+	throttler := NewThrottler(config)
+	throttler.Timestamps = append(throttler.Timestamps, rule.Timestamps...)
+	return throttler, nil
+}
+
+// ExportConfig returns the rules of the throttler in a json serializable form,
+// pass true to include the live token state
+func (t *Throttler) ExportConfig(includeState ...bool) []ThrottleRuleConfig {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+	rule := ThrottleRuleConfig{
+		Id:         ToString(t.Config["algorithm"]),
+		Capacity:   ToFloat64(t.Config["capacity"]),
+		RefillRate: ToFloat64(t.Config["refillRate"]),
+		Interval:   ToFloat64(t.Config["windowSize"]),
+		Delay:      ToFloat64(t.Config["delay"]),
+		Cost:       ToFloat64(t.Config["cost"]),
+		RateLimit:  ToFloat64(t.Config["rateLimit"]),
+	}
+	if len(includeState) > 0 && includeState[0] {
+		tokens := ToFloat64(t.Config["tokens"])
+		rule.Tokens = &tokens
+		rule.Timestamps = append([]TimestampedCost{}, t.Timestamps...)
+	}
+	return []ThrottleRuleConfig{rule}
+}
+
 func (t *Throttler) Throttle(cost2 interface{}) <-chan bool {
 	if cost2 == nil {
 		t.Mutex.Lock()
@@ -55,9 +160,10 @@ func (t *Throttler) Throttle(cost2 interface{}) <-chan bool {
 	task := make(chan bool)
 
 	queueElement := QueueElement{
-		Cost: cost,
-		Task: task,
-		Id:   u.New().String(),
+		Cost:      cost,
+		Task:      task,
+		Id:        u.New().String(),
+		Timestamp: t.clock().Milliseconds(),
 	}
 
 	t.Queue.Enqueue(queueElement)
@@ -82,7 +188,7 @@ func (t *Throttler) Throttle(cost2 interface{}) <-chan bool {
 
 func (t *Throttler) leakyBucketLoop() {
 
-	lastTimestamp := Milliseconds()
+	lastTimestamp := t.clock().Milliseconds()
 	for {
 		if t.Queue.IsEmpty() {
 			t.Mutex.Lock()
@@ -99,6 +205,7 @@ func (t *Throttler) leakyBucketLoop() {
 		tokens := ToFloat64(t.Config["tokens"])
 		if tokens >= 0 {
 			t.Config["tokens"] = tokens - cost
+			t.admit(first)
 			t.Mutex.Unlock()
 
 			if task != nil {
@@ -109,9 +216,9 @@ func (t *Throttler) leakyBucketLoop() {
 		} else {
 			sleepTime := ToFloat64(t.Config["delay"]) * 1000
 			t.Mutex.Unlock()
-			time.Sleep(time.Duration(sleepTime) * time.Millisecond)
+			t.clock().Sleep(time.Duration(sleepTime) * time.Millisecond)
 
-			current := Milliseconds()
+			current := t.clock().Milliseconds()
 			elapsed := current - lastTimestamp
 			lastTimestamp = current
 
@@ -136,7 +243,7 @@ func (t *Throttler) rollingWindowLoop() {
 		first, _ := t.Queue.Peek()
 		task := first.Task
 		cost := first.Cost
-		now := Milliseconds()
+		now := t.clock().Milliseconds()
 
 		t.Mutex.Lock()
 		windowSize := ToFloat64(t.Config["windowSize"])
@@ -146,6 +253,7 @@ func (t *Throttler) rollingWindowLoop() {
 
 		if totalCost+cost <= maxWeight {
 			t.Timestamps = append(t.Timestamps, TimestampedCost{Timestamp: now, Cost: cost})
+			t.admit(first)
 			t.Mutex.Unlock()
 
 			if task != nil {
@@ -158,12 +266,47 @@ func (t *Throttler) rollingWindowLoop() {
 			t.Mutex.Unlock()
 
 			if waitTime > 0 {
-				time.Sleep(time.Duration(waitTime) * time.Millisecond)
+				t.clock().Sleep(time.Duration(waitTime) * time.Millisecond)
 			}
 		}
 	}
 }
 
+// Acquire waits until the total cost of all the rules is admitted by the single rule of the
+// throttler or ctx is done, in which case the queued request is still consumed when its turn comes
+func (t *Throttler) Acquire(ctx context.Context, cost map[string]float64) error {
+	total := 0.0
+	for _, ruleCost := range cost {
+		total += ruleCost
+	}
+	task := t.Throttle(total)
+	select {
+	case <-task:
+		return nil
+	case <-ctx.Done():
+		go func() { <-task }()
+		return ctx.Err()
+	}
+}
+
+// admit updates the cumulative metrics, the caller must hold the mutex
+func (t *Throttler) admit(element QueueElement) {
+	t.Metrics.ConsumedTokens += element.Cost
+	t.Metrics.Requests++
+	if element.Timestamp > 0 {
+		t.Metrics.WaitTime += t.clock().Milliseconds() - element.Timestamp
+	}
+}
+
+// GetMetrics returns a snapshot of the cumulative counters keyed by the rule (algorithm) name
+func (t *Throttler) GetMetrics() map[string]RuleMetrics {
+	t.Mutex.Lock()
+	defer t.Mutex.Unlock()
+	return map[string]RuleMetrics{
+		ToString(t.Config["algorithm"]): t.Metrics,
+	}
+}
+
 func filterTimestamps(timestamps []TimestampedCost, now int64, windowSize float64) []TimestampedCost {
 	result := []TimestampedCost{}
 	for _, t := range timestamps {
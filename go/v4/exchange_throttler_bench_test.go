@@ -0,0 +1,199 @@
+package ccxt
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// ---------------------------------------------------------------------------
+// Throttler benchmarks: throughput, wait time and fairness under a virtual clock
+//
+//	go test -run '^$' -bench 'Throttler' -benchtime 2000x .
+//
+// every run reports req/s (virtual), p50-ms and p99-ms (virtual wait) and
+// fairness (max/min requests served per worker, 1 is perfectly fair)
+// ---------------------------------------------------------------------------
+
+// virtualClock advances only when the throttler sleeps, the results do not depend on the machine
+type virtualClock struct {
+	mu  sync.Mutex
+	now int64
+}
+
+func (c *virtualClock) Milliseconds() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *virtualClock) Sleep(d time.Duration) {
+	c.mu.Lock()
+	c.now += int64(math.Max(1, float64(d.Milliseconds())))
+	c.mu.Unlock()
+	// let the workers queue their next request before the time moves on
+	runtime.Gosched()
+}
+
+// throttleScenario describes a load, Concurrency workers send requests back to back until
+// Requests of them are admitted, Cost returns the cost of the i-th request of a worker
+type throttleScenario struct {
+	Concurrency int
+	Requests    int
+	Cost        func(worker int, i int) float64
+}
+
+type throttleReport struct {
+	Throughput float64 // admitted requests per virtual second
+	P50        int64   // virtual milliseconds spent waiting
+	P99        int64
+	Fairness   float64
+	Served     []int
+}
+
+func runThrottleScenario(newLimiter func(clock Clock) RateLimiter, scenario throttleScenario) throttleReport {
+	clock := &virtualClock{}
+	limiter := newLimiter(clock)
+	requests := int64(scenario.Requests)
+	waits := make([]int64, scenario.Requests)
+	served := make([]int, scenario.Concurrency)
+	var admitted int64
+	var finished int64
+	var wg sync.WaitGroup
+	for w := 0; w < scenario.Concurrency; w++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for i := 0; atomic.LoadInt64(&admitted) < requests; i++ {
+				start := clock.Milliseconds()
+				limiter.Acquire(context.Background(), map[string]float64{"cost": scenario.Cost(worker, i)})
+				n := atomic.AddInt64(&admitted, 1)
+				if n > requests {
+					return
+				}
+				now := clock.Milliseconds()
+				waits[n-1] = now - start
+				served[worker]++
+				if n == requests {
+					atomic.StoreInt64(&finished, now)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	report := throttleReport{Served: served}
+	if elapsed := atomic.LoadInt64(&finished); elapsed > 0 {
+		report.Throughput = float64(requests) * 1000 / float64(elapsed)
+	} else {
+		report.Throughput = math.Inf(1)
+	}
+	sort.Slice(waits, func(i, j int) bool { return waits[i] < waits[j] })
+	report.P50 = waits[(len(waits)-1)*50/100]
+	report.P99 = waits[(len(waits)-1)*99/100]
+	least, most := served[0], served[0]
+	for _, count := range served {
+		least = int(math.Min(float64(least), float64(count)))
+		most = int(math.Max(float64(most), float64(count)))
+	}
+	report.Fairness = math.Inf(1)
+	if least > 0 {
+		report.Fairness = float64(most) / float64(least)
+	}
+	return report
+}
+
+// both limiters admit 20 units of cost per second, the rolling window admits a whole window at
+// once so a quick worker can be served twice in a burst
+var throttleLimiters = []struct {
+	name       string
+	newLimiter func(clock Clock) RateLimiter
+}{
+	{"leakyBucket", func(clock Clock) RateLimiter {
+		throttler := NewThrottler(map[string]interface{}{"refillRate": 1 / 50.0, "capacity": 1.0, "delay": 0.001})
+		throttler.Clock = clock
+		return throttler
+	}},
+	{"rollingWindow", func(clock Clock) RateLimiter {
+		throttler := NewThrottler(map[string]interface{}{"algorithm": "rollingWindow", "rateLimit": 50.0, "windowSize": 1000.0})
+		throttler.Clock = clock
+		return throttler
+	}},
+}
+
+var throttleCosts = []struct {
+	name string
+	cost func(worker int, i int) float64
+}{
+	{"constant", func(worker int, i int) float64 { return 1 }},
+	// spread between 0.5 and 1.49 without a random source so that the runs are reproducible
+	{"uniform", func(worker int, i int) float64 { return 0.5 + float64((worker*7919+i*104729)%100)/100 }},
+	{"heavyTail", func(worker int, i int) float64 {
+		if i%10 == 9 {
+			return 10
+		}
+		return 1
+	}},
+}
+
+func BenchmarkThrottler(b *testing.B) {
+	for _, limiter := range throttleLimiters {
+		for _, concurrency := range []int{1, 8, 32} {
+			for _, cost := range throttleCosts {
+				name := fmt.Sprintf("%s/concurrency=%d/cost=%s", limiter.name, concurrency, cost.name)
+				b.Run(name, func(b *testing.B) {
+					report := runThrottleScenario(limiter.newLimiter, throttleScenario{
+						Concurrency: concurrency,
+						Requests:    b.N,
+						Cost:        cost.cost,
+					})
+					b.ReportMetric(report.Throughput, "req/s")
+					b.ReportMetric(float64(report.P50), "p50-ms")
+					b.ReportMetric(float64(report.P99), "p99-ms")
+					b.ReportMetric(report.Fairness, "fairness")
+				})
+			}
+		}
+	}
+}
+
+func TestThrottlerThroughputAndFairness(t *testing.T) {
+	for _, limiter := range throttleLimiters {
+		for _, concurrency := range []int{1, 4, 16} {
+			t.Run(fmt.Sprintf("%s/concurrency=%d", limiter.name, concurrency), func(t *testing.T) {
+				report := runThrottleScenario(limiter.newLimiter, throttleScenario{
+					Concurrency: concurrency,
+					Requests:    320,
+					Cost:        throttleCosts[0].cost,
+				})
+				// 20 requests of cost 1 per second, the rolling window admits its first window at once
+				if report.Throughput < 16 || report.Throughput > 25 {
+					t.Fatalf("expected about 20 requests per second, got %.2f", report.Throughput)
+				}
+				// the order the workers are served in depends on the goroutine scheduling,
+				// the fairness is reported and not checked
+				t.Logf("fairness %.2f, served %v", report.Fairness, report.Served)
+				if report.P50 > report.P99 {
+					t.Fatalf("expected p50 <= p99, got %d and %d", report.P50, report.P99)
+				}
+			})
+		}
+	}
+}
+
+func TestThrottlerVirtualClockWaitTime(t *testing.T) {
+	// every worker waits for the three others, a request every 50ms
+	report := runThrottleScenario(throttleLimiters[0].newLimiter, throttleScenario{
+		Concurrency: 4,
+		Requests:    80,
+		Cost:        throttleCosts[0].cost,
+	})
+	if report.P50 < 150 || report.P50 > 250 {
+		t.Fatalf("expected a median wait of about 200ms, got %dms", report.P50)
+	}
+}
@@ -0,0 +1,246 @@
+package ccxt
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// ---------------------------------------------------------------------------
+// Throttler: cumulative metrics
+// ---------------------------------------------------------------------------
+
+func TestThrottlerMetricsLeakyBucket(t *testing.T) {
+	throttler := NewThrottler(map[string]interface{}{
+		"refillRate": 1.0,
+		"capacity":   1.0,
+		"delay":      0.001,
+	})
+	costs := []float64{1, 2, 0.5, 3}
+	for _, cost := range costs {
+		select {
+		case <-throttler.Throttle(cost):
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for the throttler")
+		}
+	}
+	metrics, ok := throttler.GetMetrics()["leakyBucket"]
+	if !ok {
+		t.Fatal("expected metrics for the leakyBucket rule")
+	}
+	if metrics.Requests != 4 {
+		t.Fatalf("expected 4 requests, got %d", metrics.Requests)
+	}
+	if metrics.ConsumedTokens != 6.5 {
+		t.Fatalf("expected 6.5 consumed tokens, got %v", metrics.ConsumedTokens)
+	}
+	// after the first request the bucket is empty, so the next ones have to wait for the refill
+	if metrics.WaitTime <= 0 {
+		t.Fatalf("expected a positive wait time, got %d", metrics.WaitTime)
+	}
+}
+
+func TestThrottlerMetricsRollingWindow(t *testing.T) {
+	throttler := NewThrottler(map[string]interface{}{
+		"algorithm":  "rollingWindow",
+		"rateLimit":  10.0,
+		"windowSize": 1000.0,
+	})
+	for i := 0; i < 5; i++ {
+		<-throttler.Throttle(2.0)
+	}
+	metrics := throttler.GetMetrics()["rollingWindow"]
+	if metrics.Requests != 5 || metrics.ConsumedTokens != 10 {
+		t.Fatalf("expected 5 requests and 10 tokens, got %d and %v", metrics.Requests, metrics.ConsumedTokens)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// RateLimiter: every implementation is usable through the interface
+// ---------------------------------------------------------------------------
+
+func TestRateLimiterImplementations(t *testing.T) {
+	cases := []struct {
+		name    string
+		limiter RateLimiter
+		rule    string
+	}{
+		{"leakyBucket", NewThrottler(map[string]interface{}{"refillRate": 1.0, "capacity": 1.0}), "leakyBucket"},
+		{"rollingWindow", NewThrottler(map[string]interface{}{"algorithm": "rollingWindow", "rateLimit": 10.0, "windowSize": 1000.0}), "rollingWindow"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			for i := 0; i < 3; i++ {
+				if err := c.limiter.Acquire(ctx, map[string]float64{"weight": 1, "orders": 1}); err != nil {
+					t.Fatalf("acquire %d: %v", i, err)
+				}
+			}
+			metrics := c.limiter.GetMetrics()[c.rule]
+			if metrics.Requests != 3 || metrics.ConsumedTokens != 6 {
+				t.Fatalf("expected 3 requests and 6 tokens, got %d and %v", metrics.Requests, metrics.ConsumedTokens)
+			}
+		})
+	}
+}
+
+func TestRateLimiterAcquireHonoursContext(t *testing.T) {
+	limiter := NewThrottler(map[string]interface{}{
+		"algorithm":  "rollingWindow",
+		"rateLimit":  1000.0,
+		"windowSize": 1000.0,
+	})
+	// the window admits a single request per second
+	if err := limiter.Acquire(context.Background(), map[string]float64{"cost": 1}); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := limiter.Acquire(ctx, map[string]float64{"cost": 1}); err != context.DeadlineExceeded {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+type countingRateLimiter struct {
+	costs []float64
+}
+
+func (c *countingRateLimiter) Acquire(ctx context.Context, cost map[string]float64) error {
+	c.costs = append(c.costs, cost["cost"])
+	return nil
+}
+
+func (c *countingRateLimiter) GetMetrics() map[string]RuleMetrics {
+	return map[string]RuleMetrics{"cost": {Requests: int64(len(c.costs))}}
+}
+
+func TestExchangeThrottleUsesRateLimiter(t *testing.T) {
+	exchange := &Exchange{}
+	limiter := &countingRateLimiter{}
+	exchange.RateLimiter = limiter
+	if result := <-exchange.Throttle(5); result != true {
+		t.Fatalf("expected the request to be admitted, got %v", result)
+	}
+	if len(limiter.costs) != 1 || limiter.costs[0] != 5 {
+		t.Fatalf("expected a single acquire of cost 5, got %v", limiter.costs)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// ExportConfig / NewThrottlerFromConfig: the rules round-trip through json
+// ---------------------------------------------------------------------------
+
+func roundTripThrottleConfig(t *testing.T, rules []ThrottleRuleConfig) []ThrottleRuleConfig {
+	data, err := json.Marshal(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := []ThrottleRuleConfig{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	return decoded
+}
+
+func TestThrottlerExportConfigRoundTrip(t *testing.T) {
+	cases := []struct {
+		name   string
+		config map[string]interface{}
+	}{
+		{"leakyBucket", map[string]interface{}{"refillRate": 0.02, "capacity": 5.0, "delay": 0.002, "cost": 2.0}},
+		{"rollingWindow", map[string]interface{}{"algorithm": "rollingWindow", "rateLimit": 50.0, "windowSize": 10000.0}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			original := NewThrottler(c.config)
+			exported := original.ExportConfig()
+			if exported[0].Id != c.name || exported[0].Tokens != nil || exported[0].Timestamps != nil {
+				t.Fatalf("unexpected exported rule %+v", exported[0])
+			}
+			rebuilt, err := NewThrottlerFromConfig(roundTripThrottleConfig(t, exported))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if again := rebuilt.ExportConfig(); !reflect.DeepEqual(again, exported) {
+				t.Fatalf("expected %+v after the round trip, got %+v", exported, again)
+			}
+			for _, key := range []string{"algorithm", "refillRate", "capacity", "delay", "cost", "rateLimit", "windowSize", "maxWeight"} {
+				if ToString(rebuilt.Config[key]) != ToString(original.Config[key]) {
+					t.Fatalf("expected %s to be %v, got %v", key, original.Config[key], rebuilt.Config[key])
+				}
+			}
+		})
+	}
+}
+
+func TestThrottlerExportConfigIncludesState(t *testing.T) {
+	bucket := NewThrottler(map[string]interface{}{"refillRate": 0.001, "capacity": 10.0, "tokens": 10.0})
+	<-bucket.Throttle(4.0)
+	rebuilt, err := NewThrottlerFromConfig(roundTripThrottleConfig(t, bucket.ExportConfig(true)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tokens := ToFloat64(rebuilt.Config["tokens"]); tokens != 6 {
+		t.Fatalf("expected the 6 remaining tokens to be restored, got %v", tokens)
+	}
+	if fresh, _ := NewThrottlerFromConfig(bucket.ExportConfig()); ToFloat64(fresh.Config["tokens"]) != 0 {
+		t.Fatalf("expected the default tokens without the state, got %v", fresh.Config["tokens"])
+	}
+
+	window := NewThrottler(map[string]interface{}{"algorithm": "rollingWindow", "rateLimit": 100.0, "windowSize": 1000.0})
+	<-window.Throttle(3.0)
+	<-window.Throttle(4.0)
+	rebuilt, err = NewThrottlerFromConfig(roundTripThrottleConfig(t, window.ExportConfig(true)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(rebuilt.Timestamps, window.Timestamps) {
+		t.Fatalf("expected the window timestamps %v, got %v", window.Timestamps, rebuilt.Timestamps)
+	}
+	// the window admits 10 per second and 7 are already used, so the rebuilt throttler has to wait
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := rebuilt.Acquire(ctx, map[string]float64{"cost": 4}); err != context.DeadlineExceeded {
+		t.Fatalf("expected the restored window to be full, got %v", err)
+	}
+}
+
+func TestNewThrottlerFromConfigRejectsUnknownRules(t *testing.T) {
+	if _, err := NewThrottlerFromConfig(nil); err == nil {
+		t.Fatal("expected an error without rules")
+	}
+	if _, err := NewThrottlerFromConfig([]ThrottleRuleConfig{{Id: "fixedWindow"}}); err == nil {
+		t.Fatal("expected an error for an unknown rule")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// ws order rule: defaults to the rest rate limit and can be replaced
+// ---------------------------------------------------------------------------
+
+func TestWsOrderThrottlerDefaultsAndReplacement(t *testing.T) {
+	exchange := NewBinanceCore()
+	exchange.Init(map[string]interface{}{})
+	if rateLimit := exchange.GetWsOrderThrottler().Config["rateLimit"]; rateLimit != exchange.RateLimit {
+		t.Fatalf("expected the rest rate limit %v without options.ws.orderThrottle, got %v", exchange.RateLimit, rateLimit)
+	}
+
+	tuned, err := NewThrottlerFromConfig([]ThrottleRuleConfig{{Id: "leakyBucket", Capacity: 1, RefillRate: 0.01, Cost: 1, Delay: 0.001, RateLimit: 100}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exchange.WsOrderThrottler = tuned
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		<-exchange.ThrottleWsOrder(nil)
+	}
+	if elapsed := time.Since(start); elapsed < 180*time.Millisecond {
+		t.Fatalf("expected 3 orders to take about 200ms with the replaced rule, took %v", elapsed)
+	}
+	if metrics := tuned.GetMetrics()["leakyBucket"]; metrics.Requests != 3 {
+		t.Fatalf("expected the replaced rule to admit 3 orders, got %d", metrics.Requests)
+	}
+}
@@ -0,0 +1,75 @@
+package ccxt
+
+import (
+	"strconv"
+	"time"
+)
+
+// timeframeUnits are the seconds of each timeframe unit, months and years are approximated with
+// 30 and 365 days like the timeframes of the exchanges
+var timeframeUnits = map[byte]int{
+	's': 1,
+	'm': 60,
+	'h': 60 * 60,
+	'd': 60 * 60 * 24,
+	'w': 60 * 60 * 24 * 7,
+	'M': 60 * 60 * 24 * 30,
+	'y': 60 * 60 * 24 * 365,
+}
+
+// ParseTimeframeChecked returns the duration of a timeframe like 1m, 4h or 1M in seconds, a BadRequest
+// when the amount is not a positive integer or the unit is unknown. (*Exchange).ParseTimeframe returns nil
+// instead of the error
+func ParseTimeframeChecked(tf string) (int, error) {
+	if len(tf) < 2 {
+		return 0, BadRequest("invalid timeframe " + strconv.Quote(tf))
+	}
+	scale, ok := timeframeUnits[tf[len(tf)-1]]
+	if !ok {
+		return 0, BadRequest("invalid timeframe " + strconv.Quote(tf) + ", the unit must be one of s, m, h, d, w, M or y")
+	}
+	amount, err := strconv.Atoi(tf[:len(tf)-1])
+	if err != nil || amount <= 0 {
+		return 0, BadRequest("invalid timeframe " + strconv.Quote(tf) + ", the amount must be a positive integer")
+	}
+	return amount * scale, nil
+}
+
+// firstMonday is 1970-01-05 00:00 UTC in milliseconds, the weeks of the exchanges start on mondays
+const firstMonday = 4 * 24 * 60 * 60 * 1000
+
+// RoundTimestampToTimeframe returns the start of the timeframe ts (in milliseconds) falls into, as the
+// exchanges start their candles: the timeframes up to days start at the multiples of their duration since
+// the epoch, the weeks on mondays and the months and years on the first day of a calendar month (1M, 3M)
+// or year. ts is returned unchanged when tf is not a valid timeframe.
+func RoundTimestampToTimeframe(ts int64, tf string) int64 {
+	seconds, err := ParseTimeframeChecked(tf)
+	if err != nil {
+		return ts
+	}
+	unit := tf[len(tf)-1]
+	switch unit {
+	case 'M', 'y':
+		months := int64(seconds / timeframeUnits['M'])
+		if unit == 'y' {
+			months = int64(seconds/timeframeUnits['y']) * 12
+		}
+		date := time.UnixMilli(ts).UTC()
+		// the months are counted from january of year 0 so that quarters and years start in january
+		month := int64(date.Year())*12 + int64(date.Month()-1)
+		month -= floorMod(month, months)
+		return time.Date(int(month/12), time.Month(month%12+1), 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+	case 'w':
+		return ts - floorMod(ts-firstMonday, int64(seconds)*1000)
+	}
+	return ts - floorMod(ts, int64(seconds)*1000)
+}
+
+// floorMod is the remainder of a by b with the sign of b, timestamps before the epoch round down too
+func floorMod(a int64, b int64) int64 {
+	mod := a % b
+	if mod < 0 {
+		mod += b
+	}
+	return mod
+}
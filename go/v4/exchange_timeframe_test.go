@@ -0,0 +1,80 @@
+package ccxt
+
+import (
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// Timeframes: durations in seconds and the start of the timeframe of a timestamp
+// ---------------------------------------------------------------------------
+
+func TestParseTimeframeChecked(t *testing.T) {
+	cases := map[string]int{
+		"1s":  1,
+		"30s": 30,
+		"1m":  60,
+		"15m": 900,
+		"1h":  3600,
+		"4h":  14400,
+		"1d":  86400,
+		"3d":  259200,
+		"1w":  604800,
+		"1M":  2592000,
+		"1y":  31536000,
+	}
+	for tf, expected := range cases {
+		seconds, err := ParseTimeframeChecked(tf)
+		if err != nil || seconds != expected {
+			t.Fatalf("expected %d seconds for %s, got %d (%v)", expected, tf, seconds, err)
+		}
+	}
+}
+
+func TestParseTimeframeCheckedInvalid(t *testing.T) {
+	for _, tf := range []string{"", "m", "1", "1x", "1H", "0m", "-5m", "1.5h", "m1", "h1"} {
+		if _, err := ParseTimeframeChecked(tf); !IsErrorType(err, "BadRequest") {
+			t.Fatalf("expected a BadRequest for %q, got %v", tf, err)
+		}
+	}
+	// the exchange method keeps returning nil for the unified code
+	exchange := &Exchange{}
+	if result := exchange.ParseTimeframe("1x"); result != nil {
+		t.Fatalf("expected nil, got %v", result)
+	}
+	if result := exchange.ParseTimeframe("5m"); result != 300 {
+		t.Fatalf("expected 300, got %v", result)
+	}
+}
+
+func TestRoundTimestampToTimeframe(t *testing.T) {
+	ts := int64(1565616128000) // 2019-08-12 13:22:08 UTC
+	cases := map[string]int64{
+		"1s":  1565616128000,
+		"1m":  1565616120000, // 13:22:00
+		"5m":  1565616000000, // 13:20:00
+		"1h":  1565614800000, // 13:00:00
+		"4h":  1565611200000, // 12:00:00
+		"1d":  1565568000000, // 2019-08-12 00:00:00
+		"1w":  1565568000000, // 2019-08-12 00:00:00, a monday
+		"2w":  1565568000000, // 2019-08-12 00:00:00, the weeks are counted from monday 1970-01-05
+		"1M":  1564617600000, // 2019-08-01 00:00:00
+		"3M":  1561939200000, // 2019-07-01 00:00:00, the quarters start in january
+		"1y":  1546300800000, // 2019-01-01 00:00:00
+		"xyz": ts,            // unchanged for an invalid timeframe
+	}
+	for tf, expected := range cases {
+		if rounded := RoundTimestampToTimeframe(ts, tf); rounded != expected {
+			t.Fatalf("expected %d for %s, got %d", expected, tf, rounded)
+		}
+	}
+	if rounded := RoundTimestampToTimeframe(-1, "1m"); rounded != -60000 {
+		t.Fatalf("expected timestamps before the epoch to be rounded down, got %d", rounded)
+	}
+	// the start of a week or a month is its own start
+	if rounded := RoundTimestampToTimeframe(1565568000000, "1w"); rounded != 1565568000000 {
+		t.Fatalf("expected monday 2019-08-12 to start its week, got %d", rounded)
+	}
+	if rounded := RoundTimestampToTimeframe(1582934400000, "1M"); rounded != 1580515200000 {
+		t.Fatalf("expected 2020-02-29 to round to 2020-02-01, got %d", rounded)
+	}
+}
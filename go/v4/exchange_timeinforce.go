@@ -0,0 +1,45 @@
+package ccxt
+
+// unified timeInForce values and the aliases users commonly pass instead
+var timeInForceAliases = map[string]string{
+	"GTC":                 "GTC",
+	"GOODTILLCANCEL":      "GTC",
+	"GOOD_TILL_CANCEL":    "GTC",
+	"GOODTILLCANCELED":    "GTC",
+	"GOODTILLCANCELLED":   "GTC",
+	"IOC":                 "IOC",
+	"IMMEDIATEORCANCEL":   "IOC",
+	"IMMEDIATE_OR_CANCEL": "IOC",
+	"FOK":                 "FOK",
+	"FILLORKILL":          "FOK",
+	"FILL_OR_KILL":        "FOK",
+	"PO":                  "PO",
+	"GTX":                 "PO",
+	"POSTONLY":            "PO",
+	"POST_ONLY":           "PO",
+	"GTD":                 "GTD",
+}
+
+// NormalizeTimeInForce maps params["timeInForce"] to its unified value (GTC, IOC, FOK, PO, GTD)
+// and validates it against the createOrder features of the market and the order type,
+// unsupported values and combinations panic with InvalidOrder
+func (this *Exchange) NormalizeTimeInForce(market interface{}, orderType interface{}, params interface{}) interface{} {
+	rawTimeInForce := this.SafeString(params, "timeInForce")
+	if rawTimeInForce == nil {
+		return params
+	}
+	timeInForce, ok := timeInForceAliases[ToUpper(rawTimeInForce)]
+	if !ok {
+		panic(InvalidOrder(Add(Add(Add(this.Id, " invalid timeInForce \""), rawTimeInForce), "\"")))
+	}
+	if IsEqual(ToLower(orderType), "market") && timeInForce != "GTC" {
+		panic(InvalidOrder(Add(Add(this.Id, " market orders do not support timeInForce "), timeInForce)))
+	}
+	supported := this.FeatureValueByType(this.SafeString(market, "type"), this.SafeString(market, "subType"), "createOrder", "timeInForce")
+	if supported != nil && !IsTrue(this.SafeBool(supported, timeInForce, false)) {
+		panic(InvalidOrder(Add(Add(Add(this.Id, " timeInForce "), timeInForce), Add(" is not supported for ", this.SafeString(market, "type")))))
+	}
+	return this.Extend(params, map[string]interface{}{
+		"timeInForce": timeInForce,
+	})
+}
@@ -0,0 +1,130 @@
+package ccxt
+
+import (
+	"strings"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// timeInForce normalization and validation
+// ---------------------------------------------------------------------------
+
+func newTimeInForceTestMarket(exchange *Exchange, id string, symbol string, marketType string) map[string]interface{} {
+	market := exchange.SafeMarketStructure(map[string]interface{}{
+		"id":       id,
+		"symbol":   symbol,
+		"base":     "BTC",
+		"quote":    "USDT",
+		"settle":   nil,
+		"baseId":   "BTC",
+		"quoteId":  "USDT",
+		"type":     marketType,
+		"spot":     marketType == "spot",
+		"swap":     marketType == "swap",
+		"contract": marketType == "swap",
+		"linear":   nil,
+		"active":   true,
+		"info": map[string]interface{}{
+			"orderTypes": []interface{}{"LIMIT", "LIMIT_MAKER", "MARKET"},
+		},
+		"precision": map[string]interface{}{
+			"amount": 0.001,
+			"price":  0.01,
+		},
+	}).(map[string]interface{})
+	if marketType == "swap" {
+		market["settle"] = "USDT"
+		market["settleId"] = "USDT"
+		market["linear"] = true
+		market["inverse"] = false
+		market["subType"] = "linear"
+		market["contractSize"] = 1.0
+	}
+	return market
+}
+
+func createOrderRequestPanic(fn func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = r.(error)
+		}
+	}()
+	fn()
+	return nil
+}
+
+func TestNormalizeTimeInForceAliases(t *testing.T) {
+	exchange := NewBinanceCore()
+	exchange.Init(map[string]interface{}{})
+	market := newTimeInForceTestMarket(&exchange.Exchange, "BTCUSDT", "BTC/USDT", "spot")
+	cases := map[string]string{
+		"gtc":               "GTC",
+		"GoodTillCancel":    "GTC",
+		"ImmediateOrCancel": "IOC",
+		"fill_or_kill":      "FOK",
+		"PostOnly":          "PO",
+		"GTX":               "PO",
+	}
+	for raw, expected := range cases {
+		params := exchange.NormalizeTimeInForce(market, "limit", map[string]interface{}{"timeInForce": raw})
+		if actual := exchange.SafeString(params, "timeInForce"); actual != expected {
+			t.Fatalf("%s: expected %s, got %v", raw, expected, actual)
+		}
+	}
+	params := exchange.NormalizeTimeInForce(market, "limit", map[string]interface{}{})
+	if _, ok := params.(map[string]interface{})["timeInForce"]; ok {
+		t.Fatal("expected timeInForce to stay undefined")
+	}
+}
+
+func TestNormalizeTimeInForceRejectsTypos(t *testing.T) {
+	exchange := NewBinanceCore()
+	exchange.Init(map[string]interface{}{})
+	market := newTimeInForceTestMarket(&exchange.Exchange, "BTCUSDT", "BTC/USDT", "spot")
+	err := createOrderRequestPanic(func() {
+		exchange.NormalizeTimeInForce(market, "limit", map[string]interface{}{"timeInForce": "GCT"})
+	})
+	if err == nil || !strings.Contains(err.Error(), "InvalidOrder") {
+		t.Fatalf("expected InvalidOrder, got %v", err)
+	}
+}
+
+func TestBinanceCreateOrderRequestTimeInForce(t *testing.T) {
+	exchange := NewBinanceCore()
+	exchange.Init(map[string]interface{}{})
+	exchange.SetMarkets([]interface{}{
+		newTimeInForceTestMarket(&exchange.Exchange, "BTCUSDT", "BTC/USDT", "spot"),
+	})
+	request := exchange.CreateOrderRequest("BTC/USDT", "limit", "buy", 1.0, 100.0, map[string]interface{}{"timeInForce": "ImmediateOrCancel"})
+	if timeInForce := exchange.SafeString(request, "timeInForce"); timeInForce != "IOC" {
+		t.Fatalf("expected IOC, got %v", timeInForce)
+	}
+	err := createOrderRequestPanic(func() {
+		exchange.CreateOrderRequest("BTC/USDT", "market", "buy", 1.0, nil, map[string]interface{}{"timeInForce": "IOC"})
+	})
+	if err == nil || !strings.Contains(err.Error(), "InvalidOrder") {
+		t.Fatalf("expected InvalidOrder for an IOC market order, got %v", err)
+	}
+}
+
+func TestBybitCreateOrderRequestTimeInForce(t *testing.T) {
+	exchange := NewBybitCore()
+	exchange.Init(map[string]interface{}{})
+	exchange.SetMarkets([]interface{}{
+		newTimeInForceTestMarket(&exchange.Exchange, "BTCUSDT", "BTC/USDT:USDT", "swap"),
+	})
+	request := exchange.CreateOrderRequest("BTC/USDT:USDT", "limit", "buy", 1.0, 100.0, map[string]interface{}{"timeInForce": "fok"})
+	if timeInForce := exchange.SafeString(request, "timeInForce"); timeInForce != "FOK" {
+		t.Fatalf("expected FOK, got %v", timeInForce)
+	}
+	request = exchange.CreateOrderRequest("BTC/USDT:USDT", "limit", "buy", 1.0, 100.0, map[string]interface{}{"timeInForce": "post_only"})
+	if timeInForce := exchange.SafeString(request, "timeInForce"); timeInForce != "PostOnly" {
+		t.Fatalf("expected PostOnly, got %v", timeInForce)
+	}
+	err := createOrderRequestPanic(func() {
+		exchange.CreateOrderRequest("BTC/USDT:USDT", "market", "buy", 1.0, nil, map[string]interface{}{"timeInForce": "IOC"})
+	})
+	if err == nil || !strings.Contains(err.Error(), "InvalidOrder") {
+		t.Fatalf("expected InvalidOrder for an IOC market order, got %v", err)
+	}
+}
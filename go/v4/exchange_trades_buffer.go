@@ -0,0 +1,125 @@
+package ccxt
+
+import (
+	"sync"
+	"time"
+)
+
+// WatchTradesBufferedOptions configures WatchTradesBuffered, a batch is flushed every FlushInterval
+// (100ms by default) or as soon as MaxTrades (0 disables it) trades are pending, whichever comes first
+type WatchTradesBufferedOptions struct {
+	FlushInterval time.Duration
+	MaxTrades     int
+	Params        map[string]interface{}
+}
+
+// TradesBuffer delivers the trades of a watchTrades stream in batches on Batches, the channel is
+// closed after Close or when watchTrades fails, Err then returns the failure
+type TradesBuffer struct {
+	Batches <-chan []Trade
+	done    chan struct{}
+	once    sync.Once
+	mu      sync.Mutex
+	err     error
+}
+
+// Close stops watching, the trades still pending are dropped
+func (this *TradesBuffer) Close() {
+	this.once.Do(func() { close(this.done) })
+}
+
+// Err returns the error that ended the stream, nil while it is running or after Close
+func (this *TradesBuffer) Err() error {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	return this.err
+}
+
+func (this *TradesBuffer) fail(err error) {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	this.err = err
+}
+
+// WatchTradesBuffered watches the trades of symbol and groups them into batches so that busy
+// symbols are read once per batch instead of once per trade message
+func (this *Exchange) WatchTradesBuffered(symbol interface{}, optionalArgs ...WatchTradesBufferedOptions) *TradesBuffer {
+	opts := WatchTradesBufferedOptions{}
+	if len(optionalArgs) > 0 {
+		opts = optionalArgs[0]
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = 100 * time.Millisecond
+	}
+	var params interface{} = map[string]interface{}{}
+	if opts.Params != nil {
+		params = opts.Params
+	}
+	batches := make(chan []Trade)
+	buffer := &TradesBuffer{Batches: batches, done: make(chan struct{})}
+	incoming := make(chan []Trade)
+	go func() {
+		defer close(incoming)
+		for {
+			res := <-this.DerivedExchange.WatchTrades(symbol, nil, nil, params)
+			if IsError(res) {
+				buffer.fail(CreateReturnError(res))
+				return
+			}
+			select {
+			case incoming <- NewTradeArray(res):
+			case <-buffer.done:
+				return
+			}
+		}
+	}()
+	go func() {
+		defer close(batches)
+		pending := []Trade{}
+		timer := time.NewTimer(opts.FlushInterval)
+		timer.Stop()
+		flush := func(size int) bool {
+			batch := pending[:size:size]
+			pending = append([]Trade{}, pending[size:]...)
+			select {
+			case batches <- batch:
+				return true
+			case <-buffer.done:
+				return false
+			}
+		}
+		for {
+			select {
+			case trades, ok := <-incoming:
+				if !ok {
+					// the stream failed, hand over what was already received
+					if len(pending) > 0 {
+						flush(len(pending))
+					}
+					return
+				}
+				if len(pending) == 0 && len(trades) > 0 {
+					timer.Reset(opts.FlushInterval)
+				}
+				pending = append(pending, trades...)
+				for opts.MaxTrades > 0 && len(pending) >= opts.MaxTrades {
+					if !flush(opts.MaxTrades) {
+						return
+					}
+					timer.Stop()
+					if len(pending) > 0 {
+						timer.Reset(opts.FlushInterval)
+					}
+				}
+			case <-timer.C:
+				if len(pending) > 0 && !flush(len(pending)) {
+					return
+				}
+			case <-buffer.done:
+				timer.Stop()
+				return
+			}
+		}
+	}()
+	return buffer
+}
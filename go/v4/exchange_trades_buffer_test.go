@@ -0,0 +1,127 @@
+package ccxt
+
+import (
+	"testing"
+	"time"
+)
+
+// ---------------------------------------------------------------------------
+// WatchTradesBuffered: trades are flushed by count or after the flush interval
+// ---------------------------------------------------------------------------
+
+type tradesBufferExchange struct {
+	*Exchange
+	messages chan interface{}
+}
+
+func (this *tradesBufferExchange) WatchTrades(symbol interface{}, optionalArgs ...interface{}) <-chan interface{} {
+	ch := make(chan interface{})
+	go func() {
+		defer close(ch)
+		defer ReturnPanicError(ch)
+		message := <-this.messages
+		if err, ok := message.(error); ok {
+			panic(err)
+		}
+		ch <- message
+	}()
+	return ch
+}
+
+func newTradesBufferExchange() *tradesBufferExchange {
+	exchange := &Exchange{}
+	exchange.Init(map[string]interface{}{})
+	derived := &tradesBufferExchange{Exchange: exchange, messages: make(chan interface{})}
+	exchange.DerivedExchange = derived
+	return derived
+}
+
+func tradesMessage(ids ...string) []interface{} {
+	trades := []interface{}{}
+	for _, id := range ids {
+		trades = append(trades, map[string]interface{}{"id": id, "symbol": "BTC/USDT", "price": 1.0, "amount": 1.0})
+	}
+	return trades
+}
+
+func receiveTradesBatch(t *testing.T, buffer *TradesBuffer) []string {
+	select {
+	case batch, ok := <-buffer.Batches:
+		if !ok {
+			t.Fatalf("batches closed, err %v", buffer.Err())
+		}
+		ids := []string{}
+		for _, trade := range batch {
+			ids = append(ids, *trade.Id)
+		}
+		return ids
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a batch")
+	}
+	return nil
+}
+
+func TestWatchTradesBufferedFlushesByCount(t *testing.T) {
+	exchange := newTradesBufferExchange()
+	buffer := exchange.WatchTradesBuffered("BTC/USDT", WatchTradesBufferedOptions{FlushInterval: time.Hour, MaxTrades: 3})
+	defer buffer.Close()
+
+	exchange.messages <- tradesMessage("1")
+	exchange.messages <- tradesMessage("2", "3", "4", "5")
+	exchange.messages <- tradesMessage("6", "7")
+	for _, expected := range [][]string{{"1", "2", "3"}, {"4", "5", "6"}} {
+		if ids := receiveTradesBatch(t, buffer); len(ids) != 3 || ids[0] != expected[0] || ids[2] != expected[2] {
+			t.Fatalf("expected batch %v, got %v", expected, ids)
+		}
+	}
+	select {
+	case batch := <-buffer.Batches:
+		t.Fatalf("expected trade 7 to wait for more trades, got %v", batch)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestWatchTradesBufferedFlushesAfterInterval(t *testing.T) {
+	exchange := newTradesBufferExchange()
+	interval := 80 * time.Millisecond
+	buffer := exchange.WatchTradesBuffered("BTC/USDT", WatchTradesBufferedOptions{FlushInterval: interval, MaxTrades: 100})
+	defer buffer.Close()
+
+	started := time.Now()
+	exchange.messages <- tradesMessage("1")
+	exchange.messages <- tradesMessage("2", "3")
+	ids := receiveTradesBatch(t, buffer)
+	elapsed := time.Since(started)
+	if len(ids) != 3 {
+		t.Fatalf("expected the 3 pending trades in one batch, got %v", ids)
+	}
+	// the interval starts with the first pending trade
+	if elapsed < interval || elapsed > 10*interval {
+		t.Fatalf("expected the batch after about %v, got it after %v", interval, elapsed)
+	}
+
+	// an idle stream does not produce empty batches
+	select {
+	case batch := <-buffer.Batches:
+		t.Fatalf("expected no batch without trades, got %v", batch)
+	case <-time.After(2 * interval):
+	}
+}
+
+func TestWatchTradesBufferedStopsOnError(t *testing.T) {
+	exchange := newTradesBufferExchange()
+	buffer := exchange.WatchTradesBuffered("BTC/USDT", WatchTradesBufferedOptions{FlushInterval: time.Hour})
+	defer buffer.Close()
+
+	exchange.messages <- tradesMessage("1", "2")
+	exchange.messages <- NetworkError("connection lost")
+	if ids := receiveTradesBatch(t, buffer); len(ids) != 2 {
+		t.Fatalf("expected the pending trades before the error, got %v", ids)
+	}
+	if _, ok := <-buffer.Batches; ok {
+		t.Fatal("expected the batches to be closed")
+	}
+	if buffer.Err() == nil {
+		t.Fatal("expected the watchTrades error")
+	}
+}
@@ -0,0 +1,107 @@
+package ccxt
+
+// CreateTrailingStopOrder creates a trailing stop order that trails the market price either by
+// params.trailingAmount, in units of the quote currency, or by params.trailingPercent, exactly one of them
+// must be set. params.trailingTriggerPrice is the price that activates the order. The order is placed with
+// createTrailingAmountOrder or createTrailingPercentOrder, so NotSupported is returned when the exchange
+// cannot trail by the requested kind of distance
+func (this *Exchange) CreateTrailingStopOrder(symbol interface{}, typeVar interface{}, side interface{}, amount interface{}, optionalArgs ...interface{}) <-chan interface{} {
+	ch := make(chan interface{})
+	go func() interface{} {
+		defer close(ch)
+		defer ReturnPanicError(ch)
+		price := GetArg(optionalArgs, 0, nil)
+		_ = price
+		params := GetArg(optionalArgs, 1, map[string]interface{}{})
+		_ = params
+		var trailingAmount interface{} = this.SafeValue(params, "trailingAmount")
+		var trailingPercent interface{} = this.SafeValue(params, "trailingPercent")
+		var trailingTriggerPrice interface{} = this.SafeValue(params, "trailingTriggerPrice")
+		if IsTrue(IsTrue(!IsEqual(trailingAmount, nil)) && IsTrue(!IsEqual(trailingPercent, nil))) {
+			panic(InvalidOrder(Add(this.Id, " createTrailingStopOrder() accepts either a trailingAmount or a trailingPercent, not both")))
+		}
+		if IsTrue(IsTrue(IsEqual(trailingAmount, nil)) && IsTrue(IsEqual(trailingPercent, nil))) {
+			panic(ArgumentsRequired(Add(this.Id, " createTrailingStopOrder() requires a trailingAmount or a trailingPercent parameter")))
+		}
+		var query interface{} = this.Omit(params, []interface{}{"trailingAmount", "trailingPercent", "trailingTriggerPrice"})
+		var retRes interface{} = nil
+		if IsTrue(!IsEqual(trailingAmount, nil)) {
+			retRes = <-this.CreateTrailingAmountOrder(symbol, typeVar, side, amount, price, trailingAmount, trailingTriggerPrice, query)
+		} else {
+			retRes = <-this.CreateTrailingPercentOrder(symbol, typeVar, side, amount, price, trailingPercent, trailingTriggerPrice, query)
+		}
+		PanicOnError(retRes)
+		ch <- retRes
+		return nil
+	}()
+	return ch
+}
+
+type CreateTrailingStopOrderOptionsStruct struct {
+	Price                *float64
+	TrailingAmount       *float64
+	TrailingPercent      *float64
+	TrailingTriggerPrice *float64
+	Params               *map[string]interface{}
+}
+
+type CreateTrailingStopOrderOptions func(opts *CreateTrailingStopOrderOptionsStruct)
+
+func WithCreateTrailingStopOrderPrice(price float64) CreateTrailingStopOrderOptions {
+	return func(opts *CreateTrailingStopOrderOptionsStruct) {
+		opts.Price = &price
+	}
+}
+
+func WithCreateTrailingStopOrderTrailingAmount(trailingAmount float64) CreateTrailingStopOrderOptions {
+	return func(opts *CreateTrailingStopOrderOptionsStruct) {
+		opts.TrailingAmount = &trailingAmount
+	}
+}
+
+func WithCreateTrailingStopOrderTrailingPercent(trailingPercent float64) CreateTrailingStopOrderOptions {
+	return func(opts *CreateTrailingStopOrderOptionsStruct) {
+		opts.TrailingPercent = &trailingPercent
+	}
+}
+
+func WithCreateTrailingStopOrderTrailingTriggerPrice(trailingTriggerPrice float64) CreateTrailingStopOrderOptions {
+	return func(opts *CreateTrailingStopOrderOptionsStruct) {
+		opts.TrailingTriggerPrice = &trailingTriggerPrice
+	}
+}
+
+func WithCreateTrailingStopOrderParams(params map[string]interface{}) CreateTrailingStopOrderOptions {
+	return func(opts *CreateTrailingStopOrderOptionsStruct) {
+		opts.Params = &params
+	}
+}
+
+func (this *ExchangeTyped) CreateTrailingStopOrder(symbol string, typeVar string, side string, amount float64, options ...CreateTrailingStopOrderOptions) (Order, error) {
+	opts := CreateTrailingStopOrderOptionsStruct{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	var price interface{} = nil
+	if opts.Price != nil {
+		price = *opts.Price
+	}
+	var params interface{} = map[string]interface{}{}
+	if opts.Params != nil {
+		params = this.Extend(*opts.Params)
+	}
+	if opts.TrailingAmount != nil {
+		AddElementToObject(params, "trailingAmount", *opts.TrailingAmount)
+	}
+	if opts.TrailingPercent != nil {
+		AddElementToObject(params, "trailingPercent", *opts.TrailingPercent)
+	}
+	if opts.TrailingTriggerPrice != nil {
+		AddElementToObject(params, "trailingTriggerPrice", *opts.TrailingTriggerPrice)
+	}
+	res := <-this.Exchange.CreateTrailingStopOrder(symbol, typeVar, side, amount, price, params)
+	if IsError(res) {
+		return Order{}, CreateReturnError(res)
+	}
+	return NewOrder(res), nil
+}
@@ -3,6 +3,7 @@ package ccxt
 import (
 	"fmt"
 	"math"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -103,6 +104,7 @@ type MarketInterface struct {
 	OptionType     *string
 	Taker          *float64
 	Maker          *float64
+	Precision      *Precision
 	Limits         Limits
 	Created        *int64
 }
@@ -115,9 +117,14 @@ func NewMarketInterface(data interface{}) MarketInterface {
 
 	m := data.(map[string]interface{})
 
-	// Handle limits if present
+	// Handle precision and limits if present
+	var precision *Precision
+	if v, ok := m["precision"].(map[string]interface{}); ok {
+		precisionValue := NewPrecision(v)
+		precision = &precisionValue
+	}
 	var limits Limits
-	if v, ok := m["limits"]; ok {
+	if v, ok := m["limits"].(map[string]interface{}); ok {
 		limits = NewLimits(v)
 	}
 
@@ -151,6 +158,7 @@ func NewMarketInterface(data interface{}) MarketInterface {
 		OptionType:     SafeStringTyped(m, "optionType"),
 		Taker:          SafeFloatTyped(m, "taker"),
 		Maker:          SafeFloatTyped(m, "maker"),
+		Precision:      precision,
 		Limits:         limits,
 		Created:        SafeInt64Typed(m, "created"),
 	}
@@ -295,6 +303,17 @@ type Market struct {
 	QuoteId       *string
 	Active        *bool
 	Type          *string
+	Spot          *bool
+	Margin        *bool
+	Swap          *bool
+	Future        *bool
+	Option        *bool
+	Contract      *bool
+	Linear        *bool
+	Inverse       *bool
+	Settle        *string
+	SettleId      *string
+	ContractSize  *float64
 	Precision     *Precision
 	MarginModes   *MarketMarginModes
 	Limits        *Limits
@@ -307,15 +326,15 @@ func NewMarket(data interface{}) Market {
 	var precision *Precision
 	var marginModes *MarketMarginModes
 	var limits *Limits
-	if v, ok := m["precision"]; ok {
+	if v, ok := m["precision"].(map[string]interface{}); ok {
 		precisionValue := NewPrecision(v)
 		precision = &precisionValue
 	}
-	if v, ok := m["marginModes"]; ok {
+	if v, ok := m["marginModes"].(map[string]interface{}); ok {
 		marginModesValue := NewMarketMarginModes(v)
 		marginModes = &marginModesValue
 	}
-	if v, ok := m["limits"]; ok {
+	if v, ok := m["limits"].(map[string]interface{}); ok {
 		limitsValue := NewLimits(v)
 		limits = &limitsValue
 	}
@@ -336,6 +355,17 @@ func NewMarket(data interface{}) Market {
 		QuoteId:       SafeStringTyped(m, "quoteId"),
 		Active:        SafeBoolTyped(m, "active"),
 		Type:          SafeStringTyped(m, "type"),
+		Spot:          SafeBoolTyped(m, "spot"),
+		Margin:        SafeBoolTyped(m, "margin"),
+		Swap:          SafeBoolTyped(m, "swap"),
+		Future:        SafeBoolTyped(m, "future"),
+		Option:        SafeBoolTyped(m, "option"),
+		Contract:      SafeBoolTyped(m, "contract"),
+		Linear:        SafeBoolTyped(m, "linear"),
+		Inverse:       SafeBoolTyped(m, "inverse"),
+		Settle:        SafeStringTyped(m, "settle"),
+		SettleId:      SafeStringTyped(m, "settleId"),
+		ContractSize:  SafeFloatTyped(m, "contractSize"),
 		Precision:     precision,
 		MarginModes:   marginModes,
 		Limits:        limits,
@@ -467,6 +497,8 @@ type Ticker struct {
 	Average       *float64
 	BaseVolume    *float64
 	QuoteVolume   *float64
+	MarkPrice     *float64
+	IndexPrice    *float64
 	Info          map[string]interface{}
 }
 
@@ -492,6 +524,8 @@ func NewTicker(data interface{}) Ticker {
 		Average:       SafeFloatTyped(m, "average"),
 		BaseVolume:    SafeFloatTyped(m, "baseVolume"),
 		QuoteVolume:   SafeFloatTyped(m, "quoteVolume"),
+		MarkPrice:     SafeFloatTyped(m, "markPrice"),
+		IndexPrice:    SafeFloatTyped(m, "indexPrice"),
 		Info:          GetInfo(m),
 	}
 }
@@ -1075,6 +1109,7 @@ func NewWithdrawlResponse(withdrawlResponseData map[string]interface{}) Withdraw
 type CrossBorrowRate struct {
 	Currency  *string
 	Rate      *float64
+	Period    *int64
 	Timestamp *int64
 	Datetime  *string
 	Info      map[string]interface{}
@@ -1084,6 +1119,7 @@ func NewCrossBorrowRate(data interface{}) CrossBorrowRate {
 	return CrossBorrowRate{
 		Currency:  SafeStringTyped(data, "currency"),
 		Rate:      SafeFloatTyped(data, "rate"),
+		Period:    SafeInt64Typed(data, "period"),
 		Timestamp: SafeInt64Typed(data, "timestamp"),
 		Datetime:  SafeStringTyped(data, "datetime"),
 		Info:      GetInfo(data),
@@ -1109,10 +1145,12 @@ func NewCrossBorrowRates(data2 interface{}) CrossBorrowRates {
 
 type IsolatedBorrowRate struct {
 	Symbol    *string
+	Base      *string
 	BaseRate  *float64
 	Quote     *string
 	QuoteRate *float64
 	Rate      *float64
+	Period    *int64
 	Timestamp *int64
 	Datetime  *string
 	Info      map[string]interface{}
@@ -1121,10 +1159,12 @@ type IsolatedBorrowRate struct {
 func NewIsolatedBorrowRate(data interface{}) IsolatedBorrowRate {
 	return IsolatedBorrowRate{
 		Symbol:    SafeStringTyped(data, "symbol"),
+		Base:      SafeStringTyped(data, "base"),
 		BaseRate:  SafeFloatTyped(data, "baseRate"),
 		Quote:     SafeStringTyped(data, "quote"),
 		QuoteRate: SafeFloatTyped(data, "quoteRate"),
 		Rate:      SafeFloatTyped(data, "rate"),
+		Period:    SafeInt64Typed(data, "period"),
 		Timestamp: SafeInt64Typed(data, "timestamp"),
 		Datetime:  SafeStringTyped(data, "datetime"),
 		Info:      GetInfo(data),
@@ -1272,26 +1312,28 @@ func NewCurrencyLimits(data interface{}) CurrencyLimits {
 }
 
 type Network struct {
-	Info      map[string]interface{}
-	Id        *string
-	Fee       *float64
-	Active    *bool
-	Deposit   *bool
-	Withdraw  *bool
-	Precision *float64
-	Limits    CurrencyLimits
+	Info          map[string]interface{}
+	Id            *string
+	Fee           *float64
+	Active        *bool
+	Deposit       *bool
+	Withdraw      *bool
+	Precision     *float64
+	Confirmations *int64
+	Limits        CurrencyLimits
 }
 
 func NewNetwork(data interface{}) Network {
 	return Network{
-		Info:      GetInfo(data),
-		Id:        SafeStringTyped(data, "id"),
-		Fee:       SafeFloatTyped(data, "fee"),
-		Active:    SafeBoolTyped(data, "active"),
-		Deposit:   SafeBoolTyped(data, "deposit"),
-		Withdraw:  SafeBoolTyped(data, "withdraw"),
-		Precision: SafeFloatTyped(data, "precision"),
-		Limits:    NewCurrencyLimits(SafeValue(data, "limits", map[string]interface{}{}).(map[string]interface{})),
+		Info:          GetInfo(data),
+		Id:            SafeStringTyped(data, "id"),
+		Fee:           SafeFloatTyped(data, "fee"),
+		Active:        SafeBoolTyped(data, "active"),
+		Deposit:       SafeBoolTyped(data, "deposit"),
+		Withdraw:      SafeBoolTyped(data, "withdraw"),
+		Precision:     SafeFloatTyped(data, "precision"),
+		Confirmations: SafeInt64Typed(data, "confirmations"),
+		Limits:        NewCurrencyLimits(SafeValue(data, "limits", map[string]interface{}{}).(map[string]interface{})),
 	}
 }
 
@@ -1472,6 +1514,11 @@ type Option struct {
 	Symbol            *string
 	Timestamp         *int64
 	Datetime          *string
+	Strike            *float64
+	Expiry            *int64
+	ExpiryDatetime    *string
+	OptionType        *string
+	Underlying        *string
 	ImpliedVolatility *float64
 	OpenInterest      *float64
 	BidPrice          *float64
@@ -1493,14 +1540,19 @@ func NewOption(data interface{}) Option {
 		Symbol:            SafeStringTyped(data, "symbol"),
 		Timestamp:         SafeInt64Typed(data, "timestamp"),
 		Datetime:          SafeStringTyped(data, "datetime"),
-		ImpliedVolatility: SafeFloatTyped(data, "bidVolume"),
+		Strike:            SafeFloatTyped(data, "strike"),
+		Expiry:            SafeInt64Typed(data, "expiry"),
+		ExpiryDatetime:    SafeStringTyped(data, "expiryDatetime"),
+		OptionType:        SafeStringTyped(data, "optionType"),
+		Underlying:        SafeStringTyped(data, "underlying"),
+		ImpliedVolatility: SafeFloatTyped(data, "impliedVolatility"),
 		OpenInterest:      SafeFloatTyped(data, "openInterest"),
-		BidPrice:          SafeFloatTyped(data, "askVolume"),
-		AskPrice:          SafeFloatTyped(data, "vwap"),
-		MidPrice:          SafeFloatTyped(data, "open"),
-		MarkPrice:         SafeFloatTyped(data, "close"),
-		LastPrice:         SafeFloatTyped(data, "last"),
-		UnderlyingPrice:   SafeFloatTyped(data, "previousClose"),
+		BidPrice:          SafeFloatTyped(data, "bidPrice"),
+		AskPrice:          SafeFloatTyped(data, "askPrice"),
+		MidPrice:          SafeFloatTyped(data, "midPrice"),
+		MarkPrice:         SafeFloatTyped(data, "markPrice"),
+		LastPrice:         SafeFloatTyped(data, "lastPrice"),
+		UnderlyingPrice:   SafeFloatTyped(data, "underlyingPrice"),
 		Change:            SafeFloatTyped(data, "change"),
 		Percentage:        SafeFloatTyped(data, "percentage"),
 		BaseVolume:        SafeFloatTyped(data, "baseVolume"),
@@ -1860,30 +1912,34 @@ func NewFundingRateHistory(data interface{}) FundingRateHistory {
 }
 
 type Conversion struct {
-	Info         map[string]interface{}
-	Timestamp    *int64
-	Datetime     *string
-	Id           *string
-	FromCurrency *string
-	FromAmount   *float64
-	ToCurrency   *string
-	ToAmount     *float64
-	Price        *float64
-	Fee          *float64
+	Info           map[string]interface{}
+	Timestamp      *int64
+	Datetime       *string
+	Id             *string
+	FromCurrency   *string
+	FromAmount     *float64
+	ToCurrency     *string
+	ToAmount       *float64
+	Price          *float64
+	Fee            *float64
+	Expiry         *int64
+	ExpiryDatetime *string
 }
 
 func NewConversion(data interface{}) Conversion {
 	return Conversion{
-		Info:         GetInfo(data),
-		Timestamp:    SafeInt64Typed(data, "timestamp"),
-		Datetime:     SafeStringTyped(data, "datetime"),
-		Id:           SafeStringTyped(data, "id"),
-		FromCurrency: SafeStringTyped(data, "fromCurrency"),
-		FromAmount:   SafeFloatTyped(data, "fromAmount"),
-		ToCurrency:   SafeStringTyped(data, "toCurrency"),
-		ToAmount:     SafeFloatTyped(data, "toAmount"),
-		Price:        SafeFloatTyped(data, "price"),
-		Fee:          SafeFloatTyped(data, "fee"),
+		Info:           GetInfo(data),
+		Timestamp:      SafeInt64Typed(data, "timestamp"),
+		Datetime:       SafeStringTyped(data, "datetime"),
+		Id:             SafeStringTyped(data, "id"),
+		FromCurrency:   SafeStringTyped(data, "fromCurrency"),
+		FromAmount:     SafeFloatTyped(data, "fromAmount"),
+		ToCurrency:     SafeStringTyped(data, "toCurrency"),
+		ToAmount:       SafeFloatTyped(data, "toAmount"),
+		Price:          SafeFloatTyped(data, "price"),
+		Fee:            SafeFloatTyped(data, "fee"),
+		Expiry:         SafeInt64Typed(data, "expiry"),
+		ExpiryDatetime: SafeStringTyped(data, "expiryDatetime"),
 	}
 }
 
@@ -2179,7 +2235,33 @@ func NewLongShortRatioArray(orders2 interface{}) []LongShortRatio {
 	return result
 }
 
+// NewDepositAddressesByNetwork converts the dictionary of addresses indexed by network returned by FetchDepositAddressesByNetwork
+func NewDepositAddressesByNetwork(data interface{}) map[string]DepositAddress {
+	result := make(map[string]DepositAddress)
+	if addresses, ok := data.(map[string]interface{}); ok {
+		for network, address := range addresses {
+			if addressMap, ok := address.(map[string]interface{}); ok {
+				result[network] = NewDepositAddress(addressMap)
+			}
+		}
+	}
+	return result
+}
+
 func NewDepositAddressArray(orders2 interface{}) []DepositAddress {
+	// FetchDepositAddressesByNetwork returns the addresses indexed by network
+	if byNetwork, ok := orders2.(map[string]interface{}); ok {
+		networks := make([]string, 0, len(byNetwork))
+		for network := range byNetwork {
+			networks = append(networks, network)
+		}
+		sort.Strings(networks)
+		list := make([]interface{}, 0, len(networks))
+		for _, network := range networks {
+			list = append(list, byNetwork[network])
+		}
+		orders2 = list
+	}
 	orders := orders2.([]interface{})
 	result := make([]DepositAddress, 0, len(orders))
 	for _, t := range orders {
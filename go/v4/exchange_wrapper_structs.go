@@ -1955,6 +1955,25 @@ func WithFetchMarkPricesParams(params map[string]interface{}) FetchMarkPricesOpt
 	}
 }
 
+type FetchIndexPricesOptionsStruct struct {
+	Symbols *[]string
+	Params  *map[string]interface{}
+}
+
+type FetchIndexPricesOptions func(opts *FetchIndexPricesOptionsStruct)
+
+func WithFetchIndexPricesSymbols(symbols []string) FetchIndexPricesOptions {
+	return func(opts *FetchIndexPricesOptionsStruct) {
+		opts.Symbols = &symbols
+	}
+}
+
+func WithFetchIndexPricesParams(params map[string]interface{}) FetchIndexPricesOptions {
+	return func(opts *FetchIndexPricesOptionsStruct) {
+		opts.Params = &params
+	}
+}
+
 type FetchTickersWsOptionsStruct struct {
 	Symbols *[]string
 	Params  *map[string]interface{}
@@ -0,0 +1,137 @@
+package ccxt
+
+import "sync"
+
+// Streams
+// -------
+// A future hands an update only to the callers awaiting it when the update
+// arrives. Stream keeps every update of a message hash instead, in a bounded
+// per-subscriber buffer, so a consumer reading in a loop does not miss the
+// updates published between two reads. The policy decides what happens when
+// a consumer falls behind and its buffer is full: DropOldest and DropNewest
+// discard an update and never hold up the read loop, Block waits for the
+// consumer and stalls every subscription of the connection meanwhile.
+
+// BackpressurePolicy is what a stream does with an update when its buffer is full
+type BackpressurePolicy int
+
+const (
+	DropOldest BackpressurePolicy = iota // discard the oldest buffered update to make room
+	DropNewest                           // discard the incoming update
+	Block                                // wait until the consumer reads
+)
+
+type wsStream struct {
+	hash   string
+	ch     chan interface{}
+	policy BackpressurePolicy
+	done   chan struct{}
+	mu     sync.Mutex
+	closed bool
+	once   sync.Once
+}
+
+// Stream subscribes to every update resolved for messageHash, buffered up to size updates (at least 1)
+// and handled with policy once the buffer is full. The returned function ends the stream and closes the channel,
+// a stream ended by the subscription receives the error as its last value, see SubscriptionError
+func (this *Client) Stream(messageHash string, size int, policy BackpressurePolicy) (<-chan interface{}, func()) {
+	if size < 1 {
+		size = 1
+	}
+	stream := &wsStream{
+		hash:   messageHash,
+		ch:     make(chan interface{}, size),
+		policy: policy,
+		done:   make(chan struct{}),
+	}
+	this.StreamsMu.Lock()
+	if this.Streams == nil {
+		this.Streams = make(map[string][]*wsStream)
+	}
+	this.Streams[messageHash] = append(this.Streams[messageHash], stream)
+	this.StreamsMu.Unlock()
+	this.clearCloseReason(messageHash)
+	return stream.ch, func() {
+		this.StreamsMu.Lock()
+		streams := this.Streams[messageHash]
+		for i, candidate := range streams {
+			if candidate == stream {
+				this.Streams[messageHash] = append(streams[:i:i], streams[i+1:]...)
+				break
+			}
+		}
+		if len(this.Streams[messageHash]) == 0 {
+			delete(this.Streams, messageHash)
+		}
+		this.StreamsMu.Unlock()
+		stream.end(nil)
+	}
+}
+
+func (this *Client) publish(hash string, data interface{}) {
+	this.StreamsMu.RLock()
+	streams := append([]*wsStream(nil), this.Streams[hash]...)
+	this.StreamsMu.RUnlock()
+	for _, stream := range streams {
+		if !stream.push(data) {
+			this.metrics.drop(hash)
+		}
+	}
+}
+
+// end closes the stream once, after delivering reason when it is not nil
+func (this *wsStream) end(reason error) {
+	this.once.Do(func() {
+		// unblocks a Block publish before taking the lock it holds
+		close(this.done)
+		this.mu.Lock()
+		defer this.mu.Unlock()
+		if reason != nil {
+			// the reason takes the place of the oldest update if the buffer is full
+			select {
+			case this.ch <- reason:
+			default:
+				select {
+				case <-this.ch:
+				default:
+				}
+				this.ch <- reason
+			}
+		}
+		this.closed = true
+		close(this.ch)
+	})
+}
+
+// push delivers data to the stream buffer and reports false when an update was discarded
+func (this *wsStream) push(data interface{}) bool {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	if this.closed {
+		return true
+	}
+	select {
+	case this.ch <- data:
+		return true
+	default:
+	}
+	switch this.policy {
+	case DropNewest:
+		return false
+	case Block:
+		select {
+		case this.ch <- data:
+		case <-this.done:
+		}
+		return true
+	}
+	// DropOldest, the consumer may have read in the meantime so the buffer is not necessarily full anymore
+	select {
+	case <-this.ch:
+		this.ch <- data
+		return false
+	default:
+		this.ch <- data
+		return true
+	}
+}
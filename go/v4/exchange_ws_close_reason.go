@@ -0,0 +1,65 @@
+package ccxt
+
+// Close reasons
+// -------------
+// A subscription ends when its futures are rejected: an error frame of the
+// exchange, an unwatch (UnsubscribeError) or a lost connection. The futures
+// hand the error to the callers awaiting them, Stream consumers receive it as
+// the last value before their channel is closed, and SubscriptionError keeps
+// it so that a consumer finding a closed channel can still tell why. The
+// reason is cleared once the message hash is watched again.
+
+// SubscriptionError returns the error that ended the subscription to messageHash, nil if it did not end
+func (this *Client) SubscriptionError(messageHash string) error {
+	this.StreamsMu.RLock()
+	defer this.StreamsMu.RUnlock()
+	return this.closeReasons[messageHash]
+}
+
+// SubscriptionError returns the error that ended the subscription to messageHash on one of the connections
+// of the exchange, nil if it did not end
+func (this *Exchange) SubscriptionError(messageHash string) error {
+	this.WsClientsMu.Lock()
+	clients := make([]*WSClient, 0, len(this.Clients))
+	for _, client := range this.Clients {
+		clients = append(clients, client.(*WSClient))
+	}
+	this.WsClientsMu.Unlock()
+	for _, client := range clients {
+		if err := client.SubscriptionError(messageHash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// endSubscriptions records reason for hashes and ends their streams with it, all also ends every other stream
+func (this *Client) endSubscriptions(reason error, hashes []string, all bool) {
+	this.StreamsMu.Lock()
+	if all {
+		for hash := range this.Streams {
+			hashes = append(hashes, hash)
+		}
+	}
+	if this.closeReasons == nil {
+		this.closeReasons = make(map[string]error)
+	}
+	ended := []*wsStream{}
+	for _, hash := range hashes {
+		if reason != nil {
+			this.closeReasons[hash] = reason
+		}
+		ended = append(ended, this.Streams[hash]...)
+		delete(this.Streams, hash)
+	}
+	this.StreamsMu.Unlock()
+	for _, stream := range ended {
+		stream.end(reason)
+	}
+}
+
+func (this *Client) clearCloseReason(hash string) {
+	this.StreamsMu.Lock()
+	delete(this.closeReasons, hash)
+	this.StreamsMu.Unlock()
+}
@@ -0,0 +1,56 @@
+package ccxt
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gorilla/websocket"
+)
+
+// Error classification
+// --------------------
+// A lost connection rejects every pending future of the client, the error
+// tells the callers how to react: an AuthenticationError needs new
+// credentials or a new session, a RateLimitExceeded a slower pace, an
+// ExchangeNotAvailable a later retry and a NetworkError a plain reconnect.
+// The read loop maps the close code of the server with ClassifyWsClose, the
+// exchange maps its own error frames with ClassifyWsError.
+
+// ClassifyWsClose returns the typed error for the error that ended the read loop
+func ClassifyWsClose(err error) error {
+	var closeError *websocket.CloseError
+	if !errors.As(err, &closeError) {
+		return NetworkError(err)
+	}
+	message := fmt.Sprintf("connection closed by remote server, closing code %d", closeError.Code)
+	if closeError.Text != "" {
+		message += " " + closeError.Text
+	}
+	switch closeError.Code {
+	case websocket.ClosePolicyViolation:
+		// the exchanges close with a policy violation when a connection sends too many messages
+		return RateLimitExceeded(message)
+	case websocket.CloseGoingAway, websocket.CloseInternalServerErr, websocket.CloseServiceRestart, websocket.CloseTryAgainLater:
+		return ExchangeNotAvailable(message)
+	}
+	return NetworkError(message)
+}
+
+// ClassifyWsError returns the error an error frame of the exchange ends the connection with, nil when
+// the frame is left to HandleMessage. Exchanges override it for the frames that invalidate the connection.
+func (this *Exchange) ClassifyWsError(client interface{}, message interface{}) interface{} {
+	return nil
+}
+
+// classifyMessage ends the connection with the error ClassifyError returns for message, if any
+func (this *Client) classifyMessage(message interface{}) bool {
+	if this.ClassifyError == nil {
+		return false
+	}
+	err, ok := this.ClassifyError(this, message).(error)
+	if !ok || err == nil {
+		return false
+	}
+	this.OnError(err)
+	return true
+}
@@ -0,0 +1,119 @@
+package ccxt
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// ---------------------------------------------------------------------------
+// Error classification: close codes and error frames map to typed errors
+// ---------------------------------------------------------------------------
+
+func TestClassifyWsClose(t *testing.T) {
+	cases := []struct {
+		err      error
+		expected string
+	}{
+		{&websocket.CloseError{Code: websocket.ClosePolicyViolation, Text: "Too many requests"}, "RateLimitExceeded"},
+		{&websocket.CloseError{Code: websocket.CloseGoingAway}, "ExchangeNotAvailable"},
+		{&websocket.CloseError{Code: websocket.CloseServiceRestart}, "ExchangeNotAvailable"},
+		{&websocket.CloseError{Code: websocket.CloseTryAgainLater}, "ExchangeNotAvailable"},
+		{&websocket.CloseError{Code: websocket.CloseNormalClosure}, "NetworkError"},
+		{&websocket.CloseError{Code: websocket.CloseAbnormalClosure}, "NetworkError"},
+		{io.ErrUnexpectedEOF, "NetworkError"},
+	}
+	for _, c := range cases {
+		// the classes below NetworkError are subtypes of it, the type is compared exactly
+		if err, ok := ClassifyWsClose(c.err).(*Error); !ok || string(err.Type) != c.expected {
+			t.Fatalf("expected %s for %v, got %v", c.expected, c.err, err)
+		}
+	}
+	err := ClassifyWsClose(&websocket.CloseError{Code: websocket.ClosePolicyViolation, Text: "Too many requests"})
+	if !strings.Contains(err.Error(), "1008") || !strings.Contains(err.Error(), "Too many requests") {
+		t.Fatalf("expected the close code and reason in the message, got %v", err)
+	}
+}
+
+func newErrorTestClient(t *testing.T, frames []string, closeCode int, classify func(interface{}, interface{}) interface{}) (*WSClient, chan interface{}) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade failed: %v", err)
+			return
+		}
+		defer conn.Close()
+		for _, frame := range frames {
+			conn.WriteMessage(websocket.TextMessage, []byte(frame))
+		}
+		if closeCode != 0 {
+			conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(closeCode, "restarting"))
+		}
+		conn.ReadMessage()
+	}))
+	t.Cleanup(server.Close)
+	errs := make(chan interface{}, 10)
+	onError := func(_ interface{}, err interface{}) { errs <- err }
+	client := NewWSClient("ws"+strings.TrimPrefix(server.URL, "http"), func(interface{}, interface{}) {}, onError, func(interface{}, interface{}) {}, nil, "", map[string]interface{}{
+		"ClassifyError": classify,
+	})
+	t.Cleanup(func() { client.Close() })
+	return client, errs
+}
+
+func receiveError(t *testing.T, errs chan interface{}) error {
+	select {
+	case err := <-errs:
+		return err.(error)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the connection error")
+	}
+	return nil
+}
+
+func TestWsClientClassifiesCloseCode(t *testing.T) {
+	client, errs := newErrorTestClient(t, nil, websocket.CloseServiceRestart, nil)
+	ticker := client.ReusableFuture("ticker")
+	if err := client.CreateConnection(); err != nil {
+		t.Fatal(err)
+	}
+	if err := receiveError(t, errs); !IsErrorType(err, "ExchangeNotAvailable") {
+		t.Fatalf("expected ExchangeNotAvailable, got %v", err)
+	}
+	select {
+	case err := <-ticker.err:
+		if !IsErrorType(err.(error), "ExchangeNotAvailable") {
+			t.Fatalf("expected the watcher to be rejected with ExchangeNotAvailable, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the watcher to be rejected")
+	}
+}
+
+func TestWsClientClassifiesErrorFrame(t *testing.T) {
+	frames := []string{`{"topic":"ticker"}`, `{"event":"sessionExpired"}`, `{"topic":"trades"}`}
+	classify := func(_ interface{}, message interface{}) interface{} {
+		if GetValue(message, "event") == "sessionExpired" {
+			return AuthenticationError("session expired")
+		}
+		return nil
+	}
+	client, errs := newErrorTestClient(t, frames, 0, classify)
+	if err := client.CreateConnection(); err != nil {
+		t.Fatal(err)
+	}
+	err := receiveError(t, errs)
+	if !IsErrorType(err, "AuthenticationError") {
+		t.Fatalf("expected an AuthenticationError, got %v", err)
+	}
+	// the connection ends with the error frame, the frames after it are not read
+	if client.GetError() != err || client.IsOpen() || client.Metrics().FramesReceived != 2 {
+		t.Fatalf("expected the connection to be closed with the classified error, got %v", client.GetError())
+	}
+}
@@ -0,0 +1,103 @@
+package ccxt
+
+import (
+	"sync"
+	"time"
+)
+
+// WsMetrics is a snapshot of the dispatch counters of a websocket client, see Client.Metrics
+type WsMetrics struct {
+	FramesReceived    int64
+	BytesReceived     int64
+	ParseErrors       int64            // frames that looked like json but could not be decoded
+	MessagesPerSecond float64          // frames per second between the first and the last frame
+	FirstFrame        int64            // milliseconds
+	LastFrame         int64            // milliseconds
+	Deliveries        map[string]int64 // updates handed to a waiting future, by message hash
+	Undelivered       map[string]int64 // updates resolved while nothing was waiting on the message hash
+	Dropped           map[string]int64 // updates discarded by the full buffer of a Stream, by message hash
+}
+
+type wsMetrics struct {
+	mu          sync.Mutex
+	frames      int64
+	bytes       int64
+	parseErrors int64
+	firstFrame  int64
+	lastFrame   int64
+	deliveries  map[string]int64
+	undelivered map[string]int64
+	dropped     map[string]int64
+}
+
+func (this *wsMetrics) frame(size int) {
+	now := time.Now().UnixMilli()
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	this.frames++
+	this.bytes += int64(size)
+	if this.firstFrame == 0 {
+		this.firstFrame = now
+	}
+	this.lastFrame = now
+}
+
+func (this *wsMetrics) parseError() {
+	this.mu.Lock()
+	this.parseErrors++
+	this.mu.Unlock()
+}
+
+func (this *wsMetrics) delivery(hash string, delivered bool) {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	if delivered {
+		if this.deliveries == nil {
+			this.deliveries = map[string]int64{}
+		}
+		this.deliveries[hash]++
+		return
+	}
+	if this.undelivered == nil {
+		this.undelivered = map[string]int64{}
+	}
+	this.undelivered[hash]++
+}
+
+func (this *wsMetrics) drop(hash string) {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	if this.dropped == nil {
+		this.dropped = map[string]int64{}
+	}
+	this.dropped[hash]++
+}
+
+// Metrics returns the counters of the frames read by the client and of the updates resolved per message hash
+func (this *Client) Metrics() WsMetrics {
+	this.metrics.mu.Lock()
+	defer this.metrics.mu.Unlock()
+	snapshot := WsMetrics{
+		FramesReceived: this.metrics.frames,
+		BytesReceived:  this.metrics.bytes,
+		ParseErrors:    this.metrics.parseErrors,
+		FirstFrame:     this.metrics.firstFrame,
+		LastFrame:      this.metrics.lastFrame,
+		Deliveries:     make(map[string]int64, len(this.metrics.deliveries)),
+		Undelivered:    make(map[string]int64, len(this.metrics.undelivered)),
+		Dropped:        make(map[string]int64, len(this.metrics.dropped)),
+	}
+	for hash, count := range this.metrics.deliveries {
+		snapshot.Deliveries[hash] = count
+	}
+	for hash, count := range this.metrics.undelivered {
+		snapshot.Undelivered[hash] = count
+	}
+	for hash, count := range this.metrics.dropped {
+		snapshot.Dropped[hash] = count
+	}
+	if elapsed := snapshot.LastFrame - snapshot.FirstFrame; elapsed > 0 {
+		snapshot.MessagesPerSecond = float64(snapshot.FramesReceived) * 1000 / float64(elapsed)
+	}
+	return snapshot
+}
@@ -0,0 +1,183 @@
+package ccxt
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// ---------------------------------------------------------------------------
+// Metrics: frames, parse errors and deliveries counted by the read loop
+// ---------------------------------------------------------------------------
+
+func TestWsClientMetrics(t *testing.T) {
+	frames := []string{
+		`{"topic":"ticker","price":"1"}`,
+		`{"topic":"ticker","price":"2"}`,
+		`{"topic":"trades","price":"3"}`,
+		`{"topic":"ticker",broken}`,
+		`{"topic":"ticker","price":"4"}`,
+	}
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade failed: %v", err)
+			return
+		}
+		defer conn.Close()
+		for _, frame := range frames {
+			conn.WriteMessage(websocket.TextMessage, []byte(frame))
+		}
+		conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	var client *WSClient
+	onMessage := func(_ interface{}, message interface{}) {
+		if topic, ok := GetValue(message, "topic").(string); ok {
+			client.Resolve(message, topic)
+		}
+	}
+	client = NewWSClient("ws"+strings.TrimPrefix(server.URL, "http"), onMessage, func(interface{}, interface{}) {}, func(interface{}, interface{}) {}, nil, "")
+	// a single watcher waits on ticker, the following ticker updates find nobody waiting
+	ticker := client.Future("ticker")
+	if err := client.CreateConnection(); err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	select {
+	case <-ticker:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the ticker")
+	}
+	// the last frame is counted before its update is resolved
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if metrics := client.Metrics(); metrics.FramesReceived == int64(len(frames)) && metrics.Undelivered["ticker"] == 2 {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	metrics := client.Metrics()
+	if metrics.FramesReceived != 5 || metrics.ParseErrors != 1 {
+		t.Fatalf("expected 5 frames and 1 parse error, got %d and %d", metrics.FramesReceived, metrics.ParseErrors)
+	}
+	size := 0
+	for _, frame := range frames {
+		size += len(frame)
+	}
+	if metrics.BytesReceived != int64(size) || metrics.FirstFrame == 0 || metrics.LastFrame < metrics.FirstFrame {
+		t.Fatalf("unexpected byte count or frame timestamps %+v", metrics)
+	}
+	if metrics.Deliveries["ticker"] != 1 || metrics.Undelivered["ticker"] != 2 || metrics.Undelivered["trades"] != 1 {
+		t.Fatalf("unexpected deliveries %v and undelivered updates %v", metrics.Deliveries, metrics.Undelivered)
+	}
+	// the snapshot is a copy
+	metrics.Deliveries["ticker"] = 100
+	if client.Metrics().Deliveries["ticker"] != 1 {
+		t.Fatal("expected the snapshot not to share its maps with the client")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Stream: a consumer that stops reading does not hold up the others
+// ---------------------------------------------------------------------------
+
+func TestWsClientStreamSlowConsumer(t *testing.T) {
+	const updates = 50
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade failed: %v", err)
+			return
+		}
+		defer conn.Close()
+		for i := 1; i <= updates; i++ {
+			conn.WriteMessage(websocket.TextMessage, []byte(`{"topic":"ticker","seq":`+strconv.Itoa(i)+`}`))
+		}
+		conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	var client *WSClient
+	onMessage := func(_ interface{}, message interface{}) {
+		client.Resolve(message, GetValue(message, "topic"))
+	}
+	client = NewWSClient("ws"+strings.TrimPrefix(server.URL, "http"), onMessage, func(interface{}, interface{}) {}, func(interface{}, interface{}) {}, nil, "")
+	stalledOldest, stopOldest := client.Stream("ticker", 2, DropOldest)
+	defer stopOldest()
+	stalledNewest, stopNewest := client.Stream("ticker", 2, DropNewest)
+	defer stopNewest()
+	reader, stopReader := client.Stream("ticker", 1, Block)
+	defer stopReader()
+	if err := client.CreateConnection(); err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	for i := 1; i <= updates; i++ {
+		select {
+		case update := <-reader:
+			if seq := GetValue(update, "seq"); ToFloat64(seq) != float64(i) {
+				t.Fatalf("expected update %d, got %v", i, seq)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("the reading consumer stalled after %d updates", i-1)
+		}
+	}
+	seqs := func(ch <-chan interface{}) []float64 {
+		result := []float64{}
+		for len(ch) > 0 {
+			result = append(result, ToFloat64(GetValue(<-ch, "seq")))
+		}
+		return result
+	}
+	if oldest := seqs(stalledOldest); len(oldest) != 2 || oldest[0] != updates-1 || oldest[1] != updates {
+		t.Fatalf("expected DropOldest to keep the latest updates, got %v", oldest)
+	}
+	if newest := seqs(stalledNewest); len(newest) != 2 || newest[0] != 1 || newest[1] != 2 {
+		t.Fatalf("expected DropNewest to keep the first updates, got %v", newest)
+	}
+	if dropped := client.Metrics().Dropped["ticker"]; dropped != 2*(updates-2) {
+		t.Fatalf("expected %d dropped updates, got %d", 2*(updates-2), dropped)
+	}
+}
+
+func TestWsClientStreamBlockAndStop(t *testing.T) {
+	client := NewClient("wss://example.com", nil, nil, nil, nil)
+	updates, stop := client.Stream("orders", 1, Block)
+	client.Resolve(1, "orders")
+	published := make(chan struct{})
+	go func() {
+		client.Resolve(2, "orders")
+		close(published)
+	}()
+	select {
+	case <-published:
+		t.Fatal("expected Block to wait for the consumer")
+	case <-time.After(50 * time.Millisecond):
+	}
+	if first := <-updates; first != 1 {
+		t.Fatalf("expected the first update, got %v", first)
+	}
+	<-published
+	// stopping the stream closes the channel and releases a blocked publish
+	go client.Resolve(3, "orders")
+	time.Sleep(20 * time.Millisecond)
+	stop()
+	stop()
+	for range updates {
+	}
+	if len(client.Streams) != 0 {
+		t.Fatalf("expected the stream to be removed, got %v", client.Streams)
+	}
+	client.Resolve(4, "orders")
+}
@@ -0,0 +1,94 @@
+package ccxt
+
+import (
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Keepalive
+// ---------
+// By default the pro clients ping every streaming["keepAlive"] milliseconds
+// with the message returned by the Ping method of the exchange. A PingConfig
+// replaces both: an exchange sets it in describe()["streaming"]["pingConfig"],
+// a user overrides it for all the exchange connections with Exchange.PingConfig.
+
+// PingConfig describes the pings sent to keep a websocket connection alive
+type PingConfig struct {
+	Interval   time.Duration // time between two pings, 0 disables them
+	Message    func() []byte // application level ping payload, nil sends a ping control frame
+	ExpectPong bool          // reset the connection when no pong arrives within MaxPingPongMisses intervals
+}
+
+// WsPingConfig returns the ping configuration of the websocket clients, nil
+// when the exchange relies on the keepAlive and ping streaming entries
+func (this *Exchange) WsPingConfig() *PingConfig {
+	if this.PingConfig != nil {
+		return this.PingConfig
+	}
+	if config, ok := SafeValue(this.streaming, "pingConfig", nil).(*PingConfig); ok {
+		return config
+	}
+	return nil
+}
+
+func (this *WSClient) setPingConfigInterval() {
+	if this.PingConfig.Interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(this.PingConfig.Interval)
+	this.PingInterval = ticker
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				this.OnPingConfigInterval()
+			case <-this.Disconnected.(*Future).Await():
+				return
+			}
+		}
+	}()
+}
+
+// OnPingConfigInterval sends the ping of the PingConfig, when a pong is expected and
+// the last one is overdue the connection is reset and dialed again by the exchange
+func (this *WSClient) OnPingConfigInterval() {
+	this.PingMu.Lock()
+	defer this.PingMu.Unlock()
+	if this.IsConnected != true {
+		return
+	}
+	config := this.PingConfig
+	if config.ExpectPong {
+		now := Milliseconds()
+		lastPong := this.GetLastPong()
+		if lastPong == nil {
+			lastPong = now
+			this.SetLastPong(lastPong)
+		}
+		maxPingPongMisses := 2.0
+		if misses, ok := this.MaxPingPongMisses.(float64); ok {
+			maxPingPongMisses = misses
+		}
+		deadline := lastPong.(int64) + int64(float64(config.Interval.Milliseconds())*maxPingPongMisses)
+		if deadline < now {
+			this.PongTimedOut = true
+			this.OnError(RequestTimeout("Connection to " + this.Url + " timed out due to a ping-pong keepalive missing on time"))
+			return
+		}
+	}
+	if this.Verbose {
+		this.Log(time.Now(), "sending connection ping")
+	}
+	if config.Message != nil {
+		message := string(config.Message())
+		go func() {
+			if err, ok := (<-this.Send(message)).(error); ok {
+				this.OnError(err)
+			}
+		}()
+	} else if this.Connection != nil {
+		this.Connection.WriteControl(websocket.PingMessage, nil, time.Now().Add(5*time.Second))
+	}
+}
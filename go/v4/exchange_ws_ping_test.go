@@ -0,0 +1,126 @@
+package ccxt
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// ---------------------------------------------------------------------------
+// PingConfig: pings at the configured cadence, a missing pong resets the connection
+// ---------------------------------------------------------------------------
+
+// newPingTestServer records the arrival of every ping, application level or control frame,
+// and answers with a pong when pong is set
+func newPingTestServer(t *testing.T, pong bool) (*httptest.Server, chan time.Time) {
+	pings := make(chan time.Time, 100)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade failed: %v", err)
+			return
+		}
+		defer conn.Close()
+		conn.SetPingHandler(func(string) error {
+			pings <- time.Now()
+			if pong {
+				return conn.WriteControl(websocket.PongMessage, nil, time.Now().Add(time.Second))
+			}
+			return nil
+		})
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if string(message) == "ping" {
+				pings <- time.Now()
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, pings
+}
+
+func newPingTestClient(server *httptest.Server, config *PingConfig, errors chan interface{}) *WSClient {
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	onError := func(client interface{}, err interface{}) {
+		select {
+		case errors <- err:
+		default:
+		}
+	}
+	return NewWSClient(url, func(interface{}, interface{}) {}, onError, func(interface{}, interface{}) {}, nil, "", map[string]interface{}{
+		"PingConfig": config,
+	})
+}
+
+func TestPingConfigSendsPingsAtTheConfiguredCadence(t *testing.T) {
+	for _, message := range []func() []byte{nil, func() []byte { return []byte("ping") }} {
+		server, pings := newPingTestServer(t, true)
+		errors := make(chan interface{}, 1)
+		client := newPingTestClient(server, &PingConfig{Interval: 50 * time.Millisecond, Message: message, ExpectPong: message == nil}, errors)
+		connected, err := client.Connect()
+		if err != nil {
+			t.Fatal(err)
+		}
+		<-connected.Await()
+		previous := time.Now()
+		for i := 0; i < 4; i++ {
+			select {
+			case ping := <-pings:
+				if gap := ping.Sub(previous); gap < 25*time.Millisecond || gap > 150*time.Millisecond {
+					t.Fatalf("expected a ping every 50ms, got one after %v", gap)
+				}
+				previous = ping
+			case err := <-errors:
+				t.Fatalf("unexpected error %v", err)
+			case <-time.After(time.Second):
+				t.Fatal("timed out waiting for a ping")
+			}
+		}
+		client.Close()
+	}
+}
+
+func TestPingConfigResetsTheConnectionWithoutPong(t *testing.T) {
+	server, _ := newPingTestServer(t, false)
+	errors := make(chan interface{}, 10)
+	client := newPingTestClient(server, &PingConfig{Interval: 20 * time.Millisecond, ExpectPong: true}, errors)
+	connected, err := client.Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-connected.Await()
+	select {
+	case <-errors:
+	case <-time.After(time.Second):
+		t.Fatal("expected the missing pong to reset the connection")
+	}
+	if !client.PongTimedOut || client.IsOpen() {
+		t.Fatal("expected the connection to be closed after the pong timeout")
+	}
+}
+
+func TestWsPingConfigPrecedence(t *testing.T) {
+	exchange := NewBinanceCore()
+	exchange.Init(map[string]interface{}{})
+	if exchange.WsPingConfig() != nil {
+		t.Fatal("expected no ping config by default")
+	}
+	venue := &PingConfig{Interval: 20 * time.Second}
+	exchange.streaming["pingConfig"] = venue
+	if exchange.WsPingConfig() != venue {
+		t.Fatal("expected the exchange ping config")
+	}
+	user := &PingConfig{Interval: 5 * time.Second, ExpectPong: true}
+	exchange.PingConfig = user
+	if exchange.WsPingConfig() != user {
+		t.Fatal("expected the user ping config to take precedence")
+	}
+}
@@ -0,0 +1,130 @@
+package ccxt
+
+// Duplicate subscriptions
+// -----------------------
+// Watching the same stream from several places (two goroutines calling
+// WatchTicker("BTC/USDT") for instance) shares one exchange subscription and
+// one future per update. The subscription is reference counted: every new
+// watcher of a stream takes a reference, every unwatch releases one, and an
+// unwatch only unsubscribes from the exchange when it releases the last one.
+// Set options["ws"]["shareSubscriptions"] to false to unsubscribe on the
+// first unwatch, regardless of the other watchers.
+
+// wsWatchers counts the subscribe and unsubscribe calls of one subscribe hash.
+// The watch methods are called in a loop, one call per update, so a call is
+// a new subscribe call only when there are more calls for the current update
+// than there were callers of the previous update, the others carry on
+// watching and keep the reference they already hold
+type wsWatchers struct {
+	refs       int     // subscribe calls minus unsubscribe calls
+	generation *Future // the future of the current update
+	calls      int     // the calls awaiting generation
+	delivered  int     // the calls that awaited the previous update
+	left       int     // the watchers that left after their call for generation
+}
+
+// AddWatcher records a watch call awaiting generation for subscribeHash, fresh is
+// set when the call created the subscription and starts counting from scratch
+func (this *Client) AddWatcher(subscribeHash string, generation *Future, fresh bool) {
+	this.WatchersMu.Lock()
+	defer this.WatchersMu.Unlock()
+	if this.Watchers == nil {
+		this.Watchers = make(map[string]*wsWatchers)
+	}
+	watchers, ok := this.Watchers[subscribeHash]
+	if !ok || fresh {
+		watchers = &wsWatchers{}
+		this.Watchers[subscribeHash] = watchers
+	}
+	if watchers.generation != generation {
+		watchers.generation = generation
+		watchers.delivered = watchers.calls - watchers.left
+		watchers.calls = 0
+		watchers.left = 0
+	}
+	watchers.calls++
+	if watchers.calls > watchers.delivered {
+		// a subscribe call of a new watcher
+		watchers.refs++
+	}
+}
+
+// ReleaseWatchers records an unsubscribe call of each subscribe hash and reports
+// whether all of them are still watched, otherwise their counts are cleared
+// and the caller goes on with the unsubscription
+func (this *Client) ReleaseWatchers(subscribeHashes []string) bool {
+	this.WatchersMu.Lock()
+	defer this.WatchersMu.Unlock()
+	if len(subscribeHashes) == 0 {
+		return false
+	}
+	stillWatched := true
+	for _, hash := range subscribeHashes {
+		watchers, ok := this.Watchers[hash]
+		if !ok || watchers.refs <= 1 {
+			stillWatched = false
+		}
+	}
+	for _, hash := range subscribeHashes {
+		if stillWatched {
+			watchers := this.Watchers[hash]
+			watchers.refs--
+			// the watcher that left does not call again, for this update or for the next one
+			if watchers.calls < watchers.delivered {
+				watchers.delivered--
+			} else {
+				watchers.left++
+			}
+		} else {
+			delete(this.Watchers, hash)
+		}
+	}
+	return stillWatched
+}
+
+// WatcherCount returns the number of watchers sharing subscribeHash
+func (this *Client) WatcherCount(subscribeHash string) int {
+	this.WatchersMu.Lock()
+	defer this.WatchersMu.Unlock()
+	if watchers, ok := this.Watchers[subscribeHash]; ok {
+		return watchers.refs
+	}
+	return 0
+}
+
+func (this *Exchange) shareSubscriptions() bool {
+	wsOptions := SafeValue(this.Options, "ws", map[string]interface{}{})
+	return this.SafeBool(wsOptions, "shareSubscriptions", true).(bool)
+}
+
+// isUnsubscription tells the subscription of an unwatch call apart
+func isUnsubscription(subscription interface{}) bool {
+	return IsTrue(SafeValue(subscription, "unsubscribe", false))
+}
+
+// releaseSharedSubscription is called by the unwatch methods before they
+// unsubscribe, it returns true when other watchers still use the stream and
+// the unsubscribe message must not be sent
+func (this *Exchange) releaseSharedSubscription(client *WSClient, subscription interface{}) bool {
+	if !isUnsubscription(subscription) || !this.shareSubscriptions() {
+		return false
+	}
+	subscribeHashes := []string{}
+	if hashes, ok := SafeValue(subscription, "subMessageHashes", nil).([]interface{}); ok {
+		for _, hash := range hashes {
+			if str, ok := hash.(string); ok {
+				subscribeHashes = append(subscribeHashes, str)
+			}
+		}
+	} else if hash, ok := SafeValue(subscription, "subHash", nil).(string); ok {
+		subscribeHashes = append(subscribeHashes, hash)
+	}
+	return client.ReleaseWatchers(subscribeHashes)
+}
+
+// resolvedFuture is returned by an unwatch that only released its reference
+func resolvedFuture(value interface{}) <-chan interface{} {
+	future := NewFuture()
+	future.Resolve(value)
+	return future.Await()
+}
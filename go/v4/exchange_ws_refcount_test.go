@@ -0,0 +1,88 @@
+package ccxt
+
+import "testing"
+
+func TestWatchersAreReferenceCounted(t *testing.T) {
+	client := &Client{}
+	generation := NewFuture()
+	// two callers awaiting the same update, then one of them awaiting the next one
+	client.AddWatcher("ticker:BTC/USDT", generation, true)
+	client.AddWatcher("ticker:BTC/USDT", generation, false)
+	client.AddWatcher("ticker:BTC/USDT", NewFuture(), false)
+	if count := client.WatcherCount("ticker:BTC/USDT"); count != 2 {
+		t.Fatalf("expected 2 watchers, got %d", count)
+	}
+	if !client.ReleaseWatchers([]string{"ticker:BTC/USDT"}) {
+		t.Fatal("expected the subscription to be kept for the other watcher")
+	}
+	if client.ReleaseWatchers([]string{"ticker:BTC/USDT"}) {
+		t.Fatal("expected the last watcher to unsubscribe")
+	}
+	if count := client.WatcherCount("ticker:BTC/USDT"); count != 0 {
+		t.Fatalf("expected no watcher left, got %d", count)
+	}
+
+	// a new subscription starts counting from scratch
+	client.AddWatcher("ticker:BTC/USDT", generation, false)
+	client.AddWatcher("ticker:BTC/USDT", generation, false)
+	client.AddWatcher("ticker:BTC/USDT", NewFuture(), true)
+	if count := client.WatcherCount("ticker:BTC/USDT"); count != 1 {
+		t.Fatalf("expected 1 watcher, got %d", count)
+	}
+}
+
+func TestWatchersCountSubscribeCalls(t *testing.T) {
+	client := &Client{}
+	first := NewFuture()
+	client.AddWatcher("ticker:BTC/USDT", first, true)
+	// a second watcher subscribes while the first one handles the update
+	second := NewFuture()
+	client.AddWatcher("ticker:BTC/USDT", second, false)
+	client.AddWatcher("ticker:BTC/USDT", second, false)
+	if count := client.WatcherCount("ticker:BTC/USDT"); count != 2 {
+		t.Fatalf("expected 2 watchers, got %d", count)
+	}
+	// the watchers loop over the next updates without subscribing again
+	for i := 0; i < 3; i++ {
+		generation := NewFuture()
+		client.AddWatcher("ticker:BTC/USDT", generation, false)
+		client.AddWatcher("ticker:BTC/USDT", generation, false)
+	}
+	if count := client.WatcherCount("ticker:BTC/USDT"); count != 2 {
+		t.Fatalf("expected the loops to keep 2 watchers, got %d", count)
+	}
+	// one watcher leaves, a third one subscribes with the next update
+	if !client.ReleaseWatchers([]string{"ticker:BTC/USDT"}) {
+		t.Fatal("expected the subscription to be kept for the other watcher")
+	}
+	generation := NewFuture()
+	client.AddWatcher("ticker:BTC/USDT", generation, false)
+	client.AddWatcher("ticker:BTC/USDT", generation, false)
+	if count := client.WatcherCount("ticker:BTC/USDT"); count != 2 {
+		t.Fatalf("expected the new watcher to be counted, got %d", count)
+	}
+}
+
+func TestShareSubscriptionsCanBeDisabled(t *testing.T) {
+	exchange := NewBinanceCore()
+	exchange.Init(map[string]interface{}{
+		"options": map[string]interface{}{
+			"ws": map[string]interface{}{"shareSubscriptions": false},
+		},
+	})
+	client := &WSClient{Client: &Client{}}
+	generation := NewFuture()
+	client.AddWatcher("ticker:BTC/USDT", generation, true)
+	client.AddWatcher("ticker:BTC/USDT", generation, false)
+	unsubscription := map[string]interface{}{
+		"unsubscribe":      true,
+		"subMessageHashes": []interface{}{"ticker:BTC/USDT"},
+	}
+	if exchange.releaseSharedSubscription(client, unsubscription) {
+		t.Fatal("expected the unwatch to unsubscribe when sharing is disabled")
+	}
+	AddElementToObject(exchange.Options, "ws", map[string]interface{}{})
+	if !exchange.releaseSharedSubscription(client, unsubscription) {
+		t.Fatal("expected the unwatch to keep the shared subscription")
+	}
+}
@@ -0,0 +1,40 @@
+package ccxt
+
+// GetWsOrderThrottler returns the rule that paces the orders sent over the websocket api, by default
+// a leaky bucket of one order every options.ws.orderThrottle.rateLimit milliseconds (the rest rateLimit if unset)
+func (this *Exchange) GetWsOrderThrottler() *Throttler {
+	this.wsOrderThrottlerMu.Lock()
+	defer this.wsOrderThrottlerMu.Unlock()
+	if this.WsOrderThrottler == nil {
+		var rule interface{} = this.SafeDict(this.SafeDict(this.Options, "ws", map[string]interface{}{}), "orderThrottle", map[string]interface{}{})
+		var rateLimit interface{} = this.SafeNumber(rule, "rateLimit", this.RateLimit)
+		var refillRate interface{} = this.MAX_VALUE
+		if IsTrue(IsGreaterThan(rateLimit, 0)) {
+			refillRate = Divide(1, rateLimit)
+		}
+		var config interface{} = this.Extend(map[string]interface{}{
+			"algorithm":  "leakyBucket",
+			"capacity":   1.0,
+			"cost":       1.0,
+			"refillRate": refillRate,
+			"rateLimit":  rateLimit,
+		}, rule)
+		this.WsOrderThrottler = NewThrottler(config.(map[string]interface{}))
+	}
+	return this.WsOrderThrottler
+}
+
+// ThrottleWsOrder waits until the websocket order rule admits an order of the given cost,
+// the cost of the rule is used when it is nil. Orders are not paced when the rate limit is disabled
+func (this *Exchange) ThrottleWsOrder(cost interface{}) <-chan interface{} {
+	ch := make(chan interface{})
+	go func() {
+		defer close(ch)
+		if !this.EnableRateLimit {
+			ch <- true
+			return
+		}
+		ch <- <-this.GetWsOrderThrottler().Throttle(cost)
+	}()
+	return ch
+}
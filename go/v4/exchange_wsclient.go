@@ -13,6 +13,7 @@ package ccxt
 import (
 	"net/http"
 	"net/url"
+	"os"
 	"strings"
 	"sync"
 	"time"
@@ -35,6 +36,9 @@ type WSClient struct {
 	ProxyUrl          string
 
 	PingMu sync.RWMutex
+
+	Tap   *os.File // raw inbound frames are appended here, see WsTap
+	TapMu sync.Mutex
 }
 
 // NewWSClient dials the given URL and starts the read-loop.
@@ -122,12 +126,13 @@ func (this *WSClient) handleMessages() {
 
 		messageType, data, err := this.Connection.ReadMessage()
 		if err != nil {
-			this.OnError(NetworkError(err))
+			this.OnError(ClassifyWsClose(err))
 			return
 		}
 
 		switch messageType {
 		case websocket.TextMessage, websocket.BinaryMessage:
+			this.tapFrame(messageType == websocket.BinaryMessage, data)
 			this.OnMessage(data)
 		case websocket.PingMessage:
 			this.OnPing()
@@ -156,11 +161,10 @@ func (this *WSClient) Connect(backoffDelay ...int) (*Future, error) {
 		if delay > 0 {
 			go func() {
 				time.Sleep(time.Duration(delay) * time.Millisecond)
-				this.CreateConnection()
-				// TODO: handle error
-				// if err != nil {
-				// 	return nil, err
-				// }
+				if err := this.CreateConnection(); err != nil {
+					this.Connected.(*Future).Reject(NetworkError(err))
+					this.OnError(NetworkError(err))
+				}
 			}()
 		} else {
 			err := this.CreateConnection()
@@ -183,6 +187,10 @@ func (this *WSClient) ResetConnection(err interface{}) {
 }
 
 func (this *WSClient) SetPingInterval() {
+	if this.PingConfig != nil {
+		this.setPingConfigInterval()
+		return
+	}
 	if this.KeepAlive.(int64) > 0 {
 		ticker := time.NewTicker(time.Duration(this.KeepAlive.(int64)) * time.Millisecond)
 		this.PingInterval = ticker
@@ -287,6 +295,9 @@ func (this *WSClient) Close() *Future {
 		}
 		this.Connection.Close()
 		this.Connection = nil
+	} else if this.Disconnected != nil {
+		// a client that never connected (e.g. only used to store the auth token) has no read loop to report the closure
+		this.Disconnected.(*Future).Resolve(true)
 	}
 	return this.Disconnected.(*Future)
 }
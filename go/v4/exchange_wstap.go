@@ -0,0 +1,88 @@
+package ccxt
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"time"
+)
+
+// WsTapFrame is a single inbound frame persisted by WsTap, one JSON object per line
+type WsTapFrame struct {
+	Timestamp int64  `json:"timestamp"`
+	Binary    bool   `json:"binary,omitempty"`
+	Data      []byte `json:"data"`
+}
+
+// WsTap appends every inbound frame of the client with its timestamp to the file at path,
+// the raw frames can later be fed back through the parsers with WsReplay
+func (this *WSClient) WsTap(path string) error {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	this.TapMu.Lock()
+	defer this.TapMu.Unlock()
+	if this.Tap != nil {
+		this.Tap.Close()
+	}
+	this.Tap = file
+	return nil
+}
+
+// StopWsTap stops recording the inbound frames and closes the tap file
+func (this *WSClient) StopWsTap() error {
+	this.TapMu.Lock()
+	defer this.TapMu.Unlock()
+	if this.Tap == nil {
+		return nil
+	}
+	err := this.Tap.Close()
+	this.Tap = nil
+	return err
+}
+
+func (this *WSClient) tapFrame(binary bool, data []byte) {
+	this.TapMu.Lock()
+	defer this.TapMu.Unlock()
+	if this.Tap == nil {
+		return
+	}
+	line, err := json.Marshal(WsTapFrame{Timestamp: Milliseconds(), Binary: binary, Data: data})
+	if err != nil {
+		return
+	}
+	if _, err := this.Tap.Write(append(line, '\n')); err != nil && this.Verbose {
+		this.Log(time.Now(), "wsTap", err)
+	}
+}
+
+// WsReplay feeds the frames recorded by WsTap through the normal parse/dispatch path of the client.
+// speed scales the original timing, 1 (default) replays in real time, 10 ten times faster
+// and 0 as fast as possible
+func (this *WSClient) WsReplay(path string, speed ...float64) error {
+	factor := 1.0
+	if len(speed) > 0 {
+		factor = speed[0]
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	var previous int64
+	for scanner.Scan() {
+		var frame WsTapFrame
+		if err := json.Unmarshal(scanner.Bytes(), &frame); err != nil {
+			return err
+		}
+		if factor > 0 && previous > 0 && frame.Timestamp > previous {
+			time.Sleep(time.Duration(float64(frame.Timestamp-previous)/factor) * time.Millisecond)
+		}
+		previous = frame.Timestamp
+		this.OnMessage(frame.Data)
+	}
+	return scanner.Err()
+}
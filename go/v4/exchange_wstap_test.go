@@ -0,0 +1,119 @@
+package ccxt
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// ---------------------------------------------------------------------------
+// WsTap / WsReplay: recorded frames replay into identical parsed updates
+// ---------------------------------------------------------------------------
+
+var wsTapTickers = []string{
+	`{"e":"24hrTicker","E":1700000000000,"s":"BTCUSDT","c":"37000.10","b":"37000.00","a":"37000.20","v":"1234.5"}`,
+	`{"e":"24hrTicker","E":1700000000100,"s":"BTCUSDT","c":"37000.30","b":"37000.20","a":"37000.40","v":"1234.7"}`,
+	`{"e":"24hrTicker","E":1700000000200,"s":"ETHUSDT","c":"2000.01","b":"2000.00","a":"2000.02","v":"99.1"}`,
+}
+
+func newWsTapTestServer(t *testing.T) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade failed: %v", err)
+			return
+		}
+		defer conn.Close()
+		for _, ticker := range wsTapTickers {
+			conn.WriteMessage(websocket.TextMessage, []byte(ticker))
+			time.Sleep(10 * time.Millisecond)
+		}
+		// keep the connection open until the client is done
+		conn.ReadMessage()
+	}))
+}
+
+func newWsTapTestClient(url string, updates chan interface{}) *WSClient {
+	onMessage := func(client interface{}, message interface{}) {
+		updates <- message
+	}
+	return NewWSClient(url, onMessage, func(interface{}, interface{}) {}, func(interface{}, interface{}) {}, nil, "")
+}
+
+func collectWsTapUpdates(t *testing.T, updates chan interface{}) []interface{} {
+	result := []interface{}{}
+	for range wsTapTickers {
+		select {
+		case update := <-updates:
+			result = append(result, update)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out after %d updates", len(result))
+		}
+	}
+	return result
+}
+
+func TestWsTapReplayProducesIdenticalUpdates(t *testing.T) {
+	server := newWsTapTestServer(t)
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "ticker.jsonl")
+
+	liveUpdates := make(chan interface{}, len(wsTapTickers))
+	live := newWsTapTestClient("ws"+strings.TrimPrefix(server.URL, "http"), liveUpdates)
+	if err := live.WsTap(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := live.CreateConnection(); err != nil {
+		t.Fatal(err)
+	}
+	recorded := collectWsTapUpdates(t, liveUpdates)
+	live.StopWsTap()
+	live.Close()
+
+	replayUpdates := make(chan interface{}, len(wsTapTickers))
+	replay := newWsTapTestClient("wss://replay", replayUpdates)
+	if err := replay.WsReplay(path, 0); err != nil {
+		t.Fatal(err)
+	}
+	replayed := collectWsTapUpdates(t, replayUpdates)
+
+	if !reflect.DeepEqual(recorded, replayed) {
+		t.Fatalf("replayed updates differ from the recorded ones:\n%v\n%v", recorded, replayed)
+	}
+	if ticker, ok := replayed[2].(map[string]interface{}); !ok || ticker["s"] != "ETHUSDT" {
+		t.Fatalf("expected the replayed frames to be parsed, got %v", replayed[2])
+	}
+}
+
+func TestWsReplayKeepsOriginalTiming(t *testing.T) {
+	server := newWsTapTestServer(t)
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "ticker.jsonl")
+
+	liveUpdates := make(chan interface{}, len(wsTapTickers))
+	live := newWsTapTestClient("ws"+strings.TrimPrefix(server.URL, "http"), liveUpdates)
+	live.WsTap(path)
+	if err := live.CreateConnection(); err != nil {
+		t.Fatal(err)
+	}
+	collectWsTapUpdates(t, liveUpdates)
+	live.StopWsTap()
+	live.Close()
+
+	replay := newWsTapTestClient("wss://replay", make(chan interface{}, len(wsTapTickers)))
+	started := time.Now()
+	if err := replay.WsReplay(path); err != nil {
+		t.Fatal(err)
+	}
+	// the frames were sent 10ms apart
+	if elapsed := time.Since(started); elapsed < 15*time.Millisecond {
+		t.Fatalf("expected the replay to follow the recorded timing, took %v", elapsed)
+	}
+}
@@ -138,7 +138,7 @@ func (this *GateCore) Describe() interface{} {
 			"fetchLeverageTiers":             true,
 			"fetchLiquidations":              true,
 			"fetchMarginAdjustmentHistory":   false,
-			"fetchMarginMode":                false,
+			"fetchMarginMode":                true,
 			"fetchMarketLeverageTiers":       true,
 			"fetchMarkets":                   true,
 			"fetchMarkOHLCV":                 true,
@@ -176,7 +176,7 @@ func (this *GateCore) Describe() interface{} {
 			"repayIsolatedMargin":            true,
 			"sandbox":                        true,
 			"setLeverage":                    true,
-			"setMarginMode":                  false,
+			"setMarginMode":                  true,
 			"setPositionMode":                true,
 			"signIn":                         false,
 			"transfer":                       true,
@@ -6995,6 +6995,8 @@ func (this *GateCore) SetLeverage(leverage interface{}, optionalArgs ...interfac
 		_ = symbol
 		params := GetArg(optionalArgs, 1, map[string]interface{}{})
 		_ = params
+		retRes69987 := (<-this.CheckLeverage(leverage, symbol, params))
+		PanicOnError(retRes69987)
 		if IsTrue(IsEqual(symbol, nil)) {
 			panic(ArgumentsRequired(Add(this.Id, " setLeverage() requires a symbol argument")))
 		}
@@ -8168,6 +8170,10 @@ func (this *GateCore) Sign(path interface{}, optionalArgs ...interface{}) interf
 			var pathParts interface{} = Split(path, "/")
 			var secondPart interface{} = this.SafeString(pathParts, 1, "")
 			requiresURLEncoding = IsTrue((IsGreaterThanOrEqual(GetIndexOf(secondPart, "dual"), 0))) || IsTrue((IsGreaterThanOrEqual(GetIndexOf(secondPart, "positions"), 0)))
+			// the margin mode switch takes a json body
+			if IsTrue(IsEqual(this.SafeString(pathParts, Subtract(GetArrayLength(pathParts), 1)), "cross_mode")) {
+				requiresURLEncoding = false
+			}
 		}
 		if IsTrue(IsTrue(IsTrue(IsTrue((IsEqual(method, "GET"))) || IsTrue((IsEqual(method, "DELETE")))) || IsTrue(requiresURLEncoding)) || IsTrue((IsEqual(method, "PATCH")))) {
 			if IsTrue(GetArrayLength(ObjectKeys(query))) {
@@ -9062,6 +9068,161 @@ func (this *GateCore) SetPositionMode(hedged interface{}, optionalArgs ...interf
 	return ch
 }
 
+/**
+ * @method
+ * @name gate#setMarginMode
+ * @description set margin mode to 'cross' or 'isolated' for a perpetual swap market
+ * @see https://www.gate.com/docs/developers/apiv4/en/#switch-between-cross-and-isolated-margin-modes
+ * @see https://www.gate.com/docs/developers/apiv4/en/#switch-between-cross-and-isolated-margin-modes-in-dual-mode
+ * @param {string} marginMode 'cross' or 'isolated'
+ * @param {string} symbol unified market symbol
+ * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @param {bool} [params.hedged] set to true if the account is in dual mode
+ * @returns {object} response from the exchange
+ */
+func (this *GateCore) SetMarginMode(marginMode interface{}, optionalArgs ...interface{}) <-chan interface{} {
+	ch := make(chan interface{})
+	go func() interface{} {
+		defer close(ch)
+		defer ReturnPanicError(ch)
+		symbol := GetArg(optionalArgs, 0, nil)
+		_ = symbol
+		params := GetArg(optionalArgs, 1, map[string]interface{}{})
+		_ = params
+		if IsTrue(IsEqual(symbol, nil)) {
+			panic(ArgumentsRequired(Add(this.Id, " setMarginMode() requires a symbol argument")))
+		}
+		marginMode = this.SafeStringLower(map[string]interface{}{
+			"marginMode": marginMode,
+		}, "marginMode")
+		if IsTrue(IsTrue(!IsEqual(marginMode, "cross")) && IsTrue(!IsEqual(marginMode, "isolated"))) {
+			panic(BadRequest(Add(this.Id, " setMarginMode() marginMode must be either cross or isolated")))
+		}
+
+		retRes77018 := (<-this.LoadMarkets())
+		PanicOnError(retRes77018)
+		var market interface{} = this.Market(symbol)
+		if !IsTrue(GetValue(market, "swap")) {
+			panic(BadSymbol(Add(this.Id, " setMarginMode() supports swap contracts only")))
+		}
+		var hedged interface{} = nil
+		hedgedparamsVariable := this.HandleParamBool(params, "hedged", false)
+		hedged = GetValue(hedgedparamsVariable, 0)
+		params = GetValue(hedgedparamsVariable, 1)
+		requestqueryVariable := this.PrepareRequest(market, nil, params)
+		request := GetValue(requestqueryVariable, 0)
+		query := GetValue(requestqueryVariable, 1)
+		AddElementToObject(request, "mode", ToUpper(marginMode))
+		var response interface{} = nil
+		if IsTrue(hedged) {
+
+			response = (<-this.PrivateFuturesPostSettleDualCompPositionsCrossMode(this.Extend(request, query)))
+			PanicOnError(response)
+		} else {
+
+			response = (<-this.PrivateFuturesPostSettlePositionsCrossMode(this.Extend(request, query)))
+			PanicOnError(response)
+		}
+
+		//
+		//     {
+		//         "value": "0",
+		//         "leverage": "0",
+		//         "mode": "single",
+		//         "contract": "BTC_USDT",
+		//         "size": 0,
+		//         "cross_leverage_limit": "10",
+		//         ...
+		//     }
+		//
+		ch <- response
+		return nil
+
+	}()
+	return ch
+}
+
+/**
+ * @method
+ * @name gate#fetchMarginMode
+ * @description fetches the margin mode of a perpetual swap market
+ * @see https://www.gate.com/docs/developers/apiv4/en/#get-single-position
+ * @see https://www.gate.com/docs/developers/apiv4/en/#get-position-information-in-dual-mode
+ * @param {string} symbol unified symbol of the market to fetch the margin mode for
+ * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @param {bool} [params.hedged] set to true if the account is in dual mode
+ * @returns {object} a [margin mode structure]{@link https://docs.ccxt.com/?id=margin-mode-structure}
+ */
+func (this *GateCore) FetchMarginMode(symbol interface{}, optionalArgs ...interface{}) <-chan interface{} {
+	ch := make(chan interface{})
+	go func() interface{} {
+		defer close(ch)
+		defer ReturnPanicError(ch)
+		params := GetArg(optionalArgs, 0, map[string]interface{}{})
+		_ = params
+
+		retRes77188 := (<-this.LoadMarkets())
+		PanicOnError(retRes77188)
+		var market interface{} = this.Market(symbol)
+		if !IsTrue(GetValue(market, "swap")) {
+			panic(BadSymbol(Add(this.Id, " fetchMarginMode() supports swap contracts only")))
+		}
+		var hedged interface{} = nil
+		hedgedparamsVariable := this.HandleParamBool(params, "hedged", false)
+		hedged = GetValue(hedgedparamsVariable, 0)
+		params = GetValue(hedgedparamsVariable, 1)
+		requestqueryVariable := this.PrepareRequest(market, nil, params)
+		request := GetValue(requestqueryVariable, 0)
+		query := GetValue(requestqueryVariable, 1)
+		var position interface{} = nil
+		if IsTrue(hedged) {
+
+			response := (<-this.PrivateFuturesGetSettleDualCompPositionsContract(this.Extend(request, query)))
+			PanicOnError(response)
+			// both sides of a dual mode position share the margin mode
+			position = this.SafeDict(response, 0, map[string]interface{}{})
+		} else {
+
+			position = (<-this.PrivateFuturesGetSettlePositionsContract(this.Extend(request, query)))
+			PanicOnError(position)
+		}
+
+		ch <- this.ParseMarginMode(position, market)
+		return nil
+
+	}()
+	return ch
+}
+func (this *GateCore) ParseMarginMode(marginMode interface{}, optionalArgs ...interface{}) interface{} {
+	//
+	//     {
+	//         "value": "0",
+	//         "leverage": "0",
+	//         "mode": "dual_long",
+	//         "contract": "BTC_USDT",
+	//         "size": 0,
+	//         "cross_leverage_limit": "10",
+	//         ...
+	//     }
+	//
+	// a leverage of 0 means cross margin, up to cross_leverage_limit
+	//
+	market := GetArg(optionalArgs, 0, nil)
+	_ = market
+	var marketId interface{} = this.SafeString(marginMode, "contract")
+	market = this.SafeMarket(marketId, market, "_", "swap")
+	var leverage interface{} = this.SafeString(marginMode, "leverage")
+	var reMarginMode interface{} = nil
+	if IsTrue(!IsEqual(leverage, nil)) {
+		reMarginMode = Ternary(IsTrue(Precise.StringEquals(leverage, "0")), "cross", "isolated")
+	}
+	return map[string]interface{}{
+		"info":       marginMode,
+		"symbol":     GetValue(market, "symbol"),
+		"marginMode": reMarginMode,
+	}
+}
+
 /**
  * @method
  * @name gate#fetchUnderlyingAssets
@@ -0,0 +1,131 @@
+package ccxt
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// setMarginMode / fetchMarginMode: a leverage of 0 is cross margin, the dual mode positions have their own endpoints
+// ---------------------------------------------------------------------------
+
+func newMockedGate(bodies map[string]string) (*GateCore, *mockTransport) {
+	exchange := NewGateCore()
+	exchange.Init(map[string]interface{}{
+		"apiKey": "key",
+		"secret": "secret",
+	})
+	transport := &mockTransport{body: `{}`, bodies: bodies}
+	exchange.httpClient.Transport = transport
+	exchange.SetMarkets([]interface{}{
+		exchange.SafeMarketStructure(map[string]interface{}{
+			"id":       "BTC_USDT",
+			"symbol":   "BTC/USDT:USDT",
+			"base":     "BTC",
+			"quote":    "USDT",
+			"settle":   "USDT",
+			"baseId":   "BTC",
+			"quoteId":  "USDT",
+			"settleId": "usdt",
+			"type":     "swap",
+			"swap":     true,
+			"contract": true,
+			"linear":   true,
+			"active":   true,
+		}),
+		exchange.SafeMarketStructure(map[string]interface{}{
+			"id":      "BTC_USDT",
+			"symbol":  "BTC/USDT",
+			"base":    "BTC",
+			"quote":   "USDT",
+			"baseId":  "BTC",
+			"quoteId": "USDT",
+			"type":    "spot",
+			"spot":    true,
+			"active":  true,
+		}),
+	})
+	return exchange, transport
+}
+
+func gatePosition(leverage string, mode string) string {
+	return `{"value":"0","leverage":"` + leverage + `","mode":"` + mode + `","contract":"BTC_USDT","entry_price":"0","size":0,` +
+		`"cross_leverage_limit":"10","leverage_max":"100","risk_limit":"1000000","margin":"0","liq_price":"0"}`
+}
+
+func TestGateSetMarginMode(t *testing.T) {
+	exchange, transport := newMockedGate(map[string]string{
+		"/futures/usdt/positions/cross_mode":           gatePosition("0", "single"),
+		"/futures/usdt/dual_comp/positions/cross_mode": `[` + gatePosition("5", "dual_long") + `,` + gatePosition("5", "dual_short") + `]`,
+	})
+	result := <-exchange.SetMarginMode("Cross", "BTC/USDT:USDT")
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	result = <-exchange.SetMarginMode("isolated", "BTC/USDT:USDT", map[string]interface{}{"hedged": true})
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if len(transport.requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(transport.requests))
+	}
+	for i, expected := range []struct{ path, mode string }{
+		{"/futures/usdt/positions/cross_mode", "CROSS"},
+		{"/futures/usdt/dual_comp/positions/cross_mode", "ISOLATED"},
+	} {
+		request := transport.requests[i]
+		if request.Method != "POST" || !strings.HasSuffix(request.URL.Path, expected.path) {
+			t.Fatalf("request %d: expected a POST to %s, got %s %s", i, expected.path, request.Method, request.URL)
+		}
+		body, _ := request.GetBody()
+		raw, _ := io.ReadAll(body)
+		var sent map[string]interface{}
+		if err := json.Unmarshal(raw, &sent); err != nil {
+			t.Fatal(err)
+		}
+		if sent["mode"] != expected.mode || sent["contract"] != "BTC_USDT" || sent["hedged"] != nil {
+			t.Fatalf("request %d: expected mode %s for BTC_USDT, got %s", i, expected.mode, raw)
+		}
+	}
+}
+
+func TestGateSetMarginModeRejectsInvalidModes(t *testing.T) {
+	exchange, transport := newMockedGate(nil)
+	result := <-exchange.SetMarginMode("portfolio", "BTC/USDT:USDT")
+	if err, ok := CreateReturnError(result).(*Error); !ok || err.Type != "BadRequest" {
+		t.Fatalf("expected a BadRequest error, got %v", result)
+	}
+	result = <-exchange.SetMarginMode("cross", "BTC/USDT")
+	if err, ok := CreateReturnError(result).(*Error); !ok || err.Type != "BadSymbol" {
+		t.Fatalf("expected a BadSymbol error, got %v", result)
+	}
+	if len(transport.requests) != 0 {
+		t.Fatalf("expected no request, got %d", len(transport.requests))
+	}
+}
+
+func TestGateFetchMarginMode(t *testing.T) {
+	exchange, transport := newMockedGate(map[string]string{
+		"/futures/usdt/positions/BTC_USDT":           gatePosition("0", "single"),
+		"/futures/usdt/dual_comp/positions/BTC_USDT": `[` + gatePosition("5", "dual_long") + `,` + gatePosition("5", "dual_short") + `]`,
+	})
+	result := <-exchange.FetchMarginMode("BTC/USDT:USDT")
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if marginMode := NewMarginMode(result); *marginMode.Symbol != "BTC/USDT:USDT" || *marginMode.MarginMode != "cross" {
+		t.Fatalf("expected cross margin, got %v", result)
+	}
+	result = <-exchange.FetchMarginMode("BTC/USDT:USDT", map[string]interface{}{"hedged": true})
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if marginMode := NewMarginMode(result); *marginMode.Symbol != "BTC/USDT:USDT" || *marginMode.MarginMode != "isolated" {
+		t.Fatalf("expected isolated margin, got %v", result)
+	}
+	if !strings.HasSuffix(transport.requests[1].URL.Path, "/futures/usdt/dual_comp/positions/BTC_USDT") || transport.requests[1].URL.Query().Get("hedged") != "" {
+		t.Fatalf("expected the dual mode position, got %s", transport.requests[1].URL)
+	}
+}
@@ -1888,6 +1888,72 @@ func (this *Gate) SetPositionMode(hedged bool, options ...SetPositionModeOptions
 	return res.(map[string]interface{}), nil
 }
 
+/**
+ * @method
+ * @name gate#setMarginMode
+ * @description set margin mode to 'cross' or 'isolated' for a perpetual swap market
+ * @see https://www.gate.com/docs/developers/apiv4/en/#switch-between-cross-and-isolated-margin-modes
+ * @see https://www.gate.com/docs/developers/apiv4/en/#switch-between-cross-and-isolated-margin-modes-in-dual-mode
+ * @param {string} marginMode 'cross' or 'isolated'
+ * @param {string} symbol unified market symbol
+ * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @param {bool} [params.hedged] set to true if the account is in dual mode
+ * @returns {object} response from the exchange
+ */
+func (this *Gate) SetMarginMode(marginMode string, options ...SetMarginModeOptions) (map[string]interface{}, error) {
+
+	opts := SetMarginModeOptionsStruct{}
+
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	var symbol interface{} = nil
+	if opts.Symbol != nil {
+		symbol = *opts.Symbol
+	}
+
+	var params interface{} = nil
+	if opts.Params != nil {
+		params = *opts.Params
+	}
+	res := <-this.Core.SetMarginMode(marginMode, symbol, params)
+	if IsError(res) {
+		return map[string]interface{}{}, CreateReturnError(res)
+	}
+	return res.(map[string]interface{}), nil
+}
+
+/**
+ * @method
+ * @name gate#fetchMarginMode
+ * @description fetches the margin mode of a perpetual swap market
+ * @see https://www.gate.com/docs/developers/apiv4/en/#get-single-position
+ * @see https://www.gate.com/docs/developers/apiv4/en/#get-position-information-in-dual-mode
+ * @param {string} symbol unified symbol of the market to fetch the margin mode for
+ * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @param {bool} [params.hedged] set to true if the account is in dual mode
+ * @returns {object} a [margin mode structure]{@link https://docs.ccxt.com/?id=margin-mode-structure}
+ */
+func (this *Gate) FetchMarginMode(symbol string, options ...FetchMarginModeOptions) (MarginMode, error) {
+
+	opts := FetchMarginModeOptionsStruct{}
+
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	var params interface{} = nil
+	if opts.Params != nil {
+		params = *opts.Params
+	}
+	res := <-this.Core.FetchMarginMode(symbol, params)
+	if IsError(res) {
+		return MarginMode{}, CreateReturnError(res)
+	}
+	return NewMarginMode(res), nil
+}
+
 /**
  * @method
  * @name gate#fetchUnderlyingAssets
@@ -2362,9 +2428,6 @@ func (this *Gate) FetchLongShortRatioHistory(options ...FetchLongShortRatioHisto
 func (this *Gate) FetchMarginAdjustmentHistory(options ...FetchMarginAdjustmentHistoryOptions) ([]MarginModification, error) {
 	return this.exchangeTyped.FetchMarginAdjustmentHistory(options...)
 }
-func (this *Gate) FetchMarginMode(symbol string, options ...FetchMarginModeOptions) (MarginMode, error) {
-	return this.exchangeTyped.FetchMarginMode(symbol, options...)
-}
 func (this *Gate) FetchMarginModes(options ...FetchMarginModesOptions) (MarginModes, error) {
 	return this.exchangeTyped.FetchMarginModes(options...)
 }
@@ -2434,9 +2497,6 @@ func (this *Gate) FetchTransfers(options ...FetchTransfersOptions) ([]TransferEn
 func (this *Gate) SetMargin(symbol string, amount float64, options ...SetMarginOptions) (MarginModification, error) {
 	return this.exchangeTyped.SetMargin(symbol, amount, options...)
 }
-func (this *Gate) SetMarginMode(marginMode string, options ...SetMarginModeOptions) (map[string]interface{}, error) {
-	return this.exchangeTyped.SetMarginMode(marginMode, options...)
-}
 func (this *Gate) CancelAllOrdersWs(options ...CancelAllOrdersWsOptions) ([]Order, error) {
 	return this.exchangeTyped.CancelAllOrdersWs(options...)
 }
@@ -4404,6 +4404,8 @@ func (this *HashkeyCore) SetLeverage(leverage interface{}, optionalArgs ...inter
 		_ = symbol
 		params := GetArg(optionalArgs, 1, map[string]interface{}{})
 		_ = params
+		retRes44077 := (<-this.CheckLeverage(leverage, symbol, params))
+		PanicOnError(retRes44077)
 		if IsTrue(IsEqual(symbol, nil)) {
 			panic(ArgumentsRequired(Add(this.Id, " setLeverage() requires a symbol argument")))
 		}
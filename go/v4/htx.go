@@ -8770,6 +8770,8 @@ func (this *HtxCore) SetLeverage(leverage interface{}, optionalArgs ...interface
 		_ = symbol
 		params := GetArg(optionalArgs, 1, map[string]interface{}{})
 		_ = params
+		retRes87737 := (<-this.CheckLeverage(leverage, symbol, params))
+		PanicOnError(retRes87737)
 		if IsTrue(IsEqual(symbol, nil)) {
 			panic(ArgumentsRequired(Add(this.Id, " setLeverage() requires a symbol argument")))
 		}
@@ -418,6 +418,9 @@ func (this *KrakenCore) Describe() interface{} {
 				"Celestia":                   "TIA",
 			},
 			"marketHelperProps": []interface{}{"marketsByAltname", "delistedMarketsById"},
+			"editOrder": map[string]interface{}{
+				"method": "privatePostAmendOrder", // or privatePostEditOrder
+			},
 		},
 		"features": map[string]interface{}{
 			"spot": map[string]interface{}{
@@ -2113,6 +2116,23 @@ func (this *KrakenCore) ParseOrder(order interface{}, optionalArgs ...interface{
 	//         "amend_id": "TJSMEH-AA67V-YUSQ6O"
 	//     }
 	//
+	// editOrder (privatePostEditOrder)
+	//
+	//     {
+	//         "status": "ok",
+	//         "txid": "OFVXHJ-KPQ3B-VS7ELA",
+	//         "originaltxid": "OHYO67-6LP66-HMQ437",
+	//         "volume": "0.00030000",
+	//         "price": "19500.0",
+	//         "price2": "0",
+	//         "orders_cancelled": 1,
+	//         "newuserref": 1234,
+	//         "olduserref": 1234,
+	//         "descr": {
+	//             "order": "buy 0.00030000 XXBTZUSD @ limit 19500.0"
+	//         }
+	//     }
+	//
 	//  ws - createOrder
 	//     {
 	//         "order_id": "OXM2QD-EALR2-YBAVEU"
@@ -2261,6 +2281,9 @@ func (this *KrakenCore) ParseOrder(order interface{}, optionalArgs ...interface{
 	var flags interface{} = this.SafeString(order, "oflags", "")
 	var isPostOnly interface{} = IsGreaterThan(GetIndexOf(flags, "post"), OpNeg(1))
 	var average interface{} = this.SafeNumber(order, "price")
+	if IsTrue(InOp(order, "originaltxid")) {
+		average = nil // the edited order returns its limit price
+	}
 	if IsTrue(!IsEqual(market, nil)) {
 		symbol = GetValue(market, "symbol")
 		if IsTrue(InOp(order, "fee")) {
@@ -2282,7 +2305,7 @@ func (this *KrakenCore) ParseOrder(order interface{}, optionalArgs ...interface{
 		var txid interface{} = this.SafeList(order, "txid")
 		id = this.SafeString(txid, 0)
 	}
-	var userref interface{} = this.SafeString(order, "userref")
+	var userref interface{} = this.SafeString2(order, "userref", "newuserref")
 	var clientOrderId interface{} = this.SafeString(order, "cl_ord_id", userref)
 	var rawTrades interface{} = this.SafeValue(order, "trades", []interface{}{})
 	var trades interface{} = []interface{}{}
@@ -2487,6 +2510,7 @@ func (this *KrakenCore) OrderRequest(method interface{}, symbol interface{}, typ
  * @method
  * @name kraken#editOrder
  * @description edit a trade order
+ * @see https://docs.kraken.com/api/docs/rest-api/edit-order
  * @see https://docs.kraken.com/api/docs/rest-api/amend-order
  * @param {string} id order id
  * @param {string} symbol unified symbol of the market to create an order in
@@ -2503,7 +2527,9 @@ func (this *KrakenCore) OrderRequest(method interface{}, symbol interface{}, typ
  * @param {string} [params.trailingLimitPercent] the percent away from the trailingAmount
  * @param {string} [params.offset] '+' or '-' whether you want the trailingLimitAmount value to be positive or negative
  * @param {boolean} [params.postOnly] if true, the order will only be posted to the order book and not executed immediately
- * @param {string} [params.clientOrderId] the orders client order id
+ * @param {string} [params.clientOrderId] the orders client order id, sent as cl_ord_id, or as userref by privatePostEditOrder when it is an integer
+ * @param {int} [params.userref] the user reference of the replacement order, privatePostEditOrder only
+ * @param {string} [params.method] 'privatePostAmendOrder' (default) or 'privatePostEditOrder', privatePostEditOrder replaces the order and returns its details
  * @returns {object} an [order structure]{@link https://docs.ccxt.com/?id=order-structure}
  */
 func (this *KrakenCore) EditOrder(id interface{}, symbol interface{}, typeVar interface{}, side interface{}, optionalArgs ...interface{}) <-chan interface{} {
@@ -2524,68 +2550,178 @@ func (this *KrakenCore) EditOrder(id interface{}, symbol interface{}, typeVar in
 		if !IsTrue(GetValue(market, "spot")) {
 			panic(NotSupported(Add(Add(Add(this.Id, " editOrder() does not support "), GetValue(market, "type")), " orders, only spot orders are accepted")))
 		}
-		var request interface{} = map[string]interface{}{
-			"txid": id,
-		}
-		var clientOrderId interface{} = this.SafeString2(params, "clientOrderId", "cl_ord_id")
-		if IsTrue(!IsEqual(clientOrderId, nil)) {
-			AddElementToObject(request, "cl_ord_id", clientOrderId)
-			params = this.Omit(params, []interface{}{"clientOrderId", "cl_ord_id"})
-			request = this.Omit(request, "txid")
-		}
-		var isMarket interface{} = (IsEqual(typeVar, "market"))
-		var postOnly interface{} = nil
-		postOnlyparamsVariable := this.HandlePostOnly(isMarket, false, params)
-		postOnly = GetValue(postOnlyparamsVariable, 0)
-		params = GetValue(postOnlyparamsVariable, 1)
-		if IsTrue(postOnly) {
-			AddElementToObject(request, "post_only", "true") // not using boolean in this case, because the urlencodedNested transforms it into 'True' string
-		}
-		if IsTrue(!IsEqual(amount, nil)) {
-			AddElementToObject(request, "order_qty", this.AmountToPrecision(symbol, amount))
-		}
-		if IsTrue(!IsEqual(price, nil)) {
-			AddElementToObject(request, "limit_price", this.PriceToPrecision(symbol, price))
-		}
-		var allTriggerPrices interface{} = this.SafeStringN(params, []interface{}{"stopLossPrice", "takeProfitPrice", "trailingAmount", "trailingPercent", "trailingLimitAmount", "trailingLimitPercent"})
-		if IsTrue(!IsEqual(allTriggerPrices, nil)) {
-			var offset interface{} = this.SafeString(params, "offset")
-			params = this.Omit(params, []interface{}{"stopLossPrice", "takeProfitPrice", "trailingAmount", "trailingPercent", "trailingLimitAmount", "trailingLimitPercent", "offset"})
-			if IsTrue(!IsEqual(offset, nil)) {
-				allTriggerPrices = Add(offset, allTriggerPrices)
-				AddElementToObject(request, "trigger_price", allTriggerPrices)
-			} else {
-				AddElementToObject(request, "trigger_price", this.PriceToPrecision(symbol, allTriggerPrices))
-			}
+		var method interface{} = nil
+		methodparamsVariable := this.HandleOptionAndParams(params, "editOrder", "method", "privatePostAmendOrder")
+		method = GetValue(methodparamsVariable, 0)
+		params = GetValue(methodparamsVariable, 1)
+		if IsTrue(!IsEqual(method, "privatePostEditOrder")) {
+			var request interface{} = nil
+			requestparamsVariable := this.AmendOrderRequest(id, symbol, typeVar, amount, price, params)
+			request = GetValue(requestparamsVariable, 0)
+			params = GetValue(requestparamsVariable, 1)
+
+			response := (<-this.PrivatePostAmendOrder(this.Extend(request, params)))
+			PanicOnError(response)
+			//
+			//     {
+			//         "error": [],
+			//         "result": {
+			//             "amend_id": "TJSMEH-AA67V-YUSQ6O"
+			//         }
+			//     }
+			//
+			var result interface{} = this.SafeDict(response, "result", map[string]interface{}{})
+
+			ch <- this.ParseOrder(result, market)
+			return nil
 		}
+		var request interface{} = nil
+		requestparamsVariable := this.EditOrderRequest(id, symbol, typeVar, amount, price, params)
+		request = GetValue(requestparamsVariable, 0)
+		params = GetValue(requestparamsVariable, 1)
 
-		response := (<-this.PrivatePostAmendOrder(this.Extend(request, params)))
+		response := (<-this.PrivatePostEditOrder(this.Extend(request, params)))
 		PanicOnError(response)
 		//
 		//     {
 		//         "error": [],
 		//         "result": {
-		//             "amend_id": "TJSMEH-AA67V-YUSQ6O"
+		//             "status": "ok",
+		//             "txid": "OFVXHJ-KPQ3B-VS7ELA",
+		//             "originaltxid": "OHYO67-6LP66-HMQ437",
+		//             "volume": "0.00030000",
+		//             "price": "19500.0",
+		//             "price2": "0",
+		//             "orders_cancelled": 1,
+		//             "newuserref": 1234,
+		//             "olduserref": 1234,
+		//             "descr": {
+		//                 "order": "buy 0.00030000 XXBTZUSD @ limit 19500.0"
+		//             }
 		//         }
 		//     }
 		//
 		var result interface{} = this.SafeDict(response, "result", map[string]interface{}{})
+		// partially filled orders and other rejected edits are reported with an error status
+		if IsTrue(IsEqual(this.SafeStringLower(result, "status"), "err")) {
+			var errorMessage interface{} = this.SafeString(result, "error_message", this.Json(response))
+			panic(InvalidOrder(Add(Add(this.Id, " editOrder() "), errorMessage)))
+		}
+		var order interface{} = this.ParseOrder(result, market)
+		if IsTrue(IsEqual(GetValue(order, "clientOrderId"), nil)) {
+			AddElementToObject(order, "clientOrderId", this.SafeString2(request, "cl_ord_id", "userref"))
+		}
 
-		ch <- this.ParseOrder(result, market)
+		ch <- order
 		return nil
 
 	}()
 	return ch
 }
 
+func (this *KrakenCore) AmendOrderRequest(id interface{}, symbol interface{}, typeVar interface{}, amount interface{}, price interface{}, params interface{}) interface{} {
+	var request interface{} = map[string]interface{}{
+		"txid": id,
+	}
+	var clientOrderId interface{} = this.SafeString2(params, "clientOrderId", "cl_ord_id")
+	if IsTrue(!IsEqual(clientOrderId, nil)) {
+		AddElementToObject(request, "cl_ord_id", clientOrderId)
+		params = this.Omit(params, []interface{}{"clientOrderId", "cl_ord_id"})
+		request = this.Omit(request, "txid")
+	}
+	var isMarket interface{} = (IsEqual(typeVar, "market"))
+	var postOnly interface{} = nil
+	postOnlyparamsVariable := this.HandlePostOnly(isMarket, false, params)
+	postOnly = GetValue(postOnlyparamsVariable, 0)
+	params = GetValue(postOnlyparamsVariable, 1)
+	if IsTrue(postOnly) {
+		AddElementToObject(request, "post_only", "true") // not using boolean in this case, because the urlencodedNested transforms it into 'True' string
+	}
+	if IsTrue(!IsEqual(amount, nil)) {
+		AddElementToObject(request, "order_qty", this.AmountToPrecision(symbol, amount))
+	}
+	if IsTrue(!IsEqual(price, nil)) {
+		AddElementToObject(request, "limit_price", this.PriceToPrecision(symbol, price))
+	}
+	var allTriggerPrices interface{} = this.SafeStringN(params, []interface{}{"stopLossPrice", "takeProfitPrice", "trailingAmount", "trailingPercent", "trailingLimitAmount", "trailingLimitPercent"})
+	if IsTrue(!IsEqual(allTriggerPrices, nil)) {
+		var offset interface{} = this.SafeString(params, "offset")
+		params = this.Omit(params, []interface{}{"stopLossPrice", "takeProfitPrice", "trailingAmount", "trailingPercent", "trailingLimitAmount", "trailingLimitPercent", "offset"})
+		if IsTrue(!IsEqual(offset, nil)) {
+			allTriggerPrices = Add(offset, allTriggerPrices)
+			AddElementToObject(request, "trigger_price", allTriggerPrices)
+		} else {
+			AddElementToObject(request, "trigger_price", this.PriceToPrecision(symbol, allTriggerPrices))
+		}
+	}
+	return []interface{}{request, params}
+}
+func (this *KrakenCore) EditOrderRequest(id interface{}, symbol interface{}, typeVar interface{}, amount interface{}, price interface{}, params interface{}) interface{} {
+	var market interface{} = this.Market(symbol)
+	var request interface{} = map[string]interface{}{
+		"txid": id,
+		"pair": GetValue(market, "id"),
+	}
+	// the replacement order only carries the user reference and the client order id that are sent with the edit
+	var userref interface{} = this.SafeString(params, "userref")
+	var clientOrderId interface{} = this.SafeString2(params, "clientOrderId", "cl_ord_id")
+	params = this.Omit(params, []interface{}{"userref", "clientOrderId", "cl_ord_id"})
+	if IsTrue(!IsEqual(userref, nil)) {
+		AddElementToObject(request, "userref", userref)
+	}
+	if IsTrue(!IsEqual(clientOrderId, nil)) {
+		// userref is an integer, the other client order ids are sent as cl_ord_id
+		var isInteger interface{} = IsEqual(this.NumberToString(this.SafeInteger(map[string]interface{}{
+			"id": clientOrderId,
+		}, "id")), clientOrderId)
+		if IsTrue(IsTrue(isInteger) && IsTrue(IsEqual(userref, nil))) {
+			AddElementToObject(request, "userref", clientOrderId)
+		} else {
+			AddElementToObject(request, "cl_ord_id", clientOrderId)
+		}
+	}
+	var isMarket interface{} = (IsEqual(typeVar, "market"))
+	var postOnly interface{} = nil
+	postOnlyparamsVariable := this.HandlePostOnly(isMarket, false, params)
+	postOnly = GetValue(postOnlyparamsVariable, 0)
+	params = GetValue(postOnlyparamsVariable, 1)
+	if IsTrue(postOnly) {
+		AddElementToObject(request, "oflags", "post")
+	}
+	if IsTrue(!IsEqual(amount, nil)) {
+		AddElementToObject(request, "volume", this.AmountToPrecision(symbol, amount))
+	}
+	var triggerPrice interface{} = this.SafeStringN(params, []interface{}{"stopLossPrice", "takeProfitPrice", "trailingAmount", "trailingPercent"})
+	if IsTrue(!IsEqual(triggerPrice, nil)) {
+		// the trigger goes in price and the limit price of the triggered order in price2
+		var offset interface{} = this.SafeString(params, "offset")
+		params = this.Omit(params, []interface{}{"stopLossPrice", "takeProfitPrice", "trailingAmount", "trailingPercent", "offset"})
+		if IsTrue(!IsEqual(offset, nil)) {
+			AddElementToObject(request, "price", Add(offset, triggerPrice))
+		} else {
+			AddElementToObject(request, "price", this.PriceToPrecision(symbol, triggerPrice))
+		}
+		if IsTrue(!IsEqual(price, nil)) {
+			AddElementToObject(request, "price2", this.PriceToPrecision(symbol, price))
+		}
+	} else if IsTrue(!IsEqual(price, nil)) {
+		AddElementToObject(request, "price", this.PriceToPrecision(symbol, price))
+	}
+	return []interface{}{request, params}
+}
+
 /**
  * @method
  * @name kraken#fetchOrder
  * @description fetches information on an order made by the user
  * @see https://docs.kraken.com/rest/#tag/Account-Data/operation/getOrdersInfo
- * @param {string} id order id
+ * @see https://docs.kraken.com/rest/#tag/Account-Data/operation/getOpenOrders
+ * @see https://docs.kraken.com/rest/#tag/Account-Data/operation/getClosedOrders
+ * @param {string} id order id (txid), can be undefined when params.userref or params.clientOrderId is given, the order is then looked up among the open and the recently closed orders
  * @param {string} symbol not used by kraken fetchOrder
  * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @param {int} [params.userref] the user reference of the order, several orders can share it
+ * @param {string} [params.clientOrderId] the client order id of the order, sent as cl_ord_id, or as userref when it is an integer
  * @returns {object} An [order structure]{@link https://docs.ccxt.com/?id=order-structure}
  */
 func (this *KrakenCore) FetchOrder(id interface{}, optionalArgs ...interface{}) <-chan interface{} {
@@ -2600,64 +2736,158 @@ func (this *KrakenCore) FetchOrder(id interface{}, optionalArgs ...interface{})
 
 		retRes23268 := (<-this.LoadMarkets())
 		PanicOnError(retRes23268)
-		var clientOrderId interface{} = this.SafeValue2(params, "userref", "clientOrderId")
+		if IsTrue(IsEqual(id, "")) {
+			id = nil
+		}
+		var userref interface{} = this.SafeString(params, "userref")
+		var clientOrderId interface{} = this.SafeString2(params, "clientOrderId", "cl_ord_id")
+		var query interface{} = this.Omit(params, []interface{}{"userref", "clientOrderId", "cl_ord_id"})
+		if IsTrue(!IsEqual(clientOrderId, nil)) {
+			// userref is an integer, the other client order ids are sent as cl_ord_id
+			var isInteger interface{} = IsEqual(this.NumberToString(this.SafeInteger(map[string]interface{}{
+				"id": clientOrderId,
+			}, "id")), clientOrderId)
+			if IsTrue(IsTrue(isInteger) && IsTrue(IsEqual(userref, nil))) {
+				userref = clientOrderId
+				clientOrderId = nil
+			}
+		}
 		var request interface{} = map[string]interface{}{
 			"trades": true,
-			"txid":   id,
 		}
-		var query interface{} = params
+		var result interface{} = nil
+		if IsTrue(!IsEqual(id, nil)) {
+			AddElementToObject(request, "txid", id)
+			if IsTrue(!IsEqual(userref, nil)) {
+				AddElementToObject(request, "userref", userref)
+			}
+			var response interface{} = nil
+
+			{
+				func(this *KrakenCore) (ret_ interface{}) {
+					defer func() {
+						if e := recover(); e != nil {
+							if e == "break" {
+								return
+							}
+							ret_ = func(this *KrakenCore) interface{} {
+								// catch block:
+								// kraken reports unknown txids as EOrder:Unknown order, which maps to InvalidOrder
+								if IsTrue(IsTrue(IsString(e)) && IsTrue(IsGreaterThanOrEqual(GetIndexOf(e, "EOrder:Unknown order"), 0))) {
+									panic(OrderNotFound(Add(Add(this.Id, " fetchOrder() could not find order id "), id)))
+								}
+								panic(e)
+
+							}(this)
+						}
+					}()
+					// try block:
+
+					response = (<-this.PrivatePostQueryOrders(this.Extend(request, query)))
+					PanicOnError(response)
+					return nil
+				}(this)
+
+			}
+			//
+			//     {
+			//         "error":[],
+			//         "result":{
+			//             "OTLAS3-RRHUF-NDWH5A":{
+			//                 "refid":null,
+			//                 "userref":null,
+			//                 "status":"closed",
+			//                 "reason":null,
+			//                 "opentm":1586822919.3342,
+			//                 "closetm":1586822919.365,
+			//                 "starttm":0,
+			//                 "expiretm":0,
+			//                 "descr":{
+			//                     "pair":"XBTUSDT",
+			//                     "type":"sell",
+			//                     "ordertype":"market",
+			//                     "price":"0",
+			//                     "price2":"0",
+			//                     "leverage":"none",
+			//                     "order":"sell 0.21804000 XBTUSDT @ market",
+			//                     "close":""
+			//                 },
+			//                 "vol":"0.21804000",
+			//                 "vol_exec":"0.21804000",
+			//                 "cost":"1493.9",
+			//                 "fee":"3.8",
+			//                 "price":"6851.5",
+			//                 "stopprice":"0.00000",
+			//                 "limitprice":"0.00000",
+			//                 "misc":"",
+			//                 "oflags":"fciq",
+			//                 "trades":["TT5UC3-GOIRW-6AZZ6R"]
+			//             }
+			//         }
+			//     }
+			//
+			result = this.SafeDict(response, "result", map[string]interface{}{})
+		} else if IsTrue(IsTrue(!IsEqual(userref, nil)) || IsTrue(!IsEqual(clientOrderId, nil))) {
+			// queryOrders requires a txid, the open and then the closed orders are filtered by the userref or the cl_ord_id instead
+			if IsTrue(!IsEqual(userref, nil)) {
+				AddElementToObject(request, "userref", userref)
+			}
+			if IsTrue(!IsEqual(clientOrderId, nil)) {
+				AddElementToObject(request, "cl_ord_id", clientOrderId)
+			}
+
+			openOrders := (<-this.PrivatePostOpenOrders(this.Extend(request, query)))
+			PanicOnError(openOrders)
+			result = this.SafeDict(this.SafeDict(openOrders, "result", map[string]interface{}{}), "open", map[string]interface{}{})
+			if IsTrue(IsEqual(GetArrayLength(ObjectKeys(result)), 0)) {
+
+				closedOrders := (<-this.PrivatePostClosedOrders(this.Extend(request, query)))
+				PanicOnError(closedOrders)
+				result = this.SafeDict(this.SafeDict(closedOrders, "result", map[string]interface{}{}), "closed", map[string]interface{}{})
+			}
+		} else {
+			panic(ArgumentsRequired(Add(this.Id, " fetchOrder() requires an id argument or a userref / clientOrderId param")))
+		}
+		var reference interface{} = nil
 		if IsTrue(!IsEqual(clientOrderId, nil)) {
-			AddElementToObject(request, "userref", clientOrderId)
-			query = this.Omit(params, []interface{}{"userref", "clientOrderId"})
+			reference = Add("with clientOrderId ", clientOrderId)
+		} else if IsTrue(!IsEqual(userref, nil)) {
+			reference = Add("with userref ", userref)
 		}
-
-		response := (<-this.PrivatePostQueryOrders(this.Extend(request, query)))
-		PanicOnError(response)
-		//
-		//     {
-		//         "error":[],
-		//         "result":{
-		//             "OTLAS3-RRHUF-NDWH5A":{
-		//                 "refid":null,
-		//                 "userref":null,
-		//                 "status":"closed",
-		//                 "reason":null,
-		//                 "opentm":1586822919.3342,
-		//                 "closetm":1586822919.365,
-		//                 "starttm":0,
-		//                 "expiretm":0,
-		//                 "descr":{
-		//                     "pair":"XBTUSDT",
-		//                     "type":"sell",
-		//                     "ordertype":"market",
-		//                     "price":"0",
-		//                     "price2":"0",
-		//                     "leverage":"none",
-		//                     "order":"sell 0.21804000 XBTUSDT @ market",
-		//                     "close":""
-		//                 },
-		//                 "vol":"0.21804000",
-		//                 "vol_exec":"0.21804000",
-		//                 "cost":"1493.9",
-		//                 "fee":"3.8",
-		//                 "price":"6851.5",
-		//                 "stopprice":"0.00000",
-		//                 "limitprice":"0.00000",
-		//                 "misc":"",
-		//                 "oflags":"fciq",
-		//                 "trades":["TT5UC3-GOIRW-6AZZ6R"]
-		//             }
-		//         }
-		//     }
-		//
-		var result interface{} = this.SafeValue(response, "result", []interface{}{})
-		if !IsTrue((InOp(result, id))) {
-			panic(OrderNotFound(Add(Add(this.Id, " fetchOrder() could not find order id "), id)))
+		var orderId interface{} = id
+		if IsTrue(IsTrue(IsEqual(orderId, nil)) || !IsTrue((InOp(result, orderId)))) {
+			orderId = nil
+			if IsTrue(!IsEqual(reference, nil)) {
+				// kraken keys the result by txid, look the order up by its userref or its cl_ord_id
+				var matches interface{} = []interface{}{}
+				var txids interface{} = ObjectKeys(result)
+				for i := 0; IsLessThan(i, GetArrayLength(txids)); i++ {
+					var txid interface{} = GetValue(txids, i)
+					var order interface{} = GetValue(result, txid)
+					var matchesUserref interface{} = IsTrue(IsEqual(userref, nil)) || IsTrue(IsEqual(this.SafeString(order, "userref"), userref))
+					var matchesClientOrderId interface{} = IsTrue(IsEqual(clientOrderId, nil)) || IsTrue(IsEqual(this.SafeString(order, "cl_ord_id"), clientOrderId))
+					if IsTrue(IsTrue(matchesUserref) && IsTrue(matchesClientOrderId)) {
+						AppendToArray(&matches, txid)
+					}
+				}
+				// a userref is not unique, the order is ambiguous when several orders share it
+				if IsTrue(IsGreaterThan(GetArrayLength(matches), 1)) {
+					panic(InvalidOrder(Add(Add(Add(this.Id, " fetchOrder() found several orders "), reference), ", fetch the order by its id instead")))
+				}
+				orderId = this.SafeString(matches, 0)
+			}
+		}
+		if IsTrue(IsEqual(orderId, nil)) {
+			var missing interface{} = id
+			if IsTrue(IsEqual(missing, nil)) {
+				missing = reference
+			}
+			panic(OrderNotFound(Add(Add(this.Id, " fetchOrder() could not find order id "), missing)))
 		}
 
 		ch <- this.ParseOrder(this.Extend(map[string]interface{}{
-			"id": id,
-		}, GetValue(result, id)))
+			"id": orderId,
+		}, GetValue(result, orderId)))
 		return nil
 
 	}()
@@ -0,0 +1,250 @@
+package ccxt
+
+import (
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// editOrder: the order is amended, or replaced through the private EditOrder endpoint
+// ---------------------------------------------------------------------------
+
+func newMockedKraken(bodies map[string]string) (*KrakenCore, *mockTransport) {
+	exchange := NewKrakenCore()
+	exchange.Init(map[string]interface{}{
+		"apiKey": "key",
+		"secret": "c2VjcmV0",
+	})
+	transport := &mockTransport{body: `{"error":[],"result":{}}`, bodies: bodies}
+	exchange.httpClient.Transport = transport
+	exchange.SetMarkets([]interface{}{
+		exchange.SafeMarketStructure(map[string]interface{}{
+			"id":      "XXBTZUSD",
+			"altname": "XBTUSD",
+			"symbol":  "BTC/USD",
+			"base":    "BTC",
+			"quote":   "USD",
+			"baseId":  "XXBT",
+			"quoteId": "ZUSD",
+			"type":    "spot",
+			"spot":    true,
+			"active":  true,
+			"precision": map[string]interface{}{
+				"amount": 0.00000001,
+				"price":  0.1,
+			},
+		}),
+	})
+	return exchange, transport
+}
+
+func requestForm(t *testing.T, transport *mockTransport, index int) url.Values {
+	body, err := transport.requests[index].GetBody()
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := io.ReadAll(body)
+	form, err := url.ParseQuery(string(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return form
+}
+
+func TestKrakenEditOrder(t *testing.T) {
+	exchange, transport := newMockedKraken(map[string]string{
+		"/EditOrder": `{"error":[],"result":{"status":"ok","txid":"OFVXHJ-KPQ3B-VS7ELA","originaltxid":"OHYO67-6LP66-HMQ437",` +
+			`"volume":"0.00030000","price":"19500.0","price2":"0","orders_cancelled":1,"newuserref":1234,"olduserref":1234,` +
+			`"descr":{"order":"buy 0.00030000 XXBTZUSD @ limit 19500.0"}}}`,
+	})
+	result := <-exchange.EditOrder("OHYO67-6LP66-HMQ437", "BTC/USD", "limit", "buy", 0.0003, 19500, map[string]interface{}{"clientOrderId": "1234", "method": "privatePostEditOrder"})
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	request := transport.requests[0]
+	if !strings.HasSuffix(request.URL.Path, "/0/private/EditOrder") {
+		t.Fatalf("expected the EditOrder endpoint, got %s", request.URL.Path)
+	}
+	form := requestForm(t, transport, 0)
+	expected := map[string]string{"txid": "OHYO67-6LP66-HMQ437", "pair": "XXBTZUSD", "volume": "0.0003", "price": "19500", "userref": "1234"}
+	for key, value := range expected {
+		if form.Get(key) != value {
+			t.Fatalf("expected %s=%s, got %s", key, value, form.Encode())
+		}
+	}
+	order := NewOrder(result)
+	if *order.Id != "OFVXHJ-KPQ3B-VS7ELA" || *order.ClientOrderId != "1234" || *order.Symbol != "BTC/USD" {
+		t.Fatalf("unexpected order %v", result)
+	}
+	if *order.Side != "buy" || *order.Type != "limit" || *order.Amount != 0.0003 || *order.Price != 19500 {
+		t.Fatalf("unexpected order %v", result)
+	}
+	if order.Average != nil {
+		t.Fatalf("expected no average price, got %v", *order.Average)
+	}
+
+	// the userref is kept next to a client order id that is not an integer
+	AddElementToObject(exchange.Options, "editOrder", map[string]interface{}{"method": "privatePostEditOrder"})
+	result = <-exchange.EditOrder("OHYO67-6LP66-HMQ437", "BTC/USD", "limit", "buy", 0.0003, 19500, map[string]interface{}{"clientOrderId": "my-order", "userref": 1234})
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if form := requestForm(t, transport, 1); form.Get("cl_ord_id") != "my-order" || form.Get("userref") != "1234" || form.Has("clientOrderId") {
+		t.Fatalf("unexpected request %s", form.Encode())
+	}
+}
+
+func TestKrakenEditOrderPartiallyFilled(t *testing.T) {
+	exchange, _ := newMockedKraken(map[string]string{
+		"/EditOrder": `{"error":[],"result":{"status":"err","error_message":"Order partially filled, edit rejected"}}`,
+	})
+	result := <-exchange.EditOrder("OHYO67-6LP66-HMQ437", "BTC/USD", "limit", "buy", 0.0003, 19500, map[string]interface{}{"method": "privatePostEditOrder"})
+	err, ok := CreateReturnError(result).(*Error)
+	if !ok || err.Type != "InvalidOrder" || !strings.Contains(err.Message, "Order partially filled, edit rejected") {
+		t.Fatalf("expected an InvalidOrder with the exchange message, got %v", result)
+	}
+}
+
+func TestKrakenEditOrderAmendsByDefault(t *testing.T) {
+	exchange, transport := newMockedKraken(map[string]string{
+		"/AmendOrder": `{"error":[],"result":{"amend_id":"TJSMEH-AA67V-YUSQ6O"}}`,
+	})
+	result := <-exchange.EditOrder("OHYO67-6LP66-HMQ437", "BTC/USD", "limit", "buy", nil, 19600)
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if !strings.HasSuffix(transport.requests[0].URL.Path, "/0/private/AmendOrder") {
+		t.Fatalf("expected the AmendOrder endpoint, got %s", transport.requests[0].URL.Path)
+	}
+	if form := requestForm(t, transport, 0); form.Get("limit_price") != "19600" || form.Get("txid") != "OHYO67-6LP66-HMQ437" {
+		t.Fatalf("unexpected request %s", form.Encode())
+	}
+}
+
+// ---------------------------------------------------------------------------
+// fetchTime: the server time is given in seconds
+// ---------------------------------------------------------------------------
+
+func TestKrakenFetchTime(t *testing.T) {
+	exchange, transport := newMockedKraken(map[string]string{
+		"/0/public/Time": `{"error":[],"result":{"unixtime":1591502873,"rfc1123":"Sun,  7 Jun 20 04:07:53 +0000"}}`,
+	})
+	result := <-exchange.FetchTime()
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if result != int64(1591502873000) {
+		t.Fatalf("expected the server time in milliseconds, got %v", result)
+	}
+	if request := transport.requests[0].URL; !strings.HasSuffix(request.Path, "/0/public/Time") {
+		t.Fatalf("expected the server time endpoint, got %s", request)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// fetchOrder: a single order is queried by txid or by userref
+// ---------------------------------------------------------------------------
+
+const krakenQueriedOrder = `{"error":[],"result":{"OTLAS3-RRHUF-NDWH5A":{"refid":null,"userref":4321,"status":"closed","reason":null,` +
+	`"opentm":1586822919.3342,"closetm":1586822919.365,"starttm":0,"expiretm":0,"descr":{"pair":"XXBTZUSD","type":"sell",` +
+	`"ordertype":"limit","price":"6850.0","price2":"0","leverage":"none","order":"sell 0.21804000 XXBTZUSD @ limit 6850.0","close":""},` +
+	`"vol":"0.21804000","vol_exec":"0.21804000","cost":"1493.9","fee":"3.8","price":"6851.5","stopprice":"0.00000",` +
+	`"limitprice":"0.00000","misc":"","oflags":"fciq","trades":["TT5UC3-GOIRW-6AZZ6R"]}}}`
+
+func TestKrakenFetchOrder(t *testing.T) {
+	exchange, transport := newMockedKraken(map[string]string{"/QueryOrders": krakenQueriedOrder})
+	result := <-exchange.FetchOrder("OTLAS3-RRHUF-NDWH5A")
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if form := requestForm(t, transport, 0); form.Get("txid") != "OTLAS3-RRHUF-NDWH5A" || form.Has("userref") {
+		t.Fatalf("unexpected request %s", form.Encode())
+	}
+	order := NewOrder(result)
+	if *order.Id != "OTLAS3-RRHUF-NDWH5A" || *order.Symbol != "BTC/USD" || *order.Status != "closed" {
+		t.Fatalf("unexpected order %v", result)
+	}
+	if *order.Side != "sell" || *order.Type != "limit" || *order.Amount != 0.21804 || *order.Filled != 0.21804 {
+		t.Fatalf("unexpected order %v", result)
+	}
+	if *order.ClientOrderId != "4321" {
+		t.Fatalf("expected the userref as client order id, got %v", *order.ClientOrderId)
+	}
+}
+
+func TestKrakenFetchOrderByUserref(t *testing.T) {
+	order := strings.TrimSuffix(strings.TrimPrefix(krakenQueriedOrder, `{"error":[],"result":`), `}`)
+	// the order is not open anymore, it is found among the closed orders
+	exchange, transport := newMockedKraken(map[string]string{
+		"/OpenOrders":   `{"error":[],"result":{"open":{}}}`,
+		"/ClosedOrders": `{"error":[],"result":{"closed":` + order + `,"count":1}}`,
+	})
+	result := <-exchange.FetchOrder(nil, nil, map[string]interface{}{"clientOrderId": 4321})
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if len(transport.requests) != 2 || !strings.HasSuffix(transport.requests[0].URL.Path, "/OpenOrders") || !strings.HasSuffix(transport.requests[1].URL.Path, "/ClosedOrders") {
+		t.Fatalf("expected the open and then the closed orders to be requested, got %d requests", len(transport.requests))
+	}
+	for i := range transport.requests {
+		// queryOrders requires a txid, it is not used without one
+		if form := requestForm(t, transport, i); form.Get("userref") != "4321" || form.Has("txid") || form.Has("clientOrderId") {
+			t.Fatalf("unexpected request %s", form.Encode())
+		}
+	}
+	if order := NewOrder(result); *order.Id != "OTLAS3-RRHUF-NDWH5A" {
+		t.Fatalf("expected the order to be resolved from its userref, got %v", result)
+	}
+	// an open order is returned without requesting the closed ones
+	exchange, transport = newMockedKraken(map[string]string{"/OpenOrders": `{"error":[],"result":{"open":` + order + `}}`})
+	if result := <-exchange.FetchOrder(nil, nil, map[string]interface{}{"userref": 4321}); IsError(result) || len(transport.requests) != 1 {
+		t.Fatalf("expected the open order from a single request, got %v after %d requests", result, len(transport.requests))
+	}
+	exchange, _ = newMockedKraken(map[string]string{"/ClosedOrders": `{"error":[],"result":{"closed":` + order + `,"count":1}}`})
+	missing := <-exchange.FetchOrder(nil, nil, map[string]interface{}{"userref": 1})
+	if !IsErrorType(CreateReturnError(missing), "OrderNotFound") {
+		t.Fatalf("expected OrderNotFound for an unknown userref, got %v", missing)
+	}
+	// a userref shared by several orders is ambiguous
+	second := strings.Replace(order, "OTLAS3-RRHUF-NDWH5A", "OBCMZD-JIEE7-77TH3F", 1)
+	shared := strings.TrimSuffix(order, "}") + "," + strings.TrimPrefix(second, "{")
+	exchange, _ = newMockedKraken(map[string]string{"/OpenOrders": `{"error":[],"result":{"open":` + shared + `}}`})
+	ambiguous := <-exchange.FetchOrder(nil, nil, map[string]interface{}{"userref": 4321})
+	if !IsErrorType(CreateReturnError(ambiguous), "InvalidOrder") {
+		t.Fatalf("expected InvalidOrder for a shared userref, got %v", ambiguous)
+	}
+}
+
+func TestKrakenFetchOrderByClientOrderId(t *testing.T) {
+	order := strings.TrimSuffix(strings.TrimPrefix(krakenQueriedOrder, `{"error":[],"result":`), `}`)
+	order = strings.Replace(order, `"userref":4321,`, `"userref":0,"cl_ord_id":"my-order-1",`, 1)
+	exchange, transport := newMockedKraken(map[string]string{"/OpenOrders": `{"error":[],"result":{"open":` + order + `}}`})
+	result := <-exchange.FetchOrder(nil, nil, map[string]interface{}{"clientOrderId": "my-order-1"})
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	// a string client order id is sent as cl_ord_id, only an integer is a userref
+	if form := requestForm(t, transport, 0); form.Get("cl_ord_id") != "my-order-1" || form.Has("userref") || form.Has("clientOrderId") {
+		t.Fatalf("unexpected request %s", form.Encode())
+	}
+	if order := NewOrder(result); *order.Id != "OTLAS3-RRHUF-NDWH5A" || *order.ClientOrderId != "my-order-1" {
+		t.Fatalf("expected the order to be resolved from its cl_ord_id, got %v", result)
+	}
+}
+
+func TestKrakenFetchOrderNotFound(t *testing.T) {
+	exchange, _ := newMockedKraken(map[string]string{
+		"/QueryOrders": `{"error":["EOrder:Unknown order"]}`,
+	})
+	result := <-exchange.FetchOrder("OAAAAA-BBBBB-CCCCCC")
+	if !IsErrorType(CreateReturnError(result), "OrderNotFound") {
+		t.Fatalf("expected OrderNotFound, got %v", result)
+	}
+	exchange, _ = newMockedKraken(map[string]string{"/QueryOrders": `{"error":[],"result":{}}`})
+	result = <-exchange.FetchOrder("OAAAAA-BBBBB-CCCCCC")
+	if !IsErrorType(CreateReturnError(result), "OrderNotFound") {
+		t.Fatalf("expected OrderNotFound for an empty result, got %v", result)
+	}
+}
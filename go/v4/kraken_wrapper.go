@@ -531,6 +531,7 @@ func (this *Kraken) CreateOrders(orders []OrderRequest, options ...CreateOrdersO
  * @method
  * @name kraken#editOrder
  * @description edit a trade order
+ * @see https://docs.kraken.com/api/docs/rest-api/edit-order
  * @see https://docs.kraken.com/api/docs/rest-api/amend-order
  * @param {string} id order id
  * @param {string} symbol unified symbol of the market to create an order in
@@ -547,7 +548,9 @@ func (this *Kraken) CreateOrders(orders []OrderRequest, options ...CreateOrdersO
  * @param {string} [params.trailingLimitPercent] the percent away from the trailingAmount
  * @param {string} [params.offset] '+' or '-' whether you want the trailingLimitAmount value to be positive or negative
  * @param {boolean} [params.postOnly] if true, the order will only be posted to the order book and not executed immediately
- * @param {string} [params.clientOrderId] the orders client order id
+ * @param {string} [params.clientOrderId] the orders client order id, sent as cl_ord_id, or as userref by privatePostEditOrder when it is an integer
+ * @param {int} [params.userref] the user reference of the replacement order, privatePostEditOrder only
+ * @param {string} [params.method] 'privatePostAmendOrder' (default) or 'privatePostEditOrder', privatePostEditOrder replaces the order and returns its details
  * @returns {object} an [order structure]{@link https://docs.ccxt.com/?id=order-structure}
  */
 func (this *Kraken) EditOrder(id string, symbol string, typeVar string, side string, options ...EditOrderOptions) (Order, error) {
@@ -584,9 +587,13 @@ func (this *Kraken) EditOrder(id string, symbol string, typeVar string, side str
  * @name kraken#fetchOrder
  * @description fetches information on an order made by the user
  * @see https://docs.kraken.com/rest/#tag/Account-Data/operation/getOrdersInfo
- * @param {string} id order id
+ * @see https://docs.kraken.com/rest/#tag/Account-Data/operation/getOpenOrders
+ * @see https://docs.kraken.com/rest/#tag/Account-Data/operation/getClosedOrders
+ * @param {string} id order id (txid), can be undefined when params.userref or params.clientOrderId is given, the order is then looked up among the open and the recently closed orders
  * @param {string} symbol not used by kraken fetchOrder
  * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @param {int} [params.userref] the user reference of the order, several orders can share it
+ * @param {string} [params.clientOrderId] the client order id of the order, sent as cl_ord_id, or as userref when it is an integer
  * @returns {object} An [order structure]{@link https://docs.ccxt.com/?id=order-structure}
  */
 func (this *Kraken) FetchOrder(id string, options ...FetchOrderOptions) (Order, error) {
@@ -3425,6 +3425,8 @@ func (this *KrakenfuturesCore) SetLeverage(leverage interface{}, optionalArgs ..
 		_ = symbol
 		params := GetArg(optionalArgs, 1, map[string]interface{}{})
 		_ = params
+		retRes34287 := (<-this.CheckLeverage(leverage, symbol, params))
+		PanicOnError(retRes34287)
 		if IsTrue(IsEqual(symbol, nil)) {
 			panic(ArgumentsRequired(Add(this.Id, " setLeverage() requires a symbol argument")))
 		}
@@ -1,5 +1,10 @@
 package ccxt
 
+import (
+	"errors"
+	"strings"
+)
+
 // PLEASE DO NOT EDIT THIS FILE, IT IS GENERATED AND WILL BE OVERWRITTEN:
 // https://github.com/ccxt/ccxt/blob/master/CONTRIBUTING.md#how-to-contribute-code
 
@@ -2408,14 +2413,20 @@ func (this *KucoinCore) ParseDepositWithdrawFee(fee interface{}, optionalArgs ..
 				"withdraw": map[string]interface{}{
 					"fee":        this.SafeNumber2(chain, "withdrawalMinFee", "withdrawMinFee"),
 					"percentage": false,
+					"min":        this.SafeNumber2(chain, "withdrawalMinSize", "withdrawMinSize"),
+					"max":        this.SafeNumber(chain, "maxWithdraw"),
+					"enabled":    this.SafeBool(chain, "isWithdrawEnabled"),
 				},
 				"deposit": map[string]interface{}{
 					"fee":        nil,
 					"percentage": nil,
+					"min":        this.SafeNumber(chain, "depositMinSize"),
+					"max":        this.SafeNumber(chain, "maxDeposit"),
+					"enabled":    this.SafeBool(chain, "isDepositEnabled"),
 				},
 			})
 		}
-		return resultNew
+		return this.AssignDefaultDepositWithdrawFees(resultNew, currency)
 	}
 	var minWithdrawFee interface{} = this.SafeNumber(fee, "withdrawMinFee")
 	var result interface{} = map[string]interface{}{
@@ -3452,6 +3463,17 @@ func (this *KucoinCore) FetchContractOHLCV(symbol interface{}, optionalArgs ...i
 	return ch
 }
 
+// depositAddressExists tells whether createDepositAddress was rejected with the code 260000, the address
+// of the network already exists, the code is read from the body the exception was raised with
+func (this *KucoinCore) depositAddressExists(response interface{}) bool {
+	var err *Error
+	if !errors.As(CreateReturnError(response), &err) || !IsErrorType(err, "InvalidAddress") {
+		return false
+	}
+	body := strings.TrimPrefix(err.Message, ToString(this.Id)+" ")
+	return IsEqual(this.SafeString(this.ParseJson(body), "code"), "260000")
+}
+
 /**
  * @method
  * @name kucoin#createDepositAddress
@@ -3481,29 +3503,45 @@ func (this *KucoinCore) CreateDepositAddress(code interface{}, optionalArgs ...i
 		networkCode = GetValue(networkCodeparamsVariable, 0)
 		params = GetValue(networkCodeparamsVariable, 1)
 		if IsTrue(!IsEqual(networkCode, nil)) {
-			AddElementToObject(request, "chain", this.NetworkCodeToId(networkCode, GetValue(currency, "code"))) // docs mention "chain-name", but seems "chain-id" is used, like in "fetchDepositAddress"
+			AddElementToObject(request, "chain", ToLower(this.NetworkCodeToId(networkCode, GetValue(currency, "code")))) // docs mention "chain-name", but seems "chain-id" is used, like in "fetchDepositAddress"
 		}
 
+		var address interface{} = nil
+
 		response := (<-this.PrivatePostDepositAddressCreate(this.Extend(request, params)))
-		PanicOnError(response)
 		// {"code":"260000","msg":"Deposit address already exists."}
-		//
-		//   {
-		//     "code": "200000",
-		//     "data": {
-		//       "address": "0x2336d1834faab10b2dac44e468f2627138417431",
-		//       "memo": null,
-		//       "chainId": "bsc",
-		//       "to": "MAIN",
-		//       "expirationDate": 0,
-		//       "currency": "BNB",
-		//       "chainName": "BEP20"
-		//     }
-		//   }
-		//
-		var data interface{} = this.SafeDict(response, "data", map[string]interface{}{})
+		// an address is created once per network, the existing one is returned
+		if IsTrue(IsTrue(IsError(response)) && IsTrue(this.depositAddressExists(response))) {
+			if IsTrue(!IsEqual(networkCode, nil)) {
+				AddElementToObject(params, "network", networkCode)
+			}
 
-		ch <- this.ParseDepositAddress(data, currency)
+			address = (<-this.FetchDepositAddress(code, params))
+			PanicOnError(address)
+		} else {
+			PanicOnError(response)
+			//
+			//   {
+			//     "code": "200000",
+			//     "data": {
+			//       "address": "0x2336d1834faab10b2dac44e468f2627138417431",
+			//       "memo": null,
+			//       "chainId": "bsc",
+			//       "to": "MAIN",
+			//       "expirationDate": 0,
+			//       "currency": "BNB",
+			//       "chainName": "BEP20"
+			//     }
+			//   }
+			//
+			var data interface{} = this.SafeDict(response, "data", map[string]interface{}{})
+			address = this.ParseDepositAddress(data, currency)
+		}
+		if IsTrue(IsEqual(GetValue(address, "network"), nil)) {
+			AddElementToObject(address, "network", networkCode)
+		}
+
+		ch <- address
 		return nil
 
 	}()
@@ -3663,12 +3701,17 @@ func (this *KucoinCore) ParseDepositAddress(depositAddress interface{}, optional
 		}
 	}
 	var chainId interface{} = this.SafeString(depositAddress, "chainId")
+	// the memo is "" or null for the currencies that do not need one
+	var tag interface{} = this.SafeString(depositAddress, "memo")
+	if IsTrue(IsEqual(tag, "")) {
+		tag = nil
+	}
 	return map[string]interface{}{
 		"info":     depositAddress,
 		"currency": code,
 		"network":  this.NetworkIdToCode(chainId, code),
 		"address":  address,
-		"tag":      this.SafeString(depositAddress, "memo"),
+		"tag":      tag,
 	}
 }
 
@@ -9835,6 +9878,7 @@ func (this *KucoinCore) ParseLedgerEntryType(typeVar interface{}) interface{} {
 		"Deposit":                               "transaction",
 		"Withdrawal":                            "transaction",
 		"Transfer":                              "transfer",
+		"Exchange":                              "trade",
 		"Trade_Exchange":                        "trade",
 		"KuCoin Bonus":                          "bonus",
 		"Referral Bonus":                        "referral",
@@ -9843,7 +9887,7 @@ func (this *KucoinCore) ParseLedgerEntryType(typeVar interface{}) interface{} {
 		"Other rewards":                         "bonus",
 		"Fee Rebate":                            "rebate",
 		"Buy Crypto":                            "trade",
-		"Sell Crypto":                           "sell",
+		"Sell Crypto":                           "trade",
 		"Public Offering Purchase":              "trade",
 		"Refunded Fees":                         "fee",
 		"KCS Pay Fees":                          "fee",
@@ -9856,6 +9900,8 @@ func (this *KucoinCore) ParseLedgerEntryType(typeVar interface{}) interface{} {
 		"TransferIn":                            "transfer",
 		"TransferOut":                           "transfer",
 		"TRADE_EXCHANGE":                        "trade",
+		"DEPOSIT":                               "transaction",
+		"WITHDRAWAL":                            "transaction",
 		"TRANSFER":                              "transfer",
 		"SUB_TRANSFER":                          "transfer",
 		"RETURNED_FEES":                         "fee",
@@ -9943,10 +9989,22 @@ func (this *KucoinCore) ParseLedgerEntry(item interface{}, optionalArgs ...inter
 	var code interface{} = this.SafeCurrencyCode(currencyId, currency)
 	currency = this.SafeCurrency(currencyId, currency)
 	var amount interface{} = this.SafeString(item, "amount")
-	var balanceAfter interface{} = this.SafeNumberOmitZero(item, "balance")
+	if IsTrue(!IsEqual(amount, nil)) {
+		amount = Precise.StringAbs(amount)
+	}
+	var balanceAfter interface{} = this.OmitZero(this.SafeString(item, "balance"))
 	var bizType interface{} = this.SafeStringN(item, []interface{}{"bizType", "businessType", "type"})
 	var typeVar interface{} = this.ParseLedgerEntryType(bizType)
-	var direction interface{} = this.SafeString2(item, "direction", "type")
+	var direction interface{} = this.ParseLedgerDirection(this.SafeString2(item, "direction", "type"))
+	// amount is unsigned, so the balance before the entry depends on its direction
+	var balanceBefore interface{} = nil
+	if IsTrue(IsTrue(!IsEqual(balanceAfter, nil)) && IsTrue(!IsEqual(amount, nil))) {
+		if IsTrue(IsEqual(direction, "out")) {
+			balanceBefore = Precise.StringAdd(balanceAfter, amount)
+		} else if IsTrue(IsEqual(direction, "in")) {
+			balanceBefore = Precise.StringSub(balanceAfter, amount)
+		}
+	}
 	var account interface{} = this.SafeString(item, "accountType") // MAIN, TRADE, MARGIN, or CONTRACT
 	var timestamp interface{} = this.SafeInteger(item, "createdAt")
 	if IsTrue(IsEqual(timestamp, nil)) {
@@ -10018,17 +10076,17 @@ func (this *KucoinCore) ParseLedgerEntry(item interface{}, optionalArgs ...inter
 	return this.SafeLedgerEntry(map[string]interface{}{
 		"info":             item,
 		"id":               id,
-		"direction":        this.ParseLedgerDirection(direction),
+		"direction":        direction,
 		"account":          account,
 		"referenceId":      referenceId,
 		"referenceAccount": account,
 		"type":             typeVar,
 		"currency":         code,
-		"amount":           this.ParseNumber(Precise.StringAbs(amount)),
+		"amount":           this.ParseNumber(amount),
 		"timestamp":        timestamp,
 		"datetime":         datetime,
-		"before":           nil,
-		"after":            balanceAfter,
+		"before":           this.ParseNumber(balanceBefore),
+		"after":            this.ParseNumber(balanceAfter),
 		"status":           this.ParseLedgerStatus(status),
 		"fee":              fee,
 	}, currency)
@@ -10928,7 +10986,7 @@ func (this *KucoinCore) ParseMarginLoan(info interface{}, optionalArgs ...interf
  * @method
  * @name kucoin#fetchDepositWithdrawFees
  * @description fetch deposit and withdraw fees - *IMPORTANT* use fetchDepositWithdrawFee to get more in-depth info
- * @see https://docs.kucoin.com/#get-currencies
+ * @see https://www.kucoin.com/docs-new/rest/spot-trading/market-data/get-all-currencies
  * @param {string[]|undefined} codes list of unified currency codes
  * @param {object} [params] extra parameters specific to the exchange API endpoint
  * @returns {object} a list of [fee structures]{@link https://docs.ccxt.com/?id=fee-structure}
@@ -10949,22 +11007,35 @@ func (this *KucoinCore) FetchDepositWithdrawFees(optionalArgs ...interface{}) <-
 		response := (<-this.PublicGetCurrencies(params))
 		PanicOnError(response)
 		//
-		//  [
-		//      {
-		//        "currency": "CSP",
-		//        "name": "CSP",
-		//        "fullName": "Caspian",
-		//        "precision": 8,
-		//        "confirms": 12,
-		//        "contractAddress": "0xa6446d655a0c34bc4f05042ee88170d056cbaf45",
-		//        "withdrawalMinSize": "2000",
-		//        "withdrawalMinFee": "1000",
-		//        "isWithdrawEnabled": true,
-		//        "isDepositEnabled": true,
-		//        "isMarginEnabled": false,
-		//        "isDebitEnabled": false
-		//      },
-		//  ]
+		//    {
+		//        "code": "200000",
+		//        "data": [
+		//            {
+		//                "currency": "USDT",
+		//                "name": "USDT",
+		//                "fullName": "Tether",
+		//                "precision": 8,
+		//                "isMarginEnabled": true,
+		//                "isDebitEnabled": true,
+		//                "chains": [
+		//                    {
+		//                        "chainName": "ERC20",
+		//                        "chainId": "eth",
+		//                        "withdrawalMinSize": "20",
+		//                        "withdrawalMinFee": "4.5",
+		//                        "isWithdrawEnabled": true,
+		//                        "isDepositEnabled": true,
+		//                        "depositMinSize": "1",
+		//                        "maxWithdraw": null,
+		//                        "maxDeposit": null,
+		//                        "confirms": 64,
+		//                        "needTag": false,
+		//                        "contractAddress": "0xdac17f958d2ee523a2206206994597c13d831ec7"
+		//                    },
+		//                ]
+		//            },
+		//        ]
+		//    }
 		//
 		var data interface{} = this.SafeList(response, "data", []interface{}{})
 
@@ -11054,6 +11125,8 @@ func (this *KucoinCore) SetLeverage(leverage interface{}, optionalArgs ...interf
 		_ = symbol
 		params := GetArg(optionalArgs, 1, map[string]interface{}{})
 		_ = params
+		retRes111287 := (<-this.CheckLeverage(leverage, symbol, params))
+		PanicOnError(retRes111287)
 
 		retRes95188 := (<-this.LoadMarkets())
 		PanicOnError(retRes95188)
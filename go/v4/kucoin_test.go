@@ -0,0 +1,203 @@
+package ccxt
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// fetchDepositWithdrawFees: the fees and limits of every chain of a currency come from the currencies endpoint
+// ---------------------------------------------------------------------------
+
+func newMockedKucoin(bodies map[string]string) (*KucoinCore, *mockTransport) {
+	exchange := NewKucoinCore()
+	exchange.Init(map[string]interface{}{})
+	transport := &mockTransport{body: `{}`, bodies: bodies}
+	exchange.httpClient.Transport = transport
+	exchange.SetMarkets([]interface{}{
+		exchange.SafeMarketStructure(map[string]interface{}{
+			"id":      "BTC-USDT",
+			"symbol":  "BTC/USDT",
+			"base":    "BTC",
+			"quote":   "USDT",
+			"baseId":  "BTC",
+			"quoteId": "USDT",
+			"type":    "spot",
+			"spot":    true,
+			"active":  true,
+		}),
+	})
+	return exchange, transport
+}
+
+func TestKucoinFetchDepositWithdrawFeesForMultipleChains(t *testing.T) {
+	exchange, transport := newMockedKucoin(map[string]string{
+		"/api/v3/currencies": `{"code":"200000","data":[` +
+			`{"currency":"USDT","name":"USDT","fullName":"Tether","precision":8,"isMarginEnabled":true,"isDebitEnabled":true,"chains":[` +
+			`{"chainName":"ERC20","chainId":"eth","withdrawalMinSize":"20","withdrawalMinFee":"4.5","isWithdrawEnabled":true,"isDepositEnabled":true,` +
+			`"depositMinSize":"1","maxWithdraw":null,"maxDeposit":null,"confirms":64,"needTag":false},` +
+			`{"chainName":"TRC20","chainId":"trx","withdrawalMinSize":"10","withdrawalMinFee":"1","isWithdrawEnabled":false,"isDepositEnabled":true,` +
+			`"depositMinSize":"0.5","maxWithdraw":"1000000","maxDeposit":null,"confirms":1,"needTag":false}]},` +
+			`{"currency":"BTC","name":"BTC","fullName":"Bitcoin","precision":8,"chains":[` +
+			`{"chainName":"BTC","chainId":"btc","withdrawalMinSize":"0.001","withdrawalMinFee":"0.0005","isWithdrawEnabled":true,"isDepositEnabled":true,` +
+			`"depositMinSize":"0.0002","maxWithdraw":null,"maxDeposit":null,"confirms":2,"needTag":false}]}]}`,
+	})
+	result := <-exchange.FetchDepositWithdrawFees([]interface{}{"USDT"})
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if !strings.HasSuffix(transport.requests[0].URL.Path, "/api/v3/currencies") {
+		t.Fatalf("expected the currencies endpoint, got %s", transport.requests[0].URL)
+	}
+	fees := result.(map[string]interface{})
+	if len(fees) != 1 {
+		t.Fatalf("expected the USDT fees only, got %v", fees)
+	}
+	networks := GetValue(GetValue(fees, "USDT"), "networks").(map[string]interface{})
+	if len(networks) != 2 {
+		t.Fatalf("expected two networks, got %v", networks)
+	}
+	for network, expected := range map[string][]interface{}{
+		"ERC20": {4.5, 20.0, nil, true, 1.0, true},
+		"TRC20": {1.0, 10.0, 1000000.0, false, 0.5, true},
+	} {
+		withdraw := GetValue(networks[network], "withdraw")
+		deposit := GetValue(networks[network], "deposit")
+		actual := []interface{}{
+			GetValue(withdraw, "fee"),
+			GetValue(withdraw, "min"),
+			GetValue(withdraw, "max"),
+			GetValue(withdraw, "enabled"),
+			GetValue(deposit, "min"),
+			GetValue(deposit, "enabled"),
+		}
+		for i := range expected {
+			if actual[i] != expected[i] {
+				t.Fatalf("%s: expected %v, got %v", network, expected, actual)
+			}
+		}
+	}
+	// with several chains there is no default withdraw fee
+	if fee := GetValue(GetValue(GetValue(fees, "USDT"), "withdraw"), "fee"); fee != nil {
+		t.Fatalf("expected no default withdraw fee, got %v", fee)
+	}
+
+	result = <-exchange.FetchDepositWithdrawFees([]interface{}{"BTC"})
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if fee := GetValue(GetValue(GetValue(result, "BTC"), "withdraw"), "fee"); fee != 0.0005 {
+		t.Fatalf("expected the single chain to be the default, got %v", result)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// parseLedgerEntry: bizType is mapped to the unified type and the balance before is derived from the direction
+// ---------------------------------------------------------------------------
+
+func TestKucoinParseLedgerEntries(t *testing.T) {
+	exchange, _ := newMockedKucoin(nil)
+	items := []interface{}{
+		map[string]interface{}{"id": "1", "currency": "USDT", "amount": "100", "fee": "0", "balance": "150", "accountType": "MAIN", "bizType": "Deposit", "direction": "in", "createdAt": int64(1629101692950), "context": "{\"orderId\":\"617ab9949e7b3b0001948081\",\"txId\":\"0x7a06\"}"},
+		map[string]interface{}{"id": "2", "currency": "USDT", "amount": "-40", "fee": "0", "balance": "110", "accountType": "TRADE", "bizType": "Exchange", "direction": "out", "createdAt": int64(1629101693950), "context": "{\"symbol\":\"BTC-USDT\",\"orderId\":\"617adcd1eb3fa20001dd29a1\",\"tradeId\":\"617adcd12e113d2b91222ff9\"}"},
+		map[string]interface{}{"accountType": "TRADE_HF", "id": "3", "currency": "USDT", "direction": "OUT", "bizType": "DEDUCTION_FEES", "amount": "0.04", "balance": "109.96", "fee": "0", "createdAt": int64(1629101694950)},
+	}
+	entries := []map[string]interface{}{}
+	for _, item := range items {
+		entries = append(entries, exchange.ParseLedgerEntry(item).(map[string]interface{}))
+	}
+	expected := []struct {
+		typeVar     string
+		direction   string
+		account     string
+		referenceId interface{}
+		amount      float64
+		before      float64
+		after       float64
+	}{
+		{"transaction", "in", "MAIN", "617ab9949e7b3b0001948081", 100, 50, 150},
+		{"trade", "out", "TRADE", "617adcd12e113d2b91222ff9", 40, 150, 110},
+		{"fee", "out", "TRADE_HF", nil, 0.04, 110, 109.96},
+	}
+	for i, want := range expected {
+		entry := entries[i]
+		if entry["type"] != want.typeVar || entry["direction"] != want.direction || entry["account"] != want.account || entry["referenceId"] != want.referenceId || entry["currency"] != "USDT" {
+			t.Fatalf("unexpected entry %d: %v", i, entry)
+		}
+		if entry["amount"] != want.amount || entry["before"] != want.before || entry["after"] != want.after {
+			t.Fatalf("unexpected balances for entry %d: amount %v before %v after %v", i, entry["amount"], entry["before"], entry["after"])
+		}
+	}
+	if entries[0]["timestamp"] != int64(1629101692950) || entries[0]["datetime"] != "2021-08-16T08:14:52.950Z" {
+		t.Fatalf("unexpected timestamp %v %v", entries[0]["timestamp"], entries[0]["datetime"])
+	}
+}
+
+// ---------------------------------------------------------------------------
+// createDepositAddress: the memo is returned as the tag, an existing address of the network is fetched instead
+// ---------------------------------------------------------------------------
+
+func newMockedKucoinXrp() (*KucoinCore, *mockTransport) {
+	exchange, transport := newMockedKucoin(nil)
+	exchange.ApiKey = "key"
+	exchange.Secret = "secret"
+	exchange.Password = "password"
+	AddElementToObject(exchange.Options, "uta", false)
+	exchange.SetMarkets(ObjectValues(exchange.Markets), map[string]interface{}{
+		"XRP": exchange.SafeCurrencyStructure(map[string]interface{}{"id": "XRP", "code": "XRP", "precision": 0.000001}),
+	})
+	return exchange, transport
+}
+
+func TestKucoinCreateDepositAddressWithMemo(t *testing.T) {
+	exchange, transport := newMockedKucoinXrp()
+	transport.body = `{"code":"200000","data":{"address":"rNFugeoj3ZN8Wv6xhuLegUBBPXKCyWLRkB","memo":"2712835744","chainId":"xrp","to":"MAIN","expirationDate":0,"currency":"XRP","chainName":"XRP"}}`
+	result := <-exchange.CreateDepositAddress("XRP", map[string]interface{}{"network": "XRP"})
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	request := transport.requests[0]
+	if request.Method != "POST" || !strings.HasSuffix(request.URL.Path, "/api/v3/deposit-address/create") {
+		t.Fatalf("unexpected request %s %s", request.Method, request.URL)
+	}
+	body, _ := request.GetBody()
+	raw, _ := io.ReadAll(body)
+	if !strings.Contains(string(raw), `"chain":"xrp"`) || !strings.Contains(string(raw), `"currency":"XRP"`) {
+		t.Fatalf("expected the xrp chain to be requested, got %s", raw)
+	}
+	address := NewDepositAddress(result)
+	if *address.Address != "rNFugeoj3ZN8Wv6xhuLegUBBPXKCyWLRkB" || *address.Tag != "2712835744" || *address.Network != "XRP" || *address.Currency != "XRP" {
+		t.Fatalf("unexpected address %v", result)
+	}
+}
+
+func TestKucoinCreateDepositAddressAlreadyExists(t *testing.T) {
+	exchange, transport := newMockedKucoinXrp()
+	transport.queue = []string{
+		`{"code":"260000","msg":"Deposit address already exists."}`,
+		`{"code":"200000","data":{"address":"rNFugeoj3ZN8Wv6xhuLegUBBPXKCyWLRkB","memo":"2712835744","chain":"XRP"}}`,
+	}
+	result := <-exchange.CreateDepositAddress("XRP", map[string]interface{}{"network": "XRP"})
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if len(transport.requests) != 2 || !strings.HasSuffix(transport.requests[1].URL.Path, "/deposit-addresses") || transport.requests[1].URL.Query().Get("chain") != "xrp" {
+		t.Fatalf("expected the existing address of the network to be fetched, got %d requests", len(transport.requests))
+	}
+	address := NewDepositAddress(result)
+	if *address.Address != "rNFugeoj3ZN8Wv6xhuLegUBBPXKCyWLRkB" || *address.Tag != "2712835744" || *address.Network != "XRP" {
+		t.Fatalf("unexpected address %v", result)
+	}
+	// another rejection mentioning 260000 is not taken for an existing address
+	transport.requests = nil
+	transport.queue = []string{`{"code":"400100","msg":"amount 260000 exceeds the limit"}`}
+	if result := <-exchange.CreateDepositAddress("XRP", map[string]interface{}{"network": "XRP"}); !IsError(result) || len(transport.requests) != 1 {
+		t.Fatalf("expected the rejection to be returned without fetching the address, got %v", result)
+	}
+	// a memo-less address has no tag
+	parsed := exchange.ParseDepositAddress(map[string]interface{}{"address": "0x2336d1834faab10b2dac44e468f2627138417431", "memo": "", "chainId": "eth"}, exchange.Currency("XRP"))
+	if GetValue(parsed, "tag") != nil {
+		t.Fatalf("expected an empty memo to leave the tag unset, got %v", parsed)
+	}
+}
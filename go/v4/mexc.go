@@ -4635,6 +4635,8 @@ func (this *MexcCore) SetLeverage(leverage interface{}, optionalArgs ...interfac
 		_ = symbol
 		params := GetArg(optionalArgs, 1, map[string]interface{}{})
 		_ = params
+		retRes46387 := (<-this.CheckLeverage(leverage, symbol, params))
+		PanicOnError(retRes46387)
 
 		retRes43308 := (<-this.LoadMarkets())
 		PanicOnError(retRes43308)
@@ -61,6 +61,7 @@ func (this *OkxCore) Describe() interface{} {
 			"fetchBorrowInterest":                  true,
 			"fetchBorrowRateHistories":             true,
 			"fetchBorrowRateHistory":               true,
+			"fetchCanceledAndClosedOrders":         true,
 			"fetchCanceledOrders":                  true,
 			"fetchClosedOrder":                     nil,
 			"fetchClosedOrders":                    true,
@@ -76,7 +77,7 @@ func (this *OkxCore) Describe() interface{} {
 			"fetchDepositAddresses":                false,
 			"fetchDepositAddressesByNetwork":       true,
 			"fetchDeposits":                        true,
-			"fetchDepositsWithdrawals":             false,
+			"fetchDepositsWithdrawals":             true,
 			"fetchDepositWithdrawFee":              "emulated",
 			"fetchDepositWithdrawFees":             true,
 			"fetchFundingHistory":                  true,
@@ -131,7 +132,7 @@ func (this *OkxCore) Describe() interface{} {
 			"fetchTime":                            true,
 			"fetchTrades":                          true,
 			"fetchTradingFee":                      true,
-			"fetchTradingFees":                     false,
+			"fetchTradingFees":                     true,
 			"fetchTradingLimits":                   false,
 			"fetchTransactionFee":                  false,
 			"fetchTransactionFees":                 false,
@@ -1461,27 +1462,22 @@ func (this *OkxCore) FetchStatus(optionalArgs ...interface{}) <-chan interface{}
 		//     }
 		//
 		var data interface{} = this.SafeList(response, "data", []interface{}{})
-		var dataLength interface{} = GetArrayLength(data)
 		var update interface{} = map[string]interface{}{
-			"updated": nil,
-			"status":  Ternary(IsTrue((IsEqual(dataLength, 0))), "ok", "maintenance"),
+			"updated": this.Milliseconds(),
+			"status":  "ok",
 			"eta":     nil,
 			"url":     nil,
 			"info":    response,
 		}
+		// any ongoing maintenance wins over the scheduled, completed and canceled events
 		for i := 0; IsLessThan(i, GetArrayLength(data)); i++ {
 			var event interface{} = GetValue(data, i)
 			var state interface{} = this.SafeString(event, "state")
-			AddElementToObject(update, "eta", this.SafeInteger(event, "end"))
-			AddElementToObject(update, "url", this.SafeString(event, "href"))
 			if IsTrue(IsEqual(state, "ongoing")) {
 				AddElementToObject(update, "status", "maintenance")
-			} else if IsTrue(IsEqual(state, "scheduled")) {
-				AddElementToObject(update, "status", "ok")
-			} else if IsTrue(IsEqual(state, "completed")) {
-				AddElementToObject(update, "status", "ok")
-			} else if IsTrue(IsEqual(state, "canceled")) {
-				AddElementToObject(update, "status", "ok")
+				AddElementToObject(update, "eta", this.SafeInteger(event, "end"))
+				AddElementToObject(update, "url", this.SafeString(event, "href"))
+				break
 			}
 		}
 
@@ -2696,7 +2692,7 @@ func (this *OkxCore) ParseOHLCV(ohlcv interface{}, optionalArgs ...interface{})
  * @param {string} [params.price] "mark" or "index" for mark price and index price candles
  * @param {int} [params.until] timestamp in ms of the latest candle to fetch
  * @param {string} [params.type] "Candles" or "HistoryCandles", default is "Candles" for recent candles, "HistoryCandles" for older candles
- * @param {boolean} [params.paginate] default false, when true will automatically paginate by calling this endpoint multiple times. See in the docs all the [availble parameters](https://github.com/ccxt/ccxt/wiki/Manual#pagination-params)
+ * @param {boolean|string} [params.paginate] default false, when true will automatically paginate by calling this endpoint multiple times. See in the docs all the [availble parameters](https://github.com/ccxt/ccxt/wiki/Manual#pagination-params), "cursor" walks the history candles backwards from until (or now) with the "after" cursor
  * @returns {int[][]} A list of candles ordered as timestamp, open, high, low, close, volume
  */
 func (this *OkxCore) FetchOHLCV(symbol interface{}, optionalArgs ...interface{}) <-chan interface{} {
@@ -2720,6 +2716,11 @@ func (this *OkxCore) FetchOHLCV(symbol interface{}, optionalArgs ...interface{})
 		paginateparamsVariable := this.HandleOptionAndParams(params, "fetchOHLCV", "paginate")
 		paginate = GetValue(paginateparamsVariable, 0)
 		params = GetValue(paginateparamsVariable, 1)
+		if IsTrue(IsEqual(paginate, "cursor")) {
+
+			ch <- this.FetchOHLCVByCursor(symbol, timeframe, since, limit, params)
+			return nil
+		}
 		if IsTrue(paginate) {
 
 			retRes258019 := (<-this.FetchPaginatedCallDeterministic("fetchOHLCV", symbol, since, limit, timeframe, params, 200))
@@ -2830,6 +2831,46 @@ func (this *OkxCore) FetchOHLCV(symbol interface{}, optionalArgs ...interface{})
 	return ch
 }
 
+// FetchOHLCVByCursor pages through the history candles newest first, the "after" cursor of a page
+// is the timestamp of its oldest candle, it stops once since is reached or after paginationCalls pages
+func (this *OkxCore) FetchOHLCVByCursor(symbol interface{}, timeframe interface{}, since interface{}, limit interface{}, params interface{}) interface{} {
+	var maxPages interface{} = nil
+	maxPagesparamsVariable := this.HandleOptionAndParams(params, "fetchOHLCV", "paginationCalls", 10)
+	maxPages = GetValue(maxPagesparamsVariable, 0)
+	params = GetValue(maxPagesparamsVariable, 1)
+	var until interface{} = this.SafeInteger(params, "until")
+	params = this.Omit(params, "until")
+	candles, err := this.PaginateByCursor(func(cursor string) ([]interface{}, string, error) {
+		var request interface{} = this.Extend(params, map[string]interface{}{
+			"type":     "HistoryCandles",
+			"paginate": false,
+		})
+		if cursor != "" {
+			AddElementToObject(request, "until", cursor)
+		} else if IsTrue(!IsEqual(until, nil)) {
+			AddElementToObject(request, "until", until)
+		}
+		page := (<-this.FetchOHLCV(symbol, timeframe, nil, 300, request))
+		if IsError(page) {
+			return nil, "", CreateReturnError(page)
+		}
+		var ohlcvs interface{} = this.ToArray(page)
+		if IsTrue(IsEqual(GetArrayLength(ohlcvs), 0)) {
+			return []interface{}{}, "", nil
+		}
+		var oldest interface{} = GetValue(GetValue(ohlcvs, 0), 0)
+		if IsTrue(IsTrue(!IsEqual(since, nil)) && IsTrue(IsLessThanOrEqual(oldest, since))) {
+			return ohlcvs.([]interface{}), "", nil
+		}
+		return ohlcvs.([]interface{}), ToString(oldest), nil
+	}, int(ParseInt(maxPages)))
+	if err != nil {
+		panic(err)
+	}
+	var sorted interface{} = this.SortBy(this.RemoveRepeatedElementsFromArray(candles), 0)
+	return this.FilterBySinceLimit(sorted, since, limit, 0, IsEqual(since, nil))
+}
+
 /**
  * @method
  * @name okx#fetchFundingRateHistory
@@ -2996,15 +3037,58 @@ func (this *OkxCore) ParseTradingFee(fee interface{}, optionalArgs ...interface{
 	//         "ts": "1639043138472"
 	//     }
 	//
+	//     {
+	//         "category": "1",
+	//         "delivery": "",
+	//         "exercise": "",
+	//         "instType": "SWAP",
+	//         "level": "Lv1",
+	//         "maker": "-0.0002",
+	//         "makerU": "-0.0002",
+	//         "makerUSDC": "-0.0002",
+	//         "taker": "-0.0005",
+	//         "takerU": "-0.0005",
+	//         "takerUSDC": "-0.0005",
+	//         "ts": "1639043138472"
+	//     }
+	//
+	// maker and taker are the rates of the USDT spot pairs and of the crypto-margined contracts,
+	// makerU and takerU the rates of the USDT-margined contracts, makerUSDC and takerUSDC the rates
+	// of the other spot pairs and of the USDC-margined contracts
+	// a negative rate is a commission, a positive rate a rebate
+	//
 	market := GetArg(optionalArgs, 0, nil)
 	_ = market
+	var suffix interface{} = ""
+	if IsTrue(!IsEqual(market, nil)) {
+		if IsTrue(GetValue(market, "spot")) {
+			if IsTrue(!IsEqual(GetValue(market, "quote"), "USDT")) {
+				suffix = "USDC"
+			}
+		} else if IsTrue(GetValue(market, "linear")) {
+			var settle interface{} = GetValue(market, "settle")
+			if IsTrue(IsEqual(settle, "USDT")) {
+				suffix = "U"
+			} else if IsTrue(IsEqual(settle, "USDC")) {
+				suffix = "USDC"
+			}
+		}
+	}
+	var maker interface{} = this.SafeString(fee, Add("maker", suffix))
+	var taker interface{} = this.SafeString(fee, Add("taker", suffix))
+	if IsTrue(IsTrue(IsEqual(maker, nil)) || IsTrue(IsEqual(maker, ""))) {
+		maker = this.SafeString(fee, "maker")
+	}
+	if IsTrue(IsTrue(IsEqual(taker, nil)) || IsTrue(IsEqual(taker, ""))) {
+		taker = this.SafeString(fee, "taker")
+	}
 	return map[string]interface{}{
 		"info":       fee,
 		"symbol":     this.SafeSymbol(nil, market),
-		"maker":      this.ParseNumber(Precise.StringNeg(this.SafeString2(fee, "maker", "makerU"))),
-		"taker":      this.ParseNumber(Precise.StringNeg(this.SafeString2(fee, "taker", "takerU"))),
-		"percentage": nil,
-		"tierBased":  nil,
+		"maker":      this.ParseNumber(Precise.StringNeg(maker)),
+		"taker":      this.ParseNumber(Precise.StringNeg(taker)),
+		"percentage": true,
+		"tierBased":  true,
 	}
 }
 
@@ -3069,6 +3153,82 @@ func (this *OkxCore) FetchTradingFee(symbol interface{}, optionalArgs ...interfa
 	return ch
 }
 
+/**
+ * @method
+ * @name okx#fetchTradingFees
+ * @description fetch the trading fees for multiple markets
+ * @see https://www.okx.com/docs-v5/en/#trading-account-rest-api-get-fee-rates
+ * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @param {string} [params.type] market type, 'spot', 'swap', 'future' or 'option', defaults to options.defaultType
+ * @param {string} [params.instFamily] the instrument family of the contracts, e.g. BTC-USD, required for options
+ * @returns {object} a dictionary of [fee structures]{@link https://docs.ccxt.com/?id=fee-structure} indexed by market symbols
+ */
+func (this *OkxCore) FetchTradingFees(optionalArgs ...interface{}) <-chan interface{} {
+	ch := make(chan interface{})
+	go func() interface{} {
+		defer close(ch)
+		defer ReturnPanicError(ch)
+		params := GetArg(optionalArgs, 0, map[string]interface{}{})
+		_ = params
+
+		retRes28289 := (<-this.LoadMarkets())
+		PanicOnError(retRes28289)
+		var marketType interface{} = nil
+		marketTypeparamsVariable := this.HandleMarketTypeAndParams("fetchTradingFees", nil, params)
+		marketType = GetValue(marketTypeparamsVariable, 0)
+		params = GetValue(marketTypeparamsVariable, 1)
+		if IsTrue(IsEqual(marketType, "margin")) {
+			marketType = "spot"
+		}
+		var request interface{} = map[string]interface{}{
+			"instType": this.ConvertToInstrumentType(marketType),
+		}
+
+		response := (<-this.PrivateGetAccountTradeFee(this.Extend(request, params)))
+		PanicOnError(response)
+		//
+		//     {
+		//         "code": "0",
+		//         "data": [
+		//             {
+		//                 "category": "1",
+		//                 "delivery": "",
+		//                 "exercise": "",
+		//                 "instType": "SPOT",
+		//                 "level": "Lv1",
+		//                 "maker": "-0.0008",
+		//                 "makerUSDC": "-0.0008",
+		//                 "taker": "-0.001",
+		//                 "takerUSDC": "-0.001",
+		//                 "ts": "1639043138472"
+		//             }
+		//         ],
+		//         "msg": ""
+		//     }
+		//
+		// the rates apply to every market of the instrument type, the margin currency selects the rate
+		var data interface{} = this.SafeList(response, "data", []interface{}{})
+		var first interface{} = this.SafeDict(data, 0, map[string]interface{}{})
+		var instFamily interface{} = this.SafeString2(params, "instFamily", "uly")
+		var result interface{} = map[string]interface{}{}
+		for i := 0; IsLessThan(i, GetArrayLength(this.Symbols)); i++ {
+			var market interface{} = this.Market(GetValue(this.Symbols, i))
+			if IsTrue(!IsEqual(GetValue(market, "type"), marketType)) {
+				continue
+			}
+			if IsTrue(IsTrue(!IsEqual(instFamily, nil)) && IsTrue(!IsEqual(Add(Add(GetValue(market, "baseId"), "-"), GetValue(market, "quoteId")), instFamily))) {
+				continue
+			}
+			AddElementToObject(result, GetValue(market, "symbol"), this.ParseTradingFee(first, market))
+		}
+
+		ch <- result
+		return nil
+
+	}()
+	return ch
+}
+
 /**
  * @method
  * @name okx#fetchBalance
@@ -4006,29 +4166,35 @@ func (this *OkxCore) ParseIds(ids interface{}) interface{} {
  * @see https://www.okx.com/docs-v5/en/#order-book-trading-trade-post-cancel-multiple-orders
  * @see https://www.okx.com/docs-v5/en/#order-book-trading-algo-trading-post-cancel-algo-order
  * @param {string[]} ids order ids
- * @param {string} symbol unified market symbol
+ * @param {string} [symbol] unified market symbol, required unless params.symbols is used
  * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @param {string[]} [params.symbols] the unified symbol of each id, in the same order as ids, the markets must share the same instrument type
  * @param {boolean} [params.trigger] whether the order is a stop/trigger order
  * @param {boolean} [params.trailing] set to true if you want to cancel trailing orders
- * @returns {object} an list of [order structures]{@link https://docs.ccxt.com/?id=order-structure}
+ * @returns {object} an list of [order structures]{@link https://docs.ccxt.com/?id=order-structure}, the orders that could not be cancelled have the status 'rejected'
  */
 func (this *OkxCore) CancelOrders(ids interface{}, optionalArgs ...interface{}) <-chan interface{} {
 	ch := make(chan interface{})
 	go func() interface{} {
 		defer close(ch)
 		defer ReturnPanicError(ch)
-		// TODO : the original endpoint signature differs, according to that you can skip individual symbol and assign ids in batch. At this moment, `params` is not being used too.
 		symbol := GetArg(optionalArgs, 0, nil)
 		_ = symbol
 		params := GetArg(optionalArgs, 1, map[string]interface{}{})
 		_ = params
-		if IsTrue(IsEqual(symbol, nil)) {
-			panic(ArgumentsRequired(Add(this.Id, " cancelOrders() requires a symbol argument")))
+		// ids of several symbols can be cancelled together, params.symbols holds the symbol of each id in the same order
+		var symbols interface{} = this.SafeList(params, "symbols")
+		params = this.Omit(params, "symbols")
+		if IsTrue(IsTrue(IsEqual(symbol, nil)) && IsTrue(IsEqual(symbols, nil))) {
+			panic(ArgumentsRequired(Add(this.Id, " cancelOrders() requires a symbol argument or a params.symbols list")))
 		}
 
 		retRes36988 := (<-this.LoadMarkets())
 		PanicOnError(retRes36988)
-		var market interface{} = this.Market(symbol)
+		var market interface{} = nil
+		if IsTrue(!IsEqual(symbol, nil)) {
+			market = this.Market(symbol)
+		}
 		var request interface{} = []interface{}{}
 		var options interface{} = this.SafeValue(this.Options, "cancelOrders", map[string]interface{}{})
 		var defaultMethod interface{} = this.SafeString(options, "method", "privatePostTradeCancelBatchOrders")
@@ -4040,86 +4206,151 @@ func (this *OkxCore) CancelOrders(ids interface{}, optionalArgs ...interface{})
 		if IsTrue(IsTrue(trigger) || IsTrue(trailing)) {
 			method = "privatePostTradeCancelAlgos"
 		}
+		var isAlgo interface{} = IsEqual(method, "privatePostTradeCancelAlgos")
+		var orderIds interface{} = clientOrderIds
+		if IsTrue(IsEqual(clientOrderIds, nil)) {
+			orderIds = this.ParseIds(ids)
+		}
+		// the market of each id, a single market when the symbol argument is used
+		var markets interface{} = []interface{}{}
+		if IsTrue(!IsEqual(symbols, nil)) {
+			if IsTrue(!IsEqual(GetArrayLength(symbols), GetArrayLength(orderIds))) {
+				panic(BadRequest(Add(this.Id, " cancelOrders() requires params.symbols to have one symbol per id")))
+			}
+			for i := 0; IsLessThan(i, GetArrayLength(symbols)); i++ {
+				AppendToArray(&markets, this.Market(GetValue(symbols, i)))
+				if IsTrue(!IsEqual(GetValue(GetValue(markets, i), "type"), GetValue(GetValue(markets, 0), "type"))) {
+					panic(BadRequest(Add(this.Id, " cancelOrders() requires all symbols to be of the same instrument type")))
+				}
+			}
+		} else {
+			for i := 0; IsLessThan(i, GetArrayLength(orderIds)); i++ {
+				AppendToArray(&markets, market)
+			}
+		}
+		var requestMarkets interface{} = []interface{}{}
 		if IsTrue(IsEqual(clientOrderIds, nil)) {
-			ids = this.ParseIds(ids)
 			if IsTrue(!IsEqual(algoIds, nil)) {
+				if IsTrue(IsEqual(market, nil)) {
+					panic(ArgumentsRequired(Add(this.Id, " cancelOrders() requires a symbol argument when params.algoId is used")))
+				}
 				for i := 0; IsLessThan(i, GetArrayLength(algoIds)); i++ {
 					AppendToArray(&request, map[string]interface{}{
 						"algoId": GetValue(algoIds, i),
 						"instId": GetValue(market, "id"),
 					})
+					AppendToArray(&requestMarkets, market)
 				}
 			}
-			for i := 0; IsLessThan(i, GetArrayLength(ids)); i++ {
+			for i := 0; IsLessThan(i, GetArrayLength(orderIds)); i++ {
+				var orderMarket interface{} = GetValue(markets, i)
 				if IsTrue(IsTrue(trailing) || IsTrue(trigger)) {
 					AppendToArray(&request, map[string]interface{}{
-						"algoId": GetValue(ids, i),
-						"instId": GetValue(market, "id"),
+						"algoId": GetValue(orderIds, i),
+						"instId": GetValue(orderMarket, "id"),
 					})
 				} else {
 					AppendToArray(&request, map[string]interface{}{
-						"ordId":  GetValue(ids, i),
-						"instId": GetValue(market, "id"),
+						"ordId":  GetValue(orderIds, i),
+						"instId": GetValue(orderMarket, "id"),
 					})
 				}
+				AppendToArray(&requestMarkets, orderMarket)
 			}
 		} else {
-			for i := 0; IsLessThan(i, GetArrayLength(clientOrderIds)); i++ {
+			for i := 0; IsLessThan(i, GetArrayLength(orderIds)); i++ {
+				var orderMarket interface{} = GetValue(markets, i)
 				if IsTrue(IsTrue(trailing) || IsTrue(trigger)) {
 					AppendToArray(&request, map[string]interface{}{
-						"instId":      GetValue(market, "id"),
-						"algoClOrdId": GetValue(clientOrderIds, i),
+						"instId":      GetValue(orderMarket, "id"),
+						"algoClOrdId": GetValue(orderIds, i),
 					})
 				} else {
 					AppendToArray(&request, map[string]interface{}{
-						"instId":  GetValue(market, "id"),
-						"clOrdId": GetValue(clientOrderIds, i),
+						"instId":  GetValue(orderMarket, "id"),
+						"clOrdId": GetValue(orderIds, i),
 					})
 				}
+				AppendToArray(&requestMarkets, orderMarket)
 			}
 		}
-		var response interface{} = nil
-		if IsTrue(IsEqual(method, "privatePostTradeCancelAlgos")) {
+		// a batch takes up to 20 orders (10 algo orders), larger lists are sent in several batches in their original order
+		var maxOrdersPerRequest interface{} = Ternary(IsTrue(isAlgo), 10, 20)
+		var chunks interface{} = []interface{}{}
+		for i := 0; IsLessThan(i, GetArrayLength(request)); i++ {
+			if IsTrue(IsEqual(Mod(i, maxOrdersPerRequest), 0)) {
+				var end interface{} = MathMin(Add(i, maxOrdersPerRequest), GetArrayLength(request))
+				AppendToArray(&chunks, this.ArraySlice(request, i, end))
+			}
+		}
+		var orders interface{} = []interface{}{}
+		for i := 0; IsLessThan(i, GetArrayLength(chunks)); i++ {
+			var chunk interface{} = GetValue(chunks, i)
+			var offset interface{} = Multiply(i, maxOrdersPerRequest)
+			var response interface{} = nil
+			if IsTrue(isAlgo) {
 
-			response = (<-this.PrivatePostTradeCancelAlgos(request))
-			PanicOnError(response) // * dont extend with params, otherwise ARRAY will be turned into OBJECT
-		} else {
+				response = (<-this.PrivatePostTradeCancelAlgos(chunk))
+				PanicOnError(response) // * dont extend with params, otherwise ARRAY will be turned into OBJECT
+			} else {
 
-			response = (<-this.PrivatePostTradeCancelBatchOrders(request))
-			PanicOnError(response) // * dont extend with params, otherwise ARRAY will be turned into OBJECT
+				response = (<-this.PrivatePostTradeCancelBatchOrders(chunk))
+				PanicOnError(response) // * dont extend with params, otherwise ARRAY will be turned into OBJECT
+			}
+			//
+			//     {
+			//         "code": "0",
+			//         "data": [
+			//             {
+			//                 "clOrdId": "e123456789ec4dBC1123456ba123b45e",
+			//                 "ordId": "405071912345641543",
+			//                 "sCode": "0",
+			//                 "sMsg": ""
+			//             },
+			//             ...
+			//         ],
+			//         "msg": ""
+			//     }
+			//
+			// partial failure, the orders that were not cancelled have a non-zero sCode
+			//
+			//     {
+			//         "code": "2",
+			//         "data": [
+			//             { "clOrdId": "", "ordId": "405071912345641543", "sCode": "0", "sMsg": "" },
+			//             { "clOrdId": "", "ordId": "405071912345641544", "sCode": "51400", "sMsg": "Cancellation failed as the order has been filled, canceled or does not exist" }
+			//         ],
+			//         "msg": "Bulk operation partially succeeded"
+			//     }
+			//
+			// Algo order
+			//
+			//     {
+			//         "code": "0",
+			//         "data": [
+			//             {
+			//                 "algoId": "431375349042380800",
+			//                 "sCode": "0",
+			//                 "sMsg": ""
+			//             }
+			//         ],
+			//         "msg": ""
+			//     }
+			//
+			var ordersData interface{} = this.SafeList(response, "data", []interface{}{})
+			for j := 0; IsLessThan(j, GetArrayLength(ordersData)); j++ {
+				var entry interface{} = GetValue(ordersData, j)
+				var order interface{} = this.ParseOrder(entry, GetValue(requestMarkets, Add(offset, j)))
+				if IsTrue(IsEqual(this.SafeString(entry, "sCode"), "0")) {
+					order = this.Extend(order, map[string]interface{}{
+						"status": "canceled",
+					})
+				}
+				AppendToArray(&orders, this.Extend(order, params))
+			}
 		}
-		//
-		//     {
-		//         "code": "0",
-		//         "data": [
-		//             {
-		//                 "clOrdId": "e123456789ec4dBC1123456ba123b45e",
-		//                 "ordId": "405071912345641543",
-		//                 "sCode": "0",
-		//                 "sMsg": ""
-		//             },
-		//             ...
-		//         ],
-		//         "msg": ""
-		//     }
-		//
-		// Algo order
-		//
-		//     {
-		//         "code": "0",
-		//         "data": [
-		//             {
-		//                 "algoId": "431375349042380800",
-		//                 "sCode": "0",
-		//                 "sMsg": ""
-		//             }
-		//         ],
-		//         "msg": ""
-		//     }
-		//
-		var ordersData interface{} = this.SafeList(response, "data", []interface{}{})
 
-		ch <- this.ParseOrders(ordersData, market, nil, nil, params)
+		ch <- orders
 		return nil
 
 	}()
@@ -5329,6 +5560,109 @@ func (this *OkxCore) FetchClosedOrders(optionalArgs ...interface{}) <-chan inter
 	return ch
 }
 
+/**
+ * @method
+ * @name okx#fetchCanceledAndClosedOrders
+ * @description fetches information on multiple canceled and closed orders made by the user with a single request
+ * @see https://www.okx.com/docs-v5/en/#order-book-trading-trade-get-order-history-last-7-days
+ * @see https://www.okx.com/docs-v5/en/#order-book-trading-trade-get-order-history-last-3-months
+ * @param {string} symbol unified market symbol of the market orders were made in
+ * @param {int} [since] the earliest time in ms to fetch orders for
+ * @param {int} [limit] the maximum number of order structures to retrieve
+ * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @param {int} [params.until] timestamp in ms to fetch orders for
+ * @param {bool} [params.trigger] True if fetching trigger or conditional orders, the algo history is then queried once per state
+ * @param {boolean} [params.paginate] default false, when true will automatically paginate by calling this endpoint multiple times. See in the docs all the [availble parameters](https://github.com/ccxt/ccxt/wiki/Manual#pagination-params)
+ * @param {string} [params.method] method to be used, either 'privateGetTradeOrdersHistory' or 'privateGetTradeOrdersHistoryArchive' default is 'privateGetTradeOrdersHistory'
+ * @returns {Order[]} a list of [order structures]{@link https://docs.ccxt.com/?id=order-structure}
+ */
+func (this *OkxCore) FetchCanceledAndClosedOrders(optionalArgs ...interface{}) <-chan interface{} {
+	ch := make(chan interface{})
+	go func() interface{} {
+		defer close(ch)
+		defer ReturnPanicError(ch)
+		symbol := GetArg(optionalArgs, 0, nil)
+		_ = symbol
+		since := GetArg(optionalArgs, 1, nil)
+		_ = since
+		limit := GetArg(optionalArgs, 2, nil)
+		_ = limit
+		params := GetArg(optionalArgs, 3, map[string]interface{}{})
+		_ = params
+
+		retRes48108 := (<-this.LoadMarkets())
+		PanicOnError(retRes48108)
+		var algoOrderTypes interface{} = this.SafeDict(this.Options, "algoOrderTypes", map[string]interface{}{})
+		var ordType interface{} = this.SafeString(params, "ordType")
+		var trigger interface{} = this.SafeBool2(params, "stop", "trigger")
+		var trailing interface{} = this.SafeBool(params, "trailing", false)
+		if IsTrue(IsTrue(IsTrue(trailing) || IsTrue(trigger)) || IsTrue((InOp(algoOrderTypes, ordType)))) {
+			// the algo history endpoint requires a state, so it cannot return both in one call
+
+			retRes48169 := (<-this.Exchange.FetchCanceledAndClosedOrders(symbol, since, limit, params))
+			PanicOnError(retRes48169)
+			ch <- retRes48169
+			return nil
+		}
+		var maxLimit interface{} = 100
+		var paginate interface{} = false
+		paginateparamsVariable := this.HandleOptionAndParams(params, "fetchCanceledAndClosedOrders", "paginate")
+		paginate = GetValue(paginateparamsVariable, 0)
+		params = GetValue(paginateparamsVariable, 1)
+		if IsTrue(paginate) {
+
+			retRes48229 := (<-this.FetchPaginatedCallDynamic("fetchCanceledAndClosedOrders", symbol, since, limit, params, maxLimit))
+			PanicOnError(retRes48229)
+			ch <- retRes48229
+			return nil
+		}
+		var request interface{} = map[string]interface{}{}
+		var market interface{} = nil
+		if IsTrue(!IsEqual(symbol, nil)) {
+			market = this.Market(symbol)
+			AddElementToObject(request, "instId", GetValue(market, "id"))
+		}
+		var typeVar interface{} = nil
+		var query interface{} = nil
+		typeVarqueryVariable := this.HandleMarketTypeAndParams("fetchCanceledAndClosedOrders", market, params)
+		typeVar = GetValue(typeVarqueryVariable, 0)
+		query = GetValue(typeVarqueryVariable, 1)
+		AddElementToObject(request, "instType", this.ConvertToInstrumentType(typeVar))
+		if IsTrue(!IsEqual(limit, nil)) {
+			AddElementToObject(request, "limit", mathMin(limit, maxLimit)) // default 100, max 100
+		}
+		if IsTrue(!IsEqual(since, nil)) {
+			AddElementToObject(request, "begin", since)
+		}
+		var until interface{} = this.SafeInteger(query, "until")
+		if IsTrue(!IsEqual(until, nil)) {
+			AddElementToObject(request, "end", until)
+			query = this.Omit(query, []interface{}{"until"})
+		}
+		// without a state filter the order history holds both the filled and the canceled orders
+		var options interface{} = this.SafeDict(this.Options, "fetchCanceledAndClosedOrders", map[string]interface{}{})
+		var defaultMethod interface{} = this.SafeString(options, "method", "privateGetTradeOrdersHistory")
+		var method interface{} = this.SafeString(params, "method", defaultMethod)
+		var send interface{} = this.Omit(query, []interface{}{"method", "stop", "trigger", "trailing"})
+		var response interface{} = nil
+		if IsTrue(IsEqual(method, "privateGetTradeOrdersHistoryArchive")) {
+
+			response = (<-this.PrivateGetTradeOrdersHistoryArchive(this.Extend(request, send)))
+			PanicOnError(response)
+		} else {
+
+			response = (<-this.PrivateGetTradeOrdersHistory(this.Extend(request, send)))
+			PanicOnError(response)
+		}
+		var data interface{} = this.SafeList(response, "data", []interface{}{})
+
+		ch <- this.ParseOrders(data, market, since, limit)
+		return nil
+
+	}()
+	return ch
+}
+
 /**
  * @method
  * @name okx#fetchMyTrades
@@ -5918,6 +6252,7 @@ func (this *OkxCore) FetchDepositAddress(code interface{}, optionalArgs ...inter
  * @param {string} address the address to withdraw to
  * @param {string} tag
  * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @param {string} [params.network] unified network code, required for currencies with several chains
  * @returns {object} a [transaction structure]{@link https://docs.ccxt.com/?id=transaction-structure}
  */
 func (this *OkxCore) Withdraw(code interface{}, amount interface{}, address interface{}, optionalArgs ...interface{}) <-chan interface{} {
@@ -5937,21 +6272,35 @@ func (this *OkxCore) Withdraw(code interface{}, amount interface{}, address inte
 		retRes53818 := (<-this.LoadMarkets())
 		PanicOnError(retRes53818)
 		var currency interface{} = this.Currency(code)
-		if IsTrue(IsTrue((!IsEqual(tag, nil))) && IsTrue((IsGreaterThan(GetLength(tag), 0)))) {
-			address = Add(Add(address, ":"), tag)
-		}
+		var hasTag interface{} = IsTrue((!IsEqual(tag, nil))) && IsTrue((IsGreaterThan(GetLength(tag), 0)))
 		var request interface{} = map[string]interface{}{
 			"ccy":    GetValue(currency, "id"),
 			"toAddr": address,
 			"dest":   "4",
 			"amt":    this.NumberToString(amount),
 		}
+		var currencyNetworks interface{} = this.SafeDict(currency, "networks", map[string]interface{}{})
+		var networkCodes interface{} = ObjectKeys(currencyNetworks)
+		var targetNetwork interface{} = nil
 		var network interface{} = this.SafeString(params, "network") // this line allows the user to specify either ERC20 or ETH
 		if IsTrue(!IsEqual(network, nil)) {
 			var networks interface{} = this.SafeDict(this.Options, "networks", map[string]interface{}{})
 			network = this.SafeString(networks, ToUpper(network), network) // handle ETH>ERC20 alias
 			AddElementToObject(request, "chain", Add(Add(GetValue(currency, "id"), "-"), network))
 			params = this.Omit(params, "network")
+			targetNetwork = this.SafeDict(currencyNetworks, this.NetworkIdToCode(network, GetValue(currency, "code")))
+		} else if IsTrue(IsGreaterThan(GetArrayLength(networkCodes), 1)) {
+			panic(ArgumentsRequired(Add(Add(Add(Add(this.Id, " withdraw() requires a network parameter for "), GetValue(currency, "code")), ", one of "), Join(networkCodes, ", "))))
+		} else if IsTrue(IsEqual(GetArrayLength(networkCodes), 1)) {
+			targetNetwork = this.SafeDict(currencyNetworks, GetValue(networkCodes, 0))
+		}
+		// assets like XRP or EOS are credited by memo, a withdrawal without it would be lost
+		var needTag interface{} = this.SafeBool(this.SafeDict(targetNetwork, "info"), "needTag", false)
+		if IsTrue(IsTrue(needTag) && !IsTrue(hasTag)) {
+			panic(ArgumentsRequired(Add(Add(this.Id, " withdraw() requires a tag argument for "), GetValue(currency, "code"))))
+		}
+		if IsTrue(hasTag) {
+			AddElementToObject(request, "toAddr", Add(Add(address, ":"), tag))
 		}
 		var fee interface{} = this.SafeString(params, "fee")
 		if IsTrue(IsEqual(fee, nil)) {
@@ -7641,6 +7990,8 @@ func (this *OkxCore) SetLeverage(leverage interface{}, optionalArgs ...interface
 		_ = symbol
 		params := GetArg(optionalArgs, 1, map[string]interface{}{})
 		_ = params
+		retRes79937 := (<-this.CheckLeverage(leverage, symbol, params))
+		PanicOnError(retRes79937)
 		if IsTrue(IsEqual(symbol, nil)) {
 			panic(ArgumentsRequired(Add(this.Id, " setLeverage() requires a symbol argument")))
 		}
@@ -9135,11 +9486,14 @@ func (this *OkxCore) ParseDepositWithdrawFees(response interface{}, optionalArgs
  * @method
  * @name okx#fetchSettlementHistory
  * @description fetches historical settlement records
+ * @see https://www.okx.com/docs-v5/en/#public-data-rest-api-get-futures-settlement-history
  * @see https://www.okx.com/docs-v5/en/#rest-api-public-data-get-delivery-exercise-history
- * @param {string} symbol unified market symbol to fetch the settlement history for
+ * @param {string} [symbol] unified market symbol to fetch the settlement history for, required unless params.underlying is set
  * @param {int} [since] timestamp in ms
  * @param {int} [limit] number of records
  * @param {object} [params] exchange specific params
+ * @param {string} [params.underlying] the underlying or instrument family to fetch the settlements of, like BTC-USD
+ * @param {string} [params.type] 'future' (default) or 'option', used when no symbol is given
  * @returns {object[]} a list of [settlement history objects]{@link https://docs.ccxt.com/?id=settlement-history-structure}
  */
 func (this *OkxCore) FetchSettlementHistory(optionalArgs ...interface{}) <-chan interface{} {
@@ -9155,33 +9509,69 @@ func (this *OkxCore) FetchSettlementHistory(optionalArgs ...interface{}) <-chan
 		_ = limit
 		params := GetArg(optionalArgs, 3, map[string]interface{}{})
 		_ = params
-		if IsTrue(IsEqual(symbol, nil)) {
-			panic(ArgumentsRequired(Add(this.Id, " fetchSettlementHistory() requires a symbol argument")))
+		var underlying interface{} = this.SafeStringN(params, []interface{}{"underlying", "instFamily", "uly"})
+		params = this.Omit(params, []interface{}{"underlying", "instFamily", "uly"})
+		if IsTrue(IsTrue(IsEqual(symbol, nil)) && IsTrue(IsEqual(underlying, nil))) {
+			panic(ArgumentsRequired(Add(this.Id, " fetchSettlementHistory() requires a symbol argument or an underlying parameter")))
 		}
 
 		retRes80608 := (<-this.LoadMarkets())
 		PanicOnError(retRes80608)
-		var market interface{} = this.Market(symbol)
+		var market interface{} = nil
+		if IsTrue(!IsEqual(symbol, nil)) {
+			market = this.Market(symbol)
+			symbol = GetValue(market, "symbol")
+		}
 		var typeVar interface{} = nil
-		typeVarparamsVariable := this.HandleMarketTypeAndParams("fetchSettlementHistory", market, params)
+		typeVarparamsVariable := this.HandleMarketTypeAndParams("fetchSettlementHistory", market, params, "future")
 		typeVar = GetValue(typeVarparamsVariable, 0)
 		params = GetValue(typeVarparamsVariable, 1)
 		if IsTrue(IsTrue(!IsEqual(typeVar, "future")) && IsTrue(!IsEqual(typeVar, "option"))) {
 			panic(NotSupported(Add(this.Id, " fetchSettlementHistory() supports futures and options markets only")))
 		}
-		var request interface{} = map[string]interface{}{
-			"instType": this.ConvertToInstrumentType(typeVar),
-			"uly":      Add(Add(GetValue(market, "baseId"), "-"), GetValue(market, "quoteId")),
+		if IsTrue(IsEqual(underlying, nil)) {
+			underlying = this.SafeString2(GetValue(market, "info"), "instFamily", "uly", Add(Add(GetValue(market, "baseId"), "-"), GetValue(market, "quoteId")))
 		}
+		var request interface{} = map[string]interface{}{}
 		if IsTrue(!IsEqual(since, nil)) {
 			AddElementToObject(request, "before", Subtract(since, 1))
 		}
 		if IsTrue(!IsEqual(limit, nil)) {
 			AddElementToObject(request, "limit", limit)
 		}
+		var response interface{} = nil
+		if IsTrue(IsEqual(typeVar, "future")) {
+			AddElementToObject(request, "instFamily", underlying)
 
-		response := (<-this.PublicGetPublicDeliveryExerciseHistory(this.Extend(request, params)))
-		PanicOnError(response)
+			response = (<-this.PublicGetPublicSettlementHistory(this.Extend(request, params)))
+			PanicOnError(response)
+		} else {
+			AddElementToObject(request, "instType", this.ConvertToInstrumentType(typeVar))
+			AddElementToObject(request, "uly", underlying)
+
+			response = (<-this.PublicGetPublicDeliveryExerciseHistory(this.Extend(request, params)))
+			PanicOnError(response)
+		}
+		//
+		// futures
+		//
+		//     {
+		//         "code": "0",
+		//         "data": [
+		//             {
+		//                 "details": [
+		//                     {
+		//                         "instId": "XRP-USDT-250307",
+		//                         "settlePx": "2.5192078615298715"
+		//                     }
+		//                 ],
+		//                 "ts": "1741161600000"
+		//             }
+		//         ],
+		//         "msg": ""
+		//     }
+		//
+		// options
 		//
 		//     {
 		//         "code": "0",
@@ -9201,16 +9591,27 @@ func (this *OkxCore) FetchSettlementHistory(optionalArgs ...interface{}) <-chan
 		//     }
 		//
 		var data interface{} = this.SafeList(response, "data", []interface{}{})
-		var settlements interface{} = this.ParseSettlements(data, market)
+		// the records cover the whole underlying, they are not parsed against the requested market
+		// so that the other, possibly expired, instruments keep their own id
+		var settlements interface{} = this.ParseSettlements(data, nil)
 		var sorted interface{} = this.SortBy(settlements, "timestamp")
 
-		ch <- this.FilterBySymbolSinceLimit(sorted, GetValue(market, "symbol"), since, limit)
+		ch <- this.FilterBySymbolSinceLimit(sorted, symbol, since, limit)
 		return nil
 
 	}()
 	return ch
 }
 func (this *OkxCore) ParseSettlement(settlement interface{}, market interface{}) interface{} {
+	//
+	// futures
+	//
+	//     {
+	//         "instId": "XRP-USDT-250307",
+	//         "settlePx": "2.5192078615298715"
+	//     }
+	//
+	// options
 	//
 	//     {
 	//         "insId": "BTC-USD-230521-28500-P",
@@ -9218,11 +9619,11 @@ func (this *OkxCore) ParseSettlement(settlement interface{}, market interface{})
 	//         "type": "exercised"
 	//     }
 	//
-	var marketId interface{} = this.SafeString(settlement, "insId")
+	var marketId interface{} = this.SafeString2(settlement, "instId", "insId")
 	return map[string]interface{}{
 		"info":      settlement,
 		"symbol":    this.SafeSymbol(marketId, market),
-		"price":     this.SafeNumber(settlement, "px"),
+		"price":     this.SafeNumber2(settlement, "settlePx", "px"),
 		"timestamp": nil,
 		"datetime":  nil,
 	}
@@ -9625,6 +10026,9 @@ func (this *OkxCore) ClosePosition(symbol interface{}, optionalArgs ...interface
  * @name okx#fetchOption
  * @description fetches option data that is commonly found in an option chain
  * @see https://www.okx.com/docs-v5/en/#order-book-trading-market-data-get-ticker
+ * @see https://www.okx.com/docs-v5/en/#public-data-rest-api-get-option-market-data
+ * @see https://www.okx.com/docs-v5/en/#public-data-rest-api-get-mark-price
+ * @see https://www.okx.com/docs-v5/en/#public-data-rest-api-get-open-interest
  * @param {string} symbol unified market symbol
  * @param {object} [params] extra parameters specific to the exchange API endpoint
  * @returns {object} an [option chain structure]{@link https://docs.ccxt.com/?id=option-chain-structure}
@@ -9640,12 +10044,26 @@ func (this *OkxCore) FetchOption(symbol interface{}, optionalArgs ...interface{}
 		retRes84538 := (<-this.LoadMarkets())
 		PanicOnError(retRes84538)
 		var market interface{} = this.Market(symbol)
+		if !IsTrue(GetValue(market, "option")) {
+			panic(BadSymbol(Add(this.Id, " fetchOption() supports option markets only")))
+		}
+		var marketId interface{} = GetValue(market, "id")
 		var request interface{} = map[string]interface{}{
-			"instId": GetValue(market, "id"),
+			"instId": marketId,
+		}
+		var summaryRequest interface{} = map[string]interface{}{
+			"uly":        GetValue(GetValue(market, "info"), "uly"),
+			"instFamily": GetValue(GetValue(market, "info"), "instFamily"),
+			"expTime":    this.SafeString(Split(marketId, "-"), 2),
+		}
+		var instrumentRequest interface{} = map[string]interface{}{
+			"instType": "OPTION",
+			"instId":   marketId,
 		}
 
-		response := (<-this.PublicGetMarketTicker(this.Extend(request, params)))
-		PanicOnError(response)
+		responses := (<-promiseAll([]interface{}{this.PublicGetMarketTicker(this.Extend(request, params)), this.PublicGetPublicOptSummary(summaryRequest), this.PublicGetPublicMarkPrice(instrumentRequest), this.PublicGetPublicOpenInterest(instrumentRequest)}))
+		PanicOnError(responses)
+		var response interface{} = GetValue(responses, 0)
 		//
 		//     {
 		//         "code": "0",
@@ -9674,6 +10092,22 @@ func (this *OkxCore) FetchOption(symbol interface{}, optionalArgs ...interface{}
 		//
 		var result interface{} = this.SafeList(response, "data", []interface{}{})
 		var chain interface{} = this.SafeDict(result, 0, map[string]interface{}{})
+		// the ticker has no implied volatility, mark price nor open interest
+		var summaries interface{} = this.SafeList(GetValue(responses, 1), "data", []interface{}{})
+		var summary interface{} = map[string]interface{}{}
+		for i := 0; IsLessThan(i, GetArrayLength(summaries)); i++ {
+			if IsTrue(IsEqual(this.SafeString(GetValue(summaries, i), "instId"), marketId)) {
+				summary = GetValue(summaries, i)
+			}
+		}
+		var markPrice interface{} = this.SafeDict(this.SafeList(GetValue(responses, 2), "data", []interface{}{}), 0, map[string]interface{}{})
+		var openInterest interface{} = this.SafeDict(this.SafeList(GetValue(responses, 3), "data", []interface{}{}), 0, map[string]interface{}{})
+		chain = this.Extend(chain, map[string]interface{}{
+			"markVol": this.SafeString(summary, "markVol"),
+			"fwdPx":   this.SafeString(summary, "fwdPx"),
+			"markPx":  this.SafeString(markPrice, "markPx"),
+			"oi":      this.SafeString(openInterest, "oi"),
+		})
 
 		ch <- this.ParseOption(chain, nil, market)
 		return nil
@@ -9765,6 +10199,8 @@ func (this *OkxCore) ParseOption(chain interface{}, optionalArgs ...interface{})
 	//         "sodUtc8": ""
 	//     }
 	//
+	// fetchOption adds markVol, fwdPx, markPx and oi from the option summary, mark price and open interest endpoints
+	//
 	currency := GetArg(optionalArgs, 0, nil)
 	_ = currency
 	market := GetArg(optionalArgs, 1, nil)
@@ -9772,20 +10208,26 @@ func (this *OkxCore) ParseOption(chain interface{}, optionalArgs ...interface{})
 	var marketId interface{} = this.SafeString(chain, "instId")
 	market = this.SafeMarket(marketId, market)
 	var timestamp interface{} = this.SafeInteger(chain, "ts")
+	var expiry interface{} = this.SafeInteger(market, "expiry")
 	return map[string]interface{}{
 		"info":              chain,
 		"currency":          nil,
 		"symbol":            GetValue(market, "symbol"),
 		"timestamp":         timestamp,
 		"datetime":          this.Iso8601(timestamp),
-		"impliedVolatility": nil,
-		"openInterest":      nil,
+		"strike":            this.SafeNumber(market, "strike"),
+		"expiry":            expiry,
+		"expiryDatetime":    this.Iso8601(expiry),
+		"optionType":        this.SafeString(market, "optionType"),
+		"underlying":        this.SafeString(market, "base"),
+		"impliedVolatility": this.SafeNumber(chain, "markVol"),
+		"openInterest":      this.SafeNumber(chain, "oi"),
 		"bidPrice":          this.SafeNumber(chain, "bidPx"),
 		"askPrice":          this.SafeNumber(chain, "askPx"),
 		"midPrice":          nil,
-		"markPrice":         nil,
+		"markPrice":         this.SafeNumber(chain, "markPx"),
 		"lastPrice":         this.SafeNumber(chain, "last"),
-		"underlyingPrice":   nil,
+		"underlyingPrice":   this.SafeNumber(chain, "fwdPx"),
 		"change":            nil,
 		"percentage":        nil,
 		"baseVolume":        this.SafeNumber(chain, "volCcy24h"),
@@ -0,0 +1,610 @@
+package ccxt
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// cancelOrders: batches are split at the venue limit, failed cancellations are reported per order
+// ---------------------------------------------------------------------------
+
+func newMockedOkx() (*OkxCore, *mockTransport) {
+	exchange := NewOkxCore()
+	exchange.Init(map[string]interface{}{
+		"apiKey":   "key",
+		"secret":   "secret",
+		"password": "password",
+	})
+	transport := &mockTransport{body: `{"code":"0","data":[],"msg":""}`}
+	exchange.httpClient.Transport = transport
+	market := func(id string, symbol string, base string, quote string, marketType string) interface{} {
+		return exchange.SafeMarketStructure(map[string]interface{}{
+			"id":       id,
+			"symbol":   symbol,
+			"base":     base,
+			"quote":    quote,
+			"settle":   Ternary(marketType == "swap", quote, nil),
+			"type":     marketType,
+			"spot":     marketType == "spot",
+			"swap":     marketType == "swap",
+			"contract": marketType == "swap",
+			"linear":   Ternary(marketType == "swap", true, nil),
+			"active":   true,
+		})
+	}
+	exchange.SetMarkets([]interface{}{
+		market("BTC-USDT-SWAP", "BTC/USDT:USDT", "BTC", "USDT", "swap"),
+		market("ETH-USDT-SWAP", "ETH/USDT:USDT", "ETH", "USDT", "swap"),
+		market("BTC-USDT", "BTC/USDT", "BTC", "USDT", "spot"),
+	})
+	return exchange, transport
+}
+
+// okxCancelResponse acknowledges the cancellation of the given ids, the failed ones get an error code
+func okxCancelResponse(ids []string, failed map[string]string) string {
+	data := []string{}
+	for _, id := range ids {
+		if message, ok := failed[id]; ok {
+			data = append(data, `{"clOrdId":"","ordId":"`+id+`","sCode":"51400","sMsg":"`+message+`"}`)
+		} else {
+			data = append(data, `{"clOrdId":"","ordId":"`+id+`","sCode":"0","sMsg":""}`)
+		}
+	}
+	code := "0"
+	if len(failed) > 0 {
+		code = "2"
+	}
+	return `{"code":"` + code + `","data":[` + strings.Join(data, ",") + `],"msg":""}`
+}
+
+func okxBatchRequest(t *testing.T, transport *mockTransport, index int) []map[string]string {
+	body, err := transport.requests[index].GetBody()
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := io.ReadAll(body)
+	var request []map[string]string
+	if err := json.Unmarshal(raw, &request); err != nil {
+		t.Fatalf("expected a list of orders, got %s", raw)
+	}
+	return request
+}
+
+func okxRequestBody(t *testing.T, transport *mockTransport, index int) map[string]interface{} {
+	body, err := transport.requests[index].GetBody()
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := io.ReadAll(body)
+	var request map[string]interface{}
+	if err := json.Unmarshal(raw, &request); err != nil {
+		t.Fatalf("expected a json object, got %s", raw)
+	}
+	return request
+}
+
+func TestOkxCancelOrdersPartialFailure(t *testing.T) {
+	exchange, transport := newMockedOkx()
+	ids := []interface{}{}
+	symbols := []interface{}{}
+	firstBatch := []string{}
+	for i := 0; i < 21; i++ {
+		id := fmt.Sprintf("6000000000000000%02d", i)
+		ids = append(ids, id)
+		symbols = append(symbols, []string{"BTC/USDT:USDT", "ETH/USDT:USDT"}[i%2])
+		if i < 20 {
+			firstBatch = append(firstBatch, id)
+		}
+	}
+	transport.queue = []string{
+		okxCancelResponse(firstBatch, map[string]string{"600000000000000003": "Order does not exist"}),
+		okxCancelResponse([]string{"600000000000000020"}, nil),
+	}
+
+	result := <-exchange.CancelOrders(ids, nil, map[string]interface{}{"symbols": symbols})
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if len(transport.requests) != 2 {
+		t.Fatalf("expected 2 batches, got %d", len(transport.requests))
+	}
+	if !strings.HasSuffix(transport.requests[0].URL.Path, "/trade/cancel-batch-orders") {
+		t.Fatalf("unexpected endpoint %s", transport.requests[0].URL.Path)
+	}
+	first := okxBatchRequest(t, transport, 0)
+	second := okxBatchRequest(t, transport, 1)
+	if len(first) != 20 || len(second) != 1 {
+		t.Fatalf("expected batches of 20 and 1 orders, got %d and %d", len(first), len(second))
+	}
+	if first[0]["instId"] != "BTC-USDT-SWAP" || first[1]["instId"] != "ETH-USDT-SWAP" || second[0]["ordId"] != "600000000000000020" {
+		t.Fatalf("unexpected batches %v %v", first, second)
+	}
+
+	orders := result.([]interface{})
+	if len(orders) != 21 {
+		t.Fatalf("expected 21 orders, got %d", len(orders))
+	}
+	for i, order := range orders {
+		expected := "canceled"
+		if i == 3 {
+			expected = "rejected"
+		}
+		if exchange.SafeString(order, "id") != ids[i] || exchange.SafeString(order, "status") != expected {
+			t.Fatalf("order %d: expected %s with status %s, got %v", i, ids[i], expected, order)
+		}
+	}
+	if info := GetValue(orders[3], "info"); exchange.SafeString(info, "sMsg") != "Order does not exist" {
+		t.Fatalf("expected the failure message in the order info, got %v", info)
+	}
+	if exchange.SafeString(orders[1], "symbol") != "ETH/USDT:USDT" {
+		t.Fatalf("expected the symbol of the id, got %v", orders[1])
+	}
+}
+
+func TestOkxCancelOrdersRequiresSameInstrumentType(t *testing.T) {
+	exchange, transport := newMockedOkx()
+	result := <-exchange.CancelOrders([]interface{}{"1", "2"}, nil, map[string]interface{}{
+		"symbols": []interface{}{"BTC/USDT:USDT", "BTC/USDT"},
+	})
+	if err, ok := CreateReturnError(result).(*Error); !ok || err.Type != "BadRequest" {
+		t.Fatalf("expected a BadRequest error, got %v", result)
+	}
+	if len(transport.requests) != 0 {
+		t.Fatalf("expected no request, got %d", len(transport.requests))
+	}
+}
+
+// ---------------------------------------------------------------------------
+// fetchOHLCV: cursor pagination walks the history candles backwards
+// ---------------------------------------------------------------------------
+
+func TestOkxFetchOHLCVPaginatesByCursor(t *testing.T) {
+	exchange, transport := newMockedOkx()
+	exchange.EnableRateLimit = false
+	candle := func(timestamp int) string {
+		return fmt.Sprintf(`["%d","100","110","90","105","1","1","100","1"]`, timestamp)
+	}
+	// newest first, each page starts one candle before the cursor
+	transport.queue = []string{
+		`{"code":"0","msg":"","data":[` + candle(300000) + `,` + candle(240000) + `]}`,
+		`{"code":"0","msg":"","data":[` + candle(180000) + `,` + candle(120000) + `]}`,
+		`{"code":"0","msg":"","data":[]}`,
+	}
+	result := <-exchange.FetchOHLCV("BTC/USDT:USDT", "1m", nil, nil, map[string]interface{}{
+		"paginate": "cursor",
+		"until":    360000,
+	})
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if len(transport.requests) != 3 {
+		t.Fatalf("expected 3 pages, got %d", len(transport.requests))
+	}
+	for i, after := range []string{"360000", "240000", "120000"} {
+		request := transport.requests[i].URL
+		if !strings.HasSuffix(request.Path, "/market/history-candles") || request.Query().Get("after") != after {
+			t.Fatalf("request %d: expected the history candles after %s, got %s", i, after, request)
+		}
+	}
+	candles := result.([]interface{})
+	if len(candles) != 4 {
+		t.Fatalf("expected 4 candles, got %v", candles)
+	}
+	for i, timestamp := range []int64{120000, 180000, 240000, 300000} {
+		if GetValue(candles[i], 0) != timestamp {
+			t.Fatalf("expected the candles in ascending order, got %v", candles)
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// fetchOption: the ticker is completed with the option summary, mark price and open interest
+// ---------------------------------------------------------------------------
+
+func TestOkxFetchOption(t *testing.T) {
+	exchange, transport := newMockedOkx()
+	exchange.SetMarkets([]interface{}{
+		exchange.SafeMarketStructure(map[string]interface{}{
+			"id":         "BTC-USD-241227-60000-P",
+			"symbol":     "BTC/USD:BTC-241227-60000-P",
+			"base":       "BTC",
+			"quote":      "USD",
+			"settle":     "BTC",
+			"type":       "option",
+			"option":     true,
+			"contract":   true,
+			"strike":     60000.0,
+			"optionType": "put",
+			"expiry":     int64(1735286400000),
+			"active":     true,
+			"info":       map[string]interface{}{"instId": "BTC-USD-241227-60000-P", "uly": "BTC-USD", "instFamily": "BTC-USD"},
+		}),
+		exchange.SafeMarketStructure(map[string]interface{}{
+			"id":       "BTC-USDT-SWAP",
+			"symbol":   "BTC/USDT:USDT",
+			"base":     "BTC",
+			"quote":    "USDT",
+			"settle":   "USDT",
+			"type":     "swap",
+			"swap":     true,
+			"contract": true,
+			"active":   true,
+		}),
+	})
+	transport.bodies = map[string]string{
+		"/market/ticker": `{"code":"0","msg":"","data":[{"instType":"OPTION","instId":"BTC-USD-241227-60000-P","last":"0.1365","lastSz":"1",` +
+			`"askPx":"0.139","askSz":"100","bidPx":"0.134","bidSz":"100","open24h":"0.1425","high24h":"0.1425","low24h":"0.1365",` +
+			`"volCcy24h":"0.6","vol24h":"6","ts":"1711176035035","sodUtc0":"0.1425","sodUtc8":"0.1425"}]}`,
+		"/public/opt-summary": `{"code":"0","msg":"","data":[` +
+			`{"instId":"BTC-USD-241227-50000-P","instType":"OPTION","markVol":"0.71","fwdPx":"68890.1","ts":"1711176035035","uly":"BTC-USD"},` +
+			`{"instId":"BTC-USD-241227-60000-P","instType":"OPTION","markVol":"0.6582","bidVol":"0.64","askVol":"0.67","fwdPx":"68890.1",` +
+			`"delta":"-0.33","gamma":"1.62","theta":"-0.0001","vega":"0.0024","ts":"1711176035035","uly":"BTC-USD"}]}`,
+		"/public/mark-price":    `{"code":"0","msg":"","data":[{"instType":"OPTION","instId":"BTC-USD-241227-60000-P","markPx":"0.1368","ts":"1711176035035"}]}`,
+		"/public/open-interest": `{"code":"0","msg":"","data":[{"instType":"OPTION","instId":"BTC-USD-241227-60000-P","oi":"1250","oiCcy":"12.5","ts":"1711176035035"}]}`,
+	}
+
+	result := <-exchange.FetchOption("BTC/USD:BTC-241227-60000-P")
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if len(transport.requests) != 4 {
+		t.Fatalf("expected 4 requests, got %d", len(transport.requests))
+	}
+	for _, request := range transport.requests {
+		if strings.HasSuffix(request.URL.Path, "/public/opt-summary") && request.URL.Query().Get("expTime") != "241227" {
+			t.Fatalf("expected the summary of the expiry, got %s", request.URL)
+		}
+	}
+	option := NewOption(result)
+	if *option.Symbol != "BTC/USD:BTC-241227-60000-P" || *option.Timestamp != 1711176035035 {
+		t.Fatalf("unexpected option %+v", option)
+	}
+	if *option.Strike != 60000 || *option.Expiry != 1735286400000 || *option.ExpiryDatetime != "2024-12-27T08:00:00.000Z" ||
+		*option.OptionType != "put" || *option.Underlying != "BTC" {
+		t.Fatalf("expected the contract terms of the market, got %+v", option)
+	}
+	if *option.MarkPrice != 0.1368 || *option.BidPrice != 0.134 || *option.AskPrice != 0.139 || *option.LastPrice != 0.1365 {
+		t.Fatalf("unexpected prices %+v", option)
+	}
+	if *option.OpenInterest != 1250 || *option.ImpliedVolatility != 0.6582 || *option.UnderlyingPrice != 68890.1 {
+		t.Fatalf("unexpected open interest, volatility or underlying price %+v", option)
+	}
+
+	result = <-exchange.FetchOption("BTC/USDT:USDT")
+	if err, ok := CreateReturnError(result).(*Error); !ok || err.Type != "BadSymbol" {
+		t.Fatalf("expected a BadSymbol error, got %v", result)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// withdraw: the chain and the memo are validated against the currencies before the request is sent
+// ---------------------------------------------------------------------------
+
+func newMockedOkxWithCurrencies(t *testing.T) (*OkxCore, *mockTransport) {
+	exchange, transport := newMockedOkx()
+	var chains []interface{}
+	if err := json.Unmarshal([]byte(`[`+
+		`{"ccy":"USDT","chain":"USDT-TRC20","canDep":true,"canWd":true,"minFee":"0.8","minWd":"2","maxWd":"8852150","wdTickSz":"3","needTag":false},`+
+		`{"ccy":"USDT","chain":"USDT-ERC20","canDep":true,"canWd":true,"minFee":"8","minWd":"2","maxWd":"8852150","wdTickSz":"3","needTag":false},`+
+		`{"ccy":"XRP","chain":"XRP-Ripple","canDep":true,"canWd":true,"minFee":"0.2","minWd":"20","maxWd":"1000000","wdTickSz":"6","needTag":true}]`), &chains); err != nil {
+		t.Fatal(err)
+	}
+	currencies := exchange.ParseCurrencies(ObjectValues(exchange.GroupBy(chains, "ccy")))
+	exchange.SetMarkets(ObjectValues(exchange.Markets), currencies)
+	return exchange, transport
+}
+
+func TestOkxWithdrawRequiresTag(t *testing.T) {
+	exchange, transport := newMockedOkxWithCurrencies(t)
+	transport.body = `{"code":"0","msg":"","data":[{"amt":"25","wdId":"67485","ccy":"XRP","chain":"XRP-Ripple"}]}`
+	result := <-exchange.Withdraw("XRP", 25, "rLW9gnQo7BQhU6igk5keqYnH3TVrCxGRzm", nil, map[string]interface{}{"fee": "0.2"})
+	if err, ok := CreateReturnError(result).(*Error); !ok || err.Type != "ArgumentsRequired" {
+		t.Fatalf("expected an ArgumentsRequired error, got %v", result)
+	}
+	if len(transport.requests) != 0 {
+		t.Fatalf("expected no request, got %d", len(transport.requests))
+	}
+
+	result = <-exchange.Withdraw("XRP", 25, "rLW9gnQo7BQhU6igk5keqYnH3TVrCxGRzm", "123456", map[string]interface{}{"fee": "0.2"})
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	sent := okxRequestBody(t, transport, 0)
+	if sent["toAddr"] != "rLW9gnQo7BQhU6igk5keqYnH3TVrCxGRzm:123456" || sent["ccy"] != "XRP" {
+		t.Fatalf("expected the memo to be appended to the address, got %v", sent)
+	}
+}
+
+func TestOkxWithdrawRequiresNetwork(t *testing.T) {
+	exchange, transport := newMockedOkxWithCurrencies(t)
+	transport.body = `{"code":"0","msg":"","data":[{"amt":"100","wdId":"67486","ccy":"USDT","chain":"USDT-TRC20"}]}`
+	address := "TJrZLMSnrtkzGhmK7hP45JzvtTvEdxZLGd"
+	result := <-exchange.Withdraw("USDT", 100, address, nil, map[string]interface{}{"fee": "0.8"})
+	if err, ok := CreateReturnError(result).(*Error); !ok || err.Type != "ArgumentsRequired" {
+		t.Fatalf("expected an ArgumentsRequired error, got %v", result)
+	}
+	if len(transport.requests) != 0 {
+		t.Fatalf("expected no request, got %d", len(transport.requests))
+	}
+
+	result = <-exchange.Withdraw("USDT", 100, address, nil, map[string]interface{}{"fee": "0.8", "network": "TRX"})
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	sent := okxRequestBody(t, transport, 0)
+	if sent["chain"] != "USDT-TRC20" || sent["toAddr"] != address || sent["amt"] != "100" || sent["network"] != nil {
+		t.Fatalf("expected a withdrawal on USDT-TRC20, got %v", sent)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// fetchTime: the server time is the ts of the first entry
+// ---------------------------------------------------------------------------
+
+func TestOkxFetchTime(t *testing.T) {
+	exchange, transport := newMockedOkx()
+	transport.body = `{"code":"0","data":[{"ts":"1621247923668"}],"msg":""}`
+	result := <-exchange.FetchTime()
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if result != int64(1621247923668) {
+		t.Fatalf("expected the server time in milliseconds, got %v", result)
+	}
+	if request := transport.requests[0].URL; !strings.HasSuffix(request.Path, "/api/v5/public/time") {
+		t.Fatalf("expected the system time endpoint, got %s", request)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// fetchSettlementHistory: futures settlements come from the settlement history of their underlying
+// ---------------------------------------------------------------------------
+
+const okxSettlementHistory = `{"code":"0","msg":"","data":[
+	{"details":[{"instId":"BTC-USD-250307","settlePx":"88431.3"},{"instId":"BTC-USD-250314","settlePx":"88430.1"}],"ts":"1741334400000"},
+	{"details":[{"instId":"BTC-USD-250228","settlePx":"84293.65"}],"ts":"1740729600000"}
+]}`
+
+func newMockedOkxFuture() (*OkxCore, *mockTransport) {
+	exchange, transport := newMockedOkx()
+	exchange.SetMarkets([]interface{}{
+		exchange.SafeMarketStructure(map[string]interface{}{
+			"id":       "BTC-USD-250307",
+			"symbol":   "BTC/USD:BTC-250307",
+			"base":     "BTC",
+			"quote":    "USD",
+			"settle":   "BTC",
+			"baseId":   "BTC",
+			"quoteId":  "USD",
+			"type":     "future",
+			"future":   true,
+			"contract": true,
+			"inverse":  true,
+			"expiry":   int64(1741334400000),
+			"active":   true,
+			"info":     map[string]interface{}{"instId": "BTC-USD-250307", "uly": "BTC-USD", "instFamily": "BTC-USD"},
+		}),
+	})
+	transport.body = okxSettlementHistory
+	return exchange, transport
+}
+
+func TestOkxFetchSettlementHistory(t *testing.T) {
+	exchange, transport := newMockedOkxFuture()
+	result := <-exchange.FetchSettlementHistory("BTC/USD:BTC-250307")
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	request := transport.requests[0]
+	if !strings.HasSuffix(request.URL.Path, "/public/settlement-history") || request.URL.Query().Get("instFamily") != "BTC-USD" {
+		t.Fatalf("unexpected request %s", request.URL.String())
+	}
+	settlements := result.([]interface{})
+	if len(settlements) != 1 {
+		t.Fatalf("expected the settlement of the requested future only, got %v", settlements)
+	}
+	settlement := settlements[0]
+	if GetValue(settlement, "symbol") != "BTC/USD:BTC-250307" || GetValue(settlement, "price") != 88431.3 ||
+		GetValue(settlement, "timestamp") != int64(1741334400000) || GetValue(settlement, "datetime") != "2025-03-07T08:00:00.000Z" {
+		t.Fatalf("unexpected settlement %v", settlement)
+	}
+}
+
+func TestOkxFetchSettlementHistoryByUnderlying(t *testing.T) {
+	exchange, transport := newMockedOkxFuture()
+	result := <-exchange.FetchSettlementHistory(nil, nil, 2, map[string]interface{}{"underlying": "BTC-USD"})
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	query := transport.requests[0].URL.Query()
+	if query.Get("instFamily") != "BTC-USD" || query.Get("limit") != "2" || query.Has("underlying") {
+		t.Fatalf("unexpected request %s", transport.requests[0].URL.String())
+	}
+	settlements := result.([]interface{})
+	symbols := []string{}
+	for _, settlement := range settlements {
+		symbols = append(symbols, fmt.Sprint(GetValue(settlement, "symbol")))
+	}
+	// the last records, sorted by time, the instruments that are no longer listed keep their id
+	if strings.Join(symbols, ",") != "BTC/USD:BTC-250307,BTC-USD-250314" {
+		t.Fatalf("unexpected settlements %v", symbols)
+	}
+
+	result = <-exchange.FetchSettlementHistory(nil)
+	if !IsError(result) || !strings.Contains(result.(string), "requires a symbol argument or an underlying parameter") {
+		t.Fatalf("expected ArgumentsRequired, got %v", result)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// fetchCanceledAndClosedOrders: one order history request without a state filter
+// ---------------------------------------------------------------------------
+
+func TestOkxFetchCanceledAndClosedOrders(t *testing.T) {
+	exchange, transport := newMockedOkx()
+	transport.body = `{"code":"0","msg":"","data":[
+		{"instId":"BTC-USDT","instType":"SPOT","ordId":"2","ordType":"limit","side":"buy","px":"30000","sz":"1","accFillSz":"0","state":"canceled","cTime":"1700000002000","uTime":"1700000002500"},
+		{"instId":"BTC-USDT","instType":"SPOT","ordId":"1","ordType":"limit","side":"sell","px":"31000","sz":"1","accFillSz":"1","avgPx":"31000","state":"filled","cTime":"1700000001000","uTime":"1700000001500"}
+	]}`
+	result := <-exchange.FetchCanceledAndClosedOrders("BTC/USDT", 1700000000000, 10)
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if len(transport.requests) != 1 {
+		t.Fatalf("expected a single request, got %d", len(transport.requests))
+	}
+	request := transport.requests[0]
+	query := request.URL.Query()
+	if !strings.HasSuffix(request.URL.Path, "/trade/orders-history") || query.Get("state") != "" || query.Get("instId") != "BTC-USDT" || query.Get("begin") != "1700000000000" || query.Get("limit") != "10" {
+		t.Fatalf("unexpected request %s", request.URL.String())
+	}
+	orders := NewOrderArray(result)
+	if len(orders) != 2 || *orders[0].Id != "1" || *orders[0].Status != "closed" || *orders[1].Id != "2" || *orders[1].Status != "canceled" {
+		t.Fatalf("unexpected orders %v", result)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// fetchStatus: an ongoing maintenance is reported whatever the order of the events
+// ---------------------------------------------------------------------------
+
+func TestOkxFetchStatus(t *testing.T) {
+	exchange, transport := newMockedOkx()
+	result := <-exchange.FetchStatus()
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if status := result.(map[string]interface{}); status["status"] != "ok" || status["eta"] != nil || status["updated"] == nil {
+		t.Fatalf("expected ok without events, got %v", result)
+	}
+	transport.body = `{"code":"0","msg":"","data":[
+		{"begin":"1621328400000","end":"1621329000000","href":"https://www.okx.com/support/1","serviceType":"1","state":"ongoing","system":"classic","title":"Classic Spot System Upgrade"},
+		{"begin":"1621415000000","end":"1621416000000","href":"https://www.okx.com/support/2","serviceType":"3","state":"scheduled","system":"unified","title":"Perpetual Upgrade"}
+	]}`
+	result = <-exchange.FetchStatus()
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	status := result.(map[string]interface{})
+	if status["status"] != "maintenance" || status["eta"] != int64(1621329000000) || status["url"] != "https://www.okx.com/support/1" {
+		t.Fatalf("unexpected maintenance status %v", result)
+	}
+	transport.body = `{"code":"0","msg":"","data":[
+		{"begin":"1621415000000","end":"1621416000000","href":"https://www.okx.com/support/2","serviceType":"3","state":"scheduled","system":"unified","title":"Perpetual Upgrade"}
+	]}`
+	result = <-exchange.FetchStatus()
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if status := result.(map[string]interface{}); status["status"] != "ok" || status["eta"] != nil {
+		t.Fatalf("expected a scheduled maintenance to leave the status ok, got %v", result)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// fetchTradingFee(s): the rate is picked by instrument type and margin currency
+// ---------------------------------------------------------------------------
+
+func TestOkxParseTradingFee(t *testing.T) {
+	exchange, _ := newMockedOkx()
+	spot := map[string]interface{}{"category": "1", "instType": "SPOT", "level": "Lv1", "maker": "-0.0008", "taker": "-0.001", "makerUSDC": "-0.0006", "takerUSDC": "-0.0009", "makerU": "", "takerU": ""}
+	swap := map[string]interface{}{"category": "1", "instType": "SWAP", "level": "Lv1", "maker": "-0.0004", "taker": "-0.0007", "makerU": "-0.0002", "takerU": "-0.0005", "makerUSDC": "0.0001", "takerUSDC": "-0.0003"}
+	market := func(symbol string, marketType string, quote string, settle interface{}, linear interface{}) interface{} {
+		return map[string]interface{}{"symbol": symbol, "type": marketType, "spot": marketType == "spot", "quote": quote, "settle": settle, "linear": linear}
+	}
+	for _, test := range []struct {
+		fee    interface{}
+		market interface{}
+		maker  float64
+		taker  float64
+	}{
+		{spot, market("BTC/USDT", "spot", "USDT", nil, nil), 0.0008, 0.001},
+		{spot, market("ETH/BTC", "spot", "BTC", nil, nil), 0.0006, 0.0009},
+		{swap, market("BTC/USDT:USDT", "swap", "USDT", "USDT", true), 0.0002, 0.0005},
+		{swap, market("BTC/USDC:USDC", "swap", "USDC", "USDC", true), -0.0001, 0.0003},
+		{swap, market("BTC/USD:BTC", "swap", "USD", "BTC", false), 0.0004, 0.0007},
+	} {
+		fee := NewTradingFeeInterface(exchange.ParseTradingFee(test.fee, test.market))
+		symbol := GetValue(test.market, "symbol").(string)
+		if *fee.Symbol != symbol || *fee.Maker != test.maker || *fee.Taker != test.taker {
+			t.Fatalf("%s: expected maker %v and taker %v, got %v and %v", symbol, test.maker, test.taker, *fee.Maker, *fee.Taker)
+		}
+		if !*fee.Percentage || !*fee.TierBased {
+			t.Fatalf("%s: expected percentage tier based fees", symbol)
+		}
+	}
+}
+
+func TestOkxFetchTradingFees(t *testing.T) {
+	exchange, transport := newMockedOkx()
+	transport.body = `{"code":"0","msg":"","data":[{"category":"1","delivery":"","exercise":"","instType":"SWAP","level":"Lv1","maker":"-0.0004","makerU":"-0.0002","makerUSDC":"-0.0002","taker":"-0.0007","takerU":"-0.0005","takerUSDC":"-0.0005","ts":"1639043138472"}]}`
+	result := <-exchange.FetchTradingFees(map[string]interface{}{"type": "swap"})
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if request := transport.requests[0].URL; !strings.HasSuffix(request.Path, "/account/trade-fee") || request.Query().Get("instType") != "SWAP" {
+		t.Fatalf("unexpected request %s", request)
+	}
+	fees := NewTradingFees(result).TradingFees
+	if len(fees) != 2 {
+		t.Fatalf("expected the fees of the 2 swaps, got %v", result)
+	}
+	for _, symbol := range []string{"BTC/USDT:USDT", "ETH/USDT:USDT"} {
+		if fee := fees[symbol]; *fee.Maker != 0.0002 || *fee.Taker != 0.0005 {
+			t.Fatalf("%s: expected the USDT-margined rates, got %v and %v", symbol, *fee.Maker, *fee.Taker)
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// fetchDepositsWithdrawals: the deposits and the withdrawals are merged by timestamp
+// ---------------------------------------------------------------------------
+
+func TestOkxFetchDepositsWithdrawals(t *testing.T) {
+	exchange, transport := newMockedOkx()
+	transport.bodies = map[string]string{
+		"/asset/deposit-history": `{"code":"0","msg":"","data":[
+			{"amt":"2","txId":"0xd2","ccy":"USDT","chain":"USDT-TRC20","from":"","to":"TLmU8w5dkw6Vr3zo3gsPRNpvyhFAnYTiCQ","ts":"3000","state":"2","depId":"d2"},
+			{"amt":"1","txId":"0xd1","ccy":"USDT","chain":"USDT-TRC20","from":"","to":"TLmU8w5dkw6Vr3zo3gsPRNpvyhFAnYTiCQ","ts":"1000","state":"2","depId":"d1"}
+		]}`,
+		"/asset/withdrawal-history": `{"code":"0","msg":"","data":[
+			{"amt":"4","wdId":"w2","fee":"1","txId":"0xw2","ccy":"USDT","chain":"USDT-TRC20","from":"","to":"TJ7hhYhVhaxNx6BPyq7yFpqZrQULL3JSdb","ts":"4000","state":"2"},
+			{"amt":"3","wdId":"w1","fee":"1","txId":"0xw1","ccy":"USDT","chain":"USDT-TRC20","from":"","to":"TJ7hhYhVhaxNx6BPyq7yFpqZrQULL3JSdb","ts":"2000","state":"2"}
+		]}`,
+	}
+	result := <-exchange.FetchDepositsWithdrawals()
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if len(transport.requests) != 2 {
+		t.Fatalf("expected the deposits and the withdrawals to be requested, got %d requests", len(transport.requests))
+	}
+	transactions := NewTransactionArray(result)
+	expected := []struct {
+		id        string
+		typeVar   string
+		timestamp int64
+	}{{"d1", "deposit", 1000}, {"w1", "withdrawal", 2000}, {"d2", "deposit", 3000}, {"w2", "withdrawal", 4000}}
+	if len(transactions) != len(expected) {
+		t.Fatalf("expected %d transactions, got %v", len(expected), result)
+	}
+	for i, want := range expected {
+		if *transactions[i].Id != want.id || *transactions[i].Type != want.typeVar || *transactions[i].Timestamp != want.timestamp {
+			t.Fatalf("transaction %d: expected %s %s at %d, got %v", i, want.typeVar, want.id, want.timestamp, result)
+		}
+	}
+	// without since the limit keeps the latest transactions
+	result = <-exchange.FetchDepositsWithdrawals(nil, nil, 3)
+	if transactions := NewTransactionArray(result); len(transactions) != 3 || *transactions[0].Id != "w1" || *transactions[2].Id != "w2" {
+		t.Fatalf("expected the 3 latest transactions, got %v", result)
+	}
+	// with since the limit keeps the first transactions after it
+	result = <-exchange.FetchDepositsWithdrawals(nil, 2000, 2)
+	if transactions := NewTransactionArray(result); len(transactions) != 2 || *transactions[0].Id != "w1" || *transactions[1].Id != "d2" {
+		t.Fatalf("expected the 2 transactions from since, got %v", result)
+	}
+}
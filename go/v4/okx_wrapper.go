@@ -343,7 +343,7 @@ func (this *Okx) FetchTrades(symbol string, options ...FetchTradesOptions) ([]Tr
  * @param {string} [params.price] "mark" or "index" for mark price and index price candles
  * @param {int} [params.until] timestamp in ms of the latest candle to fetch
  * @param {string} [params.type] "Candles" or "HistoryCandles", default is "Candles" for recent candles, "HistoryCandles" for older candles
- * @param {boolean} [params.paginate] default false, when true will automatically paginate by calling this endpoint multiple times. See in the docs all the [availble parameters](https://github.com/ccxt/ccxt/wiki/Manual#pagination-params)
+ * @param {boolean|string} [params.paginate] default false, when true will automatically paginate by calling this endpoint multiple times. See in the docs all the [availble parameters](https://github.com/ccxt/ccxt/wiki/Manual#pagination-params), "cursor" walks the history candles backwards from until (or now) with the "after" cursor
  * @returns {int[][]} A list of candles ordered as timestamp, open, high, low, close, volume
  */
 func (this *Okx) FetchOHLCV(symbol string, options ...FetchOHLCVOptions) ([]OHLCV, error) {
@@ -454,6 +454,24 @@ func (this *Okx) FetchTradingFee(symbol string, options ...FetchTradingFeeOption
 	return NewTradingFeeInterface(res), nil
 }
 
+/**
+ * @method
+ * @name okx#fetchTradingFees
+ * @description fetch the trading fees for multiple markets
+ * @see https://www.okx.com/docs-v5/en/#trading-account-rest-api-get-fee-rates
+ * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @param {string} [params.type] market type, 'spot', 'swap', 'future' or 'option', defaults to options.defaultType
+ * @param {string} [params.instFamily] the instrument family of the contracts, e.g. BTC-USD, required for options
+ * @returns {object} a dictionary of [fee structures]{@link https://docs.ccxt.com/?id=fee-structure} indexed by market symbols
+ */
+func (this *Okx) FetchTradingFees(params ...interface{}) (TradingFees, error) {
+	res := <-this.Core.FetchTradingFees(params...)
+	if IsError(res) {
+		return TradingFees{}, CreateReturnError(res)
+	}
+	return NewTradingFees(res), nil
+}
+
 /**
  * @method
  * @name okx#fetchBalance
@@ -716,11 +734,12 @@ func (this *Okx) CancelOrder(id string, options ...CancelOrderOptions) (Order, e
  * @see https://www.okx.com/docs-v5/en/#order-book-trading-trade-post-cancel-multiple-orders
  * @see https://www.okx.com/docs-v5/en/#order-book-trading-algo-trading-post-cancel-algo-order
  * @param {string[]} ids order ids
- * @param {string} symbol unified market symbol
+ * @param {string} [symbol] unified market symbol, required unless params.symbols is used
  * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @param {string[]} [params.symbols] the unified symbol of each id, in the same order as ids, the markets must share the same instrument type
  * @param {boolean} [params.trigger] whether the order is a stop/trigger order
  * @param {boolean} [params.trailing] set to true if you want to cancel trailing orders
- * @returns {object} an list of [order structures]{@link https://docs.ccxt.com/?id=order-structure}
+ * @returns {object} an list of [order structures]{@link https://docs.ccxt.com/?id=order-structure}, the orders that could not be cancelled have the status 'rejected'
  */
 func (this *Okx) CancelOrders(ids []string, options ...CancelOrdersOptions) ([]Order, error) {
 
@@ -997,6 +1016,56 @@ func (this *Okx) FetchClosedOrders(options ...FetchClosedOrdersOptions) ([]Order
 	return NewOrderArray(res), nil
 }
 
+/**
+ * @method
+ * @name okx#fetchCanceledAndClosedOrders
+ * @description fetches information on multiple canceled and closed orders made by the user with a single request
+ * @see https://www.okx.com/docs-v5/en/#order-book-trading-trade-get-order-history-last-7-days
+ * @see https://www.okx.com/docs-v5/en/#order-book-trading-trade-get-order-history-last-3-months
+ * @param {string} symbol unified market symbol of the market orders were made in
+ * @param {int} [since] the earliest time in ms to fetch orders for
+ * @param {int} [limit] the maximum number of order structures to retrieve
+ * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @param {int} [params.until] timestamp in ms to fetch orders for
+ * @param {bool} [params.trigger] True if fetching trigger or conditional orders, the algo history is then queried once per state
+ * @param {boolean} [params.paginate] default false, when true will automatically paginate by calling this endpoint multiple times. See in the docs all the [availble parameters](https://github.com/ccxt/ccxt/wiki/Manual#pagination-params)
+ * @param {string} [params.method] method to be used, either 'privateGetTradeOrdersHistory' or 'privateGetTradeOrdersHistoryArchive' default is 'privateGetTradeOrdersHistory'
+ * @returns {Order[]} a list of [order structures]{@link https://docs.ccxt.com/?id=order-structure}
+ */
+func (this *Okx) FetchCanceledAndClosedOrders(options ...FetchCanceledAndClosedOrdersOptions) ([]Order, error) {
+
+	opts := FetchCanceledAndClosedOrdersOptionsStruct{}
+
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	var symbol interface{} = nil
+	if opts.Symbol != nil {
+		symbol = *opts.Symbol
+	}
+
+	var since interface{} = nil
+	if opts.Since != nil {
+		since = *opts.Since
+	}
+
+	var limit interface{} = nil
+	if opts.Limit != nil {
+		limit = *opts.Limit
+	}
+
+	var params interface{} = nil
+	if opts.Params != nil {
+		params = *opts.Params
+	}
+	res := <-this.Core.FetchCanceledAndClosedOrders(symbol, since, limit, params)
+	if IsError(res) {
+		return nil, CreateReturnError(res)
+	}
+	return NewOrderArray(res), nil
+}
+
 /**
  * @method
  * @name okx#fetchMyTrades
@@ -1207,6 +1276,7 @@ func (this *Okx) FetchDepositAddress(code string, options ...FetchDepositAddress
  * @param {string} address the address to withdraw to
  * @param {string} tag
  * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @param {string} [params.network] unified network code, required for currencies with several chains
  * @returns {object} a [transaction structure]{@link https://docs.ccxt.com/?id=transaction-structure}
  */
 func (this *Okx) Withdraw(code string, amount float64, address string, options ...WithdrawOptions) (Transaction, error) {
@@ -2375,6 +2445,9 @@ func (this *Okx) FetchAllGreeks(options ...FetchAllGreeksOptions) ([]Greeks, err
  * @name okx#fetchOption
  * @description fetches option data that is commonly found in an option chain
  * @see https://www.okx.com/docs-v5/en/#order-book-trading-market-data-get-ticker
+ * @see https://www.okx.com/docs-v5/en/#public-data-rest-api-get-option-market-data
+ * @see https://www.okx.com/docs-v5/en/#public-data-rest-api-get-mark-price
+ * @see https://www.okx.com/docs-v5/en/#public-data-rest-api-get-open-interest
  * @param {string} symbol unified market symbol
  * @param {object} [params] extra parameters specific to the exchange API endpoint
  * @returns {object} an [option chain structure]{@link https://docs.ccxt.com/?id=option-chain-structure}
@@ -2844,9 +2917,6 @@ func (this *Okx) FetchBidsAsks(options ...FetchBidsAsksOptions) (Tickers, error)
 func (this *Okx) FetchBorrowRate(code string, amount float64, options ...FetchBorrowRateOptions) (map[string]interface{}, error) {
 	return this.exchangeTyped.FetchBorrowRate(code, amount, options...)
 }
-func (this *Okx) FetchCanceledAndClosedOrders(options ...FetchCanceledAndClosedOrdersOptions) ([]Order, error) {
-	return this.exchangeTyped.FetchCanceledAndClosedOrders(options...)
-}
 func (this *Okx) FetchDepositAddresses(options ...FetchDepositAddressesOptions) ([]DepositAddress, error) {
 	return this.exchangeTyped.FetchDepositAddresses(options...)
 }
@@ -2925,9 +2995,6 @@ func (this *Okx) FetchPositionsRisk(options ...FetchPositionsRiskOptions) ([]Pos
 func (this *Okx) FetchPremiumIndexOHLCV(symbol string, options ...FetchPremiumIndexOHLCVOptions) ([]OHLCV, error) {
 	return this.exchangeTyped.FetchPremiumIndexOHLCV(symbol, options...)
 }
-func (this *Okx) FetchTradingFees(params ...interface{}) (TradingFees, error) {
-	return this.exchangeTyped.FetchTradingFees(params...)
-}
 func (this *Okx) FetchTradingLimits(options ...FetchTradingLimitsOptions) (map[string]interface{}, error) {
 	return this.exchangeTyped.FetchTradingLimits(options...)
 }
@@ -5179,6 +5179,8 @@ func (this *PhemexCore) SetLeverage(leverage interface{}, optionalArgs ...interf
 		_ = symbol
 		params := GetArg(optionalArgs, 1, map[string]interface{}{})
 		_ = params
+		retRes51827 := (<-this.CheckLeverage(leverage, symbol, params))
+		PanicOnError(retRes51827)
 		if IsTrue(IsEqual(symbol, nil)) {
 			panic(ArgumentsRequired(Add(this.Id, " setLeverage() requires a symbol argument")))
 		}
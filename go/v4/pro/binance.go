@@ -77,7 +77,7 @@ func  (this *BinanceCore) DescribeData() interface{}  {
             "unWatchTradesForSymbols": true,
             "unWatchMyTrades": false,
             "unWatchOrders": false,
-            "unWatchPositions": false,
+            "unWatchPositions": true,
             "unWatchMarkPrices": true,
             "unWatchMarkPrice": true,
         },
@@ -179,9 +179,13 @@ func  (this *BinanceCore) DescribeData() interface{}  {
                 "awaitPositionsSnapshot": true,
             },
             "wallet": "wb",
-            "listenKeyRefreshRate": 1200000,
+            "listenKeyRefreshRate": 1200000, // milliseconds between two keepalive requests of the listenKey, they expire after 60 minutes
             "ws": map[string]interface{} {
                 "cost": 5,
+                "orderThrottle": map[string]interface{} {
+                    "rateLimit": 200, // 50 orders every 10 seconds
+                    "cost": 1,
+                },
             },
             "tickerChannelsMap": map[string]interface{} {
                 "24hrTicker": "ticker",
@@ -424,7 +428,8 @@ func  (this *BinanceCore) HandleLiquidation(client interface{}, message interfac
     var market interface{} = this.SafeMarket(marketId, nil, "", "contract")
     var symbol interface{} = ccxt.GetValue(market, "symbol")
     var liquidation interface{} = this.ParseWsLiquidation(rawLiquidation, market)
-    if ccxt.IsTrue(ccxt.IsEqual(this.Liquidations, nil)) {
+    // the exchange starts with a sync.Map placeholder, the array cache is created on the first liquidation
+    if _, ok := this.Liquidations.(ccxt.Appender); !ok {
         var limit interface{} = this.SafeInteger(this.Options, "liquidationsLimit", 1000)
         this.Liquidations = ccxt.NewArrayCache(limit)
     }
@@ -513,18 +518,33 @@ func  (this *BinanceCore) ParseWsLiquidation(liquidation interface{}, optionalAr
     var marketId interface{} = this.SafeString(liquidation, "s")
     market = this.SafeMarket(marketId, market, nil, "swap")
     var timestamp interface{} = this.SafeInteger(liquidation, "T")
+    var contracts interface{} = this.SafeString(liquidation, "l")
+    var contractSize interface{} = this.SafeString(market, "contractSize")
+    var price interface{} = this.SafeString(liquidation, "ap")
+    var baseValue interface{} = nil
+    var quoteValue interface{} = nil
+    if ccxt.IsTrue(ccxt.IsTrue(ccxt.IsTrue(!ccxt.IsEqual(contracts, nil)) && ccxt.IsTrue(!ccxt.IsEqual(contractSize, nil))) && ccxt.IsTrue(!ccxt.IsEqual(price, nil))) {
+        if ccxt.IsTrue(this.SafeBool(market, "inverse", false)) {
+            // coin-m contracts are worth contractSize in the quote currency
+            quoteValue = ccxt.Precise.StringMul(contracts, contractSize)
+            baseValue = ccxt.Precise.StringDiv(quoteValue, price)
+        } else {
+            baseValue = ccxt.Precise.StringMul(contracts, contractSize)
+            quoteValue = ccxt.Precise.StringMul(baseValue, price)
+        }
+    }
     return this.SafeLiquidation(map[string]interface{} {
         "info": liquidation,
         "symbol": this.SafeSymbol(marketId, market),
-        "contracts": this.SafeNumber(liquidation, "l"),
-        "contractSize": this.SafeNumber(market, "contractSize"),
-        "price": this.SafeNumber(liquidation, "ap"),
+        "contracts": this.ParseNumber(contracts),
+        "contractSize": this.ParseNumber(contractSize),
+        "price": this.ParseNumber(price),
         "side": this.SafeStringLower(liquidation, "S"),
-        "baseValue": nil,
-        "quoteValue": nil,
+        "baseValue": this.ParseNumber(baseValue),
+        "quoteValue": this.ParseNumber(quoteValue),
         "timestamp": timestamp,
         "datetime": this.Iso8601(timestamp),
-    })
+    }, market)
 }
 /**
  * @method
@@ -679,7 +699,7 @@ func  (this *BinanceCore) HandleMyLiquidation(client interface{}, message interf
     var symbol interface{} = this.SafeSymbol(marketId, market)
     var liquidation interface{} = this.ParseWsLiquidation(message, market)
     var cache interface{} = this.MyLiquidations
-    if ccxt.IsTrue(ccxt.IsEqual(cache, nil)) {
+    if _, ok := cache.(ccxt.Appender); !ok {
         var limit interface{} = this.SafeInteger(this.Options, "myLiquidationsLimit", 1000)
         cache = ccxt.NewArrayCache(limit)
     }
@@ -1088,8 +1108,8 @@ func  (this *BinanceCore) FetchOrderBookSnapshot(client interface{}, message int
                     }
                     var orderbook interface{} = ccxt.GetValue(this.Orderbooks, symbol)
                     orderbook.(ccxt.OrderBookInterface).Reset(snapshot)
-                    // unroll the accumulated deltas
-                    var messages interface{} = orderbook.(ccxt.OrderBookInterface).GetCache()
+                    // unroll the accumulated deltas, GetCache points at the field SetCache replaces so it is copied first
+                    var messages interface{} = *orderbook.(ccxt.OrderBookInterface).GetCache()
                     orderbook.(ccxt.OrderBookInterface).SetCache([]interface{}{})
                     for i := 0; ccxt.IsLessThan(i, ccxt.GetArrayLength(messages)); i++ {
                         var messageItem interface{} = ccxt.GetValue(messages, i)
@@ -1230,7 +1250,8 @@ func  (this *BinanceCore) HandleOrderBook(client interface{}, message interface{
                             } else {
                                 var checksum interface{} = this.HandleOption("watchOrderBook", "checksum", true)
                                 if ccxt.IsTrue(checksum) {
-                                    panic(ccxt.ChecksumError(ccxt.Add(ccxt.Add(this.Id, " "), this.OrderbookChecksumMessage(symbol))))
+                                    // a sequence gap, the book is rebuilt from a new snapshot
+                                    this.ResnapshotOrderBook(client, message, symbol)
                                 }
                             }
                         }
@@ -1248,7 +1269,8 @@ func  (this *BinanceCore) HandleOrderBook(client interface{}, message interface{
                             } else {
                                 var checksum interface{} = this.HandleOption("watchOrderBook", "checksum", true)
                                 if ccxt.IsTrue(checksum) {
-                                    panic(ccxt.ChecksumError(ccxt.Add(ccxt.Add(this.Id, " "), this.OrderbookChecksumMessage(symbol))))
+                                    // a sequence gap, the book is rebuilt from a new snapshot
+                                    this.ResnapshotOrderBook(client, message, symbol)
                                 }
                             }
                         }
@@ -1259,6 +1281,18 @@ func  (this *BinanceCore) HandleOrderBook(client interface{}, message interface{
                 }
     }
 }
+// ResnapshotOrderBook recovers the book of symbol from a sequence gap, it is emptied and its nonce cleared
+// so the diff that revealed the gap and the next ones are buffered until a new rest snapshot replays them
+func  (this *BinanceCore) ResnapshotOrderBook(client interface{}, message interface{}, symbol interface{})  {
+    var orderbook interface{} = ccxt.GetValue(this.Orderbooks, symbol)
+    orderbook.(ccxt.OrderBookInterface).Reset(map[string]interface{} {})
+    orderbook.(ccxt.OrderBookInterface).SetCache([]interface{}{message})
+    var subscription interface{} = this.SafeDict(client.(ccxt.ClientInterface).GetSubscriptions(), ccxt.Add("orderbook::", symbol), map[string]interface{} {})
+    subscription = this.Extend(subscription, map[string]interface{} {
+        "symbol": symbol,
+    })
+    this.Spawn(this.FetchOrderBookSnapshot, client, message, subscription)
+}
 func  (this *BinanceCore) HandleOrderBookSubscription(client interface{}, message interface{}, subscription interface{})  {
     var defaultLimit interface{} = this.SafeInteger(this.Options, "watchOrderBookLimit", 1000)
     // const messageHash = this.safeString (subscription, 'messageHash')
@@ -1305,7 +1339,7 @@ func  (this *BinanceCore) HandleUnSubscription(client interface{}, subscription
     for j := 0; ccxt.IsLessThan(j, ccxt.GetArrayLength(messageHashes)); j++ {
         var unsubHash interface{} = ccxt.GetValue(messageHashes, j)
         var subHash interface{} = ccxt.GetValue(subMessageHashes, j)
-        this.CleanUnsubscription(client.(*ccxt.Client), subHash, unsubHash)
+        this.CleanUnsubscription(client.(*ccxt.Client), subHash, unsubHash, this.SafeBool(subscription, "subHashIsPrefix", false))
     }
     this.CleanCache(subscription)
 }
@@ -2492,7 +2526,9 @@ func  (this *BinanceCore) UnWatchTicker(symbol interface{}, optionalArgs ...inte
                     params := ccxt.GetArg(optionalArgs, 0, map[string]interface{} {})
             _ = params
         
-                retRes201415 :=  (<-this.UnWatchTickers([]interface{}{symbol}, params))
+                retRes201415 :=  (<-this.UnWatchTickers([]interface{}{symbol}, this.Extend(params, map[string]interface{} {
+                "callerMethodName": "watchTicker",
+            })))
                 ccxt.PanicOnError(retRes201415)
                 ch <- retRes201415
                 return nil
@@ -3223,12 +3259,24 @@ func  (this *BinanceCore) Authenticate(optionalArgs ...interface{}) <- chan inte
             "listenKey": this.SafeString(response, "listenKey"),
             "lastAuthenticatedTime": time,
         }))
-                this.Delay(listenKeyRefreshRate, this.KeepAliveListenKey, params)
+                // the type is kept for the keepalive, the defaultType may differ from the authenticated stream
+                this.Delay(listenKeyRefreshRate, this.KeepAliveListenKey, this.Extend(params, map[string]interface{} {
+                    "type": typeVar,
+                }))
             }
                 return nil
             }()
             return ch
         }
+/**
+ * @method
+ * @name binance#keepAliveListenKey
+ * @description refreshes the listenKey of a user data stream every options.listenKeyRefreshRate milliseconds while the stream is subscribed. An expired listenKey is replaced by a new one right away, but the watchers of the expired stream are rejected instead of being moved to the new one: the listenKey is part of the stream url and no subscribe message is sent, so there is nothing to replay, the next watch call subscribes to the stream of the new listenKey
+ * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @param {string} [params.type] the market type of the authenticated stream, defaults to the defaultType
+ * @param {boolean} [params.portfolioMargin] set to true if the stream belongs to a portfolio margin account
+ * @returns {undefined}
+ */
 func  (this *BinanceCore) KeepAliveListenKey(optionalArgs ...interface{}) <- chan interface{} {
             ch := make(chan interface{})
             go func() interface{} {
@@ -3264,6 +3312,7 @@ func  (this *BinanceCore) KeepAliveListenKey(optionalArgs ...interface{}) <- cha
             var request interface{} = map[string]interface{} {}
             params = this.Omit(params, []interface{}{"type", "symbol"})
             var time interface{} = this.Milliseconds()
+            var expired interface{} = false
             
                 {
                      func(this *BinanceCore) (ret_ interface{}) {
@@ -3290,6 +3339,7 @@ func  (this *BinanceCore) KeepAliveListenKey(optionalArgs ...interface{}) <- cha
                 "listenKey": nil,
                 "lastAuthenticatedTime": 0,
             }))
+                    expired = true
             
                     return nil
                                     
@@ -3322,6 +3372,21 @@ func  (this *BinanceCore) KeepAliveListenKey(optionalArgs ...interface{}) <- cha
             	    }(this)
                 
                     }
+            if ccxt.IsTrue(expired) {
+                // the listenKey expired, a new one is created right away and kept alive in turn,
+                // the rejected watchers subscribe to the stream of the new listenKey on their next call, see the docblock
+                var authenticateParams interface{} = this.Extend(params, map[string]interface{} {
+                    "type": typeVar,
+                })
+                if ccxt.IsTrue(isPortfolioMargin) {
+                    ccxt.AddElementToObject(authenticateParams, "portfolioMargin", true)
+                }
+        
+                retRes267816 := (<-this.Authenticate(authenticateParams))
+                ccxt.PanicOnError(retRes267816)
+        
+                return nil
+            }
             ccxt.AddElementToObject(this.Options, typeVar, this.Extend(options, map[string]interface{} {
             "listenKey": listenKey,
             "lastAuthenticatedTime": time,
@@ -3335,7 +3400,9 @@ func  (this *BinanceCore) KeepAliveListenKey(optionalArgs ...interface{}) <- cha
                 for j := 0; ccxt.IsLessThan(j, ccxt.GetArrayLength(subscriptionKeys)); j++ {
                     var subscribeType interface{} = ccxt.GetValue(subscriptionKeys, j)
                     if ccxt.IsTrue(ccxt.IsEqual(subscribeType, typeVar)) {
-                        this.Delay(listenKeyRefreshRate, this.KeepAliveListenKey, params)
+                        this.Delay(listenKeyRefreshRate, this.KeepAliveListenKey, this.Extend(params, map[string]interface{} {
+                            "type": typeVar,
+                        }))
         
                         return nil
                     }
@@ -3945,6 +4012,10 @@ func  (this *BinanceCore) CreateOrderWs(symbol interface{}, typeVar interface{},
             var subscription interface{} = map[string]interface{} {
                 "method": this.HandleOrderWs,
             }
+            // bursts of orders are spaced by the ws order rule, order.place has a weight of 1
+        
+                retRes322314 :=  (<-this.ThrottleWsOrder(nil))
+                ccxt.PanicOnError(retRes322314)
         
                 retRes322315 :=  (<-this.Watch(url, messageHash, message, messageHash, subscription))
                 ccxt.PanicOnError(retRes322315)
@@ -5088,6 +5159,84 @@ func  (this *BinanceCore) WatchPositions(optionalArgs ...interface{}) <- chan in
             ch <- this.FilterBySymbolsSinceLimit(cache, symbols, since, limit, true)
             return nil
         
+            }()
+            return ch
+        }
+/**
+ * @method
+ * @name binance#unWatchPositions
+ * @description unWatches all open positions or the positions of a list of symbols
+ * @param {string[]|undefined} [symbols] list of unified market symbols, all positions are unwatched if not set
+ * @param {object} params extra parameters specific to the exchange API endpoint
+ * @param {boolean} [params.portfolioMargin] set to true if you would like to unwatch positions in a portfolio margin account
+ * @returns {any} status of the unwatch request
+ */
+func  (this *BinanceCore) UnWatchPositions(optionalArgs ...interface{}) <- chan interface{} {
+            ch := make(chan interface{})
+            go func() interface{} {
+                defer close(ch)
+                defer ccxt.ReturnPanicError(ch)
+                    symbols := ccxt.GetArg(optionalArgs, 0, nil)
+            _ = symbols
+            params := ccxt.GetArg(optionalArgs, 1, map[string]interface{} {})
+            _ = params
+        
+            retRes41488 := (<-this.LoadMarkets())
+            ccxt.PanicOnError(retRes41488)
+            var market interface{} = nil
+            var messageHash interface{} = ""
+            symbols = this.MarketSymbols(symbols)
+            if !ccxt.IsTrue(this.IsEmpty(symbols)) {
+                market = this.GetMarketFromSymbols(symbols)
+                messageHash = ccxt.Add("::", ccxt.Join(symbols, ","))
+            }
+            var typeVar interface{} = nil
+            typeVarparamsVariable := this.HandleMarketTypeAndParams("unWatchPositions", market, params)
+            typeVar = ccxt.GetValue(typeVarparamsVariable,0)
+            params = ccxt.GetValue(typeVarparamsVariable,1)
+            if ccxt.IsTrue(ccxt.IsTrue(ccxt.IsEqual(typeVar, "spot")) || ccxt.IsTrue(ccxt.IsEqual(typeVar, "margin"))) {
+                typeVar = "future"
+            }
+            var subType interface{} = nil
+            subTypeparamsVariable := this.HandleSubTypeAndParams("unWatchPositions", market, params)
+            subType = ccxt.GetValue(subTypeparamsVariable,0)
+            params = ccxt.GetValue(subTypeparamsVariable,1)
+            if ccxt.IsTrue(this.IsLinear(typeVar, subType)) {
+                typeVar = "future"
+            } else if ccxt.IsTrue(this.IsInverse(typeVar, subType)) {
+                typeVar = "delivery"
+            }
+            var isPortfolioMargin interface{} = nil
+            isPortfolioMarginparamsVariable := this.HandleOptionAndParams2(params, "watchPositions", "papi", "portfolioMargin", false)
+            isPortfolioMargin = ccxt.GetValue(isPortfolioMarginparamsVariable,0)
+            params = ccxt.GetValue(isPortfolioMarginparamsVariable,1)
+            var urlType interface{} = typeVar
+            if ccxt.IsTrue(isPortfolioMargin) {
+                urlType = "papi"
+            }
+            var url interface{} = this.GetPrivateWsUrl(urlType, ccxt.GetValue(ccxt.GetValue(this.Options, typeVar), "listenKey"))
+            if !ccxt.IsTrue((ccxt.InOp(this.Clients, url))) {
+        
+                ch <- true
+                return nil
+            }
+            // the user data stream is shared with the balance and order updates and has no per-channel
+            // subscriptions, so the positions are unwatched locally and the stream is left open
+            var subMessageHash interface{} = ccxt.Add(ccxt.Add(typeVar, ":positions"), messageHash)
+            var subscription interface{} = map[string]interface{} {
+                "unsubscribe": true,
+                "subMessageHashes": []interface{}{subMessageHash},
+                "messageHashes": []interface{}{ccxt.Add("unsubscribe:", subMessageHash)},
+                "subHashIsPrefix": this.IsEmpty(symbols),
+                "symbols": symbols,
+                "topic": "positions",
+            }
+            var client interface{} = this.Client(url)
+            this.HandleUnSubscription(client.(*ccxt.WSClient).Client, subscription)
+        
+            ch <- true
+            return nil
+        
             }()
             return ch
         }
@@ -5735,6 +5884,35 @@ func  (this *BinanceCore) HandleEventStreamTerminated(client interface{}, messag
         client.(ccxt.ClientInterface).Reject(message, accountType)
     }
 }
+// ClassifyWsError ends the user data stream once its listenKey expired, the pending watchers are
+// rejected with an AuthenticationError and the next watch requests a new listenKey
+func  (this *BinanceCore) ClassifyWsError(client interface{}, message interface{}) interface{}  {
+    //
+    //    {
+    //        "e": "listenKeyExpired",
+    //        "E": 1576653824250,
+    //        "listenKey": "WsCMN0a4KHUPTQuX6IUnqEZfB1inxmv1qR4kbf1LuEjur5VdbzqvyxqG9TSjVVxv"
+    //    }
+    //
+    var eventMsg interface{} = this.SafeDict(message, "event")
+    if ccxt.IsTrue(!ccxt.IsEqual(eventMsg, nil)) {
+        message = eventMsg
+    }
+    if ccxt.IsTrue(!ccxt.IsEqual(this.SafeString(message, "e"), "listenKeyExpired")) {
+        return nil
+    }
+    var listenKey interface{} = this.SafeString(message, "listenKey")
+    var accountType interface{} = this.GetAccountTypeFromSubscriptions(ccxt.ObjectKeys(client.(ccxt.ClientInterface).GetSubscriptions()))
+    var options interface{} = this.SafeDict(this.Options, accountType)
+    if ccxt.IsTrue(ccxt.IsTrue(!ccxt.IsEqual(options, nil)) && ccxt.IsTrue(ccxt.IsEqual(this.SafeString(options, "listenKey"), listenKey))) {
+        // forget the expired listenKey so that authenticate() requests a new one
+        ccxt.AddElementToObject(this.Options, accountType, this.Extend(options, map[string]interface{} {
+            "listenKey": nil,
+            "lastAuthenticatedTime": 0,
+        }))
+    }
+    return ccxt.AuthenticationError(ccxt.Add(ccxt.Add(this.Id, " listenKey expired "), listenKey))
+}
 func  (this *BinanceCore) HandleMessage(client interface{}, message interface{})  {
     // handle WebSocketAPI
     var eventMsg interface{} = this.SafeDict(message, "event")
@@ -0,0 +1,867 @@
+package ccxtpro
+
+import (
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	ccxt "github.com/ccxt/ccxt/go/v4"
+	"github.com/gorilla/websocket"
+)
+
+// ---------------------------------------------------------------------------
+// unWatchPositions: the user data stream stops delivering positions
+// ---------------------------------------------------------------------------
+
+func sendAccountUpdate(t *testing.T, conn *websocket.Conn, amount string) {
+	message := `{"e":"ACCOUNT_UPDATE","T":1667881353112,"E":1667881353115,"a":{"B":[],"P":[` +
+		`{"s":"BTCUSDT","pa":"` + amount + `","ep":"19700.03933","cr":"0","up":"1.5","mt":"cross","iw":"0","ps":"BOTH","ma":"USDT"},` +
+		`{"s":"ETHUSDT","pa":"` + amount + `","ep":"1500.1","cr":"0","up":"0.5","mt":"cross","iw":"0","ps":"BOTH","ma":"USDT"}` +
+		`],"m":"ORDER"}}`
+	writeFrame(t, conn, message)
+}
+
+func newUserDataStreamBinance(t *testing.T, server *wsTestServer) *Binance {
+	exchange := NewBinance(map[string]interface{}{
+		"apiKey": "key",
+		"secret": "secret",
+		"options": map[string]interface{}{
+			"defaultType": "future",
+			"future": map[string]interface{}{
+				"listenKey":             "listenKey",
+				"lastAuthenticatedTime": time.Now().UnixMilli(),
+			},
+			"watchPositions": map[string]interface{}{
+				"fetchPositionsSnapshot": false,
+			},
+		},
+	})
+	// plain ws:// urls are only accepted with an http agent
+	exchange.HttpProxy = &http.Transport{}
+	ws := ccxt.GetValue(ccxt.GetValue(exchange.Urls, "api"), "ws")
+	ccxt.AddElementToObject(ws, "future", server.wsUrl()+"/ws")
+	markets := []interface{}{}
+	for _, base := range []string{"BTC", "ETH"} {
+		markets = append(markets, exchange.SafeMarketStructure(map[string]interface{}{
+			"id":           base + "USDT",
+			"symbol":       base + "/USDT:USDT",
+			"base":         base,
+			"quote":        "USDT",
+			"settle":       "USDT",
+			"baseId":       base,
+			"quoteId":      "USDT",
+			"settleId":     "USDT",
+			"type":         "swap",
+			"swap":         true,
+			"contract":     true,
+			"linear":       true,
+			"contractSize": 1.0,
+			"active":       true,
+		}))
+	}
+	exchange.SetMarkets(markets)
+	t.Cleanup(func() { exchange.Close() })
+	return exchange
+}
+
+type watchResult struct {
+	positions []ccxt.Position
+	err       error
+}
+
+func watchPositionsAsync(exchange *Binance, options ...ccxt.WatchPositionsOptions) chan watchResult {
+	results := make(chan watchResult, 1)
+	go func() {
+		positions, err := exchange.WatchPositions(options...)
+		results <- watchResult{positions, err}
+	}()
+	return results
+}
+
+// waitForFuture blocks until a watcher is pending on the given message hash
+func waitForFuture(t *testing.T, exchange *Binance, messageHash string) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		for _, client := range exchange.Clients {
+			if _, ok := client.(ccxt.ClientInterface).GetFutures()[messageHash]; ok {
+				return
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for a watcher on %s", messageHash)
+}
+
+func receive(t *testing.T, results chan watchResult) watchResult {
+	select {
+	case result := <-results:
+		return result
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for watchPositions")
+	}
+	return watchResult{}
+}
+
+func assertUnsubscribed(t *testing.T, result watchResult) {
+	if err, ok := result.err.(*ccxt.Error); !ok || err.Type != "UnsubscribeError" {
+		t.Fatalf("expected an UnsubscribeError, got %v (%d positions)", result.err, len(result.positions))
+	}
+}
+
+func TestBinanceUnWatchPositions(t *testing.T) {
+	server := newWsTestServer(t)
+	exchange := newUserDataStreamBinance(t, server)
+
+	results := watchPositionsAsync(exchange)
+	conn := server.accept(t)
+	waitForFuture(t, exchange, "future:positions")
+	sendAccountUpdate(t, conn, "0.1")
+	result := receive(t, results)
+	if result.err != nil || len(result.positions) != 2 {
+		t.Fatalf("expected 2 positions, got %v, %v", result.positions, result.err)
+	}
+
+	results = watchPositionsAsync(exchange)
+	waitForFuture(t, exchange, "future:positions")
+	if res, err := exchange.UnWatchPositions(); err != nil || res != true {
+		t.Fatalf("expected the unwatch to succeed, got %v, %v", res, err)
+	}
+	// the pending watcher is released with an error instead of the positions of the next update
+	sendAccountUpdate(t, conn, "0.2")
+	assertUnsubscribed(t, receive(t, results))
+}
+
+// ---------------------------------------------------------------------------
+// listenKeyExpired: the user data stream ends with an AuthenticationError
+// ---------------------------------------------------------------------------
+
+func TestBinanceListenKeyExpired(t *testing.T) {
+	server := newWsTestServer(t)
+	exchange := newUserDataStreamBinance(t, server)
+
+	results := watchPositionsAsync(exchange)
+	conn := server.accept(t)
+	waitForFuture(t, exchange, "future:positions")
+	writeFrame(t, conn, `{"e":"listenKeyExpired","E":1576653824250,"listenKey":"listenKey"}`)
+	result := receive(t, results)
+	if !ccxt.IsErrorType(result.err, "AuthenticationError") {
+		t.Fatalf("expected an AuthenticationError, got %v", result.err)
+	}
+	// the expired listenKey is forgotten so that the next watch requests a new one
+	options := ccxt.GetValue(exchange.Options, "future")
+	if ccxt.GetValue(options, "listenKey") != nil || ccxt.GetValue(options, "lastAuthenticatedTime") != 0 {
+		t.Fatalf("expected the listenKey to be cleared, got %v", options)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// keepAliveListenKey: the listenKey is refreshed on an interval and recreated once expired
+// ---------------------------------------------------------------------------
+
+// listenKeyTransport serves the listenKey endpoints, a PUT fails while expired is set
+type listenKeyTransport struct {
+	mu        sync.Mutex
+	created   int
+	keepAlive int
+	expired   bool
+}
+
+func (l *listenKeyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	status, body := 200, `{}`
+	switch req.Method {
+	case http.MethodPost:
+		l.created++
+		body = `{"listenKey":"listenKey` + strconv.Itoa(l.created) + `"}`
+	case http.MethodPut:
+		l.keepAlive++
+		if l.expired {
+			l.expired = false
+			status, body = 400, `{"code":-1125,"msg":"This listenKey does not exist."}`
+		}
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func (l *listenKeyTransport) counts() (int, int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.created, l.keepAlive
+}
+
+func waitForListenKey(t *testing.T, exchange *Binance, listenKey string) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if exchange.SafeString(ccxt.GetValue(exchange.Options, "future"), "listenKey") == listenKey {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for listenKey %s", listenKey)
+}
+
+func TestBinanceKeepAliveListenKey(t *testing.T) {
+	exchange := NewBinance(map[string]interface{}{
+		"apiKey": "key",
+		"secret": "secret",
+		"options": map[string]interface{}{
+			"listenKeyRefreshRate": 50,
+		},
+	})
+	transport := &listenKeyTransport{}
+	exchange.SetHTTPClient(&http.Client{Transport: transport})
+	exchange.SetMarkets([]interface{}{})
+	// a user data stream of the futures keeps being refreshed
+	client := exchange.Client(exchange.GetPrivateWsUrl("future", "listenKey1"))
+	ccxt.AddElementToObject(client.GetSubscriptions(), "future", true)
+	t.Cleanup(func() { exchange.Close() })
+
+	// the default type is spot, the keepalive follows the type of the authenticated stream
+	if res := <-exchange.Core.Authenticate(map[string]interface{}{"type": "future"}); ccxt.IsError(res) {
+		t.Fatal(res)
+	}
+	waitForListenKey(t, exchange, "listenKey1")
+	deadline := time.Now().Add(5 * time.Second)
+	for _, keepAlive := transport.counts(); keepAlive < 2; _, keepAlive = transport.counts() {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the listenKey to be kept alive on the interval, got %d requests", keepAlive)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// an expired listenKey is recreated
+	transport.mu.Lock()
+	transport.expired = true
+	transport.mu.Unlock()
+	waitForListenKey(t, exchange, "listenKey2")
+	if created, _ := transport.counts(); created != 2 {
+		t.Fatalf("expected a single new listenKey, got %d", created)
+	}
+}
+
+func TestBinanceUnWatchPositionsForSymbols(t *testing.T) {
+	server := newWsTestServer(t)
+	exchange := newUserDataStreamBinance(t, server)
+
+	btc := watchPositionsAsync(exchange, ccxt.WithWatchPositionsSymbols([]string{"BTC/USDT:USDT"}))
+	conn := server.accept(t)
+	waitForFuture(t, exchange, "future:positions::BTC/USDT:USDT")
+	all := watchPositionsAsync(exchange)
+	waitForFuture(t, exchange, "future:positions")
+
+	if _, err := exchange.UnWatchPositions(ccxt.WithUnWatchPositionsSymbols([]string{"BTC/USDT:USDT"})); err != nil {
+		t.Fatal(err)
+	}
+	assertUnsubscribed(t, receive(t, btc))
+
+	// the positions of every symbol are still delivered to the other watchers
+	sendAccountUpdate(t, conn, "0.3")
+	result := receive(t, all)
+	if result.err != nil || len(result.positions) != 2 {
+		t.Fatalf("expected 2 positions, got %v, %v", result.positions, result.err)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// watchLiquidations: force orders of USD-M and COIN-M futures
+// ---------------------------------------------------------------------------
+
+func newLiquidationsBinance(t *testing.T, server *wsTestServer) *Binance {
+	exchange := NewBinance(map[string]interface{}{})
+	exchange.HttpProxy = &http.Transport{}
+	ws := ccxt.GetValue(ccxt.GetValue(exchange.Urls, "api"), "ws")
+	ccxt.AddElementToObject(ws, "future", server.wsUrl()+"/fstream/ws")
+	ccxt.AddElementToObject(ws, "delivery", server.wsUrl()+"/dstream/ws")
+	exchange.SetMarkets([]interface{}{
+		exchange.SafeMarketStructure(map[string]interface{}{
+			"id":           "BTCUSDT",
+			"lowercaseId":  "btcusdt",
+			"symbol":       "BTC/USDT:USDT",
+			"base":         "BTC",
+			"quote":        "USDT",
+			"settle":       "USDT",
+			"baseId":       "BTC",
+			"quoteId":      "USDT",
+			"settleId":     "USDT",
+			"type":         "swap",
+			"swap":         true,
+			"contract":     true,
+			"linear":       true,
+			"inverse":      false,
+			"contractSize": 1.0,
+			"active":       true,
+		}),
+		exchange.SafeMarketStructure(map[string]interface{}{
+			"id":           "BTCUSD_200925",
+			"lowercaseId":  "btcusd_200925",
+			"symbol":       "BTC/USD:BTC-200925",
+			"base":         "BTC",
+			"quote":        "USD",
+			"settle":       "BTC",
+			"baseId":       "BTC",
+			"quoteId":      "USD",
+			"settleId":     "BTC",
+			"type":         "future",
+			"future":       true,
+			"contract":     true,
+			"linear":       false,
+			"inverse":      true,
+			"contractSize": 100.0,
+			"expiry":       1601020800000,
+			"active":       true,
+		}),
+	})
+	t.Cleanup(func() { exchange.Close() })
+	return exchange
+}
+
+var binanceForceOrders = []struct {
+	symbol     string
+	stream     string
+	path       string
+	message    string
+	contracts  float64
+	price      float64
+	side       string
+	baseValue  float64
+	quoteValue float64
+	timestamp  int64
+}{
+	{
+		"BTC/USDT:USDT", "btcusdt@forceOrder", "/fstream/",
+		`{"e":"forceOrder","E":1698871323061,"o":{"s":"BTCUSDT","S":"BUY","o":"LIMIT","f":"IOC","q":"1.437","p":"35100.81","ap":"34959.70","X":"FILLED","l":"1.437","z":"1.437","T":1698871323059}}`,
+		1.437, 34959.7, "buy", 1.437, 50237.0889, 1698871323059,
+	},
+	{
+		"BTC/USD:BTC-200925", "btcusd_200925@forceOrder", "/dstream/",
+		`{"e":"forceOrder","E":1591154240950,"o":{"s":"BTCUSD_200925","ps":"BTCUSD","S":"SELL","o":"LIMIT","f":"IOC","q":"1","p":"9425.5","ap":"9500","X":"FILLED","l":"1","z":"1","T":1591154240949}}`,
+		1, 9500, "sell", 0.010526315789473684, 100, 1591154240949,
+	},
+}
+
+func TestBinanceParseWsLiquidation(t *testing.T) {
+	exchange := newLiquidationsBinance(t, newWsTestServer(t))
+	for _, c := range binanceForceOrders {
+		t.Run(c.symbol, func(t *testing.T) {
+			message := ccxt.ParseJSON(c.message)
+			parsed := exchange.ParseWsLiquidation(ccxt.GetValue(message, "o"), exchange.Market(c.symbol))
+			liquidation := ccxt.NewLiquidation(parsed)
+			if *liquidation.Symbol != c.symbol || *liquidation.Side != c.side {
+				t.Fatalf("unexpected liquidation %v", parsed)
+			}
+			if *liquidation.Contracts != c.contracts || *liquidation.Price != c.price || *liquidation.Timestamp != c.timestamp {
+				t.Fatalf("unexpected contracts, price or timestamp %v", parsed)
+			}
+			if *liquidation.BaseValue != c.baseValue || *liquidation.QuoteValue != c.quoteValue {
+				t.Fatalf("expected base value %v and quote value %v, got %v and %v", c.baseValue, c.quoteValue, *liquidation.BaseValue, *liquidation.QuoteValue)
+			}
+		})
+	}
+}
+
+func TestBinanceWatchLiquidationsForSymbols(t *testing.T) {
+	for _, c := range binanceForceOrders {
+		t.Run(c.symbol, func(t *testing.T) {
+			server := newWsTestServer(t)
+			exchange := newLiquidationsBinance(t, server)
+			type result struct {
+				liquidations []ccxt.Liquidation
+				err          error
+			}
+			results := make(chan result, 1)
+			go func() {
+				liquidations, err := exchange.WatchLiquidationsForSymbols([]string{c.symbol})
+				results <- result{liquidations, err}
+			}()
+			conn, path := server.acceptPath(t)
+			if !strings.HasPrefix(path, c.path) {
+				t.Fatalf("expected the %s endpoint, connected to %s", c.path, path)
+			}
+			frame := readJSONFrame(t, conn)
+			if frame["method"] != "SUBSCRIBE" || !reflect.DeepEqual(frame["params"], []interface{}{c.stream}) {
+				t.Fatalf("unexpected subscription %v", frame)
+			}
+			writeFrame(t, conn, c.message)
+			select {
+			case r := <-results:
+				if r.err != nil || len(r.liquidations) != 1 {
+					t.Fatalf("expected one liquidation, got %v, %v", r.liquidations, r.err)
+				}
+				if *r.liquidations[0].Symbol != c.symbol || *r.liquidations[0].Contracts != c.contracts {
+					t.Fatalf("unexpected liquidation %+v", r.liquidations[0])
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for the liquidation")
+			}
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// createOrderWs: bursts of orders are spaced by the ws order rule
+// ---------------------------------------------------------------------------
+
+func newWsApiBinance(t *testing.T, server *wsTestServer, orderRateLimit float64) *Binance {
+	exchange := NewBinance(map[string]interface{}{
+		"apiKey": "key",
+		"secret": "secret",
+		"options": map[string]interface{}{
+			"ws": map[string]interface{}{
+				"orderThrottle": map[string]interface{}{
+					"rateLimit": orderRateLimit,
+				},
+			},
+		},
+	})
+	exchange.HttpProxy = &http.Transport{}
+	ws := ccxt.GetValue(ccxt.GetValue(exchange.Urls, "api"), "ws")
+	ccxt.AddElementToObject(ccxt.GetValue(ws, "ws-api"), "spot", server.wsUrl()+"/ws-api/v3")
+	exchange.SetMarkets([]interface{}{
+		exchange.SafeMarketStructure(map[string]interface{}{
+			"id":      "BTCUSDT",
+			"symbol":  "BTC/USDT",
+			"base":    "BTC",
+			"quote":   "USDT",
+			"baseId":  "BTC",
+			"quoteId": "USDT",
+			"type":    "spot",
+			"spot":    true,
+			"active":  true,
+			"precision": map[string]interface{}{
+				"amount": 0.00001,
+				"price":  0.01,
+			},
+			"info": map[string]interface{}{
+				"orderTypes": []interface{}{"LIMIT", "MARKET"},
+			},
+		}),
+	})
+	t.Cleanup(func() { exchange.Close() })
+	return exchange
+}
+
+func TestBinanceCreateOrderWsIsPaced(t *testing.T) {
+	server := newWsTestServer(t)
+	exchange := newWsApiBinance(t, server, 150)
+	if rateLimit := exchange.GetWsOrderThrottler().Config["rateLimit"]; rateLimit != 150.0 {
+		t.Fatalf("expected the configured ws order rate limit, got %v", rateLimit)
+	}
+	const orders = 3
+	errs := make(chan error, orders)
+	for i := 0; i < orders; i++ {
+		go func() {
+			_, err := exchange.CreateOrderWs("BTC/USDT", "limit", "buy", 0.001, ccxt.WithCreateOrderWsPrice(25000))
+			errs <- err
+		}()
+	}
+	conn := server.accept(t)
+	received := []time.Time{}
+	for i := 0; i < orders; i++ {
+		frame := readJSONFrame(t, conn)
+		received = append(received, time.Now())
+		if frame["method"] != "order.place" {
+			t.Fatalf("unexpected frame %v", frame)
+		}
+		writeFrame(t, conn, `{"id":"`+ccxt.ToString(frame["id"])+`","status":200,"result":{"symbol":"BTCUSDT","orderId":7663053,`+
+			`"clientOrderId":"x-1","transactTime":1687642291434,"price":"25000.00","origQty":"0.00100","executedQty":"0","status":"NEW",`+
+			`"timeInForce":"GTC","type":"LIMIT","side":"BUY"}}`)
+	}
+	for i := 0; i < orders; i++ {
+		if err := <-errs; err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 1; i < orders; i++ {
+		if gap := received[i].Sub(received[i-1]); gap < 120*time.Millisecond {
+			t.Fatalf("expected the orders to be spaced by the ws order rule, order %d followed after %v", i, gap)
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// duplicate subscriptions: watchers of the same ticker share one subscription
+// ---------------------------------------------------------------------------
+
+func newTickerBinance(t *testing.T, server *wsTestServer) *Binance {
+	exchange := NewBinance(map[string]interface{}{})
+	exchange.HttpProxy = &http.Transport{}
+	ws := ccxt.GetValue(ccxt.GetValue(exchange.Urls, "api"), "ws")
+	ccxt.AddElementToObject(ws, "spot", server.wsUrl()+"/ws")
+	exchange.SetMarkets([]interface{}{
+		exchange.SafeMarketStructure(map[string]interface{}{
+			"id":          "BTCUSDT",
+			"lowercaseId": "btcusdt",
+			"symbol":      "BTC/USDT",
+			"base":        "BTC",
+			"quote":       "USDT",
+			"baseId":      "BTC",
+			"quoteId":     "USDT",
+			"type":        "spot",
+			"spot":        true,
+			"active":      true,
+		}),
+	})
+	t.Cleanup(func() { exchange.Close() })
+	return exchange
+}
+
+type tickerResult struct {
+	ticker ccxt.Ticker
+	err    error
+}
+
+func watchTickerAsync(exchange *Binance) chan tickerResult {
+	results := make(chan tickerResult, 1)
+	go func() {
+		ticker, err := exchange.WatchTicker("BTC/USDT")
+		results <- tickerResult{ticker, err}
+	}()
+	return results
+}
+
+func sendTicker(t *testing.T, conn *websocket.Conn, last string) {
+	writeFrame(t, conn, `{"e":"24hrTicker","E":1698871323061,"s":"BTCUSDT","p":"10.0","P":"0.03","w":"35000.0","o":"34990.0",`+
+		`"h":"35100.0","l":"34900.0","c":"`+last+`","v":"100.0","q":"3500000.0","O":1698784923061,"C":1698871323061,"b":"34999.0","B":"1.0","a":"35001.0","A":"1.0"}`)
+}
+
+func receiveTicker(t *testing.T, results chan tickerResult, last float64) {
+	select {
+	case result := <-results:
+		if result.err != nil || *result.ticker.Last != last {
+			t.Fatalf("expected a ticker at %v, got %+v, %v", last, result.ticker, result.err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for watchTicker")
+	}
+}
+
+// waitForWatchers blocks until count watchers share the subscription of subscribeHash
+func waitForWatchers(t *testing.T, exchange *Binance, subscribeHash string, count int) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		for _, client := range exchange.Clients {
+			if client.(*ccxt.WSClient).WatcherCount(subscribeHash) == count {
+				return
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d watchers on %s", count, subscribeHash)
+}
+
+func TestBinanceWatchTickerSharesSubscription(t *testing.T) {
+	server := newWsTestServer(t)
+	exchange := newTickerBinance(t, server)
+	messageHash := "ticker:ticker@BTC/USDT"
+
+	first := watchTickerAsync(exchange)
+	conn := server.accept(t)
+	frame := readJSONFrame(t, conn)
+	if frame["method"] != "SUBSCRIBE" || !reflect.DeepEqual(frame["params"], []interface{}{"btcusdt@ticker"}) {
+		t.Fatalf("unexpected subscription %v", frame)
+	}
+	second := watchTickerAsync(exchange)
+	waitForWatchers(t, exchange, messageHash, 2)
+	sendTicker(t, conn, "35000.5")
+	receiveTicker(t, first, 35000.5)
+	receiveTicker(t, second, 35000.5)
+
+	// the first unwatch only releases its reference, no message is sent
+	if res, err := exchange.UnWatchTicker("BTC/USDT"); err != nil || res != true {
+		t.Fatalf("expected the unwatch to succeed, got %v, %v", res, err)
+	}
+	second = watchTickerAsync(exchange)
+	waitForFuture(t, exchange, messageHash)
+	sendTicker(t, conn, "35010.5")
+	receiveTicker(t, second, 35010.5)
+
+	// the second unwatch releases the last reference and unsubscribes
+	unwatched := make(chan error, 1)
+	go func() {
+		_, err := exchange.UnWatchTicker("BTC/USDT")
+		unwatched <- err
+	}()
+	frame = readJSONFrame(t, conn)
+	if frame["method"] != "UNSUBSCRIBE" || !reflect.DeepEqual(frame["params"], []interface{}{"btcusdt@ticker"}) {
+		t.Fatalf("expected the unsubscription to follow the subscription, got %v", frame)
+	}
+	writeFrame(t, conn, `{"result":null,"id":`+ccxt.ToString(frame["id"])+`}`)
+	select {
+	case err := <-unwatched:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for unWatchTicker")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// auto-reconnect: a dropped connection is dialed again and its subscriptions replayed
+// ---------------------------------------------------------------------------
+
+func TestBinanceWatchTickerResubscribesAfterDisconnect(t *testing.T) {
+	server := newWsTestServer(t)
+	exchange := newTickerBinance(t, server)
+	exchange.SetReconnectPolicy(10*time.Millisecond, 100*time.Millisecond, 2)
+
+	first := watchTickerAsync(exchange)
+	conn := server.accept(t)
+	subscription := readJSONFrame(t, conn)
+	sendTicker(t, conn, "35000.5")
+	receiveTicker(t, first, 35000.5)
+
+	// the venue drops the connection, the client dials again and sends the same subscription
+	conn.Close()
+	reconnected := server.accept(t)
+	frame := readJSONFrame(t, reconnected)
+	if frame["method"] != "SUBSCRIBE" || !reflect.DeepEqual(frame["params"], subscription["params"]) {
+		t.Fatalf("expected the subscription to be replayed, got %v", frame)
+	}
+	// the replayed subscription goes through the throttler of the client
+	exchange.WsClientsMu.Lock()
+	requests := int64(0)
+	for _, client := range exchange.Clients {
+		for _, metrics := range client.(*ccxt.WSClient).Throttle.(*ccxt.Throttler).GetMetrics() {
+			requests += metrics.Requests
+		}
+	}
+	exchange.WsClientsMu.Unlock()
+	if requests == 0 {
+		t.Fatal("expected the resubscription to be throttled")
+	}
+
+	second := watchTickerAsync(exchange)
+	waitForFuture(t, exchange, "ticker:ticker@BTC/USDT")
+	sendTicker(t, reconnected, "35010.5")
+	receiveTicker(t, second, 35010.5)
+}
+
+// ---------------------------------------------------------------------------
+// watchOrderBookForSymbols: the books of several symbols share one combined stream
+// ---------------------------------------------------------------------------
+
+// depthTransport serves the rest depth snapshots, keyed by the symbol id of the request
+type depthTransport struct {
+	mu        sync.Mutex
+	snapshots map[string]string
+	requests  map[string]int
+}
+
+func (d *depthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	symbol := req.URL.Query().Get("symbol")
+	d.requests[symbol]++
+	return &http.Response{
+		StatusCode: 200,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(d.snapshots[symbol])),
+		Request:    req,
+	}, nil
+}
+
+func (d *depthTransport) setSnapshot(symbol string, snapshot string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.snapshots[symbol] = snapshot
+}
+
+func (d *depthTransport) count(symbol string) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.requests[symbol]
+}
+
+func newOrderBookBinance(t *testing.T, server *wsTestServer, snapshots map[string]string) (*Binance, *depthTransport) {
+	exchange := NewBinance(map[string]interface{}{})
+	exchange.HttpProxy = &http.Transport{}
+	ws := ccxt.GetValue(ccxt.GetValue(exchange.Urls, "api"), "ws")
+	ccxt.AddElementToObject(ws, "spot", server.wsUrl()+"/ws")
+	transport := &depthTransport{snapshots: snapshots, requests: map[string]int{}}
+	exchange.SetHTTPClient(&http.Client{Transport: transport})
+	markets := []interface{}{}
+	for _, base := range []string{"BTC", "ETH"} {
+		markets = append(markets, exchange.SafeMarketStructure(map[string]interface{}{
+			"id":          base + "USDT",
+			"lowercaseId": strings.ToLower(base) + "usdt",
+			"symbol":      base + "/USDT",
+			"base":        base,
+			"quote":       "USDT",
+			"baseId":      base,
+			"quoteId":     "USDT",
+			"type":        "spot",
+			"spot":        true,
+			"active":      true,
+		}))
+	}
+	exchange.SetMarkets(markets)
+	t.Cleanup(func() { exchange.Close() })
+	return exchange, transport
+}
+
+type orderBookResult struct {
+	orderBook ccxt.OrderBook
+	err       error
+}
+
+func watchOrderBookForSymbolsAsync(exchange *Binance, symbols []string) chan orderBookResult {
+	results := make(chan orderBookResult, 1)
+	go func() {
+		orderBook, err := exchange.WatchOrderBookForSymbols(symbols)
+		results <- orderBookResult{orderBook, err}
+	}()
+	return results
+}
+
+func receiveOrderBook(t *testing.T, results chan orderBookResult) ccxt.OrderBook {
+	select {
+	case result := <-results:
+		if result.err != nil {
+			t.Fatal(result.err)
+		}
+		return result.orderBook
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for watchOrderBookForSymbols")
+	}
+	return ccxt.OrderBook{}
+}
+
+// waitForNonce blocks until the local book of symbol reached nonce
+func waitForNonce(t *testing.T, exchange *Binance, symbol string, nonce int64) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if orderbook := exchange.SafeValue(exchange.Orderbooks, symbol); orderbook != nil {
+			if current := exchange.SafeInteger(orderbook, "nonce"); current != nil && current.(int64) == nonce {
+				return
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for the %s book to reach nonce %d", symbol, nonce)
+}
+
+func sendDepthUpdate(t *testing.T, conn *websocket.Conn, marketId string, first int64, last int64, bids string, asks string) {
+	writeFrame(t, conn, `{"e":"depthUpdate","E":`+ccxt.ToString(1700000000000+last)+`,"s":"`+marketId+`","U":`+ccxt.ToString(first)+
+		`,"u":`+ccxt.ToString(last)+`,"b":`+bids+`,"a":`+asks+`}`)
+}
+
+// expectDepthUpdate sends a diff and returns the book it was applied to
+func expectDepthUpdate(t *testing.T, exchange *Binance, conn *websocket.Conn, marketId string, first int64, last int64, bids string, asks string) ccxt.OrderBook {
+	results := watchOrderBookForSymbolsAsync(exchange, []string{"BTC/USDT", "ETH/USDT"})
+	waitForFuture(t, exchange, "orderbook::BTC/USDT")
+	waitForFuture(t, exchange, "orderbook::ETH/USDT")
+	sendDepthUpdate(t, conn, marketId, first, last, bids, asks)
+	return receiveOrderBook(t, results)
+}
+
+func assertLevels(t *testing.T, side string, levels [][]float64, expected [][]float64) {
+	if !reflect.DeepEqual(levels, expected) {
+		t.Fatalf("expected %s %v, got %v", side, expected, levels)
+	}
+}
+
+func TestBinanceWatchOrderBookForSymbols(t *testing.T) {
+	server := newWsTestServer(t)
+	exchange, _ := newOrderBookBinance(t, server, map[string]string{
+		"BTCUSDT": `{"lastUpdateId":100,"bids":[["35000.0","1.0"],["34990.0","2.0"]],"asks":[["35010.0","1.5"],["35020.0","3.0"]]}`,
+		"ETHUSDT": `{"lastUpdateId":500,"bids":[["1800.0","10.0"]],"asks":[["1801.0","12.0"]]}`,
+	})
+
+	first := watchOrderBookForSymbolsAsync(exchange, []string{"BTC/USDT", "ETH/USDT"})
+	conn, path := server.acceptPath(t)
+	frame := readJSONFrame(t, conn)
+	if frame["method"] != "SUBSCRIBE" || !reflect.DeepEqual(frame["params"], []interface{}{"btcusdt@depth@100ms", "ethusdt@depth@100ms"}) {
+		t.Fatalf("expected both depth streams in one subscription, got %v", frame)
+	}
+	writeFrame(t, conn, `{"result":null,"id":`+ccxt.ToString(frame["id"])+`}`)
+	receiveOrderBook(t, first)
+	waitForNonce(t, exchange, "BTC/USDT", 100)
+	waitForNonce(t, exchange, "ETH/USDT", 500)
+
+	// two diffs of each symbol are applied on top of their own snapshot
+	book := expectDepthUpdate(t, exchange, conn, "BTCUSDT", 101, 102, `[["35000.0","0"],["34995.0","0.5"]]`, `[["35010.0","1.0"]]`)
+	if *book.Symbol != "BTC/USDT" || *book.Nonce != 102 {
+		t.Fatalf("expected the BTC/USDT book at nonce 102, got %v at %v", *book.Symbol, *book.Nonce)
+	}
+	book = expectDepthUpdate(t, exchange, conn, "ETHUSDT", 501, 503, `[["1799.5","4.0"]]`, `[]`)
+	if *book.Symbol != "ETH/USDT" || *book.Nonce != 503 {
+		t.Fatalf("expected the ETH/USDT book at nonce 503, got %v at %v", *book.Symbol, *book.Nonce)
+	}
+	book = expectDepthUpdate(t, exchange, conn, "BTCUSDT", 103, 105, `[["34990.0","2.5"]]`, `[["35005.0","0.2"],["35020.0","0"]]`)
+	if *book.Symbol != "BTC/USDT" || *book.Nonce != 105 || *book.Timestamp != 1700000000105 {
+		t.Fatalf("expected the BTC/USDT book at nonce 105, got %v at %v", *book.Symbol, *book.Nonce)
+	}
+	assertLevels(t, "bids", book.Bids, [][]float64{{34995.0, 0.5}, {34990.0, 2.5}})
+	assertLevels(t, "asks", book.Asks, [][]float64{{35005.0, 0.2}, {35010.0, 1.0}})
+	book = expectDepthUpdate(t, exchange, conn, "ETHUSDT", 504, 504, `[["1800.0","0"]]`, `[["1801.0","11.0"]]`)
+	if *book.Symbol != "ETH/USDT" || *book.Nonce != 504 {
+		t.Fatalf("expected the ETH/USDT book at nonce 504, got %v at %v", *book.Symbol, *book.Nonce)
+	}
+	assertLevels(t, "bids", book.Bids, [][]float64{{1799.5, 4.0}})
+	assertLevels(t, "asks", book.Asks, [][]float64{{1801.0, 11.0}})
+
+	// no other connection was opened for the second symbol
+	select {
+	case other := <-server.conns:
+		t.Fatalf("expected a single connection on %s, got another one on %s", path, other.path)
+	default:
+	}
+}
+
+// ---------------------------------------------------------------------------
+// sequence gaps: a diff that does not follow the local book triggers a new snapshot
+// ---------------------------------------------------------------------------
+
+func TestBinanceWatchOrderBookResnapshotsOnGap(t *testing.T) {
+	server := newWsTestServer(t)
+	exchange, transport := newOrderBookBinance(t, server, map[string]string{
+		"BTCUSDT": `{"lastUpdateId":100,"bids":[["35000.0","1.0"]],"asks":[["35010.0","1.5"]]}`,
+		"ETHUSDT": `{"lastUpdateId":500,"bids":[["1800.0","10.0"]],"asks":[["1801.0","12.0"]]}`,
+	})
+
+	first := watchOrderBookForSymbolsAsync(exchange, []string{"BTC/USDT", "ETH/USDT"})
+	conn := server.accept(t)
+	frame := readJSONFrame(t, conn)
+	writeFrame(t, conn, `{"result":null,"id":`+ccxt.ToString(frame["id"])+`}`)
+	receiveOrderBook(t, first)
+	waitForNonce(t, exchange, "BTC/USDT", 100)
+	waitForNonce(t, exchange, "ETH/USDT", 500)
+	expectDepthUpdate(t, exchange, conn, "BTCUSDT", 101, 102, `[["34995.0","0.5"]]`, `[]`)
+	if count := transport.count("BTCUSDT"); count != 1 {
+		t.Fatalf("expected a single snapshot request, got %d", count)
+	}
+
+	// updates 103 to 109 are lost, the diff is buffered and replayed on top of a new snapshot
+	transport.setSnapshot("BTCUSDT", `{"lastUpdateId":111,"bids":[["35001.0","2.0"]],"asks":[["35009.0","1.0"]]}`)
+	book := expectDepthUpdate(t, exchange, conn, "BTCUSDT", 110, 112, `[["35000.5","0.3"]]`, `[["35009.0","0"],["35012.0","4.0"]]`)
+	if count := transport.count("BTCUSDT"); count != 2 {
+		t.Fatalf("expected the gap to fetch a new snapshot, got %d snapshot requests", count)
+	}
+	if *book.Symbol != "BTC/USDT" || *book.Nonce != 112 {
+		t.Fatalf("expected the BTC/USDT book at nonce 112, got %v at %v", *book.Symbol, *book.Nonce)
+	}
+	assertLevels(t, "bids", book.Bids, [][]float64{{35001.0, 2.0}, {35000.5, 0.3}})
+	assertLevels(t, "asks", book.Asks, [][]float64{{35012.0, 4.0}})
+
+	// the stream resumes from the new snapshot, the other symbol was left untouched
+	book = expectDepthUpdate(t, exchange, conn, "BTCUSDT", 113, 113, `[]`, `[["35011.0","0.7"]]`)
+	if *book.Nonce != 113 {
+		t.Fatalf("expected the BTC/USDT book at nonce 113, got %v", *book.Nonce)
+	}
+	assertLevels(t, "asks", book.Asks, [][]float64{{35011.0, 0.7}, {35012.0, 4.0}})
+	if count := transport.count("ETHUSDT"); count != 1 {
+		t.Fatalf("expected a single ETH/USDT snapshot request, got %d", count)
+	}
+}
@@ -1549,6 +1549,38 @@ func (this *Binance) WatchPositions(options ...ccxt.WatchPositionsOptions) ([]cc
     }
     return ccxt.NewPositionArray(res), nil
 }
+/**
+ * @method
+ * @name binance#unWatchPositions
+ * @description unWatches all open positions or the positions of a list of symbols
+ * @param {string[]|undefined} [symbols] list of unified market symbols, all positions are unwatched if not set
+ * @param {object} params extra parameters specific to the exchange API endpoint
+ * @param {boolean} [params.portfolioMargin] set to true if you would like to unwatch positions in a portfolio margin account
+ * @returns {any} status of the unwatch request
+ */
+func (this *Binance) UnWatchPositions(options ...ccxt.UnWatchPositionsOptions) (interface{}, error) {
+
+    opts := ccxt.UnWatchPositionsOptionsStruct{}
+
+    for _, opt := range options {
+        opt(&opts)
+    }
+
+    var symbols interface{} = nil
+    if opts.Symbols != nil {
+        symbols = *opts.Symbols
+    }
+
+    var params interface{} = nil
+    if opts.Params != nil {
+        params = *opts.Params
+    }
+    res := <- this.Core.UnWatchPositions(symbols, params)
+    if ccxt.IsError(res) {
+        return nil, ccxt.CreateReturnError(res)
+    }
+    return res, nil
+}
 /**
  * @method
  * @name binance#fetchMyTradesWs
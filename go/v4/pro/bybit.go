@@ -749,14 +749,19 @@ func  (this *BybitCore) HandleTicker(client interface{}, message interface{})  {
     ccxt.AddElementToObject(this.Tickers, symbol, parsed)
     var messageHash interface{} = ccxt.Add("ticker:", symbol)
     client.(ccxt.ClientInterface).Resolve(ccxt.GetValue(this.Tickers, symbol), messageHash)
+    if ccxt.IsTrue(ccxt.IsTrue(!ccxt.IsEqual(ccxt.GetValue(parsed, "bid"), nil)) || ccxt.IsTrue(!ccxt.IsEqual(ccxt.GetValue(parsed, "ask"), nil))) {
+        this.HandleBidAsk(client, this.ParseWsTickerBidAsk(parsed, this.Market(symbol)))
+    }
 }
 /**
  * @method
  * @name bybit#watchBidsAsks
- * @description watches best bid & ask for symbols
+ * @description watches best bid & ask for symbols, identical consecutive quotes are not delivered
+ * @see https://bybit-exchange.github.io/docs/v5/websocket/public/ticker
  * @see https://bybit-exchange.github.io/docs/v5/websocket/public/orderbook
  * @param {string[]} symbols unified symbol of the market to fetch the ticker for
  * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @param {string} [params.name] 'tickers' (default) or 'orderbook', spot tickers carry no quotes so spot markets always use the orderbook stream
  * @returns {object} a [ticker structure]{@link https://docs.ccxt.com/?id=ticker-structure}
  */
 func  (this *BybitCore) WatchBidsAsks(optionalArgs ...interface{}) <- chan interface{} {
@@ -776,12 +781,19 @@ func  (this *BybitCore) WatchBidsAsks(optionalArgs ...interface{}) <- chan inter
         
             url:= (<-this.GetUrlByMarketType(ccxt.GetValue(symbols, 0), false, "watchBidsAsks", params))
             ccxt.PanicOnError(url)
+            var name interface{} = nil
+            nameparamsVariable := this.HandleOptionAndParams(params, "watchBidsAsks", "name", "tickers")
+            name = ccxt.GetValue(nameparamsVariable,0)
+            params = ccxt.GetValue(nameparamsVariable,1)
             params = this.CleanParams(params)
+            var firstMarket interface{} = this.Market(ccxt.GetValue(symbols, 0))
+            // the spot tickers have no best bid and ask
+            var channel interface{} = ccxt.Ternary(ccxt.IsTrue(ccxt.IsTrue(ccxt.GetValue(firstMarket, "spot")) || ccxt.IsTrue(ccxt.IsEqual(name, "orderbook"))), "orderbook.1.", "tickers.")
             var marketIds interface{} = this.MarketIds(symbols)
             var topics interface{} = []interface{}{}
             for i := 0; ccxt.IsLessThan(i, ccxt.GetArrayLength(marketIds)); i++ {
                 var marketId interface{} = ccxt.GetValue(marketIds, i)
-                var topic interface{} = ccxt.Add("orderbook.1.", marketId)
+                var topic interface{} = ccxt.Add(channel, marketId)
                 ccxt.AppendToArray(&topics, topic)
                 ccxt.AppendToArray(&messageHashes, ccxt.Add("bidask:", ccxt.GetValue(symbols, i)))
             }
@@ -819,6 +831,43 @@ func  (this *BybitCore) ParseWsBidAsk(orderbook interface{}, optionalArgs ...int
         "info": orderbook,
     }, market)
 }
+func  (this *BybitCore) ParseWsTickerBidAsk(ticker interface{}, optionalArgs ...interface{}) interface{}  {
+    market := ccxt.GetArg(optionalArgs, 0, nil)
+    _ = market
+    var timestamp interface{} = this.SafeInteger(ticker, "timestamp")
+    return this.SafeTicker(map[string]interface{} {
+        "symbol": ccxt.GetValue(market, "symbol"),
+        "timestamp": timestamp,
+        "datetime": this.Iso8601(timestamp),
+        "ask": this.SafeNumber(ticker, "ask"),
+        "askVolume": this.SafeNumber(ticker, "askVolume"),
+        "bid": this.SafeNumber(ticker, "bid"),
+        "bidVolume": this.SafeNumber(ticker, "bidVolume"),
+        "info": this.SafeDict(ticker, "info", map[string]interface{} {}),
+    }, market)
+}
+func  (this *BybitCore) HandleBidAsk(client interface{}, bidask interface{})  {
+    // a ticker update that leaves the top of the book as it was is not delivered again
+    var symbol interface{} = ccxt.GetValue(bidask, "symbol")
+    var previous interface{} = this.SafeValue(this.Bidsasks, symbol)
+    if ccxt.IsTrue(!ccxt.IsEqual(previous, nil)) {
+        var fields interface{} = []interface{}{"bid", "bidVolume", "ask", "askVolume"}
+        var unchanged interface{} = true
+        for i := 0; ccxt.IsLessThan(i, ccxt.GetArrayLength(fields)); i++ {
+            var field interface{} = ccxt.GetValue(fields, i)
+            if ccxt.IsTrue(!ccxt.IsEqual(this.SafeString(previous, field), this.SafeString(bidask, field))) {
+                unchanged = false
+            }
+        }
+        if ccxt.IsTrue(unchanged) {
+            return
+        }
+    }
+    var newBidsAsks interface{} = map[string]interface{} {}
+    ccxt.AddElementToObject(newBidsAsks, symbol, bidask)
+    ccxt.AddElementToObject(this.Bidsasks, symbol, bidask)
+    client.(ccxt.ClientInterface).Resolve(newBidsAsks, ccxt.Add("bidask:", symbol))
+}
 /**
  * @method
  * @name bybit#watchOHLCV
@@ -1313,11 +1362,7 @@ func  (this *BybitCore) HandleOrderBook(client interface{}, message interface{})
     ccxt.AddElementToObject(this.Orderbooks, symbol, orderbook)
     client.(ccxt.ClientInterface).Resolve(orderbook, messageHash)
     if ccxt.IsTrue(ccxt.IsEqual(limit, "1")) {
-        var bidask interface{} = this.ParseWsBidAsk(ccxt.UnWrapType(ccxt.GetValue(this.Orderbooks, symbol)), market)
-        var newBidsAsks interface{} = map[string]interface{} {}
-        ccxt.AddElementToObject(newBidsAsks, symbol, bidask)
-        ccxt.AddElementToObject(this.Bidsasks, symbol, bidask)
-        client.(ccxt.ClientInterface).Resolve(newBidsAsks, ccxt.Add("bidask:", symbol))
+        this.HandleBidAsk(client, this.ParseWsBidAsk(ccxt.UnWrapType(ccxt.GetValue(this.Orderbooks, symbol)), market))
     }
 }
 func  (this *BybitCore) HandleDelta(bookside interface{}, delta interface{})  {
@@ -2754,32 +2799,28 @@ func  (this *BybitCore) HandleBalance(client interface{}, message interface{})
         }
         info = rawBalances
     }
+    var timestamp interface{} = this.SafeInteger2(message, "ts", "creationTime")
     if ccxt.IsTrue(ccxt.IsEqual(topic, "wallet")) {
-        var data interface{} = this.SafeValue(message, "data", map[string]interface{} {})
+        // every entry is the state of an account type (unified, contract), each one is cached and emitted in full
+        var data interface{} = this.SafeList(message, "data", []interface{}{})
         for i := 0; ccxt.IsLessThan(i, ccxt.GetArrayLength(data)); i++ {
-            var result interface{} = this.SafeValue(data, 0, map[string]interface{} {})
-            account = this.SafeStringLower(result, "accountType")
-            rawBalances = this.ArrayConcat(rawBalances, this.SafeValue(result, "coin", []interface{}{}))
+            var result interface{} = this.SafeDict(data, i, map[string]interface{} {})
+            var accountType interface{} = this.SafeStringLower(result, "accountType")
+            var coins interface{} = this.SafeList(result, "coin", []interface{}{})
+            for j := 0; ccxt.IsLessThan(j, ccxt.GetArrayLength(coins)); j++ {
+                this.ParseWsBalance(ccxt.GetValue(coins, j), accountType)
+            }
+            this.ResolveAccountBalance(client, accountType, result, timestamp)
         }
-        info = data
+        return
     }
     for i := 0; ccxt.IsLessThan(i, ccxt.GetArrayLength(rawBalances)); i++ {
         this.ParseWsBalance(ccxt.GetValue(rawBalances, i), account)
     }
     if ccxt.IsTrue(!ccxt.IsEqual(account, nil)) {
-        if ccxt.IsTrue(ccxt.IsEqual(this.SafeValue(this.Balance, account), nil)) {
-            ccxt.AddElementToObject(this.Balance, account, map[string]interface{} {})
-        }
-        ccxt.AddElementToObject(ccxt.GetValue(this.Balance, account), "info", info)
-        var timestamp interface{} = this.SafeInteger(message, "ts")
-        ccxt.AddElementToObject(ccxt.GetValue(this.Balance, account), "timestamp", timestamp)
-        ccxt.AddElementToObject(ccxt.GetValue(this.Balance, account), "datetime", this.Iso8601(timestamp))
-        ccxt.AddElementToObject(this.Balance, account, this.SafeBalance(ccxt.GetValue(this.Balance, account)))
-        messageHash = ccxt.Add("balances:", account)
-        client.(ccxt.ClientInterface).Resolve(ccxt.GetValue(this.Balance, account), messageHash)
+        this.ResolveAccountBalance(client, account, info, timestamp)
     } else {
         ccxt.AddElementToObject(this.Balance, "info", info)
-        var timestamp interface{} = this.SafeInteger(message, "ts")
         ccxt.AddElementToObject(this.Balance, "timestamp", timestamp)
         ccxt.AddElementToObject(this.Balance, "datetime", this.Iso8601(timestamp))
         this.Balance = this.SafeBalance(this.Balance)
@@ -2787,6 +2828,17 @@ func  (this *BybitCore) HandleBalance(client interface{}, message interface{})
         client.(ccxt.ClientInterface).Resolve(this.Balance, messageHash)
     }
 }
+func  (this *BybitCore) ResolveAccountBalance(client interface{}, account interface{}, info interface{}, timestamp interface{})  {
+    if ccxt.IsTrue(ccxt.IsEqual(this.SafeValue(this.Balance, account), nil)) {
+        ccxt.AddElementToObject(this.Balance, account, map[string]interface{} {})
+    }
+    ccxt.AddElementToObject(ccxt.GetValue(this.Balance, account), "info", info)
+    ccxt.AddElementToObject(ccxt.GetValue(this.Balance, account), "timestamp", timestamp)
+    ccxt.AddElementToObject(ccxt.GetValue(this.Balance, account), "datetime", this.Iso8601(timestamp))
+    ccxt.AddElementToObject(this.Balance, account, this.SafeBalance(ccxt.GetValue(this.Balance, account)))
+    var messageHash interface{} = ccxt.Add("balances:", account)
+    client.(ccxt.ClientInterface).Resolve(ccxt.GetValue(this.Balance, account), messageHash)
+}
 func  (this *BybitCore) ParseWsBalance(balance interface{}, optionalArgs ...interface{})  {
     //
     // spot
@@ -2818,9 +2870,28 @@ func  (this *BybitCore) ParseWsBalance(balance interface{}, optionalArgs ...inte
     var account interface{} = this.Account()
     var currencyId interface{} = this.SafeString2(balance, "a", "coin")
     var code interface{} = this.SafeCurrencyCode(currencyId)
-    ccxt.AddElementToObject(account, "free", this.SafeStringN(balance, []interface{}{"availableToWithdraw", "f", "free", "availableToWithdraw"}))
-    ccxt.AddElementToObject(account, "used", this.SafeString2(balance, "l", "locked"))
-    ccxt.AddElementToObject(account, "total", this.SafeString(balance, "walletBalance"))
+    var free interface{} = this.SafeStringN(balance, []interface{}{"availableToWithdraw", "f", "free"})
+    var total interface{} = this.SafeString(balance, "walletBalance")
+    if ccxt.IsTrue(ccxt.IsEqual(total, nil)) {
+        ccxt.AddElementToObject(account, "free", free)
+        ccxt.AddElementToObject(account, "used", this.SafeString2(balance, "l", "locked"))
+    } else {
+        // same as fetchBalance, unified accounts leave availableToWithdraw empty and the margin of the orders and positions is used
+        ccxt.AddElementToObject(account, "total", total)
+        if ccxt.IsTrue(!ccxt.IsEqual(free, nil)) {
+            ccxt.AddElementToObject(account, "free", free)
+        } else {
+            var locked interface{} = this.SafeString(balance, "locked", "0")
+            var totalPositionIm interface{} = this.SafeString(balance, "totalPositionIM", "0")
+            var totalOrderIm interface{} = this.SafeString(balance, "totalOrderIM", "0")
+            ccxt.AddElementToObject(account, "used", ccxt.Precise.StringAdd(ccxt.Precise.StringAdd(locked, totalPositionIm), totalOrderIm))
+        }
+        var loan interface{} = this.SafeString(balance, "borrowAmount")
+        var interest interface{} = this.SafeString(balance, "accruedInterest")
+        if ccxt.IsTrue(ccxt.IsTrue((!ccxt.IsEqual(loan, nil))) && ccxt.IsTrue((!ccxt.IsEqual(interest, nil)))) {
+            ccxt.AddElementToObject(account, "debt", ccxt.Precise.StringAdd(loan, interest))
+        }
+    }
     if ccxt.IsTrue(!ccxt.IsEqual(accountType, nil)) {
         if ccxt.IsTrue(ccxt.IsEqual(this.SafeValue(this.Balance, accountType), nil)) {
             ccxt.AddElementToObject(this.Balance, accountType, map[string]interface{} {})
@@ -2837,14 +2908,20 @@ func  (this *BybitCore) WatchTopics(url interface{}, messageHashes interface{},
                 defer ccxt.ReturnPanicError(ch)
                     params := ccxt.GetArg(optionalArgs, 0, map[string]interface{} {})
             _ = params
+            var reqId interface{} = this.RequestId()
             var request interface{} = map[string]interface{} {
                 "op": "subscribe",
-                "req_id": this.RequestId(),
+                "req_id": reqId,
                 "args": topics,
             }
+            // lets handleErrorMessage reject the message hashes of a subscription the exchange refused
+            var subscription interface{} = map[string]interface{} {
+                "id": reqId,
+                "messageHashes": messageHashes,
+            }
             var message interface{} = this.Extend(request, params)
         
-                retRes229415 :=  (<-this.WatchMultiple(url, messageHashes, message, messageHashes))
+                retRes229415 :=  (<-this.WatchMultiple(url, messageHashes, message, messageHashes, subscription))
                 ccxt.PanicOnError(retRes229415)
                 ch <- retRes229415
                 return nil
@@ -2867,14 +2944,19 @@ func  (this *BybitCore) UnWatchTopics(url interface{}, topic interface{}, symbol
                 "req_id": reqId,
                 "args": topics,
             }
+            // unsubscribe tells the base class not to count the unwatch as a watcher of the topics, the
+            // exchange is only told to unsubscribe once no other watcher shares them, and the other topics
+            // of the connection are left untouched
             var subscription interface{} = map[string]interface{} {
                 "id": reqId,
+                "unsubscribe": true,
                 "topic": topic,
                 "messageHashes": messageHashes,
                 "subMessageHashes": subMessageHashes,
                 "symbols": symbols,
             }
-            var message interface{} = this.Extend(request, params)
+            // callerMethodName is set by unWatchOHLCV for the market type, it is not a field of the request
+            var message interface{} = this.Extend(request, this.Omit(params, "callerMethodName"))
         
                 retRes231215 :=  (<-this.WatchMultiple(url, messageHashes, message, messageHashes, this.Extend(subscription, subExtension)))
                 ccxt.PanicOnError(retRes231215)
@@ -2979,9 +3061,13 @@ func  (this *BybitCore) HandleErrorMessage(client interface{}, message interface
                 if ccxt.IsTrue(ccxt.InOp(client.(ccxt.ClientInterface).GetSubscriptions(), messageHash)) {
                     ccxt.Remove(client.(ccxt.ClientInterface).GetSubscriptions(), messageHash)
                 }
+            } else if ccxt.IsTrue(ccxt.IsEqual(this.SafeString(message, "op"), "subscribe")) {
+                this.RejectSubscription(client, this.SafeString(message, "req_id"), error)
             } else {
                 var messageHash interface{} = this.SafeString(message, "reqId")
-                client.(ccxt.ClientInterface).Reject(error, messageHash)
+                if ccxt.IsTrue(!ccxt.IsEqual(messageHash, nil)) {
+                    client.(ccxt.ClientInterface).Reject(error, messageHash)
+                }
             }
             return true
                             
@@ -3017,6 +3103,29 @@ func  (this *BybitCore) HandleErrorMessage(client interface{}, message interface
                 return nil
             }
 }
+func  (this *BybitCore) RejectSubscription(client interface{}, reqId interface{}, error interface{})  {
+    //
+    //     {"success":false,"ret_msg":"error:handler not found","conn_id":"d266o6hqo29sqmnq4vk0-1yus1","req_id":"2","op":"subscribe"}
+    //
+    // the topics of the request are not subscribed, the watchers get the error and can subscribe again
+    if ccxt.IsTrue(ccxt.IsEqual(reqId, nil)) {
+        return
+    }
+    var messageHashes interface{} = []interface{}{}
+    var keys interface{} = ccxt.ObjectKeys(client.(ccxt.ClientInterface).GetSubscriptions())
+    for i := 0; ccxt.IsLessThan(i, ccxt.GetArrayLength(keys)); i++ {
+        var subscribeHash interface{} = ccxt.GetValue(keys, i)
+        var subscription interface{} = ccxt.GetValue(client.(ccxt.ClientInterface).GetSubscriptions(), subscribeHash)
+        if ccxt.IsTrue(!ccxt.IsEqual(this.SafeString(subscription, "id"), reqId)) {
+            continue
+        }
+        ccxt.Remove(client.(ccxt.ClientInterface).GetSubscriptions(), subscribeHash)
+        messageHashes = this.SafeList(subscription, "messageHashes", []interface{}{})
+    }
+    for i := 0; ccxt.IsLessThan(i, ccxt.GetArrayLength(messageHashes)); i++ {
+        client.(ccxt.ClientInterface).Reject(error, ccxt.GetValue(messageHashes, i))
+    }
+}
 func  (this *BybitCore) HandleMessage(client interface{}, message interface{})  {
     var topic interface{} = this.SafeString2(message, "topic", "op", "")
     if ccxt.IsTrue(this.HandleErrorMessage(client, message)) {
@@ -0,0 +1,411 @@
+package ccxtpro
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	ccxt "github.com/ccxt/ccxt/go/v4"
+	"github.com/gorilla/websocket"
+)
+
+// ---------------------------------------------------------------------------
+// watchBidsAsks: the top of the book comes from the tickers stream, repeated quotes are skipped
+// ---------------------------------------------------------------------------
+
+func newBybitWsExchange(t *testing.T, server *wsTestServer) *Bybit {
+	exchange := NewBybit(map[string]interface{}{})
+	// plain ws:// urls are only accepted with an http agent
+	exchange.HttpProxy = &http.Transport{}
+	public := ccxt.GetValue(ccxt.GetValue(ccxt.GetValue(exchange.Urls, "api"), "ws"), "public")
+	ccxt.AddElementToObject(public, "linear", server.wsUrl()+"/v5/public/linear")
+	exchange.SetMarkets([]interface{}{
+		exchange.SafeMarketStructure(map[string]interface{}{
+			"id":           "BTCUSDT",
+			"symbol":       "BTC/USDT:USDT",
+			"base":         "BTC",
+			"quote":        "USDT",
+			"settle":       "USDT",
+			"baseId":       "BTC",
+			"quoteId":      "USDT",
+			"settleId":     "USDT",
+			"type":         "swap",
+			"swap":         true,
+			"contract":     true,
+			"linear":       true,
+			"contractSize": 1.0,
+			"active":       true,
+		}),
+	})
+	t.Cleanup(func() { exchange.Close() })
+	return exchange
+}
+
+func sendBybitTicker(t *testing.T, conn *websocket.Conn, updateType string, data string) {
+	writeFrame(t, conn, `{"topic":"tickers.BTCUSDT","type":"`+updateType+`","data":{"symbol":"BTCUSDT",`+data+`},"cs":24987956059,"ts":1673272861686}`)
+}
+
+func waitForBybitFuture(t *testing.T, exchange *Bybit, messageHash string) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		for _, client := range exchange.Clients {
+			if _, ok := client.(ccxt.ClientInterface).GetFutures()[messageHash]; ok {
+				return
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for a watcher on %s", messageHash)
+}
+
+type bybitBidsAsksResult struct {
+	bidsAsks ccxt.Tickers
+	err      error
+}
+
+func TestBybitWatchBidsAsksFromTickers(t *testing.T) {
+	server := newWsTestServer(t)
+	exchange := newBybitWsExchange(t, server)
+	watch := func() chan bybitBidsAsksResult {
+		results := make(chan bybitBidsAsksResult, 1)
+		go func() {
+			bidsAsks, err := exchange.WatchBidsAsks(ccxt.WithWatchBidsAsksSymbols([]string{"BTC/USDT:USDT"}))
+			results <- bybitBidsAsksResult{bidsAsks, err}
+		}()
+		return results
+	}
+	receive := func(results chan bybitBidsAsksResult) ccxt.Ticker {
+		select {
+		case result := <-results:
+			if result.err != nil {
+				t.Fatal(result.err)
+			}
+			quote, ok := result.bidsAsks.Tickers["BTC/USDT:USDT"]
+			if !ok || len(result.bidsAsks.Tickers) != 1 {
+				t.Fatalf("expected the BTC/USDT:USDT quote, got %v", result.bidsAsks.Tickers)
+			}
+			return quote
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for watchBidsAsks")
+		}
+		return ccxt.Ticker{}
+	}
+
+	results := watch()
+	conn := server.accept(t)
+	frame := readJSONFrame(t, conn)
+	if args, ok := frame["args"].([]interface{}); frame["op"] != "subscribe" || !ok || len(args) != 1 || args[0] != "tickers.BTCUSDT" {
+		t.Fatalf("expected a subscription to the tickers stream, got %v", frame)
+	}
+	waitForBybitFuture(t, exchange, "bidask:BTC/USDT:USDT")
+	sendBybitTicker(t, conn, "snapshot", `"lastPrice":"17216.00","markPrice":"17217.33","bid1Price":"17215.50","bid1Size":"84.489","ask1Price":"17216.00","ask1Size":"83.020"`)
+	quote := receive(results)
+	if *quote.Bid != 17215.5 || *quote.BidVolume != 84.489 || *quote.Ask != 17216 || *quote.AskVolume != 83.02 {
+		t.Fatalf("unexpected quote %+v", quote)
+	}
+	if quote.Timestamp == nil || *quote.Timestamp != 1673272861686 || quote.Last != nil {
+		t.Fatalf("expected a lightweight quote with the frame timestamp, got %+v", quote)
+	}
+
+	// a delta that does not move the top of the book is not delivered
+	results = watch()
+	waitForBybitFuture(t, exchange, "bidask:BTC/USDT:USDT")
+	sendBybitTicker(t, conn, "delta", `"lastPrice":"17215.50"`)
+	sendBybitTicker(t, conn, "delta", `"bid1Price":"17215.50","bid1Size":"84.489","ask1Price":"17216.00","ask1Size":"83.020"`)
+	sendBybitTicker(t, conn, "delta", `"ask1Price":"17216.50","ask1Size":"12.5"`)
+	quote = receive(results)
+	if *quote.Bid != 17215.5 || *quote.Ask != 17216.5 || *quote.AskVolume != 12.5 {
+		t.Fatalf("expected the next distinct quote, got %+v", quote)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// watchOrderBook: a subscription refused by the exchange ends with its reason
+// ---------------------------------------------------------------------------
+
+func TestBybitWatchOrderBookSubscriptionError(t *testing.T) {
+	server := newWsTestServer(t)
+	exchange := newBybitWsExchange(t, server)
+	watch := func() chan error {
+		results := make(chan error, 1)
+		go func() {
+			_, err := exchange.WatchOrderBook("BTC/USDT:USDT")
+			results <- err
+		}()
+		return results
+	}
+	results := watch()
+	conn := server.accept(t)
+	frame := readJSONFrame(t, conn)
+	if args, ok := frame["args"].([]interface{}); frame["op"] != "subscribe" || !ok || len(args) != 1 || args[0] != "orderbook.50.BTCUSDT" {
+		t.Fatalf("expected a subscription to the orderbook stream, got %v", frame)
+	}
+	waitForBybitFuture(t, exchange, "orderbook:BTC/USDT:USDT")
+	client := exchange.Client(server.wsUrl() + "/v5/public/linear")
+	updates, stop := client.Stream("orderbook:BTC/USDT:USDT", 1, ccxt.DropOldest)
+	defer stop()
+	writeFrame(t, conn, `{"success":false,"ret_msg":"error:handler not found","conn_id":"d266o6hqo29sqmnq4vk0-1yus1","req_id":"`+ccxt.ToString(frame["req_id"])+`","op":"subscribe"}`)
+
+	select {
+	case err := <-results:
+		if err == nil || !ccxt.IsErrorType(err, ccxt.ExchangeErrorErrType) || !strings.Contains(err.Error(), "handler not found") {
+			t.Fatalf("expected the watcher to receive the error frame, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for watchOrderBook")
+	}
+	// the stream delivers the reason before closing, and it can be queried afterwards
+	if reason, ok := (<-updates).(error); !ok || !strings.Contains(reason.Error(), "handler not found") {
+		t.Fatalf("expected the reason as the last update of the stream, got %v", reason)
+	}
+	if _, open := <-updates; open {
+		t.Fatal("expected the stream to be closed")
+	}
+	if err := exchange.SubscriptionError("orderbook:BTC/USDT:USDT"); err == nil || !strings.Contains(err.Error(), "handler not found") {
+		t.Fatalf("expected the subscription error to be kept, got %v", err)
+	}
+
+	// the refused topic is subscribed again by the next watcher
+	results = watch()
+	if frame := readJSONFrame(t, conn); frame["op"] != "subscribe" {
+		t.Fatalf("expected the orderbook to be subscribed again, got %v", frame)
+	}
+	waitForBybitFuture(t, exchange, "orderbook:BTC/USDT:USDT")
+	if err := exchange.SubscriptionError("orderbook:BTC/USDT:USDT"); err != nil {
+		t.Fatalf("expected the reason to be cleared by the new watcher, got %v", err)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// watchBalance: the wallet updates are merged into the balance of their account type
+// ---------------------------------------------------------------------------
+
+func TestBybitWatchBalanceFromWallet(t *testing.T) {
+	server := newWsTestServer(t)
+	exchange := newBybitWsExchange(t, server)
+	exchange.ApiKey = "key"
+	exchange.Secret = "secret"
+	ccxt.AddElementToObject(exchange.Options, "enableUnifiedMargin", false)
+	ccxt.AddElementToObject(exchange.Options, "enableUnifiedAccount", true)
+	private := ccxt.GetValue(ccxt.GetValue(ccxt.GetValue(exchange.Urls, "api"), "ws"), "private")
+	ccxt.AddElementToObject(private, "contract", server.wsUrl()+"/v5/private")
+	watch := func() chan bybitBalanceResult {
+		results := make(chan bybitBalanceResult, 1)
+		go func() {
+			balances, err := exchange.WatchBalance()
+			results <- bybitBalanceResult{balances, err}
+		}()
+		return results
+	}
+	receive := func(results chan bybitBalanceResult) ccxt.Balances {
+		select {
+		case result := <-results:
+			if result.err != nil {
+				t.Fatal(result.err)
+			}
+			return result.balances
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for watchBalance")
+		}
+		return ccxt.Balances{}
+	}
+
+	results := watch()
+	conn, path := server.acceptPath(t)
+	if frame := readJSONFrame(t, conn); path != "/v5/private" || frame["op"] != "auth" {
+		t.Fatalf("expected to authenticate on the private stream, got %v on %s", frame, path)
+	}
+	writeFrame(t, conn, `{"success":true,"ret_msg":"","op":"auth","conn_id":"cejreaspqfh3sjdnldmg-p"}`)
+	if frame := readJSONFrame(t, conn); frame["op"] != "subscribe" || frame["args"].([]interface{})[0] != "wallet" {
+		t.Fatalf("expected a subscription to the wallet topic, got %v", frame)
+	}
+	waitForBybitFuture(t, exchange, "balances:unified")
+	writeFrame(t, conn, `{"id":"5923242c464be9-25ca-483d-a743-c60101fc656f","topic":"wallet","creationTime":1672364262482,"data":[{"accountType":"UNIFIED","totalEquity":"12837.78","coin":[`+
+		`{"coin":"USDT","equity":"11726.64","walletBalance":"11728.54","availableToWithdraw":"","borrowAmount":"0","accruedInterest":"0","totalOrderIM":"5","totalPositionIM":"2.5","locked":"0.5"},`+
+		`{"coin":"BTC","equity":"0.06","walletBalance":"0.06","availableToWithdraw":"0.05","borrowAmount":"0","accruedInterest":"0","totalOrderIM":"0","totalPositionIM":"0","locked":"0"}`+
+		`]}]}`)
+	balance := receive(results)
+	if *balance.Total["USDT"] != 11728.54 || *balance.Used["USDT"] != 8 || *balance.Free["USDT"] != 11720.54 {
+		t.Fatalf("expected the margin of the orders and positions to be used, got %v %v %v", *balance.Total["USDT"], *balance.Used["USDT"], *balance.Free["USDT"])
+	}
+	if *balance.Total["BTC"] != 0.06 || *balance.Free["BTC"] != 0.05 {
+		t.Fatalf("unexpected BTC balance %v %v", *balance.Total["BTC"], *balance.Free["BTC"])
+	}
+	timestamp := func() interface{} {
+		return ccxt.GetValue(ccxt.GetValue(exchange.Balance, "unified"), "timestamp")
+	}
+	if ts := timestamp(); ccxt.ToFloat64(ts) != 1672364262482 {
+		t.Fatalf("expected the creation time of the frame as timestamp, got %v", ts)
+	}
+
+	// an update only lists the coins that changed, each entry belongs to its own account type
+	results = watch()
+	waitForBybitFuture(t, exchange, "balances:unified")
+	writeFrame(t, conn, `{"id":"5923242c464be9-25ca-483d-a743-c60101fc656e","topic":"wallet","creationTime":1672364263000,"data":[`+
+		`{"accountType":"CONTRACT","coin":[{"coin":"USDT","walletBalance":"100","availableToWithdraw":"100"}]},`+
+		`{"accountType":"UNIFIED","coin":[{"coin":"BTC","walletBalance":"0.07","availableToWithdraw":"0.07","borrowAmount":"0","accruedInterest":"0"}]}`+
+		`]}`)
+	balance = receive(results)
+	if *balance.Total["BTC"] != 0.07 || *balance.Total["USDT"] != 11728.54 || ccxt.ToFloat64(timestamp()) != 1672364263000 {
+		t.Fatalf("expected the BTC update merged into the unified balance, got %v", balance.Total)
+	}
+	contract := ccxt.GetValue(exchange.Balance, "contract")
+	if total := ccxt.GetValue(ccxt.GetValue(contract, "USDT"), "total"); ccxt.ToFloat64(total) != 100 {
+		t.Fatalf("expected the contract account to be cached apart, got %v", contract)
+	}
+}
+
+type bybitBalanceResult struct {
+	balances ccxt.Balances
+	err      error
+}
+
+// ---------------------------------------------------------------------------
+// unWatchTrades / unWatchOHLCV: the other topics of the connection keep streaming
+// ---------------------------------------------------------------------------
+
+func TestBybitUnWatchKeepsOtherTopics(t *testing.T) {
+	server := newWsTestServer(t)
+	exchange := newBybitWsExchange(t, server)
+	trades := make(chan error, 1)
+	go func() {
+		_, err := exchange.WatchTrades("BTC/USDT:USDT")
+		trades <- err
+	}()
+	conn := server.accept(t)
+	if frame := readJSONFrame(t, conn); frame["op"] != "subscribe" || frame["args"].([]interface{})[0] != "publicTrade.BTCUSDT" {
+		t.Fatalf("expected a subscription to the trades, got %v", frame)
+	}
+	candles := make(chan []ccxt.OHLCV, 1)
+	go func() {
+		ohlcv, err := exchange.WatchOHLCV("BTC/USDT:USDT")
+		if err != nil {
+			t.Error(err)
+		}
+		candles <- ohlcv
+	}()
+	if frame := readJSONFrame(t, conn); frame["op"] != "subscribe" || frame["args"].([]interface{})[0] != "kline.1.BTCUSDT" {
+		t.Fatalf("expected the candles to be subscribed on the same connection, got %v", frame)
+	}
+	waitForBybitFuture(t, exchange, "trade:BTC/USDT:USDT")
+	waitForBybitFuture(t, exchange, "ohlcv::BTC/USDT:USDT::1m")
+
+	unwatched := make(chan error, 1)
+	go func() {
+		_, err := exchange.UnWatchTrades("BTC/USDT:USDT")
+		unwatched <- err
+	}()
+	frame := readJSONFrame(t, conn)
+	if args, ok := frame["args"].([]interface{}); frame["op"] != "unsubscribe" || !ok || len(args) != 1 || args[0] != "publicTrade.BTCUSDT" {
+		t.Fatalf("expected the trades to be unsubscribed, got %v", frame)
+	}
+	writeFrame(t, conn, `{"success":true,"ret_msg":"","conn_id":"7188110e-6908-41e9-b863-6365127e92ad","req_id":"`+ccxt.ToString(frame["req_id"])+`","op":"unsubscribe"}`)
+	for _, result := range []chan error{unwatched, trades} {
+		select {
+		case err := <-result:
+			if result == unwatched && err != nil {
+				t.Fatal(err)
+			}
+			if result == trades && !ccxt.IsErrorType(err, ccxt.UnsubscribeErrorErrType) {
+				t.Fatalf("expected the pending trades watcher to end with an UnsubscribeError, got %v", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for the unsubscription")
+		}
+	}
+	client := exchange.Client(server.wsUrl() + "/v5/public/linear")
+	subscriptions := client.GetSubscriptions()
+	if _, ok := subscriptions["trade:BTC/USDT:USDT"]; ok {
+		t.Fatalf("expected the trades subscription to be dropped, got %v", subscriptions)
+	}
+	if _, ok := subscriptions["ohlcv::BTC/USDT:USDT::1m"]; !ok || client.Connection == nil {
+		t.Fatalf("expected the connection to stay open for the candles, got %v", subscriptions)
+	}
+
+	writeFrame(t, conn, `{"topic":"kline.1.BTCUSDT","data":[{"start":1672324800000,"end":1672324859999,"interval":"1","open":"16649.5","close":"16677","high":"16677","low":"16608","volume":"2.081","turnover":"34666.4005","confirm":false,"timestamp":1672324988882}],"ts":1672324988882,"type":"snapshot"}`)
+	select {
+	case ohlcv := <-candles:
+		if len(ohlcv) != 1 || ohlcv[0].Close != 16677 {
+			t.Fatalf("expected the candle after the unsubscription of the trades, got %v", ohlcv)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for watchOHLCV")
+	}
+
+	go func() {
+		_, err := exchange.UnWatchOHLCV("BTC/USDT:USDT")
+		unwatched <- err
+	}()
+	frame = readJSONFrame(t, conn)
+	if _, ok := frame["callerMethodName"]; frame["op"] != "unsubscribe" || frame["args"].([]interface{})[0] != "kline.1.BTCUSDT" || ok {
+		t.Fatalf("expected only the candles to be unsubscribed, got %v", frame)
+	}
+	writeFrame(t, conn, `{"success":true,"ret_msg":"","conn_id":"7188110e-6908-41e9-b863-6365127e92ad","req_id":"`+ccxt.ToString(frame["req_id"])+`","op":"unsubscribe"}`)
+	select {
+	case err := <-unwatched:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for unWatchOHLCV")
+	}
+	if _, ok := client.GetSubscriptions()["ohlcv::BTC/USDT:USDT::1m"]; ok {
+		t.Fatal("expected the candles subscription to be dropped")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// ListSubscriptions: every topic watched on a connection is listed with its subscribe message
+// ---------------------------------------------------------------------------
+
+func TestBybitListSubscriptions(t *testing.T) {
+	server := newWsTestServer(t)
+	exchange := newBybitWsExchange(t, server)
+	// the core methods do not convert the nil result of the watchers released by Close
+	go exchange.Core.WatchTicker("BTC/USDT:USDT")
+	conn := server.accept(t)
+	readJSONFrame(t, conn)
+	go exchange.Core.WatchTrades("BTC/USDT:USDT")
+	readJSONFrame(t, conn)
+	go exchange.Core.WatchOHLCV("BTC/USDT:USDT")
+	readJSONFrame(t, conn)
+
+	topics := map[string]string{
+		"ticker:BTC/USDT:USDT":     "tickers.BTCUSDT",
+		"trade:BTC/USDT:USDT":      "publicTrade.BTCUSDT",
+		"ohlcv::BTC/USDT:USDT::1m": "kline.1.BTCUSDT",
+	}
+	client := exchange.Client(server.wsUrl() + "/v5/public/linear")
+	// the subscribe message is recorded once it is sent
+	var subscriptions []ccxt.Subscription
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		subscriptions = client.ListSubscriptions()
+		recorded := 0
+		for _, subscription := range subscriptions {
+			if subscription.Message != nil {
+				recorded++
+			}
+		}
+		if recorded == len(topics) {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if len(subscriptions) != len(topics) {
+		t.Fatalf("expected %d subscriptions, got %+v", len(topics), subscriptions)
+	}
+	for i, subscription := range subscriptions {
+		topic, ok := topics[subscription.Hash]
+		if !ok || subscription.Url != client.Url || subscription.SubscribedAt == 0 {
+			t.Fatalf("unexpected subscription %+v", subscription)
+		}
+		if args := ccxt.GetValue(subscription.Message, "args").([]interface{}); args[0] != topic {
+			t.Fatalf("expected the subscribe message of %s, got %v", topic, subscription.Message)
+		}
+		if i > 0 && subscription.SubscribedAt < subscriptions[i-1].SubscribedAt {
+			t.Fatalf("expected the subscriptions sorted by subscription time, got %+v", subscriptions)
+		}
+	}
+}
@@ -289,10 +289,12 @@ func (this *Bybit) UnWatchTicker(symbol string, options ...ccxt.UnWatchTickerOpt
 /**
  * @method
  * @name bybit#watchBidsAsks
- * @description watches best bid & ask for symbols
+ * @description watches best bid & ask for symbols, identical consecutive quotes are not delivered
+ * @see https://bybit-exchange.github.io/docs/v5/websocket/public/ticker
  * @see https://bybit-exchange.github.io/docs/v5/websocket/public/orderbook
  * @param {string[]} symbols unified symbol of the market to fetch the ticker for
  * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @param {string} [params.name] 'tickers' (default) or 'orderbook', spot tickers carry no quotes so spot markets always use the orderbook stream
  * @returns {object} a [ticker structure]{@link https://docs.ccxt.com/?id=ticker-structure}
  */
 func (this *Bybit) WatchBidsAsks(options ...ccxt.WatchBidsAsksOptions) (ccxt.Tickers, error) {
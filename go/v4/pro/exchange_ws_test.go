@@ -0,0 +1,83 @@
+package ccxtpro
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// wsTestServer hands every accepted websocket connection to the test, which scripts the exchange side
+type wsTestServer struct {
+	*httptest.Server
+	conns chan wsTestConn
+}
+
+type wsTestConn struct {
+	*websocket.Conn
+	path string
+}
+
+func newWsTestServer(t *testing.T) *wsTestServer {
+	server := &wsTestServer{conns: make(chan wsTestConn, 1)}
+	done := make(chan struct{})
+	upgrader := websocket.Upgrader{}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade: %v", err)
+			return
+		}
+		server.conns <- wsTestConn{conn, r.URL.Path}
+		<-done
+	}))
+	t.Cleanup(func() {
+		close(done)
+		server.Close()
+	})
+	return server
+}
+
+func (s *wsTestServer) wsUrl() string {
+	return "ws" + strings.TrimPrefix(s.URL, "http")
+}
+
+func (s *wsTestServer) accept(t *testing.T) *websocket.Conn {
+	conn, _ := s.acceptPath(t)
+	return conn
+}
+
+// acceptPath also returns the path the client connected to
+func (s *wsTestServer) acceptPath(t *testing.T) (*websocket.Conn, string) {
+	select {
+	case conn := <-s.conns:
+		t.Cleanup(func() { conn.Close() })
+		return conn.Conn, conn.path
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the websocket connection")
+	}
+	return nil, ""
+}
+
+func readJSONFrame(t *testing.T, conn *websocket.Conn) map[string]interface{} {
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, data, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("reading a client frame: %v", err)
+	}
+	frame := map[string]interface{}{}
+	if err := json.Unmarshal(data, &frame); err != nil {
+		t.Fatalf("expected a json frame, got %s", data)
+	}
+	return frame
+}
+
+func writeFrame(t *testing.T, conn *websocket.Conn, frame string) {
+	if err := conn.WriteMessage(websocket.TextMessage, []byte(frame)); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1601,42 +1601,68 @@ func  (this *KrakenCore) HandleOrders(client interface{}, message interface{}, o
     //         "sequence": 8
     //     }
     //
+    // partial fill, an update only carries the fields that changed
+    //
+    //     {
+    //         "channel": "executions",
+    //         "type": "update",
+    //         "data": [
+    //             {
+    //                 "order_id": "OK4GJX-KSTLS-7DZZO5",
+    //                 "exec_id": "TZ63HS-YBBDB-ZJYSPK",
+    //                 "exec_type": "trade",
+    //                 "order_status": "partially_filled",
+    //                 "symbol": "BTC/USD",
+    //                 "side": "sell",
+    //                 "last_qty": 0.002,
+    //                 "last_price": 26500.0,
+    //                 "cum_qty": 0.002,
+    //                 "cum_cost": 53.0,
+    //                 "avg_price": 26500.0,
+    //                 "timestamp": "2023-09-22T10:35:12.418236Z"
+    //             }
+    //         ],
+    //         "sequence": 9
+    //     }
+    //
     subscription := ccxt.GetArg(optionalArgs, 0, nil)
     _ = subscription
     var allOrders interface{} = this.SafeList(message, "data", []interface{}{})
-    var allOrdersLength interface{} =     ccxt.GetArrayLength(allOrders)
-    if ccxt.IsTrue(ccxt.IsGreaterThan(allOrdersLength, 0)) {
-        var limit interface{} = this.SafeInteger(this.Options, "ordersLimit", 1000)
-        if ccxt.IsTrue(ccxt.IsEqual(this.Orders, nil)) {
-            this.Orders = ccxt.NewArrayCacheBySymbolById(limit)
+    var limit interface{} = this.SafeInteger(this.Options, "ordersLimit", 1000)
+    // a snapshot lists every open order, the orders cached before it are stale (e.g. after a reconnection)
+    var isSnapshot interface{} = ccxt.IsEqual(this.SafeString(message, "type"), "snapshot")
+    if ccxt.IsTrue(ccxt.IsTrue(ccxt.IsEqual(this.Orders, nil)) || ccxt.IsTrue(isSnapshot)) {
+        this.Orders = ccxt.NewArrayCacheBySymbolById(limit)
+    }
+    var stored interface{} = this.Orders
+    var symbols interface{} = map[string]interface{} {}
+    for i := 0; ccxt.IsLessThan(i, ccxt.GetArrayLength(allOrders)); i++ {
+        var order interface{} = this.SafeDict(allOrders, i, map[string]interface{} {})
+        var id interface{} = this.SafeString(order, "order_id")
+        var previousOrder interface{} = this.FindCachedOrder(stored, id)
+        var newOrder interface{} = nil
+        if ccxt.IsTrue(!ccxt.IsEqual(previousOrder, nil)) {
+            var newRawOrder interface{} = this.Extend(ccxt.GetValue(previousOrder, "info"), order)
+            newOrder = this.ParseWsOrder(newRawOrder)
+        } else {
+            newOrder = this.ParseWsOrder(order)
         }
-        var stored interface{} = this.Orders
-        var symbols interface{} = map[string]interface{} {}
-        for i := 0; ccxt.IsLessThan(i, ccxt.GetArrayLength(allOrders)); i++ {
-            var order interface{} = this.SafeDict(allOrders, i, map[string]interface{} {})
-            var id interface{} = this.SafeString(order, "order_id")
-            var parsed interface{} = this.ParseWsOrder(order)
-            var symbol interface{} = this.SafeString(order, "symbol")
-            var previousOrders interface{} = this.SafeValue(stored.(*ccxt.ArrayCache).Hashmap, symbol)
-            var previousOrder interface{} = this.SafeValue(previousOrders, id)
-            var newOrder interface{} = parsed
-            if ccxt.IsTrue(!ccxt.IsEqual(previousOrder, nil)) {
-                var newRawOrder interface{} = this.Extend(ccxt.GetValue(previousOrder, "info"), ccxt.GetValue(newOrder, "info"))
-                newOrder = this.ParseWsOrder(newRawOrder)
-            }
-            var length interface{} =             ccxt.GetArrayLength(stored)
-            if ccxt.IsTrue(ccxt.IsTrue(ccxt.IsEqual(length, limit)) && ccxt.IsTrue((ccxt.IsEqual(previousOrder, nil)))) {
-                var first interface{} = ccxt.GetValue(stored, 0)
-                var symbolsByOrderId interface{} = this.SafeValue(this.Options, "symbolsByOrderId", map[string]interface{} {})
-                if ccxt.IsTrue(ccxt.InOp(symbolsByOrderId, ccxt.GetValue(first, "id"))) {
-                    ccxt.Remove(symbolsByOrderId, ccxt.GetValue(first, "id"))
-                }
-            }
-            stored.(ccxt.Appender).Append(newOrder)
-            if ccxt.IsTrue(!ccxt.IsEqual(symbol, nil)) {
-                ccxt.AddElementToObject(symbols, symbol, true)
+        var length interface{} =         ccxt.GetArrayLength(stored)
+        if ccxt.IsTrue(ccxt.IsTrue(ccxt.IsEqual(length, limit)) && ccxt.IsTrue((ccxt.IsEqual(previousOrder, nil)))) {
+            var first interface{} = ccxt.GetValue(stored, 0)
+            var symbolsByOrderId interface{} = this.SafeValue(this.Options, "symbolsByOrderId", map[string]interface{} {})
+            if ccxt.IsTrue(ccxt.InOp(symbolsByOrderId, ccxt.GetValue(first, "id"))) {
+                ccxt.Remove(symbolsByOrderId, ccxt.GetValue(first, "id"))
             }
         }
+        stored.(ccxt.Appender).Append(newOrder)
+        var symbol interface{} = this.SafeString(newOrder, "symbol")
+        if ccxt.IsTrue(!ccxt.IsEqual(symbol, nil)) {
+            ccxt.AddElementToObject(symbols, symbol, true)
+        }
+    }
+    // an empty snapshot still tells the watchers that nothing is open
+    if ccxt.IsTrue(ccxt.IsTrue(ccxt.IsGreaterThan(ccxt.GetArrayLength(allOrders), 0)) || ccxt.IsTrue(isSnapshot)) {
         var name interface{} = "orders"
         client.(ccxt.ClientInterface).Resolve(this.Orders, name)
         var keys interface{} = ccxt.ObjectKeys(symbols)
@@ -1646,6 +1672,22 @@ func  (this *KrakenCore) HandleOrders(client interface{}, message interface{}, o
         }
     }
 }
+// FindCachedOrder returns the cached order with this id whatever its symbol, the updates of kraken do not always repeat the symbol
+func  (this *KrakenCore) FindCachedOrder(stored interface{}, id interface{}) interface{}  {
+    cache, ok := stored.(*ccxt.ArrayCacheBySymbolById)
+    orderId, isString := id.(string)
+    if !ok || !isString {
+        return nil
+    }
+    cache.Mu.Lock()
+    defer cache.Mu.Unlock()
+    for _, orders := range cache.Hashmap {
+        if order, found := orders[orderId]; found {
+            return order
+        }
+    }
+    return nil
+}
 func  (this *KrakenCore) ParseWsOrder(order interface{}, optionalArgs ...interface{}) interface{}  {
     //
     // watchOrders
@@ -1712,7 +1754,7 @@ func  (this *KrakenCore) ParseWsOrder(order interface{}, optionalArgs ...interfa
         "triggerPrice": stopPrice,
         "cost": this.SafeString(order, "cum_cost"),
         "amount": this.SafeString2(order, "order_qty", "cum_qty"),
-        "filled": nil,
+        "filled": this.SafeString(order, "cum_qty"),
         "average": this.SafeString(order, "avg_price"),
         "remaining": nil,
         "fee": fee,
@@ -1941,6 +1983,10 @@ func  (this *KrakenCore) HandleMessage(client interface{}, message interface{})
             var first interface{} = this.SafeDict(data, 0, map[string]interface{} {})
             var execType interface{} = this.SafeString(first, "exec_type")
             channel = ccxt.Ternary(ccxt.IsTrue((ccxt.IsEqual(execType, "trade"))), "myTrades", "orders")
+            // a fill also changes the state of its order, except in the snapshot of the past trades
+            if ccxt.IsTrue(ccxt.IsTrue(ccxt.IsEqual(channel, "myTrades")) && ccxt.IsTrue(!ccxt.IsEqual(this.SafeString(message, "type"), "snapshot"))) {
+                this.HandleOrders(client, message)
+            }
         }
         var methods interface{} = map[string]interface{} {
             "balances": this.HandleBalance,
@@ -0,0 +1,147 @@
+package ccxtpro
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	ccxt "github.com/ccxt/ccxt/go/v4"
+)
+
+// ---------------------------------------------------------------------------
+// watchOrders: the executions snapshot fills the cache, the updates are merged by order id
+// ---------------------------------------------------------------------------
+
+// tokenTransport answers the rest request of the websocket token
+type tokenTransport struct{}
+
+func (tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: 200,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(`{"error":[],"result":{"token":"WW91ciB0b2tlbg","expires":900}}`)),
+		Request:    req,
+	}, nil
+}
+
+func newKrakenWsExchange(t *testing.T, server *wsTestServer) *Kraken {
+	exchange := NewKraken(map[string]interface{}{
+		"apiKey": "key",
+		"secret": "c2VjcmV0",
+	})
+	exchange.HttpProxy = &http.Transport{}
+	exchange.SetHTTPClient(&http.Client{Transport: tokenTransport{}})
+	ws := ccxt.GetValue(ccxt.GetValue(exchange.Urls, "api"), "ws")
+	ccxt.AddElementToObject(ws, "privateV2", server.wsUrl()+"/v2")
+	exchange.SetMarkets([]interface{}{
+		exchange.SafeMarketStructure(map[string]interface{}{
+			"id":      "XXBTZUSD",
+			"symbol":  "BTC/USD",
+			"base":    "BTC",
+			"quote":   "USD",
+			"baseId":  "XXBT",
+			"quoteId": "ZUSD",
+			"type":    "spot",
+			"spot":    true,
+			"active":  true,
+		}),
+	})
+	t.Cleanup(func() { exchange.Close() })
+	return exchange
+}
+
+type krakenOrdersResult struct {
+	orders []ccxt.Order
+	err    error
+}
+
+func TestKrakenWatchOrdersSnapshotAndPartialFill(t *testing.T) {
+	server := newWsTestServer(t)
+	exchange := newKrakenWsExchange(t, server)
+	watch := func() chan krakenOrdersResult {
+		results := make(chan krakenOrdersResult, 1)
+		go func() {
+			orders, err := exchange.WatchOrders(ccxt.WithWatchOrdersSymbol("BTC/USD"))
+			results <- krakenOrdersResult{orders, err}
+		}()
+		return results
+	}
+	receive := func(results chan krakenOrdersResult) map[string]ccxt.Order {
+		select {
+		case result := <-results:
+			if result.err != nil {
+				t.Fatal(result.err)
+			}
+			byId := map[string]ccxt.Order{}
+			for _, order := range result.orders {
+				byId[*order.Id] = order
+			}
+			return byId
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for watchOrders")
+		}
+		return nil
+	}
+	waitForFuture := func(messageHash string) {
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			for _, client := range exchange.Clients {
+				if _, ok := client.(ccxt.ClientInterface).GetFutures()[messageHash]; ok {
+					return
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		t.Fatalf("timed out waiting for a watcher on %s", messageHash)
+	}
+
+	results := watch()
+	conn := server.accept(t)
+	frame := readJSONFrame(t, conn)
+	params, _ := frame["params"].(map[string]interface{})
+	if frame["method"] != "subscribe" || params["channel"] != "executions" || params["snap_orders"] != true || params["token"] != "WW91ciB0b2tlbg" {
+		t.Fatalf("expected a subscription to the executions channel with the order snapshot, got %v", frame)
+	}
+	waitForFuture("orders:BTC/USD")
+	writeFrame(t, conn, `{"channel":"executions","type":"snapshot","data":[`+
+		`{"order_id":"OK4GJX-KSTLS-7DZZO5","symbol":"BTC/USD","order_qty":0.005,"cum_qty":0,"cum_cost":0,"time_in_force":"GTC","exec_type":"new","side":"sell","order_type":"limit","limit_price":26500.0,"order_status":"new","timestamp":"2023-09-22T10:33:05.709950Z"},`+
+		`{"order_id":"OGAB7Y-BKX5F-PTK5RW","symbol":"BTC/USD","order_qty":0.01,"cum_qty":0,"cum_cost":0,"time_in_force":"GTC","exec_type":"new","side":"buy","order_type":"limit","limit_price":25000.0,"order_status":"new","timestamp":"2023-09-22T10:34:00.000000Z"}`+
+		`],"sequence":1}`)
+	orders := receive(results)
+	if len(orders) != 2 {
+		t.Fatalf("expected the 2 orders of the snapshot, got %v", orders)
+	}
+	sell := orders["OK4GJX-KSTLS-7DZZO5"]
+	if *sell.Status != "open" || *sell.Amount != 0.005 || *sell.Filled != 0 || *sell.Price != 26500 {
+		t.Fatalf("unexpected order from the snapshot %+v", sell)
+	}
+
+	// each watch returns the orders updated since the previous one
+	// the fill only carries the fields that changed, the cancellation does not repeat the symbol
+	results = watch()
+	waitForFuture("orders:BTC/USD")
+	writeFrame(t, conn, `{"channel":"executions","type":"update","data":[`+
+		`{"order_id":"OK4GJX-KSTLS-7DZZO5","exec_id":"TZ63HS-YBBDB-ZJYSPK","exec_type":"trade","order_status":"partially_filled","symbol":"BTC/USD","side":"sell","last_qty":0.002,"last_price":26500.0,"cum_qty":0.002,"cum_cost":53.0,"avg_price":26500.0,"liquidity_ind":"m","timestamp":"2023-09-22T10:35:12.418236Z"}`+
+		`],"sequence":2}`)
+	orders = receive(results)
+	sell = orders["OK4GJX-KSTLS-7DZZO5"]
+	if *sell.Status != "open" || *sell.Amount != 0.005 || *sell.Filled != 0.002 || *sell.Remaining != 0.003 || *sell.Cost != 53 || *sell.Average != 26500 {
+		t.Fatalf("expected the partial fill merged into the order, got %+v", sell)
+	}
+	if *sell.Type != "limit" || *sell.Price != 26500 || *sell.Symbol != "BTC/USD" {
+		t.Fatalf("expected the fields of the snapshot to be kept, got %+v", sell)
+	}
+
+	results = watch()
+	waitForFuture("orders:BTC/USD")
+	writeFrame(t, conn, `{"channel":"executions","type":"update","data":[`+
+		`{"timestamp":"2025-10-11T15:11:47.695226Z","order_status":"canceled","exec_type":"canceled","order_userref":0,"order_id":"OGAB7Y-BKX5F-PTK5RW","cum_qty":0,"cum_cost":0,"fee_usd_equiv":0,"avg_price":0,"cancel_reason":"User requested","reason":"User requested"}`+
+		`],"sequence":3}`)
+	orders = receive(results)
+	buy := orders["OGAB7Y-BKX5F-PTK5RW"]
+	if len(orders) != 1 || *buy.Status != "canceled" || *buy.Symbol != "BTC/USD" || *buy.Amount != 0.01 {
+		t.Fatalf("expected the cancellation to update the cached order, got %+v", orders)
+	}
+}
@@ -42,6 +42,7 @@ func  (this *KucoinCore) Describe() interface{}  {
             "watchOrderBookForSymbols": true,
             "watchBalance": true,
             "watchOHLCV": true,
+            "watchOHLCVForSymbols": true,
             "unWatchTicker": true,
             "unWatchOHLCV": true,
             "unWatchOrderBook": true,
@@ -70,6 +71,9 @@ func  (this *KucoinCore) Describe() interface{}  {
                 "fetchPositionSnapshot": true,
                 "awaitPositionSnapshot": true,
             },
+            "watchOHLCVForSymbols": map[string]interface{} {
+                "topicsPerSubscription": 100,
+            },
         },
         "streaming": map[string]interface{} {
             "ping": this.Ping,
@@ -900,6 +904,93 @@ func  (this *KucoinCore) UnWatchOHLCV(symbol interface{}, optionalArgs ...interf
                 ch <- retRes67615
                 return nil
         
+            }()
+            return ch
+        }
+/**
+ * @method
+ * @name kucoin#watchOHLCVForSymbols
+ * @description watches historical candlestick data containing the open, high, low, and close price, and the volume of several markets over one connection
+ * @see https://www.kucoin.com/docs-new/3470071w0
+ * @see https://www.kucoin.com/docs-new/3470086w0
+ * @param {string[][]} symbolsAndTimeframes array of arrays containing unified symbols and timeframes to fetch ccxt.OHLCV data for, example [['BTC/USDT', '1m'], ['LTC/USDT', '5m']]
+ * @param {int} [since] timestamp in ms of the earliest candle to fetch
+ * @param {int} [limit] the maximum amount of candles to fetch
+ * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @param {int} [params.topicsPerSubscription] the maximum number of candle topics sent in one subscription, default 100
+ * @returns {object} A dictionary of candles keyed by symbol and timeframe, the candles are ordered as timestamp, open, high, low, close, volume
+ */
+func  (this *KucoinCore) WatchOHLCVForSymbols(symbolsAndTimeframes interface{}, optionalArgs ...interface{}) <- chan interface{} {
+            ch := make(chan interface{})
+            go func() interface{} {
+                defer close(ch)
+                defer ccxt.ReturnPanicError(ch)
+                    since := ccxt.GetArg(optionalArgs, 0, nil)
+            _ = since
+            limit := ccxt.GetArg(optionalArgs, 1, nil)
+            _ = limit
+            params := ccxt.GetArg(optionalArgs, 2, map[string]interface{} {})
+            _ = params
+            var symbolsLength interface{} =     ccxt.GetArrayLength(symbolsAndTimeframes)
+            if ccxt.IsTrue(ccxt.IsTrue(ccxt.IsEqual(symbolsLength, 0)) || !ccxt.IsTrue(ccxt.IsArray(ccxt.GetValue(symbolsAndTimeframes, 0)))) {
+                panic(ccxt.ArgumentsRequired(ccxt.Add(this.Id, " watchOHLCVForSymbols() requires a an array of symbols and timeframes, like  [['BTC/USDT', '1m'], ['LTC/USDT', '5m']]")))
+            }
+        
+            retRes6908 := (<-this.LoadMarkets())
+            ccxt.PanicOnError(retRes6908)
+            var topicsPerSubscription interface{} = nil
+            topicsPerSubscriptionparamsVariable := this.HandleOptionAndParams(params, "watchOHLCVForSymbols", "topicsPerSubscription", 100)
+            topicsPerSubscription = ccxt.GetValue(topicsPerSubscriptionparamsVariable,0)
+            params = ccxt.GetValue(topicsPerSubscriptionparamsVariable,1)
+            var firstMarket interface{} = this.Market(ccxt.GetValue(ccxt.GetValue(symbolsAndTimeframes, 0), 0))
+            var isFuturesMethod interface{} = ccxt.GetValue(firstMarket, "contract")
+        
+            url:= (<-this.Negotiate(false, isFuturesMethod))
+            ccxt.PanicOnError(url)
+            var channelName interface{} = "/market/candles:"
+            if ccxt.IsTrue(isFuturesMethod) {
+                channelName = "/contractMarket/limitCandle:"
+            }
+            var marketTopics interface{} = []interface{}{}
+            var subscriptionHashes interface{} = []interface{}{}
+            var messageHashes interface{} = []interface{}{}
+            for i := 0; ccxt.IsLessThan(i, symbolsLength); i++ {
+                var symbolAndTimeframe interface{} = ccxt.GetValue(symbolsAndTimeframes, i)
+                var market interface{} = this.Market(ccxt.GetValue(symbolAndTimeframe, 0))
+                if ccxt.IsTrue(!ccxt.IsEqual(ccxt.GetValue(market, "contract"), isFuturesMethod)) {
+                    panic(ccxt.BadRequest(ccxt.Add(this.Id, " watchOHLCVForSymbols() does not support mixing spot and contract markets")))
+                }
+                var timeframe interface{} = ccxt.GetValue(symbolAndTimeframe, 1)
+                var period interface{} = this.SafeString(this.Timeframes, timeframe, timeframe)
+                var marketTopic interface{} = ccxt.Add(ccxt.Add(ccxt.GetValue(market, "id"), "_"), period)
+                ccxt.AppendToArray(&marketTopics, marketTopic)
+                ccxt.AppendToArray(&subscriptionHashes, ccxt.Add(channelName, marketTopic))
+                ccxt.AppendToArray(&messageHashes, ccxt.Add(ccxt.Add(ccxt.Add("multi:candles:", ccxt.GetValue(market, "symbol")), ":"), timeframe))
+            }
+            // kucoin accepts a limited number of topics per subscription, the first batches
+            // are sent on their own and the last one awaits the candles of all the batches
+            var batchStart interface{} = 0
+            for ccxt.IsLessThan(ccxt.Add(batchStart, topicsPerSubscription), symbolsLength) {
+                var batchEnd interface{} = ccxt.Add(batchStart, topicsPerSubscription)
+                var batchTopic interface{} = ccxt.Add(channelName, ccxt.Join(this.ArraySlice(marketTopics, batchStart, batchEnd), ","))
+                this.Spawn(this.SubscribeMultiple, url, this.ArraySlice(messageHashes, batchStart, batchEnd), batchTopic, this.ArraySlice(subscriptionHashes, batchStart, batchEnd), params)
+                batchStart = batchEnd
+            }
+            var topic interface{} = ccxt.Add(channelName, ccxt.Join(this.ArraySlice(marketTopics, batchStart), ","))
+        
+            symboltimeframecandlesVariable := (<-this.SubscribeMultiple(url, messageHashes, topic, this.ArraySlice(subscriptionHashes, batchStart), params))
+            ccxt.PanicOnError(symboltimeframecandlesVariable)
+            symbol := ccxt.GetValue(symboltimeframecandlesVariable,0)
+            timeframe := ccxt.GetValue(symboltimeframecandlesVariable,1)
+            candles := ccxt.GetValue(symboltimeframecandlesVariable,2)
+            if ccxt.IsTrue(this.NewUpdates) {
+                limit = ccxt.ToGetsLimit(candles).GetLimit(symbol, limit)
+            }
+            var filtered interface{} = this.FilterBySinceLimit(candles, since, limit, 0, true)
+        
+            ch <- this.CreateOHLCVObject(symbol, timeframe, filtered)
+            return nil
+        
             }()
             return ch
         }
@@ -967,6 +1058,8 @@ func  (this *KucoinCore) HandleOHLCV(client interface{}, message interface{})  {
     var parsed interface{} = []interface{}{this.SafeTimestamp(candles, 0), this.SafeNumber(candles, 1), this.SafeNumber(candles, 3), this.SafeNumber(candles, 4), this.SafeNumber(candles, 2), this.SafeNumber(candles, baseVolumeIndex)}
     stored.(ccxt.Appender).Append(parsed)
     client.(ccxt.ClientInterface).Resolve(stored, messageHash)
+    // the candles do not carry their symbol and timeframe, watchOHLCVForSymbols gets them along
+    client.(ccxt.ClientInterface).Resolve([]interface{}{symbol, timeframe, stored}, ccxt.Add("multi:", messageHash))
 }
 /**
  * @method
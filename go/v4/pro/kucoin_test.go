@@ -0,0 +1,131 @@
+package ccxtpro
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	ccxt "github.com/ccxt/ccxt/go/v4"
+	"github.com/gorilla/websocket"
+)
+
+// ---------------------------------------------------------------------------
+// watchOHLCVForSymbols: candle topics are batched over one connection, updates are keyed by symbol and timeframe
+// ---------------------------------------------------------------------------
+
+func newKucoinWsExchange(t *testing.T, server *wsTestServer) *Kucoin {
+	exchange := NewKucoin(map[string]interface{}{})
+	// plain ws:// urls are only accepted with an http agent
+	exchange.HttpProxy = &http.Transport{}
+	// skip the bullet token negotiation
+	negotiated := ccxt.NewFuture()
+	negotiated.Resolve(server.wsUrl() + "/endpoint")
+	ccxt.AddElementToObject(exchange.Options, "urls", map[string]interface{}{"public": negotiated})
+	market := func(id string, symbol string, base string) interface{} {
+		return exchange.SafeMarketStructure(map[string]interface{}{
+			"id":      id,
+			"symbol":  symbol,
+			"base":    base,
+			"quote":   "USDT",
+			"baseId":  base,
+			"quoteId": "USDT",
+			"type":    "spot",
+			"spot":    true,
+			"active":  true,
+		})
+	}
+	exchange.SetMarkets([]interface{}{
+		market("BTC-USDT", "BTC/USDT", "BTC"),
+		market("ETH-USDT", "ETH/USDT", "ETH"),
+	})
+	t.Cleanup(func() { exchange.Close() })
+	return exchange
+}
+
+func sendKucoinCandle(t *testing.T, conn *websocket.Conn, marketId string, period string, close string) {
+	writeFrame(t, conn, `{"type":"message","topic":"/market/candles:`+marketId+`_`+period+`","subject":"trade.candles.update",`+
+		`"data":{"symbol":"`+marketId+`","candles":["1624881240","100","`+close+`","110","90","3","300"],"time":1624881284466023700}}`)
+}
+
+func waitForKucoinFuture(t *testing.T, exchange *Kucoin, messageHash string) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		for _, client := range exchange.Clients {
+			if _, ok := client.(ccxt.ClientInterface).GetFutures()[messageHash]; ok {
+				return
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for a watcher on %s", messageHash)
+}
+
+type kucoinOHLCVResult struct {
+	candles map[string]map[string][]ccxt.OHLCV
+	err     error
+}
+
+func TestKucoinWatchOHLCVForSymbols(t *testing.T) {
+	server := newWsTestServer(t)
+	exchange := newKucoinWsExchange(t, server)
+	symbolsAndTimeframes := [][]string{{"BTC/USDT", "1m"}, {"BTC/USDT", "1h"}, {"ETH/USDT", "1m"}, {"ETH/USDT", "1h"}}
+	watch := func() chan kucoinOHLCVResult {
+		results := make(chan kucoinOHLCVResult, 1)
+		go func() {
+			candles, err := exchange.WatchOHLCVForSymbols(symbolsAndTimeframes, ccxt.WithWatchOHLCVForSymbolsParams(map[string]interface{}{
+				"topicsPerSubscription": 2,
+			}))
+			results <- kucoinOHLCVResult{candles, err}
+		}()
+		return results
+	}
+
+	results := watch()
+	conn := server.accept(t)
+	// two topics per subscription, the batches may be sent in any order
+	topics := []string{}
+	for i := 0; i < 2; i++ {
+		frame := readJSONFrame(t, conn)
+		if frame["type"] != "subscribe" {
+			t.Fatalf("expected a subscription, got %v", frame)
+		}
+		topics = append(topics, ccxt.ToString(frame["topic"]))
+	}
+	sort.Strings(topics)
+	expected := []string{"/market/candles:BTC-USDT_1min,BTC-USDT_1hour", "/market/candles:ETH-USDT_1min,ETH-USDT_1hour"}
+	if !reflect.DeepEqual(topics, expected) {
+		t.Fatalf("expected the topics %v, got %v", expected, topics)
+	}
+
+	// every symbol and timeframe is delivered on its own
+	updates := []struct {
+		marketId, period, symbol, timeframe string
+		close                               float64
+	}{
+		{"ETH-USDT", "1hour", "ETH/USDT", "1h", 2001},
+		{"BTC-USDT", "1min", "BTC/USDT", "1m", 30001},
+		{"ETH-USDT", "1min", "ETH/USDT", "1m", 2002},
+		{"BTC-USDT", "1hour", "BTC/USDT", "1h", 30002},
+	}
+	for i, update := range updates {
+		if i > 0 {
+			results = watch()
+			waitForKucoinFuture(t, exchange, "multi:candles:"+update.symbol+":"+update.timeframe)
+		}
+		sendKucoinCandle(t, conn, update.marketId, update.period, ccxt.ToString(update.close))
+		select {
+		case result := <-results:
+			if result.err != nil {
+				t.Fatal(result.err)
+			}
+			candles := result.candles[update.symbol][update.timeframe]
+			if len(result.candles) != 1 || len(result.candles[update.symbol]) != 1 || len(candles) != 1 || candles[0].Close != update.close {
+				t.Fatalf("expected a %s %s candle closing at %v, got %v", update.symbol, update.timeframe, update.close, result.candles)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for watchOHLCVForSymbols")
+		}
+	}
+}
@@ -191,6 +191,54 @@ func (this *Kucoin) WatchOHLCV(symbol string, options ...ccxt.WatchOHLCVOptions)
     }
     return ccxt.NewOHLCVArray(res), nil
 }
+/**
+ * @method
+ * @name kucoin#watchOHLCVForSymbols
+ * @description watches historical candlestick data containing the open, high, low, and close price, and the volume of several markets over one connection
+ * @see https://www.kucoin.com/docs-new/3470071w0
+ * @see https://www.kucoin.com/docs-new/3470086w0
+ * @param {string[][]} symbolsAndTimeframes array of arrays containing unified symbols and timeframes to fetch ccxt.OHLCV data for, example [['BTC/USDT', '1m'], ['LTC/USDT', '5m']]
+ * @param {int} [since] timestamp in ms of the earliest candle to fetch
+ * @param {int} [limit] the maximum amount of candles to fetch
+ * @param {object} [params] extra parameters specific to the exchange API endpoint
+ * @param {int} [params.topicsPerSubscription] the maximum number of candle topics sent in one subscription, default 100
+ * @returns {object} A dictionary of candles keyed by symbol and timeframe, the candles are ordered as timestamp, open, high, low, close, volume
+ */
+func (this *Kucoin) WatchOHLCVForSymbols(symbolsAndTimeframes [][]string, options ...ccxt.WatchOHLCVForSymbolsOptions) (map[string]map[string][]ccxt.OHLCV, error) {
+
+    opts := ccxt.WatchOHLCVForSymbolsOptionsStruct{}
+
+    for _, opt := range options {
+        opt(&opts)
+    }
+
+    var since interface{} = nil
+    if opts.Since != nil {
+        since = *opts.Since
+    }
+
+    var limit interface{} = nil
+    if opts.Limit != nil {
+        limit = *opts.Limit
+    }
+
+    var params interface{} = nil
+    if opts.Params != nil {
+        params = *opts.Params
+    }
+    res := <- this.Core.WatchOHLCVForSymbols(symbolsAndTimeframes, since, limit, params)
+    if ccxt.IsError(res) {
+        return map[string]map[string][]ccxt.OHLCV{}, ccxt.CreateReturnError(res)
+    }
+    result := map[string]map[string][]ccxt.OHLCV{}
+    for symbol, timeframes := range res.(map[string]interface{}) {
+        result[symbol] = map[string][]ccxt.OHLCV{}
+        for timeframe, candles := range timeframes.(map[string]interface{}) {
+            result[symbol][timeframe] = ccxt.NewOHLCVArray(candles)
+        }
+    }
+    return result, nil
+}
 /**
  * @method
  * @name kucoin#unWatchOHLCV
@@ -830,7 +878,6 @@ func (this *Kucoin) WatchMarkPrice(symbol string, options ...ccxt.WatchMarkPrice
 func (this *Kucoin) WatchMarkPrices(options ...ccxt.WatchMarkPricesOptions) (ccxt.Tickers, error) {return this.exchangeTyped.WatchMarkPrices(options...)}
 func (this *Kucoin) WatchMyLiquidations(symbol string, options ...ccxt.WatchMyLiquidationsOptions) ([]ccxt.Liquidation, error) {return this.exchangeTyped.WatchMyLiquidations(symbol, options...)}
 func (this *Kucoin) WatchMyLiquidationsForSymbols(symbols []string, options ...ccxt.WatchMyLiquidationsForSymbolsOptions) ([]ccxt.Liquidation, error) {return this.exchangeTyped.WatchMyLiquidationsForSymbols(symbols, options...)}
-func (this *Kucoin) WatchOHLCVForSymbols(symbolsAndTimeframes [][]string, options ...ccxt.WatchOHLCVForSymbolsOptions) (map[string]map[string][]ccxt.OHLCV, error) {return this.exchangeTyped.WatchOHLCVForSymbols(symbolsAndTimeframes, options...)}
 func (this *Kucoin) WatchOrdersForSymbols(symbols []string, options ...ccxt.WatchOrdersForSymbolsOptions) ([]ccxt.Order, error) {return this.exchangeTyped.WatchOrdersForSymbols(symbols, options...)}
 func (this *Kucoin) WatchPositions(options ...ccxt.WatchPositionsOptions) ([]ccxt.Position, error) {return this.exchangeTyped.WatchPositions(options...)}
 func (this *Kucoin) WithdrawWs(code string, amount float64, address string, options ...ccxt.WithdrawWsOptions) (ccxt.Transaction, error) {return this.exchangeTyped.WithdrawWs(code, amount, address, options...)}
\ No newline at end of file
@@ -1,5 +1,6 @@
 package ccxtpro
 import ccxt "github.com/ccxt/ccxt/go/v4"
+import "time"
 
 // PLEASE DO NOT EDIT THIS FILE, IT IS GENERATED AND WILL BE OVERWRITTEN:
 // https://github.com/ccxt/ccxt/blob/master/CONTRIBUTING.md#how-to-contribute-code
@@ -60,6 +61,7 @@ func  (this *OkxCore) Describe() interface{}  {
         "options": map[string]interface{} {
             "watchOrderBook": map[string]interface{} {
                 "checksum": true,
+                "resubscribeOnChecksumError": true,
                 "depth": "books",
             },
             "watchBalance": "spot",
@@ -86,6 +88,11 @@ func  (this *OkxCore) Describe() interface{}  {
         "streaming": map[string]interface{} {
             "ping": this.Ping,
             "keepAlive": 18000,
+            "pingConfig": &ccxt.PingConfig{
+                Interval: 18000 * time.Millisecond,
+                Message: func() []byte { return []byte(ccxt.ToString(this.Ping(nil))) },
+                ExpectPong: true,
+            },
         },
     })
 }
@@ -1615,7 +1622,7 @@ func  (this *OkxCore) WatchOrderBookForSymbols(symbols interface{}, optionalArgs
  * @param {string[]} symbols unified array of symbols
  * @param {object} [params] extra parameters specific to the exchange API endpoint
  * @param {int} [params.limit] the maximum amount of order book entries to return
- * @param {string} [params.depth] okx order book depth, can be books, books5, books-l2-tbt, books50-l2-tbt, bbo-tbt
+ * @param {string} [params.depth] okx order book depth, can be books, books5, books-l2-tbt, books50-l2-tbt, bbo-tbt, every subscribed depth is unwatched if not set
  * @returns {object} A dictionary of [order book structures]{@link https://docs.ccxt.com/?id=order-book-structure} indexed by market symbols
  */
 func  (this *OkxCore) UnWatchOrderBookForSymbols(symbols interface{}, optionalArgs ...interface{}) <- chan interface{} {
@@ -1629,6 +1636,8 @@ func  (this *OkxCore) UnWatchOrderBookForSymbols(symbols interface{}, optionalAr
             retRes12508 := (<-this.LoadMarkets())
             ccxt.PanicOnError(retRes12508)
             symbols = this.MarketSymbols(symbols, nil, false)
+            // without an explicit depth every depth subscribed for the symbol is unwatched
+            var unsubscribeAllDepths interface{} = ccxt.IsTrue(ccxt.IsEqual(this.SafeString(params, "depth"), nil)) && ccxt.IsTrue(ccxt.IsEqual(this.SafeInteger(params, "limit"), nil))
             var depth interface{} = nil
             depthparamsVariable := this.HandleOptionAndParams(params, "watchOrderBook", "depth", "books")
             depth = ccxt.GetValue(depthparamsVariable,0)
@@ -1645,25 +1654,35 @@ func  (this *OkxCore) UnWatchOrderBookForSymbols(symbols interface{}, optionalAr
                     depth = "books"
                 }
             }
+            var url interface{} = this.GetUrl(depth, "public")
             var topics interface{} = []interface{}{}
             var subMessageHashes interface{} = []interface{}{}
             var messageHashes interface{} = []interface{}{}
             for i := 0; ccxt.IsLessThan(i, ccxt.GetArrayLength(symbols)); i++ {
                 var symbol interface{} = ccxt.GetValue(symbols, i)
-                ccxt.AppendToArray(&subMessageHashes, ccxt.Add(ccxt.Add(depth, ":"), symbol))
-                ccxt.AppendToArray(&messageHashes, ccxt.Add("unsubscribe:orderbook:", symbol))
+                var depths interface{} = []interface{}{depth}
+                if ccxt.IsTrue(unsubscribeAllDepths) {
+                    var subscribedDepths interface{} = this.GetOrderBookDepths(this.Client(url), symbol)
+                    if ccxt.IsTrue(ccxt.IsGreaterThan(ccxt.GetArrayLength(subscribedDepths), 0)) {
+                        depths = subscribedDepths
+                    }
+                }
                 var marketId interface{} = this.MarketId(symbol)
-                var topic interface{} = map[string]interface{} {
-                    "channel": depth,
-                    "instId": marketId,
+                for j := 0; ccxt.IsLessThan(j, ccxt.GetArrayLength(depths)); j++ {
+                    var channel interface{} = ccxt.GetValue(depths, j)
+                    ccxt.AppendToArray(&subMessageHashes, ccxt.Add(ccxt.Add(channel, ":"), symbol))
+                    ccxt.AppendToArray(&messageHashes, ccxt.Add(ccxt.Add(ccxt.Add("unsubscribe:orderbook:", channel), ":"), symbol))
+                    var topic interface{} = map[string]interface{} {
+                        "channel": channel,
+                        "instId": marketId,
+                    }
+                    ccxt.AppendToArray(&topics, topic)
                 }
-                ccxt.AppendToArray(&topics, topic)
             }
             var request interface{} = map[string]interface{} {
                 "op": "unsubscribe",
                 "args": topics,
             }
-            var url interface{} = this.GetUrl(depth, "public")
         
                 retRes128515 :=  (<-this.WatchMultiple(url, messageHashes, request, messageHashes))
                 ccxt.PanicOnError(retRes128515)
@@ -1681,7 +1700,7 @@ func  (this *OkxCore) UnWatchOrderBookForSymbols(symbols interface{}, optionalAr
  * @param {string} symbol unified array of symbols
  * @param {object} [params] extra parameters specific to the exchange API endpoint
  * @param {int} [params.limit] the maximum amount of order book entries to return
- * @param {string} [params.depth] okx order book depth, can be books, books5, books-l2-tbt, books50-l2-tbt, bbo-tbt
+ * @param {string} [params.depth] okx order book depth, can be books, books5, books-l2-tbt, books50-l2-tbt, bbo-tbt, every subscribed depth is unwatched if not set
  * @returns {object} A dictionary of [order book structures]{@link https://docs.ccxt.com/?id=order-book-structure} indexed by market symbols
  */
 func  (this *OkxCore) UnWatchOrderBook(symbol interface{}, optionalArgs ...interface{}) <- chan interface{} {
@@ -1777,11 +1796,16 @@ func  (this *OkxCore) HandleOrderBookMessage(client interface{}, message interfa
             error = ccxt.ChecksumError(ccxt.Add(ccxt.Add(this.Id, " "), this.OrderbookChecksumMessage(symbol)))
         }
         if ccxt.IsTrue(!ccxt.IsEqual(error, nil)) {
-            ccxt.Remove(client.(ccxt.ClientInterface).GetSubscriptions(), messageHash)
-            if ccxt.IsTrue(!ccxt.IsEqual(symbol, nil)) {
-                ccxt.Remove(this.Orderbooks, symbol)
+            var resubscribe interface{} = this.HandleOption("watchOrderBook", "resubscribeOnChecksumError", true)
+            if ccxt.IsTrue(ccxt.IsTrue(resubscribe) && ccxt.IsTrue(!ccxt.IsEqual(symbol, nil))) {
+                this.ResubscribeOrderBook(client, messageHash, symbol)
+            } else {
+                ccxt.Remove(client.(ccxt.ClientInterface).GetSubscriptions(), messageHash)
+                if ccxt.IsTrue(!ccxt.IsEqual(symbol, nil)) {
+                    ccxt.Remove(this.Orderbooks, symbol)
+                }
+                client.(ccxt.ClientInterface).Reject(error, messageHash)
             }
-            client.(ccxt.ClientInterface).Reject(error, messageHash)
         }
     }
     var timestamp interface{} = this.SafeInteger(message, "ts")
@@ -1790,6 +1814,20 @@ func  (this *OkxCore) HandleOrderBookMessage(client interface{}, message interfa
     ccxt.AddElementToObject(orderbook, "datetime", this.Iso8601(timestamp))
     return orderbook
 }
+// ResubscribeOrderBook drops the diverged book of symbol and subscribes its channel again, okx answers with a new
+// snapshot the pending watchers resolve with, the updates received meanwhile have no book to be applied to
+func  (this *OkxCore) ResubscribeOrderBook(client interface{}, messageHash interface{}, symbol interface{})  {
+    ccxt.Remove(this.Orderbooks, symbol)
+    var channel interface{} = ccxt.GetValue(ccxt.Split(messageHash, ":"), 0)
+    var request interface{} = map[string]interface{} {
+        "op": "subscribe",
+        "args": []interface{}{map[string]interface{} {
+        "channel": channel,
+        "instId": this.MarketId(symbol),
+    }},
+    }
+    this.Spawn(client.(ccxt.ClientInterface).Send, request)
+}
 func  (this *OkxCore) HandleOrderBook(client interface{}, message interface{}) interface{}  {
     //
     // snapshot
@@ -1899,7 +1937,10 @@ func  (this *OkxCore) HandleOrderBook(client interface{}, message interface{}) i
             ccxt.AddElementToObject(this.Orderbooks, symbol, orderbook)
             ccxt.AddElementToObject(orderbook, "symbol", symbol)
             this.HandleOrderBookMessage(client, update, orderbook, messageHash)
-            client.(ccxt.ClientInterface).Resolve(orderbook, messageHash)
+            // a book that failed its checksum is dropped, it is not delivered
+            if ccxt.IsTrue(ccxt.InOp(this.Orderbooks, symbol)) {
+                client.(ccxt.ClientInterface).Resolve(orderbook, messageHash)
+            }
         }
     } else if ccxt.IsTrue(ccxt.IsEqual(action, "update")) {
         if ccxt.IsTrue(ccxt.InOp(this.Orderbooks, symbol)) {
@@ -1907,6 +1948,9 @@ func  (this *OkxCore) HandleOrderBook(client interface{}, message interface{}) i
             for i := 0; ccxt.IsLessThan(i, ccxt.GetArrayLength(data)); i++ {
                 var update interface{} = ccxt.GetValue(data, i)
                 this.HandleOrderBookMessage(client, update, orderbook, messageHash, market)
+                if !ccxt.IsTrue(ccxt.InOp(this.Orderbooks, symbol)) {
+                    break
+                }
                 client.(ccxt.ClientInterface).Resolve(orderbook, messageHash)
             }
         }
@@ -3175,12 +3219,26 @@ func  (this *OkxCore) HandleUnSubscriptionTrades(client interface{}, symbol inte
 }
 func  (this *OkxCore) HandleUnsubscriptionOrderBook(client interface{}, symbol interface{}, channel interface{})  {
     var subMessageHash interface{} = ccxt.Add(ccxt.Add(channel, ":"), symbol)
-    var messageHash interface{} = ccxt.Add("unsubscribe:orderbook:", symbol)
+    var messageHash interface{} = ccxt.Add(ccxt.Add(ccxt.Add("unsubscribe:orderbook:", channel), ":"), symbol)
     this.CleanUnsubscription(client.(*ccxt.Client), subMessageHash, messageHash)
-    if ccxt.IsTrue(ccxt.InOp(this.Orderbooks, symbol)) {
+    // the depths of a symbol share the stored order book, keep it while another depth is subscribed
+    var remainingDepths interface{} = this.GetOrderBookDepths(client, symbol)
+    if ccxt.IsTrue(ccxt.IsTrue(ccxt.IsEqual(ccxt.GetArrayLength(remainingDepths), 0)) && ccxt.IsTrue(ccxt.InOp(this.Orderbooks, symbol))) {
         ccxt.Remove(this.Orderbooks, symbol)
     }
 }
+func  (this *OkxCore) GetOrderBookDepths(client interface{}, symbol interface{}) interface{}  {
+    var channels interface{} = []interface{}{"bbo-tbt", "books", "books5", "books-l2-tbt", "books50-l2-tbt"}
+    var subscriptions interface{} = client.(ccxt.ClientInterface).GetSubscriptions()
+    var depths interface{} = []interface{}{}
+    for i := 0; ccxt.IsLessThan(i, ccxt.GetArrayLength(channels)); i++ {
+        var channel interface{} = ccxt.GetValue(channels, i)
+        if ccxt.IsTrue(ccxt.InOp(subscriptions, ccxt.Add(ccxt.Add(channel, ":"), symbol))) {
+            ccxt.AppendToArray(&depths, channel)
+        }
+    }
+    return depths
+}
 func  (this *OkxCore) HandleUnsubscriptionOHLCV(client interface{}, symbol interface{}, channel interface{})  {
     var tf interface{} = ccxt.Replace(channel, "candle", "")
     var timeframe interface{} = this.FindTimeframe(tf)
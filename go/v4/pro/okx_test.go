@@ -0,0 +1,264 @@
+package ccxtpro
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	ccxt "github.com/ccxt/ccxt/go/v4"
+	"github.com/gorilla/websocket"
+)
+
+// ---------------------------------------------------------------------------
+// unWatchOrderBook: the books channel is unsubscribed and the book is dropped
+// ---------------------------------------------------------------------------
+
+func newOkxWsExchange(t *testing.T, server *wsTestServer) *Okx {
+	return newOkxWsExchangeWithChecksum(t, server, false)
+}
+
+func newOkxWsExchangeWithChecksum(t *testing.T, server *wsTestServer, checksum bool) *Okx {
+	exchange := NewOkx(map[string]interface{}{
+		"options": map[string]interface{}{
+			"watchOrderBook": map[string]interface{}{
+				"checksum": checksum,
+			},
+		},
+	})
+	// plain ws:// urls are only accepted with an http agent
+	exchange.HttpProxy = &http.Transport{}
+	ccxt.AddElementToObject(ccxt.GetValue(exchange.Urls, "api"), "ws", server.wsUrl()+"/ws/v5")
+	exchange.SetMarkets([]interface{}{
+		exchange.SafeMarketStructure(map[string]interface{}{
+			"id":      "BTC-USDT",
+			"symbol":  "BTC/USDT",
+			"base":    "BTC",
+			"quote":   "USDT",
+			"baseId":  "BTC",
+			"quoteId": "USDT",
+			"type":    "spot",
+			"spot":    true,
+			"active":  true,
+		}),
+	})
+	t.Cleanup(func() { exchange.Close() })
+	return exchange
+}
+
+func okxBookTopics(channels ...string) []interface{} {
+	topics := []interface{}{}
+	for _, channel := range channels {
+		topics = append(topics, map[string]interface{}{"channel": channel, "instId": "BTC-USDT"})
+	}
+	return topics
+}
+
+func expectOkxFrame(t *testing.T, conn *websocket.Conn, op string, channels ...string) {
+	expected := map[string]interface{}{"op": op, "args": okxBookTopics(channels...)}
+	if frame := readJSONFrame(t, conn); !reflect.DeepEqual(frame, expected) {
+		t.Fatalf("expected the frame %v, got %v", expected, frame)
+	}
+}
+
+func sendOkxBookSnapshot(t *testing.T, conn *websocket.Conn, channel string) {
+	writeFrame(t, conn, `{"arg":{"channel":"`+channel+`","instId":"BTC-USDT"},"action":"snapshot","data":[{`+
+		`"asks":[["31685","0.78","0","17"]],"bids":[["31684.9","0.01","0","1"]],"ts":"1626532416403","seqId":1,"prevSeqId":-1}]}`)
+}
+
+func ackOkxUnsubscribe(t *testing.T, conn *websocket.Conn, channel string) {
+	writeFrame(t, conn, `{"event":"unsubscribe","arg":{"channel":"`+channel+`","instId":"BTC-USDT"},"connId":"a4d3ae55"}`)
+}
+
+type okxWatchResult struct {
+	value interface{}
+	err   error
+}
+
+func watchOrderBookAsync(exchange *Okx, options ...ccxt.WatchOrderBookOptions) chan okxWatchResult {
+	results := make(chan okxWatchResult, 1)
+	go func() {
+		orderbook, err := exchange.WatchOrderBook("BTC/USDT", options...)
+		results <- okxWatchResult{orderbook, err}
+	}()
+	return results
+}
+
+func unWatchOrderBookAsync(exchange *Okx, options ...ccxt.UnWatchOrderBookOptions) chan okxWatchResult {
+	results := make(chan okxWatchResult, 1)
+	go func() {
+		res, err := exchange.UnWatchOrderBook("BTC/USDT", options...)
+		results <- okxWatchResult{res, err}
+	}()
+	return results
+}
+
+func receiveOkx(t *testing.T, results chan okxWatchResult) okxWatchResult {
+	select {
+	case result := <-results:
+		return result
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the result")
+	}
+	return okxWatchResult{}
+}
+
+func hasOrderBook(exchange *Okx, symbol string) bool {
+	return ccxt.IsTrue(ccxt.InOp(exchange.Orderbooks, symbol))
+}
+
+func TestOkxUnWatchOrderBook(t *testing.T) {
+	server := newWsTestServer(t)
+	exchange := newOkxWsExchange(t, server)
+
+	watch := watchOrderBookAsync(exchange)
+	conn := server.accept(t)
+	expectOkxFrame(t, conn, "subscribe", "books")
+	sendOkxBookSnapshot(t, conn, "books")
+	if result := receiveOkx(t, watch); result.err != nil {
+		t.Fatal(result.err)
+	}
+
+	unwatch := unWatchOrderBookAsync(exchange)
+	expectOkxFrame(t, conn, "unsubscribe", "books")
+	ackOkxUnsubscribe(t, conn, "books")
+	if result := receiveOkx(t, unwatch); result.err != nil || result.value != true {
+		t.Fatalf("expected the unwatch to succeed, got %v, %v", result.value, result.err)
+	}
+	if hasOrderBook(exchange, "BTC/USDT") {
+		t.Fatal("expected the order book to be removed")
+	}
+}
+
+func TestOkxUnWatchOrderBookWithSeveralDepths(t *testing.T) {
+	server := newWsTestServer(t)
+	exchange := newOkxWsExchange(t, server)
+
+	books := watchOrderBookAsync(exchange)
+	conn := server.accept(t)
+	expectOkxFrame(t, conn, "subscribe", "books")
+	books5 := watchOrderBookAsync(exchange, ccxt.WithWatchOrderBookParams(map[string]interface{}{"depth": "books5"}))
+	expectOkxFrame(t, conn, "subscribe", "books5")
+	sendOkxBookSnapshot(t, conn, "books")
+	if result := receiveOkx(t, books); result.err != nil {
+		t.Fatal(result.err)
+	}
+
+	// only the requested depth is unsubscribed, the book is still fed by the other one
+	unwatch := unWatchOrderBookAsync(exchange, ccxt.WithUnWatchOrderBookParams(map[string]interface{}{"depth": "books5"}))
+	expectOkxFrame(t, conn, "unsubscribe", "books5")
+	ackOkxUnsubscribe(t, conn, "books5")
+	if result := receiveOkx(t, unwatch); result.err != nil {
+		t.Fatal(result.err)
+	}
+	if err, ok := receiveOkx(t, books5).err.(*ccxt.Error); !ok || err.Type != "UnsubscribeError" {
+		t.Fatalf("expected the books5 watcher to be released with an UnsubscribeError, got %v", err)
+	}
+	if !hasOrderBook(exchange, "BTC/USDT") {
+		t.Fatal("expected the order book to be kept while books is subscribed")
+	}
+
+	// without a depth every remaining depth of the symbol is unsubscribed
+	unwatch = unWatchOrderBookAsync(exchange)
+	expectOkxFrame(t, conn, "unsubscribe", "books")
+	ackOkxUnsubscribe(t, conn, "books")
+	if result := receiveOkx(t, unwatch); result.err != nil {
+		t.Fatal(result.err)
+	}
+	if hasOrderBook(exchange, "BTC/USDT") {
+		t.Fatal("expected the order book to be removed")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// watchOrderBook checksum: a book that no longer matches the crc32 of okx is subscribed again
+// ---------------------------------------------------------------------------
+
+func sendOkxBookUpdate(t *testing.T, conn *websocket.Conn, action string, data string) {
+	writeFrame(t, conn, `{"arg":{"channel":"books","instId":"BTC-USDT"},"action":"`+action+`","data":[`+data+`]}`)
+}
+
+// waitForOkxWatcher blocks until a watcher is pending on the given message hash
+func waitForOkxWatcher(t *testing.T, exchange *Okx, messageHash string) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		for _, client := range exchange.Clients {
+			if _, ok := client.(ccxt.ClientInterface).GetFutures()[messageHash]; ok {
+				return
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for a watcher on %s", messageHash)
+}
+
+func receiveOkxOrderBook(t *testing.T, results chan okxWatchResult) ccxt.OrderBook {
+	result := receiveOkx(t, results)
+	if result.err != nil {
+		t.Fatal(result.err)
+	}
+	return result.value.(ccxt.OrderBook)
+}
+
+func TestOkxWatchOrderBookChecksum(t *testing.T) {
+	server := newWsTestServer(t)
+	exchange := newOkxWsExchangeWithChecksum(t, server, true)
+
+	// the checksums cover the interleaved bid and ask levels, bid0:ask0:bid1:ask1
+	watch := watchOrderBookAsync(exchange)
+	conn := server.accept(t)
+	expectOkxFrame(t, conn, "subscribe", "books")
+	sendOkxBookUpdate(t, conn, "snapshot", `{"asks":[["31685","0.78","0","17"],["31686.1","0.5","0","2"]],`+
+		`"bids":[["31684.9","0.01","0","1"],["31684.5","1.2","0","3"]],"ts":"1626532416403","checksum":-1522290010,"seqId":1,"prevSeqId":-1}`)
+	book := receiveOkxOrderBook(t, watch)
+	if *book.Nonce != 1 || len(book.Bids) != 2 || len(book.Asks) != 2 {
+		t.Fatalf("unexpected snapshot %+v", book)
+	}
+
+	watch = watchOrderBookAsync(exchange)
+	waitForOkxWatcher(t, exchange, "books:BTC/USDT")
+	sendOkxBookUpdate(t, conn, "update", `{"asks":[["31685.5","0.3","0","1"]],"bids":[["31684.5","0","0","0"]],`+
+		`"ts":"1626532416503","checksum":382480531,"seqId":2,"prevSeqId":1}`)
+	book = receiveOkxOrderBook(t, watch)
+	if *book.Nonce != 2 || !reflect.DeepEqual(book.Bids, [][]float64{{31684.9, 0.01}}) || len(book.Asks) != 3 {
+		t.Fatalf("unexpected book after the update %+v", book)
+	}
+
+	// the corrupted update is not delivered, the book is dropped and the channel subscribed again
+	watch = watchOrderBookAsync(exchange)
+	waitForOkxWatcher(t, exchange, "books:BTC/USDT")
+	sendOkxBookUpdate(t, conn, "update", `{"asks":[],"bids":[["31684.8","2","0","1"]],`+
+		`"ts":"1626532416603","checksum":12345,"seqId":3,"prevSeqId":2}`)
+	expectOkxFrame(t, conn, "subscribe", "books")
+	if hasOrderBook(exchange, "BTC/USDT") {
+		t.Fatal("expected the corrupted order book to be dropped")
+	}
+	select {
+	case result := <-watch:
+		t.Fatalf("expected the watcher to wait for the new snapshot, got %v, %v", result.value, result.err)
+	default:
+	}
+
+	// the watcher resolves with the new snapshot
+	sendOkxBookUpdate(t, conn, "snapshot", `{"asks":[["31690.2","0.6","0","1"]],"bids":[["31690.1","0.4","0","1"]],`+
+		`"ts":"1626532417000","checksum":-526001095,"seqId":10,"prevSeqId":-1}`)
+	book = receiveOkxOrderBook(t, watch)
+	if *book.Nonce != 10 || !reflect.DeepEqual(book.Bids, [][]float64{{31690.1, 0.4}}) || !reflect.DeepEqual(book.Asks, [][]float64{{31690.2, 0.6}}) {
+		t.Fatalf("unexpected book after the resubscription %+v", book)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// pingConfig: okx keeps the connection alive with a text ping answered by a pong
+// ---------------------------------------------------------------------------
+
+func TestOkxPingConfig(t *testing.T) {
+	exchange := NewOkx(nil)
+	config := exchange.WsPingConfig()
+	if config == nil {
+		t.Fatal("expected okx to set a ping config")
+	}
+	if config.Interval != 18*time.Second || !config.ExpectPong || config.Message == nil || string(config.Message()) != "ping" {
+		t.Fatalf("unexpected ping config %+v", config)
+	}
+}
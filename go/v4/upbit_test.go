@@ -0,0 +1,116 @@
+package ccxt
+
+import (
+	"strings"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// fetchOrderBooks: the books of several markets come from a single orderbook request
+// ---------------------------------------------------------------------------
+
+func newMockedUpbit(body string) (*UpbitCore, *mockTransport) {
+	exchange := NewUpbitCore()
+	exchange.Init(map[string]interface{}{})
+	transport := &mockTransport{body: body}
+	exchange.httpClient.Transport = transport
+	markets := []interface{}{}
+	for _, id := range []string{"KRW-BTC", "BTC-ETH"} {
+		parts := strings.Split(id, "-")
+		markets = append(markets, exchange.SafeMarketStructure(map[string]interface{}{
+			"id":      id,
+			"symbol":  parts[1] + "/" + parts[0],
+			"base":    parts[1],
+			"quote":   parts[0],
+			"baseId":  parts[1],
+			"quoteId": parts[0],
+			"type":    "spot",
+			"spot":    true,
+			"active":  true,
+		}))
+	}
+	exchange.SetMarkets(markets)
+	return exchange, transport
+}
+
+func TestUpbitFetchOrderBooks(t *testing.T) {
+	exchange, transport := newMockedUpbit(`[
+		{"market":"KRW-BTC","timestamp":1542899034662,"total_ask_size":12.89,"total_bid_size":4.88,"orderbook_units":[
+			{"ask_price":5164000,"bid_price":5162000,"ask_size":2.57606495,"bid_size":0.214},
+			{"ask_price":5176000,"bid_price":5152000,"ask_size":2.752,"bid_size":0.4650305}
+		]},
+		{"market":"BTC-ETH","timestamp":1542899030043,"total_ask_size":109.57,"total_bid_size":125.74,"orderbook_units":[
+			{"ask_price":0.02926679,"bid_price":0.02919904,"ask_size":4.20293961,"bid_size":11.65043576}
+		]}
+	]`)
+	result := <-exchange.FetchOrderBooks([]interface{}{"BTC/KRW", "ETH/BTC"}, 2)
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if len(transport.requests) != 1 {
+		t.Fatalf("expected a single request for both markets, got %d", len(transport.requests))
+	}
+	request := transport.requests[0]
+	if !strings.HasSuffix(request.URL.Path, "/orderbook") || request.URL.Query().Get("markets") != "KRW-BTC,BTC-ETH" {
+		t.Fatalf("unexpected request %s", request.URL.String())
+	}
+	books := NewOrderBooks(result).OrderBooks
+	if len(books) != 2 {
+		t.Fatalf("expected 2 order books, got %v", result)
+	}
+	btc := books["BTC/KRW"]
+	if *btc.Symbol != "BTC/KRW" || *btc.Timestamp != 1542899034662 || len(btc.Bids) != 2 || len(btc.Asks) != 2 {
+		t.Fatalf("unexpected BTC/KRW book %v", GetValue(result, "BTC/KRW"))
+	}
+	// bids are sorted from the best price down, asks from the best price up
+	if btc.Bids[0][0] != 5162000 || btc.Bids[1][0] != 5152000 || btc.Asks[0][0] != 5164000 || btc.Asks[1][1] != 2.752 {
+		t.Fatalf("unexpected BTC/KRW levels bids %v asks %v", btc.Bids, btc.Asks)
+	}
+	eth := books["ETH/BTC"]
+	if *eth.Symbol != "ETH/BTC" || len(eth.Bids) != 1 || eth.Bids[0][1] != 11.65043576 || eth.Asks[0][0] != 0.02926679 {
+		t.Fatalf("unexpected ETH/BTC book %v", GetValue(result, "ETH/BTC"))
+	}
+}
+
+// ---------------------------------------------------------------------------
+// fetchCanceledAndClosedOrders: the default merges the closed and the canceled orders
+// ---------------------------------------------------------------------------
+
+func TestUpbitFetchCanceledAndClosedOrdersMergesById(t *testing.T) {
+	exchange, transport := newMockedUpbit("")
+	exchange.ApiKey = "key"
+	exchange.Secret = "secret"
+	upbitOrder := func(uuid string, state string, createdAt string) string {
+		return `{"uuid":"` + uuid + `","side":"bid","ord_type":"limit","price":"5000000","state":"` + state + `","market":"KRW-BTC","created_at":"` + createdAt + `","volume":"1","remaining_volume":"0.5","executed_volume":"0.5","trades_count":1}`
+	}
+	transport.queue = []string{
+		// closed orders
+		"[" + upbitOrder("b", "done", "2024-01-01T10:00:02+09:00") + "," + upbitOrder("c", "cancel", "2024-01-01T10:00:03+09:00") + "]",
+		// canceled orders, the partially filled one is reported again
+		"[" + upbitOrder("c", "cancel", "2024-01-01T10:00:03+09:00") + "," + upbitOrder("a", "cancel", "2024-01-01T10:00:01+09:00") + "]",
+	}
+	result := <-exchange.FetchCanceledAndClosedOrders("BTC/KRW")
+	if IsError(result) {
+		t.Fatal(result)
+	}
+	if len(transport.requests) != 2 {
+		t.Fatalf("expected the closed and the canceled orders to be requested, got %d requests", len(transport.requests))
+	}
+	orders := NewOrderArray(result)
+	ids := []string{}
+	for _, order := range orders {
+		ids = append(ids, *order.Id)
+	}
+	if strings.Join(ids, ",") != "a,b,c" {
+		t.Fatalf("expected the orders de-duplicated and sorted by timestamp, got %v", ids)
+	}
+	// without since the limit keeps the latest orders
+	transport.queue = []string{
+		"[" + upbitOrder("b", "done", "2024-01-01T10:00:02+09:00") + "," + upbitOrder("c", "cancel", "2024-01-01T10:00:03+09:00") + "]",
+		"[" + upbitOrder("a", "cancel", "2024-01-01T10:00:01+09:00") + "]",
+	}
+	result = <-exchange.FetchCanceledAndClosedOrders("BTC/KRW", nil, 2)
+	if orders := NewOrderArray(result); len(orders) != 2 || *orders[0].Id != "b" || *orders[1].Id != "c" {
+		t.Fatalf("expected the 2 latest orders, got %v", result)
+	}
+}
@@ -4718,6 +4718,8 @@ func (this *XtCore) SetLeverage(leverage interface{}, optionalArgs ...interface{
 		_ = symbol
 		params := GetArg(optionalArgs, 1, map[string]interface{}{})
 		_ = params
+		retRes47217 := (<-this.CheckLeverage(leverage, symbol, params))
+		PanicOnError(retRes47217)
 		if IsTrue(IsEqual(symbol, nil)) {
 			panic(ArgumentsRequired(Add(this.Id, " setLeverage() requires a symbol argument")))
 		}
@@ -3128,6 +3128,7 @@ export default class ascendex extends Exchange {
      * @returns {object} response from the exchange
      */
     async setLeverage (leverage: int, symbol: Str = undefined, params = {}) {
+        await this.checkLeverage (leverage, symbol, params);
         if (symbol === undefined) {
             throw new ArgumentsRequired (this.id + ' setLeverage() requires a symbol argument');
         }
@@ -368,6 +368,9 @@ export default class Exchange {
     };
 
     markets_by_id: Dictionary<any> = undefined;
+    marketsByType: Dictionary<string[]> = undefined;
+    marketsByBase: Dictionary<string[]> = undefined;
+    marketsByQuote: Dictionary<string[]> = undefined;
     symbols: Strings = undefined;
     ids: Strings = undefined;
     currencies: Currencies = {};
@@ -4033,9 +4036,27 @@ export default class Exchange {
         this.currencies_by_id = this.indexBySafe (this.currencies, 'id');
         const currenciesSortedByCode = this.keysort (this.currencies);
         this.codes = Object.keys (currenciesSortedByCode);
+        // the symbols indexed by type, base and quote, rebuilt with the markets
+        this.marketsByType = this.indexSymbolsBy ('type');
+        this.marketsByBase = this.indexSymbolsBy ('base');
+        this.marketsByQuote = this.indexSymbolsBy ('quote');
         return this.markets;
     }
 
+    indexSymbolsBy (key: string) {
+        const result: Dict = {};
+        for (let i = 0; i < this.symbols.length; i++) {
+            const symbol = this.symbols[i];
+            const value = this.safeString (this.markets[symbol], key);
+            if (value !== undefined) {
+                const symbols = this.safeList (result, value, []);
+                symbols.push (symbol);
+                result[value] = symbols;
+            }
+        }
+        return result;
+    }
+
     setMarketsFromExchange (sourceExchange) {
         // Validate that both exchanges are of the same type
         if (this.id !== sourceExchange.id) {
@@ -4055,6 +4076,9 @@ export default class Exchange {
         this.baseCurrencies = sourceExchange.baseCurrencies;
         this.quoteCurrencies = sourceExchange.quoteCurrencies;
         this.codes = sourceExchange.codes;
+        this.marketsByType = sourceExchange.marketsByType;
+        this.marketsByBase = sourceExchange.marketsByBase;
+        this.marketsByQuote = sourceExchange.marketsByQuote;
         // check marketHelperProps
         const sourceExchangeHelpers = this.safeList (sourceExchange.options, 'marketHelperProps', []);
         for (let i = 0; i < sourceExchangeHelpers.length; i++) {
@@ -6005,7 +6029,18 @@ export default class Exchange {
     }
 
     async fetchStatus (params = {}): Promise<any> {
-        throw new NotSupported (this.id + ' fetchStatus() is not supported yet');
+        // without a status endpoint the exchange is assumed to be operational
+        let updated = this.milliseconds ();
+        if (this.has['fetchTime']) {
+            updated = await this.fetchTime (params);
+        }
+        return {
+            'status': 'ok',
+            'updated': updated,
+            'eta': undefined,
+            'url': undefined,
+            'info': undefined,
+        };
     }
 
     async fetchTransactionFee (code: string, params = {}) {
@@ -6985,6 +7020,28 @@ export default class Exchange {
     }
 
     async fetchCanceledAndClosedOrders (symbol: Str = undefined, since: Int = undefined, limit: Int = undefined, params = {}): Promise<Order[]> {
+        if (this.has['fetchClosedOrders'] && this.has['fetchCanceledOrders']) {
+            const closed = await this.fetchClosedOrders (symbol, since, limit, params);
+            const canceled = await this.fetchCanceledOrders (symbol, since, limit, params);
+            // an order canceled after a partial fill can be reported by both endpoints
+            let orders = [];
+            const seen: Dict = {};
+            const merged = this.arrayConcat (closed, canceled);
+            for (let i = 0; i < merged.length; i++) {
+                const order = merged[i];
+                const id = this.safeString (order, 'id');
+                if (id !== undefined) {
+                    if (id in seen) {
+                        continue;
+                    }
+                    seen[id] = true;
+                }
+                orders.push (order);
+            }
+            orders = this.sortBy (orders, 'timestamp');
+            // without since the limit keeps the latest orders
+            return this.filterBySinceLimit (orders, since, limit, 'timestamp', since === undefined) as Order[];
+        }
         throw new NotSupported (this.id + ' fetchCanceledAndClosedOrders() is not supported yet');
     }
 
@@ -7040,13 +7097,20 @@ export default class Exchange {
         /**
          * @method
          * @name exchange#fetchDepositsWithdrawals
-         * @description fetch history of deposits and withdrawals
-         * @param {string} [code] unified currency code for the currency of the deposit/withdrawals, default is undefined
+         * @description fetch the deposits and the withdrawals of an account sorted by timestamp, exchanges without an endpoint for both fetch the deposits and the withdrawals separately
+         * @param {string} code unified currency code for the currency of the deposit/withdrawals, default is undefined
          * @param {int} [since] timestamp in ms of the earliest deposit/withdrawal, default is undefined
          * @param {int} [limit] max number of deposit/withdrawals to return, default is undefined
          * @param {object} [params] extra parameters specific to the exchange API endpoint
          * @returns {object} a list of [transaction structures]{@link https://docs.ccxt.com/?id=transaction-structure}
          */
+        if (this.has['fetchDeposits'] && this.has['fetchWithdrawals']) {
+            const deposits = await this.fetchDeposits (code, since, limit, params);
+            const withdrawals = await this.fetchWithdrawals (code, since, limit, params);
+            const transactions = this.sortBy (this.arrayConcat (deposits, withdrawals), 'timestamp');
+            // without since the limit keeps the latest transactions
+            return this.filterBySinceLimit (transactions, since, limit, 'timestamp', since === undefined) as Transaction[];
+        }
         throw new NotSupported (this.id + ' fetchDepositsWithdrawals() is not supported yet');
     }
 
@@ -7266,7 +7330,8 @@ export default class Exchange {
             return undefined;
         }
         const market = this.market (symbol);
-        return this.decimalToPrecision (cost, TRUNCATE, market['precision']['price'], this.precisionMode, this.paddingMode);
+        const precision = this.safeValue2 (market['precision'], 'cost', 'price');
+        return this.decimalToPrecision (cost, this.precisionRoundingMode ('costRoundingMode', TRUNCATE), precision, this.precisionMode, this.paddingMode);
     }
 
     priceToPrecision (symbol: string, price): string {
@@ -7274,7 +7339,7 @@ export default class Exchange {
             return undefined;
         }
         const market = this.market (symbol);
-        const result = this.decimalToPrecision (price, ROUND, market['precision']['price'], this.precisionMode, this.paddingMode);
+        const result = this.decimalToPrecision (price, this.precisionRoundingMode ('priceRoundingMode', ROUND), market['precision']['price'], this.precisionMode, this.paddingMode);
         if (result === '0') {
             throw new InvalidOrder (this.id + ' price of ' + market['symbol'] + ' must be greater than minimum price precision of ' + this.numberToString (market['precision']['price']));
         }
@@ -7286,7 +7351,7 @@ export default class Exchange {
             return undefined;
         }
         const market = this.market (symbol);
-        const result = this.decimalToPrecision (amount, TRUNCATE, market['precision']['amount'], this.precisionMode, this.paddingMode);
+        const result = this.decimalToPrecision (amount, this.precisionRoundingMode ('amountRoundingMode', TRUNCATE), market['precision']['amount'], this.precisionMode, this.paddingMode);
         if (result === '0') {
             throw new InvalidOrder (this.id + ' amount of ' + market['symbol'] + ' must be greater than minimum amount precision of ' + this.numberToString (market['precision']['amount']));
         }
@@ -7312,11 +7377,34 @@ export default class Exchange {
         if (precision === undefined) {
             return this.forceString (fee);
         } else {
-            const roundingMode = this.safeInteger (this.options, 'currencyToPrecisionRoundingMode', ROUND);
+            const roundingMode = this.precisionRoundingMode ('currencyToPrecisionRoundingMode', ROUND);
             return this.decimalToPrecision (fee, roundingMode, precision, this.precisionMode, this.paddingMode);
         }
     }
 
+    precisionRoundingMode (key: string, defaultMode: number) {
+        // options[key] is ROUND/TRUNCATE or their names, venues that round amounts set options.amountRoundingMode to ROUND
+        const value = this.safeValue (this.options, key);
+        if (value === undefined) {
+            return defaultMode;
+        }
+        if (typeof value === 'string') {
+            const modes: Dict = {
+                'ROUND': ROUND,
+                'TRUNCATE': TRUNCATE,
+            };
+            const mode = this.safeInteger (modes, value.toUpperCase ());
+            if (mode === undefined) {
+                throw new BadRequest (this.id + ' options.' + key + ' must be ROUND or TRUNCATE, got ' + value);
+            }
+            return mode;
+        }
+        if ((value !== ROUND) && (value !== TRUNCATE)) {
+            throw new BadRequest (this.id + ' options.' + key + ' must be ROUND or TRUNCATE, got ' + this.numberToString (value));
+        }
+        return value;
+    }
+
     forceString (value) {
         if (typeof value !== 'string') {
             return this.numberToString (value);
@@ -7423,6 +7511,75 @@ export default class Exchange {
         }
     }
 
+    async checkLeverage (leverage: Num, symbol: Str = undefined, params = {}) {
+        /**
+         * @method
+         * @name exchange#checkLeverage
+         * @description checks a leverage against the leverage tiers of the market before setLeverage sends it, the tiers are fetched with fetchMarketLeverageTiers and cached for options.leverageTiersTTL milliseconds
+         * @param {float} leverage the leverage to set
+         * @param {string} symbol unified market symbol, nothing is checked without it
+         * @param {object} [params] the parameters of setLeverage, they are sent with fetchMarketLeverageTiers
+         * @param {string} [params.marginMode] 'cross' or 'isolated', defaults to options.defaultMarginMode
+         * @returns {undefined} throws a BadRequest when the leverage is below 1 or above the maximum leverage of the market, options.checkLeverageTiers = false disables the check
+         */
+        if ((symbol === undefined) || (leverage === undefined)) {
+            return;
+        }
+        if (leverage < 1) {
+            throw new BadRequest (this.id + ' setLeverage() leverage should be at least 1, got ' + leverage.toString ());
+        }
+        const checkLeverageTiers = this.safeBool (this.options, 'checkLeverageTiers', true);
+        const hasTiers = this.has['fetchMarketLeverageTiers'] || this.has['fetchLeverageTiers'];
+        if (!checkLeverageTiers || !hasTiers) {
+            return;
+        }
+        await this.loadMarkets ();
+        const tiers = await this.fetchCachedLeverageTiers (symbol, params);
+        let maxLeverage = undefined;
+        for (let i = 0; i < tiers.length; i++) {
+            const tierMaxLeverage = this.safeNumber (tiers[i], 'maxLeverage');
+            if ((tierMaxLeverage !== undefined) && ((maxLeverage === undefined) || (tierMaxLeverage > maxLeverage))) {
+                maxLeverage = tierMaxLeverage;
+            }
+        }
+        if ((maxLeverage !== undefined) && (leverage > maxLeverage)) {
+            throw new BadRequest (this.id + ' setLeverage() leverage of ' + leverage.toString () + ' exceeds the maximum leverage of ' + this.numberToString (maxLeverage) + ' for ' + symbol);
+        }
+    }
+
+    async fetchCachedLeverageTiers (symbol: string, params = {}): Promise<LeverageTier[]> {
+        // the brackets can differ between the margin modes and the accounts, the tiers are cached by symbol, margin mode and params
+        const marginModeAndParams = this.handleMarginModeAndParams ('setLeverage', params);
+        const marginMode = this.safeString (marginModeAndParams, 0, '');
+        const key = symbol + ':' + marginMode + ':' + this.json (params);
+        const cache = this.safeDict (this.options, 'leverageTiersCache', {});
+        const cached = this.safeDict (cache, key);
+        const ttl = this.safeInteger (this.options, 'leverageTiersTTL', 3600000);
+        if ((cached !== undefined) && ((this.milliseconds () - cached['timestamp']) < ttl)) {
+            return cached['tiers'];
+        }
+        let tiers = undefined;
+        try {
+            tiers = await this.fetchMarketLeverageTiers (symbol, params);
+        } catch (e) {
+            // the exchange validates the leverage itself when the tiers can not be fetched
+            if (this.verbose) {
+                this.log ('checkLeverage() could not fetch the leverage tiers of ' + symbol + ': ' + e.toString ());
+            }
+        }
+        if (!Array.isArray (tiers)) {
+            return [];
+        }
+        // the cache is replaced instead of updated in place, it is shared by the concurrent calls
+        const entry = {};
+        entry[key] = {
+            'tiers': tiers,
+            'timestamp': this.milliseconds (),
+        };
+        this.options['leverageTiersCache'] = this.extend (cache, entry);
+        return tiers;
+    }
+
     async createPostOnlyOrder (symbol: string, type: OrderType, side: OrderSide, amount: number, price: Num = undefined, params = {}) {
         if (!this.has['createPostOnlyOrder']) {
             throw new NotSupported (this.id + ' createPostOnlyOrder() is not supported yet');
@@ -1345,6 +1345,7 @@ export default class binance extends Exchange {
                 // binanceusdm
                 'throwMarginModeAlreadySet': false,
                 'fetchPositions': 'positionRisk', // or 'account' or 'option'
+                'borrowRatesExpires': 60000, // the borrow rates are cached by request for 60 seconds, 0 disables the cache
                 'recvWindow': 10 * 1000, // 10 sec
                 'timeDifference': 0, // the difference between system clock and Binance clock
                 'adjustForTimeDifference': false, // controls the adjustment logic upon instantiation
@@ -1801,8 +1802,8 @@ export default class binance extends Exchange {
                         '-4064': ExchangeError, // override commons
                         '-4065': ExchangeError, // override commons
                         '-4066': ExchangeError, // override commons
-                        '-4067': ExchangeError, // override commons
-                        '-4068': ExchangeError, // override commons
+                        '-4067': OperationRejected, // Position side cannot be changed if there exists open orders.
+                        '-4068': OperationRejected, // Position side cannot be changed if there exists position.
                         '-4069': ExchangeError, // override commons
                         '-4070': ExchangeError, // override commons
                         '-4071': ExchangeError, // override commons
@@ -2265,8 +2266,8 @@ export default class binance extends Exchange {
                         '-4064': ExchangeError, // override commons
                         '-4065': ExchangeError, // override commons
                         '-4066': ExchangeError, // override commons
-                        '-4067': ExchangeError, // override commons
-                        '-4068': ExchangeError, // override commons
+                        '-4067': OperationRejected, // Position side cannot be changed if there exists open orders.
+                        '-4068': OperationRejected, // Position side cannot be changed if there exists position.
                         '-4069': ExchangeError, // override commons
                         '-4070': ExchangeError, // override commons
                         '-4071': ExchangeError, // override commons
@@ -4327,7 +4328,7 @@ export default class binance extends Exchange {
         const statusRaw = this.safeString (response, 'status');
         return {
             'status': this.safeString ({ '0': 'ok', '1': 'maintenance' }, statusRaw, statusRaw),
-            'updated': undefined,
+            'updated': this.milliseconds (),
             'eta': undefined,
             'url': undefined,
             'info': response,
@@ -11373,6 +11374,7 @@ export default class binance extends Exchange {
      * @returns {object} response from the exchange
      */
     async setLeverage (leverage: int, symbol: Str = undefined, params = {}) {
+        await this.checkLeverage (leverage, symbol, params);
         if (symbol === undefined) {
             throw new ArgumentsRequired (this.id + ' setLeverage() requires a symbol argument');
         }
@@ -11488,6 +11490,7 @@ export default class binance extends Exchange {
      * @returns {object} response from the exchange
      */
     async setPositionMode (hedged: boolean, symbol: Str = undefined, params = {}) {
+        await this.loadMarkets ();
         let market = undefined;
         if (symbol !== undefined) {
             market = this.market (symbol);
@@ -12454,18 +12457,47 @@ export default class binance extends Exchange {
      * @name binance#fetchCrossBorrowRate
      * @description fetch the rate of interest to borrow a currency for margin trading
      * @see https://developers.binance.com/docs/margin_trading/borrow-and-repay/Query-Margin-Interest-Rate-History
+     * @see https://developers.binance.com/docs/margin_trading/borrow-and-repay/Get-future-hourly-interest-rate
      * @param {string} code unified currency code
      * @param {object} [params] extra parameters specific to the exchange API endpoint
+     * @param {string} [params.period] '1d' (default) for the daily rate or '1h' for the rate of the next hour
      * @returns {object} a [borrow rate structure]{@link https://docs.ccxt.com/?id=borrow-rate-structure}
      */
     async fetchCrossBorrowRate (code: string, params = {}): Promise<CrossBorrowRate> {
         await this.loadMarkets ();
         const currency = this.currency (code);
+        const period = this.safeString (params, 'period', '1d');
+        params = this.omit (params, 'period');
+        const cacheKey = this.borrowRateCacheKey ('fetchCrossBorrowRate', currency['code'], period, params);
+        const cached = this.getCachedBorrowRate (cacheKey);
+        if (cached !== undefined) {
+            return cached as CrossBorrowRate;
+        }
+        let response = undefined;
+        if (period === '1h') {
+            const hourlyRequest: Dict = {
+                'assets': currency['id'],
+                'isIsolated': 'FALSE',
+            };
+            response = await this.sapiGetMarginNextHourlyInterestRate (this.extend (hourlyRequest, params));
+            //
+            //     [
+            //         {
+            //             "asset": "BTC",
+            //             "nextHourlyInterestRate": "0.00000571"
+            //         }
+            //     ]
+            //
+            const hourlyRate = this.parseBorrowRate (this.safeDict (response, 0), currency);
+            this.setCachedBorrowRate (cacheKey, hourlyRate);
+            return hourlyRate as CrossBorrowRate;
+        } else if (period !== '1d') {
+            throw new BadRequest (this.id + ' fetchCrossBorrowRate() period must be 1d or 1h');
+        }
         const request: Dict = {
             'asset': currency['id'],
-            // 'vipLevel': this.safeInteger (params, 'vipLevel'),
         };
-        const response = await this.sapiGetMarginInterestRateHistory (this.extend (request, params));
+        response = await this.sapiGetMarginInterestRateHistory (this.extend (request, params));
         //
         //     [
         //         {
@@ -12476,8 +12508,9 @@ export default class binance extends Exchange {
         //         },
         //     ]
         //
-        const rate = this.safeDict (response, 0);
-        return this.parseBorrowRate (rate);
+        const rate = this.parseBorrowRate (this.safeDict (response, 0), currency);
+        this.setCachedBorrowRate (cacheKey, rate);
+        return rate as CrossBorrowRate;
     }
 
     /**
@@ -12485,19 +12518,57 @@ export default class binance extends Exchange {
      * @name binance#fetchIsolatedBorrowRate
      * @description fetch the rate of interest to borrow a currency for margin trading
      * @see https://developers.binance.com/docs/margin_trading/account/Query-Isolated-Margin-Fee-Data
+     * @see https://developers.binance.com/docs/margin_trading/borrow-and-repay/Get-future-hourly-interest-rate
      * @param {string} symbol unified market symbol
      * @param {object} [params] extra parameters specific to the exchange API endpoint
+     * @param {string} [params.period] '1d' (default) for the daily rates or '1h' for the rates of the next hour
      *
      * EXCHANGE SPECIFIC PARAMETERS
      * @param {object} [params.vipLevel] user's current specific margin data will be returned if viplevel is omitted
      * @returns {object} an [isolated borrow rate structure]{@link https://docs.ccxt.com/?id=isolated-borrow-rate-structure}
      */
     async fetchIsolatedBorrowRate (symbol: string, params = {}): Promise<IsolatedBorrowRate> {
-        const request: Dict = {
-            'symbol': symbol,
-        };
-        const borrowRates = await this.fetchIsolatedBorrowRates (this.extend (request, params));
-        return this.safeDict (borrowRates, symbol) as IsolatedBorrowRate;
+        await this.loadMarkets ();
+        const market = this.market (symbol);
+        const period = this.safeString (params, 'period', '1d');
+        params = this.omit (params, 'period');
+        const cacheKey = this.borrowRateCacheKey ('fetchIsolatedBorrowRate', market['symbol'], period, params);
+        const cached = this.getCachedBorrowRate (cacheKey);
+        if (cached !== undefined) {
+            return cached as IsolatedBorrowRate;
+        }
+        let borrowRate = undefined;
+        if (period === '1h') {
+            const request: Dict = {
+                'assets': market['baseId'] + ',' + market['quoteId'],
+                'isIsolated': 'TRUE',
+            };
+            const response = await this.sapiGetMarginNextHourlyInterestRate (this.extend (request, params));
+            const rates = this.indexBy (response, 'asset');
+            const baseRate = this.safeDict (rates, market['baseId'], {});
+            const quoteRate = this.safeDict (rates, market['quoteId'], {});
+            borrowRate = {
+                'info': rates,
+                'symbol': market['symbol'],
+                'base': market['base'],
+                'baseRate': this.safeNumber (baseRate, 'nextHourlyInterestRate'),
+                'quote': market['quote'],
+                'quoteRate': this.safeNumber (quoteRate, 'nextHourlyInterestRate'),
+                'period': 3600000,
+                'timestamp': undefined,
+                'datetime': undefined,
+            };
+        } else if (period === '1d') {
+            const request: Dict = {
+                'symbol': symbol,
+            };
+            const borrowRates = await this.fetchIsolatedBorrowRates (this.extend (request, params));
+            borrowRate = this.safeDict (borrowRates, symbol);
+        } else {
+            throw new BadRequest (this.id + ' fetchIsolatedBorrowRate() period must be 1d or 1h');
+        }
+        this.setCachedBorrowRate (cacheKey, borrowRate);
+        return borrowRate as IsolatedBorrowRate;
     }
 
     /**
@@ -12599,12 +12670,24 @@ export default class binance extends Exchange {
         //        "vipLevel": 0
         //    }
         //
+        // fetchCrossBorrowRate hourly
+        //
+        //    {
+        //        "asset": "BTC",
+        //        "nextHourlyInterestRate": "0.00000571"
+        //    }
+        //
         const timestamp = this.safeInteger (info, 'timestamp');
         const currencyId = this.safeString (info, 'asset');
+        const hourlyRate = this.safeNumber (info, 'nextHourlyInterestRate');
+        let period = 86400000;
+        if (hourlyRate !== undefined) {
+            period = 3600000;
+        }
         return {
             'currency': this.safeCurrencyCode (currencyId, currency),
-            'rate': this.safeNumber (info, 'dailyInterestRate'),
-            'period': 86400000,
+            'rate': this.safeNumber (info, 'dailyInterestRate', hourlyRate),
+            'period': period,
             'timestamp': timestamp,
             'datetime': this.iso8601 (timestamp),
             'info': info,
@@ -12649,6 +12732,36 @@ export default class binance extends Exchange {
         };
     }
 
+    getCachedBorrowRate (key: string) {
+        // the borrow rate of the request fetched less than options.borrowRatesExpires ms ago, as a copy
+        const expires = this.safeInteger (this.options, 'borrowRatesExpires', 0);
+        if (expires <= 0) {
+            return undefined;
+        }
+        const cache = this.safeDict (this.options, 'borrowRatesCache', {});
+        const cached = this.safeDict (cache, key);
+        if ((cached === undefined) || ((this.milliseconds () - cached['timestamp']) >= expires)) {
+            return undefined;
+        }
+        return this.clone (cached['rate']);
+    }
+
+    setCachedBorrowRate (key: string, rate) {
+        // the cache is replaced instead of updated in place, it is shared by the concurrent calls
+        const cache = this.safeDict (this.options, 'borrowRatesCache', {});
+        const entry = {};
+        entry[key] = {
+            'rate': this.clone (rate),
+            'timestamp': this.milliseconds (),
+        };
+        this.options['borrowRatesCache'] = this.extend (cache, entry);
+    }
+
+    borrowRateCacheKey (method: string, id: string, period: string, params = {}) {
+        // a borrow rate request is identified by the method, the currency or symbol, the period and the params
+        return method + ':' + id + ':' + period + ':' + this.json (params);
+    }
+
     /**
      * @method
      * @name binance#createGiftCode
@@ -13587,23 +13700,37 @@ export default class binance extends Exchange {
      * @description fetchs the position mode, hedged or one way, hedged for binance is set identically for all linear markets or all inverse markets
      * @see https://developers.binance.com/docs/derivatives/usds-margined-futures/account/rest-api/Get-Current-Position-Mode
      * @see https://developers.binance.com/docs/derivatives/coin-margined-futures/account/rest-api/Get-Current-Position-Mode
-     * @param {string} symbol unified symbol of the market to fetch the order book for
+     * @see https://developers.binance.com/docs/derivatives/portfolio-margin/account/Get-UM-Current-Position-Mode
+     * @see https://developers.binance.com/docs/derivatives/portfolio-margin/account/Get-CM-Current-Position-Mode
+     * @param {string} symbol unified symbol of the market to fetch the position mode for
      * @param {object} [params] extra parameters specific to the exchange API endpoint
      * @param {string} [params.subType] "linear" or "inverse"
+     * @param {boolean} [params.portfolioMargin] set to true if you would like to fetch the position mode for a portfolio margin account
      * @returns {object} an object detailing whether the market is in hedged or one-way mode
      */
     async fetchPositionMode (symbol: Str = undefined, params = {}) {
+        await this.loadMarkets ();
         let market = undefined;
         if (symbol !== undefined) {
             market = this.market (symbol);
         }
         let subType = undefined;
         [ subType, params ] = this.handleSubTypeAndParams ('fetchPositionMode', market, params);
+        let isPortfolioMargin = undefined;
+        [ isPortfolioMargin, params ] = this.handleOptionAndParams2 (params, 'fetchPositionMode', 'papi', 'portfolioMargin', false);
         let response = undefined;
         if (subType === 'linear') {
-            response = await this.fapiPrivateGetPositionSideDual (params);
+            if (isPortfolioMargin) {
+                response = await this.papiGetUmPositionSideDual (params);
+            } else {
+                response = await this.fapiPrivateGetPositionSideDual (params);
+            }
         } else if (subType === 'inverse') {
-            response = await this.dapiPrivateGetPositionSideDual (params);
+            if (isPortfolioMargin) {
+                response = await this.papiGetCmPositionSideDual (params);
+            } else {
+                response = await this.dapiPrivateGetPositionSideDual (params);
+            }
         } else {
             throw new BadRequest (this.id + ' fetchPositionMode requires either a symbol argument or params["subType"]');
         }
@@ -6996,6 +6996,7 @@ export default class bybit extends Exchange {
      * @returns {object} response from the exchange
      */
     async setLeverage (leverage: int, symbol: Str = undefined, params = {}) {
+        await this.checkLeverage (leverage, symbol, params);
         if (symbol === undefined) {
             throw new ArgumentsRequired (this.id + ' setLeverage() requires a symbol argument');
         }
@@ -8581,24 +8582,54 @@ export default class bybit extends Exchange {
      * @name bybit#fetchLeverageTiers
      * @description retrieve information on the maximum leverage, for different trade sizes
      * @see https://bybit-exchange.github.io/docs/v5/market/risk-limit
-     * @param {string[]} [symbols] a list of unified market symbols
+     * @param {string[]} [symbols] a list of unified market symbols, the linear and inverse ones are requested from their own category
      * @param {object} [params] extra parameters specific to the exchange API endpoint
-     * @param {string} [params.subType] market subType, ['linear', 'inverse'], default is 'linear'
-     * @param {boolean} [params.paginate] default false, when true will automatically paginate by calling this endpoint multiple times. See in the docs all the [available parameters](https://github.com/ccxt/ccxt/wiki/Manual#pagination-params)
+     * @param {string} [params.subType] market subType without symbols, ['linear', 'inverse'], default is 'linear'
+     * @param {boolean} [params.paginate] default true, set to false to fetch a single page of risk limits per category. See in the docs all the [available parameters](https://github.com/ccxt/ccxt/wiki/Manual#pagination-params)
+     * @param {int} [params.paginationCalls] the maximum number of pages followed per category, default 50
      * @returns {object} a dictionary of [leverage tiers structures]{@link https://docs.ccxt.com/?id=leverage-tiers-structure}, indexed by market symbols
      */
     async fetchLeverageTiers (symbols: Strings = undefined, params = {}): Promise<LeverageTiers> {
         await this.loadMarkets ();
-        let market = undefined;
-        let symbol = undefined;
-        if (symbols !== undefined) {
-            market = this.market (symbols[0]);
-            if (market['spot']) {
-                throw new NotSupported (this.id + ' fetchLeverageTiers() is not supported for spot market');
+        const paginationParams: Dict = {
+            'paginate': true,
+            'paginationCalls': 50,
+        };
+        let data = [];
+        if (symbols === undefined) {
+            data = await this.getLeverageTiersPaginated (undefined, this.extend (paginationParams, params));
+        } else {
+            // the risk limits are listed by category, the symbols are grouped by subType
+            const symbolsBySubType: Dict = {};
+            for (let i = 0; i < symbols.length; i++) {
+                const market = this.market (symbols[i]);
+                if (market['spot']) {
+                    throw new NotSupported (this.id + ' fetchLeverageTiers() is not supported for spot market');
+                }
+                let subType = 'linear';
+                if (market['inverse']) {
+                    subType = 'inverse';
+                }
+                const subTypeSymbols = this.safeList (symbolsBySubType, subType, []);
+                subTypeSymbols.push (market['symbol']);
+                symbolsBySubType[subType] = subTypeSymbols;
+            }
+            const subTypes = this.sort (Object.keys (symbolsBySubType));
+            for (let i = 0; i < subTypes.length; i++) {
+                const subType = subTypes[i];
+                const subTypeSymbols = symbolsBySubType[subType];
+                // a single symbol is requested alone, several symbols page through the whole category
+                let symbol = undefined;
+                if (subTypeSymbols.length === 1) {
+                    symbol = subTypeSymbols[0];
+                }
+                // the category of the markets wins over params.subType
+                const pageParams = this.extend (paginationParams, params);
+                pageParams['subType'] = subType;
+                const page = await this.getLeverageTiersPaginated (symbol, pageParams);
+                data = this.arrayConcat (data, page);
             }
-            symbol = market['symbol'];
         }
-        const data = await this.getLeverageTiersPaginated (symbol, this.extend ({ 'paginate': true, 'paginationCalls': 50 }, params));
         symbols = this.marketSymbols (symbols);
         return this.parseLeverageTiers (data, symbols, 'symbol');
     }
@@ -9516,6 +9516,7 @@ export default class kucoin extends Exchange {
      * @returns {object} response from the exchange
      */
     async setLeverage (leverage: int, symbol: Str = undefined, params = {}) {
+        await this.checkLeverage (leverage, symbol, params);
         await this.loadMarkets ();
         let market = undefined;
         let marketType: Str = undefined;
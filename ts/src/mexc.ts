@@ -4328,6 +4328,7 @@ export default class mexc extends Exchange {
      * @returns {object} response from the exchange
      */
     async setLeverage (leverage: int, symbol: Str = undefined, params = {}) {
+        await this.checkLeverage (leverage, symbol, params);
         await this.loadMarkets ();
         const request: Dict = {
             'leverage': leverage,
@@ -63,6 +63,7 @@ export default class okx extends Exchange {
                 'fetchBorrowInterest': true,
                 'fetchBorrowRateHistories': true,
                 'fetchBorrowRateHistory': true,
+                'fetchCanceledAndClosedOrders': true,
                 'fetchCanceledOrders': true,
                 'fetchClosedOrder': undefined,
                 'fetchClosedOrders': true,
@@ -78,7 +79,7 @@ export default class okx extends Exchange {
                 'fetchDepositAddresses': false,
                 'fetchDepositAddressesByNetwork': true,
                 'fetchDeposits': true,
-                'fetchDepositsWithdrawals': false,
+                'fetchDepositsWithdrawals': true,
                 'fetchDepositWithdrawFee': 'emulated',
                 'fetchDepositWithdrawFees': true,
                 'fetchFundingHistory': true,
@@ -1524,27 +1525,22 @@ export default class okx extends Exchange {
         //     }
         //
         const data = this.safeList (response, 'data', []);
-        const dataLength = data.length;
         const update: Dict = {
-            'updated': undefined,
-            'status': (dataLength === 0) ? 'ok' : 'maintenance',
+            'updated': this.milliseconds (),
+            'status': 'ok',
             'eta': undefined,
             'url': undefined,
             'info': response,
         };
+        // any ongoing maintenance wins over the scheduled, completed and canceled events
         for (let i = 0; i < data.length; i++) {
             const event = data[i];
             const state = this.safeString (event, 'state');
-            update['eta'] = this.safeInteger (event, 'end');
-            update['url'] = this.safeString (event, 'href');
             if (state === 'ongoing') {
                 update['status'] = 'maintenance';
-            } else if (state === 'scheduled') {
-                update['status'] = 'ok';
-            } else if (state === 'completed') {
-                update['status'] = 'ok';
-            } else if (state === 'canceled') {
-                update['status'] = 'ok';
+                update['eta'] = this.safeInteger (event, 'end');
+                update['url'] = this.safeString (event, 'href');
+                break;
             }
         }
         return update;
@@ -4883,6 +4879,74 @@ export default class okx extends Exchange {
         return this.parseOrders (data, market, since, limit);
     }
 
+    /**
+     * @method
+     * @name okx#fetchCanceledAndClosedOrders
+     * @description fetches information on multiple canceled and closed orders made by the user with a single request
+     * @see https://www.okx.com/docs-v5/en/#order-book-trading-trade-get-order-history-last-7-days
+     * @see https://www.okx.com/docs-v5/en/#order-book-trading-trade-get-order-history-last-3-months
+     * @param {string} symbol unified market symbol of the market orders were made in
+     * @param {int} [since] the earliest time in ms to fetch orders for
+     * @param {int} [limit] the maximum number of order structures to retrieve
+     * @param {object} [params] extra parameters specific to the exchange API endpoint
+     * @param {int} [params.until] timestamp in ms to fetch orders for
+     * @param {bool} [params.trigger] True if fetching trigger or conditional orders, the algo history is then queried once per state
+     * @param {boolean} [params.paginate] default false, when true will automatically paginate by calling this endpoint multiple times. See in the docs all the [availble parameters](https://github.com/ccxt/ccxt/wiki/Manual#pagination-params)
+     * @param {string} [params.method] method to be used, either 'privateGetTradeOrdersHistory' or 'privateGetTradeOrdersHistoryArchive' default is 'privateGetTradeOrdersHistory'
+     * @returns {Order[]} a list of [order structures]{@link https://docs.ccxt.com/?id=order-structure}
+     */
+    async fetchCanceledAndClosedOrders (symbol: Str = undefined, since: Int = undefined, limit: Int = undefined, params = {}): Promise<Order[]> {
+        await this.loadMarkets ();
+        const algoOrderTypes = this.safeDict (this.options, 'algoOrderTypes', {});
+        const ordType = this.safeString (params, 'ordType');
+        const trigger = this.safeBool2 (params, 'stop', 'trigger');
+        const trailing = this.safeBool (params, 'trailing', false);
+        if (trailing || trigger || (ordType in algoOrderTypes)) {
+            // the algo history endpoint requires a state, so it cannot return both in one call
+            return await super.fetchCanceledAndClosedOrders (symbol, since, limit, params);
+        }
+        const maxLimit = 100;
+        let paginate = false;
+        [ paginate, params ] = this.handleOptionAndParams (params, 'fetchCanceledAndClosedOrders', 'paginate');
+        if (paginate) {
+            return await this.fetchPaginatedCallDynamic ('fetchCanceledAndClosedOrders', symbol, since, limit, params, maxLimit) as Order[];
+        }
+        const request: Dict = {};
+        let market = undefined;
+        if (symbol !== undefined) {
+            market = this.market (symbol);
+            request['instId'] = market['id'];
+        }
+        let type = undefined;
+        let query = undefined;
+        [ type, query ] = this.handleMarketTypeAndParams ('fetchCanceledAndClosedOrders', market, params);
+        request['instType'] = this.convertToInstrumentType (type);
+        if (limit !== undefined) {
+            request['limit'] = Math.min (limit, maxLimit); // default 100, max 100
+        }
+        if (since !== undefined) {
+            request['begin'] = since;
+        }
+        const until = this.safeInteger (query, 'until');
+        if (until !== undefined) {
+            request['end'] = until;
+            query = this.omit (query, [ 'until' ]);
+        }
+        // without a state filter the order history holds both the filled and the canceled orders
+        const options = this.safeDict (this.options, 'fetchCanceledAndClosedOrders', {});
+        const defaultMethod = this.safeString (options, 'method', 'privateGetTradeOrdersHistory');
+        const method = this.safeString (params, 'method', defaultMethod);
+        const send = this.omit (query, [ 'method', 'stop', 'trigger', 'trailing' ]);
+        let response = undefined;
+        if (method === 'privateGetTradeOrdersHistoryArchive') {
+            response = await this.privateGetTradeOrdersHistoryArchive (this.extend (request, send));
+        } else {
+            response = await this.privateGetTradeOrdersHistory (this.extend (request, send));
+        }
+        const data = this.safeList (response, 'data', []);
+        return this.parseOrders (data, market, since, limit);
+    }
+
     /**
      * @method
      * @name okx#fetchMyTrades
@@ -6870,6 +6934,7 @@ export default class okx extends Exchange {
      * @returns {object} response from the exchange
      */
     async setLeverage (leverage: int, symbol: Str = undefined, params = {}) {
+        await this.checkLeverage (leverage, symbol, params);
         if (symbol === undefined) {
             throw new ArgumentsRequired (this.id + ' setLeverage() requires a symbol argument');
         }
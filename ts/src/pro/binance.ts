@@ -179,7 +179,7 @@ export default class binance extends binanceRest {
                     'awaitPositionsSnapshot': true, // whether to wait for the positions snapshot before providing updates
                 },
                 'wallet': 'wb', // wb = wallet balance, cw = cross balance
-                'listenKeyRefreshRate': 1200000, // 20 mins
+                'listenKeyRefreshRate': 1200000, // milliseconds between two keepalive requests of the listenKey, they expire after 60 minutes
                 'ws': {
                     'cost': 5,
                 },
@@ -2633,10 +2633,20 @@ export default class binance extends binanceRest {
                 'listenKey': this.safeString (response, 'listenKey'),
                 'lastAuthenticatedTime': time,
             });
-            this.delay (listenKeyRefreshRate, this.keepAliveListenKey, params);
+            // the type is kept for the keepalive, the defaultType may differ from the authenticated stream
+            this.delay (listenKeyRefreshRate, this.keepAliveListenKey, this.extend (params, { 'type': type }));
         }
     }
 
+    /**
+     * @method
+     * @name binance#keepAliveListenKey
+     * @description refreshes the listenKey of a user data stream every options.listenKeyRefreshRate milliseconds while the stream is subscribed. An expired listenKey is replaced by a new one right away, but the watchers of the expired stream are rejected instead of being moved to the new one: the listenKey is part of the stream url and no subscribe message is sent, so there is nothing to replay, the next watch call subscribes to the stream of the new listenKey
+     * @param {object} [params] extra parameters specific to the exchange API endpoint
+     * @param {string} [params.type] the market type of the authenticated stream, defaults to the defaultType
+     * @param {boolean} [params.portfolioMargin] set to true if the stream belongs to a portfolio margin account
+     * @returns {undefined}
+     */
     async keepAliveListenKey (params = {}) {
         // https://binance-docs.github.io/apidocs/spot/en/#listen-key-spot
         let type = this.safeString2 (this.options, 'defaultType', 'authenticate', 'spot');
@@ -2663,6 +2673,7 @@ export default class binance extends binanceRest {
         const request: Dict = {};
         params = this.omit (params, [ 'type', 'symbol' ]);
         const time = this.milliseconds ();
+        let expired = false;
         try {
             if (isPortfolioMargin) {
                 await this.papiPutListenKey (this.extend (request, params));
@@ -2692,6 +2703,16 @@ export default class binance extends binanceRest {
                 'listenKey': undefined,
                 'lastAuthenticatedTime': 0,
             });
+            expired = true;
+        }
+        if (expired) {
+            // the listenKey expired, a new one is created right away and kept alive in turn,
+            // the rejected watchers subscribe to the stream of the new listenKey on their next call, see the docblock
+            const authenticateParams = this.extend (params, { 'type': type });
+            if (isPortfolioMargin) {
+                authenticateParams['portfolioMargin'] = true;
+            }
+            await this.authenticate (authenticateParams);
             return;
         }
         this.options[type] = this.extend (options, {
@@ -2707,7 +2728,7 @@ export default class binance extends binanceRest {
             for (let j = 0; j < subscriptionKeys.length; j++) {
                 const subscribeType = subscriptionKeys[j];
                 if (subscribeType === type) {
-                    this.delay (listenKeyRefreshRate, this.keepAliveListenKey, params);
+                    this.delay (listenKeyRefreshRate, this.keepAliveListenKey, this.extend (params, { 'type': type }));
                     return;
                 }
             }
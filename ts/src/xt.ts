@@ -4084,6 +4084,7 @@ export default class xt extends Exchange {
      * @returns {object} response from the exchange
      */
     async setLeverage (leverage: int, symbol: string = undefined, params = {}) {
+        await this.checkLeverage (leverage, symbol, params);
         if (symbol === undefined) {
             throw new ArgumentsRequired (this.id + ' setLeverage() requires a symbol argument');
         }